@@ -1,19 +1,29 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"os"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 
+	"github.com/craftcms/nitro/internal/gateway"
+	"github.com/craftcms/nitro/internal/metrics"
 	"github.com/craftcms/nitro/internal/nitrod"
 )
 
 func main() {
 	// assign the port as a flag with a default
 	port := flag.String("port", "50051", "which port nitro API should listen on")
+	httpPort := flag.String("http-port", "", "which port the JSON gateway should listen on, the gateway is disabled when empty")
+	reflect := flag.Bool("reflection", false, "enable gRPC server reflection")
+	metricsPort := flag.String("metrics-port", "9100", "which port the Prometheus metrics should listen on, metrics are disabled when empty")
+	metricsAddress := flag.String("metrics-address", "127.0.0.1", "which address the Prometheus metrics should listen on, use 0.0.0.0 to allow scraping from outside the machine")
 	flag.Parse()
 
 	// create the network listener
@@ -23,16 +33,80 @@ func main() {
 	}
 
 	// create the grpc server
-	s := grpc.NewServer()
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(metrics.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(metrics.StreamServerInterceptor()),
+	)
 
 	// register our services
-	nitrod.RegisterNitroServiceServer(s, nitrod.NewNitroService())
+	service := nitrod.NewNitroService()
+	nitrod.RegisterNitroServiceServer(s, service)
 	nitrod.RegisterSystemServiceServer(s, nitrod.NewSystemService())
 
-	fmt.Println("running nitrod on port", *port)
+	go service.ScheduleBackups(context.Background())
+
+	if *reflect {
+		reflection.Register(s)
+	}
+
+	if *httpPort != "" {
+		go serveGateway(*port, *httpPort)
+	}
+
+	if *metricsPort != "" {
+		go serveMetrics(*metricsAddress, *metricsPort)
+	}
+
+	fmt.Println("running nitrod", nitrod.Version, "on port", *port)
 
 	// server the grpc service
 	if err := s.Serve(lis); err != nil {
 		log.Fatal("error when running the server", err)
 	}
 }
+
+// serveGateway runs the JSON gateway, requests are sent to the grpc
+// server over the loopback interface so they are handled the same
+// way as requests from the nitro CLI.
+func serveGateway(port, httpPort string) {
+	cc, err := grpc.Dial("127.0.0.1:"+port, grpc.WithInsecure())
+	if err != nil {
+		log.Fatal("error connecting the gateway to the server", err)
+	}
+
+	gw, err := gateway.New(cc, nitrod.File_internal_nitrod_nitrod_proto.Services())
+	if err != nil {
+		log.Fatal("error creating the gateway", err)
+	}
+
+	fmt.Println("running the JSON gateway on port", httpPort)
+
+	if err := http.ListenAndServe("0.0.0.0:"+httpPort, gw); err != nil {
+		log.Fatal("error when running the gateway", err)
+	}
+}
+
+// serveMetrics runs the Prometheus metrics endpoint, the php-fpm, nginx,
+// and container metrics are collected on each scrape. The endpoint has no
+// authentication so it only listens on the loopback interface by default.
+func serveMetrics(address, port string) {
+	registry := metrics.NewRegistry(log.New(os.Stdout, "nitrod ", 0))
+	registry.Register("rpc_requests", metrics.RPCRequests)
+	registry.Register("rpc_duration", metrics.RPCDuration)
+	registry.Register("import_bytes", metrics.ImportBytes)
+	registry.Register("import_duration", metrics.ImportDuration)
+	registry.Register("phpfpm", metrics.NewFPMCollector())
+	registry.Register("nginx", metrics.NewNginxCollector())
+	registry.Register("containers", &metrics.ContainerCollector{Command: nitrod.ServiceRunner{}})
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", registry)
+
+	addr := net.JoinHostPort(address, port)
+
+	fmt.Println("running the metrics endpoint on", addr)
+
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Fatal("error when running the metrics endpoint", err)
+	}
+}
@@ -0,0 +1,203 @@
+// Package backup reads the database backups stored for each machine in
+// ~/.nitro/backups/<machine>/<container>/ and decides which automatic
+// backups are kept. Automatic backups are marked in their manifest, so
+// the backups taken with db backup or before destroy are never expired.
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/craftcms/nitro/internal/datetime"
+)
+
+// namePattern matches the backup names created by Filename, the
+// database name can contain dashes so the date is matched last.
+var namePattern = regexp.MustCompile(`^(.+)-(\d{6}_\d{6})\.(sql(\.[a-z0-9]+)*)$`)
+
+// Backup is a database dump in the backups directory of a machine.
+type Backup struct {
+	Container string
+	Database  string
+	Time      time.Time
+	Size      int64
+	// Path is the path of the backup in the destination, or the key in a bucket
+	Path string
+	// Destination is the name of the destination of the path
+	Destination string
+	// Locations are the names of the destinations with the backup, see Merge
+	Locations []string
+}
+
+// Filename returns the name of a backup of the database taken at the time,
+// the extension is added after .sql, e.g. gz for compressed backups.
+func Filename(database string, t time.Time, ext string) string {
+	name := database + "-" + datetime.Parse(t) + ".sql"
+	if ext != "" {
+		name += "." + ext
+	}
+
+	return name
+}
+
+// Parse returns the database and time from the name of a backup.
+func Parse(name string) (string, time.Time, error) {
+	m := namePattern.FindStringSubmatch(name)
+	if m == nil {
+		return "", time.Time{}, fmt.Errorf("%q is not the name of a backup", name)
+	}
+
+	t, err := datetime.Time(m[2])
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return m[1], t, nil
+}
+
+// List returns the backups in each container directory of the machine's
+// backups directory, sorted by container, database and newest first.
+// Files that are not backups, are still being written or are the manifests
+// of backups are skipped.
+func List(dir string) ([]Backup, error) {
+	containers, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []Backup
+	for _, c := range containers {
+		if !c.IsDir() {
+			continue
+		}
+
+		files, err := ioutil.ReadDir(filepath.Join(dir, c.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range files {
+			if f.IsDir() || strings.HasPrefix(f.Name(), ".") || strings.HasSuffix(f.Name(), ManifestExt) {
+				continue
+			}
+
+			database, t, err := Parse(f.Name())
+			if err != nil {
+				continue
+			}
+
+			backups = append(backups, Backup{
+				Container: c.Name(),
+				Database:  database,
+				Time:      t,
+				Size:      f.Size(),
+				Path:      filepath.Join(dir, c.Name(), f.Name()),
+			})
+		}
+	}
+
+	sortBackups(backups)
+
+	return backups, nil
+}
+
+// Machines returns the machines in the backups directory that have backups.
+func Machines(dir string) ([]string, error) {
+	dirs, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var machines []string
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+
+		backups, err := List(filepath.Join(dir, d.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(backups) > 0 {
+			machines = append(machines, d.Name())
+		}
+	}
+
+	return machines, nil
+}
+
+// Automatic returns the backups with a manifest that marks them as taken
+// by the backup schedule.
+func Automatic(backups []Backup) []Backup {
+	var automatic []Backup
+	for _, b := range backups {
+		if m, err := ReadManifest(b.Path); err == nil && m.Automatic {
+			automatic = append(automatic, b)
+		}
+	}
+
+	return automatic
+}
+
+// Expired returns the backups of each database over the count to keep, or
+// older than the number of days. A zero count or days is not checked. The
+// newest backup of a database is always kept so a database that stopped
+// being backed up still has a backup. The backups must be sorted by List.
+func Expired(backups []Backup, keep, days int, now time.Time) []Backup {
+	var expired []Backup
+
+	n := 0
+	for i, b := range backups {
+		if i == 0 || b.Container != backups[i-1].Container || b.Database != backups[i-1].Database {
+			n = 0
+		}
+		n++
+
+		if n == 1 {
+			continue
+		}
+
+		if (keep > 0 && n > keep) || (days > 0 && now.Sub(b.Time) > time.Duration(days)*24*time.Hour) {
+			expired = append(expired, b)
+		}
+	}
+
+	return expired
+}
+
+// ParseSchedule returns how often backups run, the schedule is hourly,
+// daily, weekly or a duration of at least an hour, such as 12h.
+func ParseSchedule(schedule string) (time.Duration, error) {
+	switch schedule {
+	case "hourly":
+		return time.Hour, nil
+	case "daily":
+		return 24 * time.Hour, nil
+	case "weekly":
+		return 7 * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(schedule)
+	if err != nil {
+		return 0, fmt.Errorf("the schedule %q must be hourly, daily, weekly or a duration such as 12h", schedule)
+	}
+
+	if d < time.Hour {
+		return 0, errors.New("backups cannot be scheduled more than once an hour")
+	}
+
+	return d, nil
+}
@@ -0,0 +1,259 @@
+package backup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name         string
+		file         string
+		wantDatabase string
+		wantTime     time.Time
+		wantErr      bool
+	}{
+		{
+			name:         "plain backups",
+			file:         "craft-201118_020304.sql",
+			wantDatabase: "craft",
+			wantTime:     time.Date(2020, 11, 18, 2, 3, 4, 0, time.Local),
+		},
+		{
+			name:         "compressed backups of databases with dashes",
+			file:         "craft-dev-201118_020304.sql.gz",
+			wantDatabase: "craft-dev",
+			wantTime:     time.Date(2020, 11, 18, 2, 3, 4, 0, time.Local),
+		},
+		{
+			name:         "backups of every database",
+			file:         "all-dbs-201118_020304.sql",
+			wantDatabase: "all-dbs",
+			wantTime:     time.Date(2020, 11, 18, 2, 3, 4, 0, time.Local),
+		},
+		{
+			name:    "other files are not backups",
+			file:    "notes.txt",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, tm, err := Parse(tt.file)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if database != tt.wantDatabase {
+				t.Errorf("Parse() database = %q, want %q", database, tt.wantDatabase)
+			}
+			if !tm.Equal(tt.wantTime) {
+				t.Errorf("Parse() time = %v, want %v", tm, tt.wantTime)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nitro-backups-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"mysql_5.7_3306/craft-201118_020000.sql.gz":               "older",
+		"mysql_5.7_3306/craft-201119_020000.sql.gz":               "newer",
+		"mysql_5.7_3306/.craft-201120_020000.sql.gz":              "partial",
+		"mysql_5.7_3306/notes.txt":                                "not a backup",
+		"mysql_5.7_3306/craft-201119_020000.sql.gz.manifest.json": "{}",
+		"postgres_12_5432/nitro-201118_020000.sql":                "postgres",
+		"postgres_12_5432/all-dbs-201117_020000.sql":              "all",
+	}
+	for name, content := range files {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backups, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, b := range backups {
+		rel, _ := filepath.Rel(dir, b.Path)
+		got = append(got, rel)
+	}
+
+	want := []string{
+		"mysql_5.7_3306/craft-201119_020000.sql.gz",
+		"mysql_5.7_3306/craft-201118_020000.sql.gz",
+		"postgres_12_5432/all-dbs-201117_020000.sql",
+		"postgres_12_5432/nitro-201118_020000.sql",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+
+	if backups[0].Size != 5 || backups[0].Container != "mysql_5.7_3306" || backups[0].Database != "craft" {
+		t.Errorf("unexpected backup %+v", backups[0])
+	}
+
+	// a machine without backups
+	if backups, err := List(filepath.Join(dir, "missing")); err != nil || len(backups) != 0 {
+		t.Errorf("expected no backups for a missing directory, got %v, %v", backups, err)
+	}
+}
+
+func TestMachines(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nitro-backups-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"nitro-dev/mysql_5.7_3306/craft-201118_020000.sql", "old/postgres_12_5432/notes.txt", "diy/mysql_5.7_3306/craft-201118_020000.sql"} {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(file, []byte("backup"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := Machines(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"diy", "nitro-dev"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Machines() = %v, want %v", got, want)
+	}
+}
+
+func TestExpired(t *testing.T) {
+	now := time.Date(2020, 11, 20, 12, 0, 0, 0, time.Local)
+	day := func(n int) time.Time {
+		return now.Add(-time.Duration(n) * 24 * time.Hour)
+	}
+
+	backups := []Backup{
+		{Container: "mysql", Database: "craft", Time: day(0), Path: "craft-0"},
+		{Container: "mysql", Database: "craft", Time: day(1), Path: "craft-1"},
+		{Container: "mysql", Database: "craft", Time: day(2), Path: "craft-2"},
+		{Container: "mysql", Database: "craft", Time: day(10), Path: "craft-10"},
+		{Container: "mysql", Database: "old", Time: day(40), Path: "old-40"},
+		{Container: "mysql", Database: "old", Time: day(41), Path: "old-41"},
+	}
+
+	tests := []struct {
+		name string
+		keep int
+		days int
+		want []string
+	}{
+		{
+			name: "keeps the newest backups of each database",
+			keep: 2,
+			want: []string{"craft-2", "craft-10"},
+		},
+		{
+			name: "removes old backups but keeps the newest",
+			days: 7,
+			want: []string{"craft-10", "old-41"},
+		},
+		{
+			name: "both limits are applied",
+			keep: 3,
+			days: 7,
+			want: []string{"craft-10", "old-41"},
+		},
+		{
+			name: "nothing expires without limits",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, b := range Expired(backups, tt.keep, tt.days, now) {
+				got = append(got, b.Path)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAutomatic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nitro-backups")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var backups []Backup
+	for _, b := range []struct {
+		name      string
+		manifest  bool
+		automatic bool
+	}{
+		{name: "craft-201102_020000.sql", manifest: true, automatic: true},
+		{name: "craft-201101_020000.sql", manifest: true},
+		{name: "craft-201031_020000.sql"},
+	} {
+		path := filepath.Join(dir, b.name)
+		if err := ioutil.WriteFile(path, []byte("dump"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if b.manifest {
+			if err := WriteManifest(path, &Manifest{Engine: "mysql", Automatic: b.automatic}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		backups = append(backups, Backup{Container: "mysql", Database: "craft", Path: path})
+	}
+
+	got := Automatic(backups)
+	if len(got) != 1 || got[0].Path != backups[0].Path {
+		t.Errorf("Automatic() = %v, want only %s", got, backups[0].Path)
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		schedule string
+		want     time.Duration
+		wantErr  bool
+	}{
+		{schedule: "hourly", want: time.Hour},
+		{schedule: "daily", want: 24 * time.Hour},
+		{schedule: "weekly", want: 7 * 24 * time.Hour},
+		{schedule: "12h", want: 12 * time.Hour},
+		{schedule: "10m", wantErr: true},
+		{schedule: "monthly", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schedule, func(t *testing.T) {
+			got, err := ParseSchedule(tt.schedule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSchedule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
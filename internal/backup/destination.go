@@ -0,0 +1,299 @@
+package backup
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/craftcms/nitro/internal/s3"
+)
+
+// Local is the name of the backups directory on the host, ~/.nitro/backups.
+const Local = "local"
+
+// Destination is where backups are stored. The backups of each machine are
+// in <machine>/<container>/ like the backups directory on the host.
+type Destination interface {
+	// Name is the name of the destination in the config
+	Name() string
+	// Machines returns the machines that have backups
+	Machines(ctx context.Context) ([]string, error)
+	// List returns the backups of the machine, sorted like List
+	List(ctx context.Context, machine string) ([]Backup, error)
+	// Upload copies the backup on the host and its manifest to the destination
+	Upload(ctx context.Context, machine string, b Backup) error
+	// Fetch returns the path of the backup on the host, backups that are not
+	// on the host are downloaded with their manifest to the directory
+	Fetch(ctx context.Context, b Backup, dir string) (string, error)
+}
+
+// Dir is a directory on the host, such as ~/.nitro/backups or a NAS mount.
+type Dir struct {
+	name string
+	path string
+}
+
+// NewDir returns the directory as a destination.
+func NewDir(name, path string) *Dir {
+	return &Dir{name: name, path: path}
+}
+
+func (d *Dir) Name() string {
+	return d.name
+}
+
+func (d *Dir) Machines(ctx context.Context) ([]string, error) {
+	return Machines(d.path)
+}
+
+func (d *Dir) List(ctx context.Context, machine string) ([]Backup, error) {
+	backups, err := List(filepath.Join(d.path, machine))
+	for i := range backups {
+		backups[i].Destination = d.name
+	}
+
+	return backups, err
+}
+
+// Upload copies the backup to a hidden file that is renamed once
+// it is complete, so a partial copy is not listed as a backup.
+func (d *Dir) Upload(ctx context.Context, machine string, b Backup) error {
+	dir := filepath.Join(d.path, machine, b.Container)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	files := []string{b.Path}
+	if _, err := os.Stat(ManifestPath(b.Path)); err == nil {
+		files = append(files, ManifestPath(b.Path))
+	}
+
+	for _, file := range files {
+		if err := copyFile(file, filepath.Join(dir, filepath.Base(file))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *Dir) Fetch(ctx context.Context, b Backup, dir string) (string, error) {
+	return b.Path, nil
+}
+
+// Bucket is a bucket in S3 compatible storage, the keys of the backups
+// start with the prefix.
+type Bucket struct {
+	name   string
+	client *s3.Client
+	prefix string
+}
+
+// NewBucket returns the bucket of the client as a destination.
+func NewBucket(name string, client *s3.Client, prefix string) *Bucket {
+	return &Bucket{name: name, client: client, prefix: strings.Trim(prefix, "/")}
+}
+
+func (b *Bucket) Name() string {
+	return b.name
+}
+
+func (b *Bucket) key(elem ...string) string {
+	return path.Join(append([]string{b.prefix}, elem...)...)
+}
+
+// root is the start of the keys of every backup.
+func (b *Bucket) root() string {
+	if b.prefix == "" {
+		return ""
+	}
+
+	return b.prefix + "/"
+}
+
+func (b *Bucket) Machines(ctx context.Context) ([]string, error) {
+	objects, err := b.client.List(ctx, b.root())
+	if err != nil {
+		return nil, err
+	}
+
+	var machines []string
+	for _, o := range objects {
+		machine, _, ok := b.parseKey(o.Key)
+		if !ok {
+			continue
+		}
+
+		if len(machines) == 0 || machines[len(machines)-1] != machine {
+			machines = append(machines, machine)
+		}
+	}
+
+	return machines, nil
+}
+
+func (b *Bucket) List(ctx context.Context, machine string) ([]Backup, error) {
+	objects, err := b.client.List(ctx, b.key(machine)+"/")
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []Backup
+	for _, o := range objects {
+		_, container, ok := b.parseKey(o.Key)
+		if !ok {
+			continue
+		}
+
+		database, t, err := Parse(path.Base(o.Key))
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, Backup{
+			Container:   container,
+			Database:    database,
+			Time:        t,
+			Size:        o.Size,
+			Path:        o.Key,
+			Destination: b.name,
+		})
+	}
+	sortBackups(backups)
+
+	return backups, nil
+}
+
+// parseKey returns the machine and container of the key of a backup,
+// keys that are not backups, such as manifests, are skipped.
+func (b *Bucket) parseKey(key string) (string, string, bool) {
+	parts := strings.Split(strings.TrimPrefix(key, b.root()), "/")
+	if len(parts) != 3 || strings.HasPrefix(parts[2], ".") || strings.HasSuffix(parts[2], ManifestExt) {
+		return "", "", false
+	}
+	if _, _, err := Parse(parts[2]); err != nil {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+// Upload uploads the backup before the manifest, so a backup
+// with a manifest in the bucket was uploaded completely.
+func (b *Bucket) Upload(ctx context.Context, machine string, backup Backup) error {
+	key := b.key(machine, backup.Container, filepath.Base(backup.Path))
+	if err := b.client.PutFile(ctx, key, backup.Path); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(ManifestPath(backup.Path)); err != nil {
+		return nil
+	}
+
+	return b.client.PutFile(ctx, ManifestPath(key), ManifestPath(backup.Path))
+}
+
+// Fetch downloads the backup and its manifest, when it has one.
+func (b *Bucket) Fetch(ctx context.Context, backup Backup, dir string) (string, error) {
+	file := filepath.Join(dir, path.Base(backup.Path))
+	if err := b.download(ctx, backup.Path, file); err != nil {
+		return "", err
+	}
+
+	if err := b.download(ctx, ManifestPath(backup.Path), ManifestPath(file)); err != nil && !s3.IsNotFound(err) {
+		return "", err
+	}
+
+	return file, nil
+}
+
+func (b *Bucket) download(ctx context.Context, key, file string) error {
+	r, err := b.client.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(file)
+		return err
+	}
+
+	return f.Close()
+}
+
+// Merge returns the backups in each of the lists once, a backup in several
+// destinations is in the first destination with the names of all of them in
+// Locations. The lists must be sorted like List.
+func Merge(lists ...[]Backup) []Backup {
+	var backups []Backup
+	index := make(map[string]int)
+	for _, list := range lists {
+		for _, b := range list {
+			id := b.Container + "/" + filepath.Base(b.Path)
+			if i, ok := index[id]; ok {
+				backups[i].Locations = append(backups[i].Locations, b.Destination)
+				continue
+			}
+
+			index[id] = len(backups)
+			b.Locations = []string{b.Destination}
+			backups = append(backups, b)
+		}
+	}
+	sortBackups(backups)
+
+	return backups
+}
+
+// copyFile copies the file to a hidden file in the directory of
+// the destination, which is renamed once it is complete.
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := ioutil.TempFile(filepath.Dir(dest), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, in); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), dest)
+}
+
+// sortBackups sorts the backups by container, database and newest first.
+func sortBackups(backups []Backup) {
+	sort.SliceStable(backups, func(i, k int) bool {
+		a, b := backups[i], backups[k]
+		if a.Container != b.Container {
+			return a.Container < b.Container
+		}
+		if a.Database != b.Database {
+			return a.Database < b.Database
+		}
+
+		return a.Time.After(b.Time)
+	})
+}
@@ -0,0 +1,164 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/craftcms/nitro/internal/s3"
+)
+
+// bucketServer stores the objects of a bucket named nitro in memory.
+func bucketServer(objects map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/nitro/")
+
+		switch {
+		case r.Method == http.MethodPut:
+			b, _ := ioutil.ReadAll(r.Body)
+			objects[key] = string(b)
+		case r.URL.Path == "/nitro":
+			var keys []string
+			for k := range objects {
+				if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+
+			fmt.Fprint(w, "<ListBucketResult>")
+			for _, k := range keys {
+				fmt.Fprintf(w, "<Contents><Key>%s</Key><Size>%d</Size></Contents>", k, len(objects[k]))
+			}
+			fmt.Fprint(w, "</ListBucketResult>")
+		default:
+			content, ok := objects[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprint(w, content)
+		}
+	}))
+}
+
+func TestDestinations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nitro-backups-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// a backup with a manifest in the local backups directory
+	local := NewDir(Local, filepath.Join(dir, "local"))
+	file := filepath.Join(dir, "local", "nitro-dev", "mysql_5.7_3306", "craft-201118_020304.sql.gz")
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(file, []byte("backup"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteManifest(file, &Manifest{Engine: "mysql"}); err != nil {
+		t.Fatal(err)
+	}
+
+	objects := map[string]string{
+		// a backup that is only in the bucket and a file that is not a backup
+		"backups/nitro-dev/mysql_5.7_3306/craft-201117_020304.sql": "older",
+		"backups/nitro-dev/notes.txt":                              "notes",
+		"other/old/mysql_5.7_3306/craft-201117_020304.sql":         "not in the prefix",
+	}
+	server := bucketServer(objects)
+	defer server.Close()
+
+	client, err := s3.New(server.URL, "", "nitro", "minio", "minio123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	nas := NewDir("nas", filepath.Join(dir, "nas"))
+	bucket := NewBucket("minio", client, "/backups/")
+
+	backups, err := local.List(ctx, "nitro-dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range []Destination{nas, bucket} {
+		if err := d.Upload(ctx, "nitro-dev", backups[0]); err != nil {
+			t.Fatalf("%s: Upload() error = %v", d.Name(), err)
+		}
+
+		machines, err := d.Machines(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"nitro-dev"}; !reflect.DeepEqual(machines, want) {
+			t.Errorf("%s: Machines() = %v, want %v", d.Name(), machines, want)
+		}
+	}
+
+	if _, ok := objects["backups/nitro-dev/mysql_5.7_3306/craft-201118_020304.sql.gz.manifest.json"]; !ok {
+		t.Errorf("expected the manifest to be uploaded, got %v", objects)
+	}
+	if _, err := ReadManifest(filepath.Join(dir, "nas", "nitro-dev", "mysql_5.7_3306", "craft-201118_020304.sql.gz")); err != nil {
+		t.Errorf("expected the manifest to be copied, got %v", err)
+	}
+
+	var lists [][]Backup
+	for _, d := range []Destination{local, nas, bucket} {
+		list, err := d.List(ctx, "nitro-dev")
+		if err != nil {
+			t.Fatal(err)
+		}
+		lists = append(lists, list)
+	}
+
+	merged := Merge(lists...)
+	var got []string
+	for _, b := range merged {
+		got = append(got, fmt.Sprintf("%s %s %v", filepath.Base(b.Path), b.Destination, b.Locations))
+	}
+	want := []string{
+		"craft-201118_020304.sql.gz local [local nas minio]",
+		"craft-201117_020304.sql minio [minio]",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() = %q, want %q", got, want)
+	}
+
+	// backups in the bucket are downloaded with their manifest
+	tmp := filepath.Join(dir, "tmp")
+	if err := os.Mkdir(tmp, 0755); err != nil {
+		t.Fatal(err)
+	}
+	fetched, err := bucket.Fetch(ctx, lists[2][0], tmp)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if b, _ := ioutil.ReadFile(fetched); string(b) != "backup" {
+		t.Errorf("Fetch() downloaded %q", b)
+	}
+	if _, err := ReadManifest(fetched); err != nil {
+		t.Errorf("expected the manifest to be downloaded, got %v", err)
+	}
+
+	// backups without a manifest are downloaded
+	if _, err := bucket.Fetch(ctx, lists[2][1], tmp); err != nil {
+		t.Errorf("Fetch() error = %v", err)
+	}
+
+	// backups in directories are not copied
+	if path, err := nas.Fetch(ctx, lists[1][0], tmp); err != nil || path != lists[1][0].Path {
+		t.Errorf("Fetch() = %q, %v", path, err)
+	}
+}
@@ -0,0 +1,340 @@
+package backup
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/craftcms/nitro/internal/compress"
+	"github.com/craftcms/nitro/internal/database"
+	"github.com/craftcms/nitro/internal/encrypt"
+)
+
+// ManifestExt is added to the name of a backup for the name of its manifest.
+const ManifestExt = ".manifest.json"
+
+// Options are how a backup is compressed and encrypted.
+type Options struct {
+	// Compression is gzip, zstd or none
+	Compression string
+	Encryption  encrypt.Options
+}
+
+// Ext returns the extension added to the name of the backup after .sql,
+// e.g. gz.age for a backup compressed with gzip and encrypted with age.
+func (o Options) Ext() (string, error) {
+	format, err := CompressionFormat(o.Compression)
+	if err != nil {
+		return "", err
+	}
+
+	var ext []string
+	if format != "" {
+		ext = append(ext, format)
+	}
+	if o.Encryption.Enabled() {
+		ext = append(ext, o.Encryption.Format())
+	}
+
+	return strings.Join(ext, "."), nil
+}
+
+// CompressionFormat returns the format used by the compress package
+// for the compression of backups, which is gzip, zstd or none.
+func CompressionFormat(compression string) (string, error) {
+	switch compression {
+	case "", "none":
+		return "", nil
+	case "gzip":
+		return "gz", nil
+	case "zstd":
+		return "zst", nil
+	}
+
+	return "", fmt.Errorf("the compression %q must be gzip, zstd or none", compression)
+}
+
+// Manifest is written alongside a backup so the backup can be checked
+// before it is restored. The checksums are the SHA-256 of the backup
+// file and of the dump once it is decrypted and decompressed.
+type Manifest struct {
+	Container    string    `json:"container,omitempty"`
+	Engine       string    `json:"engine"`
+	Version      string    `json:"version,omitempty"`
+	Databases    []string  `json:"databases"`
+	Tables       int       `json:"tables"`
+	Size         int64     `json:"size"`
+	Checksum     string    `json:"checksum"`
+	DumpSize     int64     `json:"dumpSize"`
+	DumpChecksum string    `json:"dumpChecksum"`
+	Compression  string    `json:"compression,omitempty"`
+	Encryption   string    `json:"encryption,omitempty"`
+	Created      time.Time `json:"created"`
+	// Automatic is true for the backups taken by the backup schedule, only
+	// these backups are removed when they expire
+	Automatic bool `json:"automatic,omitempty"`
+}
+
+// ManifestPath returns the path of the manifest of the backup.
+func ManifestPath(path string) string {
+	return path + ManifestExt
+}
+
+// WriteManifest writes the manifest alongside the backup.
+func WriteManifest(path string, m *Manifest) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(ManifestPath(path), append(b, '\n'), 0644)
+}
+
+// ReadManifest returns the manifest of the backup, the error is
+// os.ErrNotExist when the backup does not have a manifest.
+func ReadManifest(path string) (*Manifest, error) {
+	b, err := ioutil.ReadFile(ManifestPath(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("the manifest of the backup is not valid, error: %w", err)
+	}
+
+	return &m, nil
+}
+
+// Remove removes the backup and its manifest.
+func Remove(path string) error {
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+
+	if err := os.Remove(ManifestPath(path)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Write writes the dump to w, compressed and then encrypted using the
+// options, and returns the manifest of the backup. The dump is scanned as
+// it is written for the engine, version and the databases it creates, the
+// container and the databases of a dump of one database are left to the
+// caller.
+func Write(w io.Writer, opts Options, dump func(io.Writer) error) (*Manifest, error) {
+	format, err := CompressionFormat(opts.Compression)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &hashWriter{h: sha256.New()}
+	out := io.Writer(io.MultiWriter(w, file))
+
+	// each writer is closed in reverse order to finish the file
+	var closers []io.Closer
+	if opts.Encryption.Enabled() {
+		ew, err := encrypt.NewWriter(out, opts.Encryption)
+		if err != nil {
+			return nil, err
+		}
+		closers = append(closers, ew)
+		out = ew
+	}
+	if format != "" {
+		cw, err := compress.NewWriter(out, format)
+		if err != nil {
+			closeAll(closers)
+			return nil, err
+		}
+		closers = append(closers, cw)
+		out = cw
+	}
+
+	pr, pw := io.Pipe()
+	analysis := make(chan *database.Analysis, 1)
+	go func() {
+		a, _ := database.Analyze(pr)
+		// the rest of the dump is read when it cannot be scanned
+		_, _ = io.Copy(ioutil.Discard, pr)
+		analysis <- a
+	}()
+
+	plain := &hashWriter{h: sha256.New()}
+	err = dump(io.MultiWriter(out, plain, pw))
+	pw.Close()
+	a := <-analysis
+
+	// the error of the command that failed is more useful than the broken pipe
+	if cerr := closeAll(closers); cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{
+		Databases:    []string{},
+		Size:         file.n,
+		Checksum:     file.sum(),
+		DumpSize:     plain.n,
+		DumpChecksum: plain.sum(),
+		Compression:  opts.Compression,
+		Encryption:   opts.Encryption.Method(),
+		Created:      time.Now(),
+	}
+	if m.Compression == "none" {
+		m.Compression = ""
+	}
+	if a != nil {
+		m.Engine = a.Engine
+		m.Version = a.Version
+		m.Tables = a.Tables
+		if len(a.Databases) > 0 {
+			m.Databases = a.Databases
+		}
+	}
+
+	return m, nil
+}
+
+// Verification is the result of verifying a backup.
+type Verification struct {
+	// Manifest is nil when the backup does not have a manifest
+	Manifest    *Manifest
+	Encryption  string
+	Compression string
+	Analysis    *database.Analysis
+}
+
+// Verify checks the backup matches its manifest, when it has one, and that
+// it can be decrypted, decompressed and scanned as a database dump.
+func Verify(path string, opts encrypt.Options) (*Verification, error) {
+	v := &Verification{}
+
+	m, err := ReadManifest(path)
+	switch {
+	case err == nil:
+		v.Manifest = m
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+
+	// the checksum is checked first so a corrupt backup is not decrypted
+	if m != nil {
+		size, sum, err := checksum(path)
+		if err != nil {
+			return nil, err
+		}
+		if size != m.Size || sum != m.Checksum {
+			return v, errors.New("the backup does not match the checksum in its manifest, it is corrupt or was changed")
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	head, _ := br.Peek(512)
+
+	var r io.Reader = br
+	if v.Encryption = encrypt.Detect(head); v.Encryption != "" {
+		dr, err := encrypt.NewReader(r, v.Encryption, opts)
+		if err != nil {
+			return v, err
+		}
+		defer dr.Close()
+
+		dbr := bufio.NewReader(dr)
+		head, _ = dbr.Peek(512)
+		r = dbr
+	}
+
+	if v.Compression = compress.Detect(head); v.Compression != "" {
+		cr, err := compress.NewReader(r, v.Compression)
+		if err != nil {
+			return v, err
+		}
+		defer cr.Close()
+		r = cr
+	}
+
+	plain := &hashWriter{h: sha256.New()}
+	r = io.TeeReader(r, plain)
+
+	a, err := database.Analyze(r)
+	if err != nil {
+		return v, fmt.Errorf("unable to read the dump in the backup, error: %w", err)
+	}
+	// the rest of the dump is read so the checksum is of the whole dump
+	if _, err := io.Copy(ioutil.Discard, r); err != nil {
+		return v, fmt.Errorf("unable to read the dump in the backup, error: %w", err)
+	}
+	v.Analysis = a
+
+	if m != nil && (plain.n != m.DumpSize || plain.sum() != m.DumpChecksum) {
+		return v, errors.New("the dump in the backup does not match the checksum in its manifest")
+	}
+
+	if a.Statements == 0 {
+		return v, errors.New("the backup does not have any SQL statements")
+	}
+
+	return v, nil
+}
+
+// checksum returns the size and checksum of the file.
+func checksum(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+
+	h := &hashWriter{h: sha256.New()}
+	if _, err := io.Copy(h, f); err != nil {
+		return 0, "", err
+	}
+
+	return h.n, h.sum(), nil
+}
+
+// hashWriter counts and hashes what is written.
+type hashWriter struct {
+	h hash.Hash
+	n int64
+}
+
+func (w *hashWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return w.h.Write(p)
+}
+
+func (w *hashWriter) sum() string {
+	return "sha256:" + hex.EncodeToString(w.h.Sum(nil))
+}
+
+func closeAll(closers []io.Closer) error {
+	var err error
+	for i := len(closers) - 1; i >= 0; i-- {
+		if cerr := closers[i].Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,197 @@
+package backup
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/craftcms/nitro/internal/encrypt"
+)
+
+var testTime = time.Date(2020, 11, 18, 2, 3, 4, 0, time.Local)
+
+const testDump = `-- MySQL dump 10.13  Distrib 5.7.32, for Linux (x86_64)
+--
+-- Host: localhost    Database:
+-- ------------------------------------------------------
+-- Server version	5.7.32
+
+CREATE DATABASE /*!32312 IF NOT EXISTS*/ ` + "`craft`" + ` /*!40100 DEFAULT CHARACTER SET utf8mb4 */;
+
+USE ` + "`craft`" + `;
+
+CREATE TABLE ` + "`users`" + ` (
+  ` + "`id`" + ` int(11) NOT NULL
+);
+
+INSERT INTO ` + "`users`" + ` VALUES (1),(2);
+`
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		wantExt  string
+		wantErr  bool
+		needs    string
+		wantEnc  string
+		wantComp string
+	}{
+		{
+			name: "plain backups",
+		},
+		{
+			name:     "gzip compressed backups",
+			opts:     Options{Compression: "gzip"},
+			wantExt:  "gz",
+			wantComp: "gz",
+		},
+		{
+			name:     "zstd compressed backups",
+			opts:     Options{Compression: "zstd"},
+			wantExt:  "zst",
+			needs:    "zstd",
+			wantComp: "zst",
+		},
+		{
+			name:     "compressed backups encrypted with a passphrase",
+			opts:     Options{Compression: "gzip", Encryption: encrypt.Options{Passphrase: "secret"}},
+			wantExt:  "gz.gpg",
+			needs:    "gpg",
+			wantEnc:  encrypt.GPG,
+			wantComp: "gz",
+		},
+		{
+			name:    "unknown compressions return an error",
+			opts:    Options{Compression: "lz4"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.needs != "" {
+				if _, err := exec.LookPath(tt.needs); err != nil {
+					t.Skipf("%s is not installed", tt.needs)
+				}
+			}
+
+			ext, err := tt.opts.Ext()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ext() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if ext != tt.wantExt {
+				t.Errorf("Ext() = %q, want %q", ext, tt.wantExt)
+			}
+
+			dir, err := ioutil.TempDir("", "nitro-backups-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			path := filepath.Join(dir, Filename("all-dbs", testTime, ext))
+			var buf bytes.Buffer
+			m, err := Write(&buf, tt.opts, func(w io.Writer) error {
+				_, err := io.Copy(w, strings.NewReader(testDump))
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if m.Engine != "mysql" || m.Version != "5.7.32" || m.Tables != 1 || !reflect.DeepEqual(m.Databases, []string{"craft"}) {
+				t.Errorf("unexpected manifest %+v", m)
+			}
+			if m.Size != int64(buf.Len()) || m.DumpSize != int64(len(testDump)) {
+				t.Errorf("got sizes %d and %d, want %d and %d", m.Size, m.DumpSize, buf.Len(), len(testDump))
+			}
+
+			if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := WriteManifest(path, m); err != nil {
+				t.Fatal(err)
+			}
+
+			v, err := Verify(path, tt.opts.Encryption)
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if v.Manifest == nil || v.Encryption != tt.wantEnc || v.Compression != tt.wantComp || v.Analysis.Tables != 1 {
+				t.Errorf("unexpected verification %+v", v)
+			}
+
+			// a changed backup does not match the manifest
+			if err := ioutil.WriteFile(path, append(buf.Bytes(), '\n'), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Verify(path, tt.opts.Encryption); err == nil {
+				t.Error("expected an error verifying a changed backup")
+			}
+
+			if err := Remove(path); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := os.Stat(ManifestPath(path)); !os.IsNotExist(err) {
+				t.Errorf("expected the manifest to be removed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	if _, err := exec.LookPath("gpg"); err != nil {
+		t.Skip("gpg is not installed")
+	}
+
+	dir, err := ioutil.TempDir("", "nitro-backups-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	opts := Options{Encryption: encrypt.Options{Passphrase: "secret"}}
+	path := filepath.Join(dir, Filename("craft", testTime, "gpg"))
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Write(f, opts, func(w io.Writer) error {
+		_, err := io.WriteString(w, testDump)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	// backups without a manifest are still decrypted and scanned
+	v, err := Verify(path, opts.Encryption)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if v.Manifest != nil || v.Analysis.Statements == 0 {
+		t.Errorf("unexpected verification %+v", v)
+	}
+
+	if _, err := Verify(path, encrypt.Options{Passphrase: "wrong"}); err == nil {
+		t.Error("expected an error verifying with the wrong passphrase")
+	}
+
+	// files that are not dumps cannot be verified
+	if err := ioutil.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Verify(path, opts.Encryption); err == nil {
+		t.Error("expected an error verifying an empty backup")
+	}
+}
@@ -0,0 +1,163 @@
+// Package catalog describes the versions of PHP and the database engines
+// that nitro supports. Validation, prompts, completion and installs all
+// read the catalog. The catalog is declared as Go values in data.go and
+// is compiled into nitro, so adding a version only requires changing
+// data.go and nothing is read from disk.
+package catalog
+
+import (
+	"fmt"
+	"time"
+)
+
+// dateLayout is used for the end of life dates.
+const dateLayout = "2006-01-02"
+
+// PHP is a version of PHP that can be installed on a machine.
+type PHP struct {
+	Version string
+	// Packages are installed with apt-get to add the version.
+	Packages []string
+	// EOL is the date the version stops receiving security fixes.
+	EOL string
+}
+
+// Engine is a database engine that runs in a docker container.
+type Engine struct {
+	Name  string
+	Title string
+	Image string
+	// Port is the default port on the machine.
+	Port string
+	// ContainerPort is the port the engine listens on in the container.
+	ContainerPort string
+	DataPath      string
+	// ConfPath is where the conf directory is mounted in the container.
+	ConfPath string
+	// InitPath is where the setup script is mounted in the container.
+	InitPath string
+	// SetupFile is the setup script on the machine.
+	SetupFile string
+	Env       []string
+	// Default is the version suggested when adding the engine.
+	Default  string
+	Versions []Version
+}
+
+// Version is a version of a database engine.
+type Version struct {
+	Version string
+	// Tag is the tag of the engine's image.
+	Tag string
+	// Conf is the directory on the machine mounted as the conf directory.
+	Conf string
+	// EOL is the date the version stops receiving security fixes. Aliases
+	// for a major version, such as 8 or 5, have the date of the release
+	// their tag points to.
+	EOL string
+}
+
+// PHPVersions returns the supported versions of PHP, the newest first.
+func PHPVersions() []string {
+	var versions []string
+	for _, p := range php {
+		versions = append(versions, p.Version)
+	}
+
+	return versions
+}
+
+// LookupPHP returns the version of PHP and false when it is not supported.
+func LookupPHP(version string) (PHP, bool) {
+	for _, p := range php {
+		if p.Version == version {
+			return p, true
+		}
+	}
+
+	return PHP{}, false
+}
+
+// Engines returns the names of the supported database engines.
+func Engines() []string {
+	var names []string
+	for _, e := range engines {
+		names = append(names, e.Name)
+	}
+
+	return names
+}
+
+// LookupEngine returns the database engine and false when it is not supported.
+func LookupEngine(name string) (Engine, bool) {
+	for _, e := range engines {
+		if e.Name == name {
+			return e, true
+		}
+	}
+
+	return Engine{}, false
+}
+
+// VersionNames returns the supported versions of the engine.
+func (e Engine) VersionNames() []string {
+	var versions []string
+	for _, v := range e.Versions {
+		versions = append(versions, v.Version)
+	}
+
+	return versions
+}
+
+// Lookup returns the version of the engine and false when it is not supported.
+func (e Engine) Lookup(version string) (Version, bool) {
+	for _, v := range e.Versions {
+		if v.Version == version {
+			return v, true
+		}
+	}
+
+	return Version{}, false
+}
+
+// Expired returns true when the end of life date is before now,
+// versions without a date are never expired.
+func Expired(eol string, now time.Time) bool {
+	if eol == "" {
+		return false
+	}
+
+	t, err := time.Parse(dateLayout, eol)
+	if err != nil {
+		return false
+	}
+
+	return now.After(t)
+}
+
+// PHPWarning returns a warning when the version of PHP has reached its
+// end of life, otherwise an empty string.
+func PHPWarning(version string, now time.Time) string {
+	p, ok := LookupPHP(version)
+	if !ok || !Expired(p.EOL, now) {
+		return ""
+	}
+
+	return fmt.Sprintf("PHP %s reached its end of life on %s and no longer receives security fixes", p.Version, p.EOL)
+}
+
+// DatabaseWarning returns a warning when the version of the engine has
+// reached its end of life, otherwise an empty string.
+func DatabaseWarning(engine, version string, now time.Time) string {
+	e, ok := LookupEngine(engine)
+	if !ok {
+		return ""
+	}
+
+	v, ok := e.Lookup(version)
+	if !ok || !Expired(v.EOL, now) {
+		return ""
+	}
+
+	return fmt.Sprintf("%s %s reached its end of life on %s and no longer receives security fixes", e.Title, v.Version, v.EOL)
+}
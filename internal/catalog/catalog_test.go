@@ -0,0 +1,109 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCatalog(t *testing.T) {
+	for _, p := range php {
+		if len(p.Packages) == 0 {
+			t.Errorf("PHP %s has no packages", p.Version)
+		}
+		if _, err := time.Parse(dateLayout, p.EOL); err != nil {
+			t.Errorf("PHP %s has an invalid end of life date: %v", p.Version, err)
+		}
+	}
+
+	if _, ok := LookupPHP(DefaultPHP); !ok {
+		t.Errorf("the default PHP version %s is not in the catalog", DefaultPHP)
+	}
+
+	for _, e := range engines {
+		if _, ok := e.Lookup(e.Default); !ok {
+			t.Errorf("the default %s version %s is not in the catalog", e.Name, e.Default)
+		}
+
+		seen := make(map[string]bool)
+		for _, v := range e.Versions {
+			if seen[v.Version] {
+				t.Errorf("%s %s is in the catalog more than once", e.Name, v.Version)
+			}
+			seen[v.Version] = true
+
+			if v.Tag == "" || v.Conf == "" {
+				t.Errorf("%s %s is missing the image tag or conf directory", e.Name, v.Version)
+			}
+			if _, err := time.Parse(dateLayout, v.EOL); err != nil {
+				t.Errorf("%s %s has an invalid end of life date: %v", e.Name, v.Version, err)
+			}
+		}
+
+		// aliases have the date of one of the releases of the major version
+		for _, alias := range e.Versions {
+			if strings.Contains(alias.Version, ".") {
+				continue
+			}
+
+			found := false
+			for _, v := range e.Versions {
+				if strings.HasPrefix(v.Version, alias.Version+".") && v.EOL == alias.EOL {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("%s %s does not have the end of life date of a %s release", e.Name, alias.Version, alias.Version)
+			}
+		}
+	}
+}
+
+func TestExpired(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		eol  string
+		want bool
+	}{
+		{name: "past dates are expired", eol: "2020-11-30", want: true},
+		{name: "future dates are not expired", eol: "2022-11-28", want: false},
+		{name: "empty dates are never expired", eol: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Expired(tt.eol, now); got != tt.want {
+				t.Errorf("Expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDatabaseWarning(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		engine  string
+		version string
+		want    string
+	}{
+		{
+			name:    "end of life versions return a warning",
+			engine:  "postgres",
+			version: "9.5",
+			want:    "PostgreSQL 9.5 reached its end of life on 2021-02-11 and no longer receives security fixes",
+		},
+		{name: "supported versions do not return a warning", engine: "mysql", version: "8.0"},
+		{name: "unknown versions do not return a warning", engine: "mysql", version: "5.8"},
+		{name: "unknown engines do not return a warning", engine: "mongo", version: "4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DatabaseWarning(tt.engine, tt.version, now); got != tt.want {
+				t.Errorf("DatabaseWarning() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package catalog
+
+import "strings"
+
+// DefaultPHP is the version of PHP used when none is set.
+const DefaultPHP = "7.4"
+
+// php is sorted with the newest version first.
+var php = []PHP{
+	{
+		Version:  "8.0",
+		Packages: strings.Fields("php8.0 php8.0-mbstring php8.0-cli php8.0-curl php8.0-fpm php8.0-gd php8.0-intl php8.0-mysql php8.0-pgsql php8.0-zip php8.0-xml php8.0-soap php8.0-bcmath php8.0-gmp php-xdebug php-imagick blackfire-agent blackfire-php"),
+		EOL:      "2023-11-26",
+	},
+	{
+		Version:  "7.4",
+		Packages: strings.Fields("php7.4 php7.4-mbstring php7.4-cli php7.4-curl php7.4-fpm php7.4-gd php7.4-intl php7.4-json php7.4-mysql php7.4-pgsql php7.4-zip php7.4-xml php7.4-soap php7.4-bcmath php7.4-gmp php-xdebug php-imagick blackfire-agent blackfire-php"),
+		EOL:      "2022-11-28",
+	},
+	{
+		Version:  "7.3",
+		Packages: strings.Fields("php7.3 php7.3-mbstring php7.3-cli php7.3-curl php7.3-fpm php7.3-gd php7.3-intl php7.3-json php7.3-mysql php7.3-pgsql php7.3-zip php7.3-xml php7.3-soap php7.3-bcmath php7.3-gmp php-xdebug php-imagick blackfire-agent blackfire-php"),
+		EOL:      "2021-12-06",
+	},
+	{
+		Version:  "7.2",
+		Packages: strings.Fields("php7.2 php7.2-mbstring php7.2-cli php7.2-curl php7.2-fpm php7.2-gd php7.2-intl php7.2-json php7.2-mysql php7.2-pgsql php7.2-zip php7.2-xml php7.2-soap php7.2-bcmath php7.2-gmp php-xdebug php-imagick blackfire-agent blackfire-php"),
+		EOL:      "2020-11-30",
+	},
+}
+
+var mysqlEnv = []string{"-e", "MYSQL_ROOT_PASSWORD=nitro", "-e", "MYSQL_DATABASE=nitro", "-e", "MYSQL_USER=nitro", "-e", "MYSQL_PASSWORD=nitro"}
+
+// engines are sorted in the order they are offered, with
+// the newest version of each engine first.
+var engines = []Engine{
+	{
+		Name:          "mysql",
+		Title:         "MySQL",
+		Image:         "mysql",
+		Port:          "3306",
+		ContainerPort: "3306",
+		DataPath:      "/var/lib/mysql",
+		ConfPath:      "/etc/mysql/conf.d",
+		InitPath:      "/docker-entrypoint-initdb.d/setup.sql",
+		SetupFile:     "/home/ubuntu/.nitro/databases/mysql/setup.sql",
+		Env:           mysqlEnv,
+		Default:       "5.7",
+		Versions: []Version{
+			{Version: "8.0", Tag: "8.0", Conf: "/home/ubuntu/.nitro/databases/mysql/conf.d/8/", EOL: "2026-04-30"},
+			{Version: "8", Tag: "8", Conf: "/home/ubuntu/.nitro/databases/mysql/conf.d/8/", EOL: "2026-04-30"},
+			{Version: "5.7", Tag: "5.7", Conf: "/home/ubuntu/.nitro/databases/mysql/conf.d/5/", EOL: "2023-10-21"},
+			{Version: "5.6", Tag: "5.6", Conf: "/home/ubuntu/.nitro/databases/mysql/conf.d/5/", EOL: "2021-02-01"},
+			{Version: "5", Tag: "5", Conf: "/home/ubuntu/.nitro/databases/mysql/conf.d/5/", EOL: "2023-10-21"},
+		},
+	},
+	{
+		Name:          "mariadb",
+		Title:         "MariaDB",
+		Image:         "mariadb",
+		Port:          "3306",
+		ContainerPort: "3306",
+		DataPath:      "/var/lib/mysql",
+		ConfPath:      "/etc/mysql/conf.d",
+		InitPath:      "/docker-entrypoint-initdb.d/setup.sql",
+		SetupFile:     "/home/ubuntu/.nitro/databases/mariadb/setup.sql",
+		Env:           mysqlEnv,
+		Default:       "10.5",
+		Versions: []Version{
+			{Version: "10.5", Tag: "10.5", Conf: "/home/ubuntu/.nitro/databases/mariadb/conf.d/", EOL: "2025-06-24"},
+			{Version: "10.4", Tag: "10.4", Conf: "/home/ubuntu/.nitro/databases/mariadb/conf.d/", EOL: "2024-06-18"},
+			{Version: "10.3", Tag: "10.3", Conf: "/home/ubuntu/.nitro/databases/mariadb/conf.d/", EOL: "2023-05-25"},
+			{Version: "10.2", Tag: "10.2", Conf: "/home/ubuntu/.nitro/databases/mariadb/conf.d/", EOL: "2022-05-23"},
+			{Version: "10", Tag: "10", Conf: "/home/ubuntu/.nitro/databases/mariadb/conf.d/", EOL: "2025-06-24"},
+		},
+	},
+	{
+		Name:          "postgres",
+		Title:         "PostgreSQL",
+		Image:         "postgres",
+		Port:          "5432",
+		ContainerPort: "5432",
+		DataPath:      "/var/lib/postgresql/data",
+		ConfPath:      "/etc/postgresql/",
+		InitPath:      "/docker-entrypoint-initdb.d/setup.sql",
+		SetupFile:     "/home/ubuntu/.nitro/databases/postgres/setup.sql",
+		Env:           []string{"-e", "POSTGRES_PASSWORD=nitro", "-e", "POSTGRES_USER=nitro"},
+		Default:       "12",
+		Versions: []Version{
+			{Version: "12", Tag: "12", Conf: "/home/ubuntu/.nitro/databases/postgres/conf.d/", EOL: "2024-11-14"},
+			{Version: "12.2", Tag: "12.2", Conf: "/home/ubuntu/.nitro/databases/postgres/conf.d/", EOL: "2024-11-14"},
+			{Version: "11", Tag: "11", Conf: "/home/ubuntu/.nitro/databases/postgres/conf.d/", EOL: "2023-11-09"},
+			{Version: "11.7", Tag: "11.7", Conf: "/home/ubuntu/.nitro/databases/postgres/conf.d/", EOL: "2023-11-09"},
+			{Version: "10", Tag: "10", Conf: "/home/ubuntu/.nitro/databases/postgres/conf.d/", EOL: "2022-11-10"},
+			{Version: "10.12", Tag: "10.12", Conf: "/home/ubuntu/.nitro/databases/postgres/conf.d/", EOL: "2022-11-10"},
+			{Version: "9.6", Tag: "9.6", Conf: "/home/ubuntu/.nitro/databases/postgres/conf.d/", EOL: "2021-11-11"},
+			{Version: "9.5", Tag: "9.5", Conf: "/home/ubuntu/.nitro/databases/postgres/conf.d/", EOL: "2021-02-11"},
+			{Version: "9", Tag: "9", Conf: "/home/ubuntu/.nitro/databases/postgres/conf.d/", EOL: "2021-11-11"},
+		},
+	},
+}
@@ -0,0 +1,373 @@
+// Package certs is the local certificate authority used to serve sites over
+// HTTPS. The CA is created once in ~/.nitro and issues a certificate for each
+// site, covering the hostname and the aliases, that is copied to the machine.
+// Trusting the CA on the host, with Trust, makes browsers accept the sites.
+package certs
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// CAFile is the name of the certificate of the CA in the nitro directory.
+	CAFile = "ca.pem"
+	// CAKeyFile is the name of the private key of the CA in the nitro directory.
+	CAKeyFile = "ca-key.pem"
+	// Name is the nickname of the CA in the trust stores.
+	Name = "Nitro CA"
+)
+
+const (
+	caValidity = 10 * 365 * 24 * time.Hour
+	// browsers reject certificates that are valid for more than 825 days
+	siteValidity = 825 * 24 * time.Hour
+	// certificates that expire sooner than this are issued again
+	renewBefore = 30 * 24 * time.Hour
+)
+
+// CA is the certificate authority that issues the site certificates.
+type CA struct {
+	Cert *x509.Certificate
+	Key  crypto.Signer
+}
+
+// CAPath returns the path of the certificate of the CA in the directory.
+func CAPath(dir string) string {
+	return filepath.Join(dir, CAFile)
+}
+
+// CertPath returns the path of the certificate of the site in the directory.
+func CertPath(dir, hostname string) string {
+	return filepath.Join(dir, "certs", hostname+".crt")
+}
+
+// KeyPath returns the path of the private key of the site in the directory.
+func KeyPath(dir, hostname string) string {
+	return filepath.Join(dir, "certs", hostname+".key")
+}
+
+// Load reads the CA from the directory.
+func Load(dir string) (*CA, error) {
+	certPEM, err := ioutil.ReadFile(CAPath(dir))
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := ioutil.ReadFile(filepath.Join(dir, CAKeyFile))
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := parseCert(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the certificate of the CA, %w", err)
+	}
+
+	key, err := parseKey(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the private key of the CA, %w", err)
+	}
+
+	return &CA{Cert: cert, Key: key}, nil
+}
+
+// LoadOrCreate reads the CA from the directory, or creates it when the
+// directory does not have one. It reports if the CA was created so it
+// can be trusted.
+func LoadOrCreate(dir string) (*CA, bool, error) {
+	if _, err := os.Stat(CAPath(dir)); err == nil {
+		ca, err := Load(dir)
+		return ca, false, err
+	}
+
+	ca, err := Create(dir)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return ca, true, nil
+}
+
+// Create creates a new CA and writes it to the directory, the private
+// key can only be read by the user.
+func Create(dir string) (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	keyID, err := subjectKeyID(key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	// the user and host are in the name so CAs from different
+	// computers can be told apart in the trust stores
+	subject := pkix.Name{Organization: []string{"Nitro"}, CommonName: Name + " " + owner()}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               subject,
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+		SubjectKeyId:          keyID,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := encodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, CAKeyFile), keyPEM, 0600); err != nil {
+		return nil, err
+	}
+
+	if err := ioutil.WriteFile(CAPath(dir), encodeCert(der), 0644); err != nil {
+		return nil, err
+	}
+
+	return &CA{Cert: cert, Key: key}, nil
+}
+
+// Issue writes a certificate for the hostname and aliases of a site to the
+// directory. A certificate that was already issued is kept unless the names
+// changed, it is about to expire or it was issued by another CA. It reports
+// if a new certificate was issued.
+func (ca *CA) Issue(dir, hostname string, aliases []string) (bool, error) {
+	if hostname == "" {
+		return false, errors.New("the hostname cannot be empty")
+	}
+
+	names := append([]string{hostname}, aliases...)
+	if ca.valid(CertPath(dir, hostname), names) {
+		return false, nil
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return false, err
+	}
+
+	serial, err := serialNumber()
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:   serial,
+		Subject:        pkix.Name{Organization: []string{"Nitro"}, CommonName: hostname},
+		NotBefore:      now.Add(-time.Hour),
+		NotAfter:       now.Add(siteValidity),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		AuthorityKeyId: ca.Cert.SubjectKeyId,
+	}
+	for _, name := range names {
+		if ip := net.ParseIP(name); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+			continue
+		}
+		tmpl.DNSNames = append(tmpl.DNSNames, name)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.Cert, key.Public(), ca.Key)
+	if err != nil {
+		return false, err
+	}
+
+	keyPEM, err := encodeKey(key)
+	if err != nil {
+		return false, err
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "certs"), 0755); err != nil {
+		return false, err
+	}
+
+	if err := ioutil.WriteFile(KeyPath(dir, hostname), keyPEM, 0600); err != nil {
+		return false, err
+	}
+
+	// the certificate of the CA is included so the chain is complete
+	chain := append(encodeCert(der), encodeCert(ca.Cert.Raw)...)
+	if err := ioutil.WriteFile(CertPath(dir, hostname), chain, 0644); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// valid reports if the certificate in the file was issued by the CA for the
+// names and does not need to be renewed.
+func (ca *CA) valid(file string, names []string) bool {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return false
+	}
+
+	cert, err := parseCert(b)
+	if err != nil {
+		return false
+	}
+
+	if time.Until(cert.NotAfter) < renewBefore {
+		return false
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Cert)
+	if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}}); err != nil {
+		return false
+	}
+
+	var have []string
+	have = append(have, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		have = append(have, ip.String())
+	}
+
+	return sameNames(have, names)
+}
+
+func sameNames(a, b []string) bool {
+	set := func(names []string) []string {
+		seen := make(map[string]bool)
+		var s []string
+		for _, name := range names {
+			if ip := net.ParseIP(name); ip != nil {
+				name = ip.String()
+			}
+			if !seen[name] {
+				seen[name] = true
+				s = append(s, name)
+			}
+		}
+		sort.Strings(s)
+		return s
+	}
+
+	x, y := set(a), set(b)
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseCert returns the first certificate in the PEM data.
+func parseCert(b []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(b)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("there is no PEM certificate")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func parseKey(b []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("there is no PEM private key")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("the private key cannot sign certificates")
+	}
+
+	return signer, nil
+}
+
+func encodeCert(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func encodeKey(key crypto.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+func serialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+// subjectKeyID is the SHA-1 of the public key, as described in RFC 5280.
+func subjectKeyID(pub crypto.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	id := sha1.Sum(der)
+	return id[:], nil
+}
+
+// owner returns user@host for the name of the CA.
+func owner() string {
+	var b bytes.Buffer
+	if u, err := user.Current(); err == nil {
+		b.WriteString(u.Username)
+	}
+	if host, err := os.Hostname(); err == nil {
+		b.WriteString("@" + host)
+	}
+
+	return b.String()
+}
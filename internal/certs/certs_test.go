@@ -0,0 +1,133 @@
+package certs
+
+import (
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestLoadOrCreate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nitro-certs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ca, created, err := LoadOrCreate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created {
+		t.Errorf("expected the CA to be created")
+	}
+	if !ca.Cert.IsCA {
+		t.Errorf("expected the certificate to be a CA")
+	}
+
+	info, err := os.Stat(dir + "/" + CAKeyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("expected the private key to only be readable by the user, got %v", perm)
+	}
+
+	loaded, created, err := LoadOrCreate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created {
+		t.Errorf("expected the existing CA to be loaded")
+	}
+	if !loaded.Cert.Equal(ca.Cert) {
+		t.Errorf("expected the loaded CA to match the created CA")
+	}
+}
+
+func TestCA_Issue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nitro-certs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ca, err := Create(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issued, err := ca.Issue(dir, "demo.test", []string{"www.demo.test", "192.168.64.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !issued {
+		t.Errorf("expected a certificate to be issued")
+	}
+
+	b, err := ioutil.ReadFile(CertPath(dir, "demo.test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := parseCert(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Cert)
+	for _, name := range []string{"demo.test", "www.demo.test", "192.168.64.2"} {
+		if _, err := cert.Verify(x509.VerifyOptions{DNSName: name, Roots: roots}); err != nil {
+			t.Errorf("expected the certificate to be valid for %q, got %v", name, err)
+		}
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{DNSName: "other.test", Roots: roots}); err == nil {
+		t.Errorf("expected the certificate to not be valid for other.test")
+	}
+
+	if _, err := parseKey(mustRead(t, KeyPath(dir, "demo.test"))); err != nil {
+		t.Errorf("expected the private key to be readable, got %v", err)
+	}
+
+	// the same names keep the certificate
+	issued, err = ca.Issue(dir, "demo.test", []string{"192.168.64.2", "www.demo.test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issued {
+		t.Errorf("expected the existing certificate to be kept")
+	}
+
+	// a new alias issues a new certificate
+	issued, err = ca.Issue(dir, "demo.test", []string{"www.demo.test", "192.168.64.2", "cms.demo.test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !issued {
+		t.Errorf("expected a new certificate for the new alias")
+	}
+
+	// a certificate from another CA is replaced
+	other, err := Create(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issued, err = other.Issue(dir, "demo.test", []string{"www.demo.test", "192.168.64.2", "cms.demo.test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !issued {
+		t.Errorf("expected a new certificate from the new CA")
+	}
+}
+
+func mustRead(t *testing.T, file string) []byte {
+	t.Helper()
+
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
@@ -0,0 +1,103 @@
+package certs
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Trust adds the certificate of the CA in the file to the trust store of
+// the system and to the NSS databases used by Firefox and Chrome. It returns
+// the names of the stores the CA was added to, with an error when one of
+// them could not be changed.
+func Trust(file string) ([]string, error) {
+	var stores []string
+
+	store, err := trustSystem(file)
+	if err != nil {
+		return stores, err
+	}
+	stores = append(stores, store)
+
+	nss, err := trustNSS(file)
+	stores = append(stores, nss...)
+
+	return stores, err
+}
+
+// trustNSS adds the CA to the NSS databases in the home directory with certutil.
+func trustNSS(file string) ([]string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dbs := nssDatabases(home)
+	if len(dbs) == 0 {
+		return nil, nil
+	}
+
+	certutil, err := exec.LookPath("certutil")
+	if err != nil {
+		return nil, fmt.Errorf("unable to add the CA to Firefox or Chrome, certutil was not found (it is in the libnss3-tools or nss-tools package)")
+	}
+
+	var stores []string
+	for _, db := range dbs {
+		out, err := exec.Command(certutil, "-A", "-d", db, "-t", "C,,", "-n", Name, "-i", file).CombinedOutput()
+		if err != nil {
+			return stores, fmt.Errorf("unable to add the CA to %s: %s", db, strings.TrimSpace(string(out)))
+		}
+		stores = append(stores, db)
+	}
+
+	return stores, nil
+}
+
+// nssDatabases returns the NSS databases of Chrome and the Firefox profiles,
+// prefixed with the database type certutil expects.
+func nssDatabases(home string) []string {
+	var dirs []string
+	for _, dir := range nssDirs {
+		dirs = append(dirs, filepath.Join(home, dir))
+	}
+	for _, pattern := range firefoxProfiles {
+		matches, _ := filepath.Glob(filepath.Join(home, pattern))
+		dirs = append(dirs, matches...)
+	}
+
+	var dbs []string
+	for _, dir := range dirs {
+		switch {
+		case exists(filepath.Join(dir, "cert9.db")):
+			dbs = append(dbs, "sql:"+dir)
+		case exists(filepath.Join(dir, "cert8.db")):
+			dbs = append(dbs, "dbm:"+dir)
+		}
+	}
+
+	return dbs
+}
+
+// run runs the command, with sudo when the user is not root, and returns
+// the output of the command in the error.
+func run(sudo bool, name string, args ...string) error {
+	if sudo && os.Geteuid() > 0 {
+		args = append([]string{name}, args...)
+		name = "sudo"
+	}
+
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s failed: %s", name, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
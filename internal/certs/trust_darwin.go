@@ -0,0 +1,16 @@
+package certs
+
+// Chrome and Safari use the system keychain on macOS.
+var nssDirs []string
+
+var firefoxProfiles = []string{"Library/Application Support/Firefox/Profiles/*"}
+
+const systemKeychain = "/Library/Keychains/System.keychain"
+
+func trustSystem(file string) (string, error) {
+	if err := run(true, "security", "add-trusted-cert", "-d", "-r", "trustRoot", "-k", systemKeychain, file); err != nil {
+		return "", err
+	}
+
+	return systemKeychain, nil
+}
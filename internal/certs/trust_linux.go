@@ -0,0 +1,51 @@
+package certs
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+)
+
+// nssDirs are the NSS databases of Chrome and Chromium, including the snap.
+var nssDirs = []string{".pki/nssdb", "snap/chromium/current/.pki/nssdb"}
+
+var firefoxProfiles = []string{".mozilla/firefox/*", "snap/firefox/common/.mozilla/firefox/*"}
+
+// systemStores are the anchor directories of the distributions and the
+// command that updates the bundle of trusted certificates.
+var systemStores = []struct {
+	dir     string
+	command []string
+}{
+	// Debian and Ubuntu
+	{dir: "/usr/local/share/ca-certificates", command: []string{"update-ca-certificates"}},
+	// Fedora, CentOS and RHEL
+	{dir: "/etc/pki/ca-trust/source/anchors", command: []string{"update-ca-trust", "extract"}},
+	// Arch
+	{dir: "/etc/ca-certificates/trust-source/anchors", command: []string{"trust", "extract-compat"}},
+	// openSUSE
+	{dir: "/usr/share/pki/trust/anchors", command: []string{"update-ca-certificates"}},
+}
+
+func trustSystem(file string) (string, error) {
+	for _, store := range systemStores {
+		if !exists(store.dir) {
+			continue
+		}
+		if _, err := exec.LookPath(store.command[0]); err != nil {
+			continue
+		}
+
+		if err := run(true, "cp", file, filepath.Join(store.dir, "nitro-ca.crt")); err != nil {
+			return "", err
+		}
+
+		if err := run(true, store.command[0], store.command[1:]...); err != nil {
+			return "", err
+		}
+
+		return store.dir, nil
+	}
+
+	return "", errors.New("unable to find the system trust store, the CA can be added manually to the trust store")
+}
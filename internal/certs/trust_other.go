@@ -0,0 +1,13 @@
+// +build !linux,!darwin,!windows
+
+package certs
+
+import "errors"
+
+var nssDirs = []string{".pki/nssdb"}
+
+var firefoxProfiles = []string{".mozilla/firefox/*"}
+
+func trustSystem(file string) (string, error) {
+	return "", errors.New("unable to add the CA to the trust store on this system, the CA can be added manually to the trust store")
+}
@@ -0,0 +1,16 @@
+package certs
+
+// Chrome and Edge use the Windows certificate store and certutil on Windows
+// is not the NSS tool, so the NSS databases of Firefox are not changed.
+var nssDirs []string
+
+var firefoxProfiles []string
+
+func trustSystem(file string) (string, error) {
+	// the store of the user does not need an administrator
+	if err := run(false, "certutil", "-user", "-addstore", "-f", "Root", file); err != nil {
+		return "", err
+	}
+
+	return "the Windows certificate store", nil
+}
@@ -13,7 +13,7 @@ import (
 // a new grpc client for interacting with nitrod nitrod
 // service.
 func NewClient(ip, port string) (nitrod.NitroServiceClient, error) {
-	cc, err := grpc.Dial(ip+":"+port, grpc.WithInsecure())
+	cc, err := dial(ip + ":" + port)
 	if err != nil {
 		log.Fatal("error creating nitrod client, error:", err)
 	}
@@ -24,7 +24,7 @@ func NewClient(ip, port string) (nitrod.NitroServiceClient, error) {
 func NewDefaultClient(machine string) (nitrod.NitroServiceClient, error) {
 	ip := nitro.IP(machine, nitro.NewMultipassRunner("multipass"))
 
-	cc, err := grpc.Dial(ip+":"+"50051", grpc.WithInsecure())
+	cc, err := dial(ip + ":" + "50051")
 	if err != nil {
 		log.Fatal("error creating nitrod client, error:", err)
 	}
@@ -36,10 +36,31 @@ func NewDefaultClient(machine string) (nitrod.NitroServiceClient, error) {
 // a new gRPC client for interacting with the nitrod systems
 // service.
 func NewSystemClient(ip, port string) (nitrod.SystemServiceClient, error) {
-	cc, err := grpc.Dial(ip+":"+port, grpc.WithInsecure())
+	cc, err := dial(ip + ":" + port)
 	if err != nil {
 		return nil, err
 	}
 
 	return nitrod.NewSystemServiceClient(cc), nil
 }
+
+// NewDefaultSystemClient creates a new gRPC client for
+// the nitrod systems service on the machine.
+func NewDefaultSystemClient(machine string) (nitrod.SystemServiceClient, error) {
+	ip := nitro.IP(machine, nitro.NewMultipassRunner("multipass"))
+
+	return NewSystemClient(ip, "50051")
+}
+
+// dial creates the connection to nitrod, every connection
+// checks the nitrod version before the first request.
+func dial(target string) (*grpc.ClientConn, error) {
+	check := &versionCheck{cli: Version}
+
+	return grpc.Dial(
+		target,
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(check.unary),
+		grpc.WithStreamInterceptor(check.stream),
+	)
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/craftcms/nitro/internal/nitrod"
+)
+
+// execBufferSize is the largest chunk of input sent in a single message.
+const execBufferSize = 32 * 1024
+
+// ExecStreams are connected to the command run by Exec.
+type ExecStreams struct {
+	// Stdin is sent to the command until it returns an error,
+	// a nil reader closes the input of the command
+	Stdin  io.Reader
+	Stdout io.Writer
+	// Stderr is not used when the command has a terminal
+	Stderr io.Writer
+	// Resize sends the size of the terminal when it changes
+	Resize <-chan *nitrod.TerminalSize
+}
+
+// Exec runs the command on the machine, streaming the input and output of
+// the command until it exits, and returns the exit code of the command.
+// Canceling the context kills the command.
+func Exec(ctx context.Context, c nitrod.NitroServiceClient, start *nitrod.ExecStart, streams ExecStreams) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// the input and resize events are sent from different goroutines
+	var mu sync.Mutex
+	send := func(req *nitrod.ExecRequest) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return stream.Send(req)
+	}
+
+	if err := send(&nitrod.ExecRequest{Request: &nitrod.ExecRequest_Start{Start: start}}); err != nil {
+		return 0, err
+	}
+
+	go sendInput(streams.Stdin, send)
+
+	if streams.Resize != nil {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case size, ok := <-streams.Resize:
+					if !ok {
+						return
+					}
+					if err := send(&nitrod.ExecRequest{Request: &nitrod.ExecRequest_Resize{Resize: size}}); err != nil {
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return 0, errors.New("the connection to nitrod closed before the command exited")
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		switch r := resp.GetResponse().(type) {
+		case *nitrod.ExecResponse_Stdout:
+			if streams.Stdout != nil {
+				_, _ = streams.Stdout.Write(r.Stdout)
+			}
+		case *nitrod.ExecResponse_Stderr:
+			if streams.Stderr != nil {
+				_, _ = streams.Stderr.Write(r.Stderr)
+			}
+		case *nitrod.ExecResponse_ExitCode:
+			return int(r.ExitCode), nil
+		}
+	}
+}
+
+// sendInput sends the input to the command until the reader
+// returns an error, then closes the input of the command.
+func sendInput(stdin io.Reader, send func(req *nitrod.ExecRequest) error) {
+	closeStdin := &nitrod.ExecRequest{Request: &nitrod.ExecRequest_CloseStdin{CloseStdin: true}}
+	if stdin == nil {
+		_ = send(closeStdin)
+		return
+	}
+
+	buf := make([]byte, execBufferSize)
+	for {
+		n, err := stdin.Read(buf)
+		if n > 0 {
+			b := make([]byte, n)
+			copy(b, buf[:n])
+			if err := send(&nitrod.ExecRequest{Request: &nitrod.ExecRequest_Stdin{Stdin: b}}); err != nil {
+				return
+			}
+		}
+
+		if err != nil {
+			_ = send(closeStdin)
+			return
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/craftcms/nitro/internal/nitrod"
+)
+
+// echoExecService writes the input of the command
+// to stdout until the input is closed.
+type echoExecService struct {
+	nitrod.UnimplementedNitroServiceServer
+	start *nitrod.ExecStart
+}
+
+func (s *echoExecService) Exec(stream nitrod.NitroService_ExecServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	s.start = req.GetStart()
+
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		switch r := req.GetRequest().(type) {
+		case *nitrod.ExecRequest_Stdin:
+			if err := stream.Send(&nitrod.ExecResponse{Response: &nitrod.ExecResponse_Stdout{Stdout: r.Stdin}}); err != nil {
+				return err
+			}
+		case *nitrod.ExecRequest_CloseStdin:
+			return stream.Send(&nitrod.ExecResponse{Response: &nitrod.ExecResponse_ExitCode{ExitCode: 2}})
+		}
+	}
+}
+
+func TestExec(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	fake := &echoExecService{}
+	nitrod.RegisterNitroServiceServer(srv, fake)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	cc, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+
+	input := strings.Repeat("composer install\n", execBufferSize/8)
+
+	var stdout, stderr bytes.Buffer
+	start := &nitrod.ExecStart{Command: "composer", Args: []string{"install"}, Dir: "/home/ubuntu/sites/demo", Php: "7.4"}
+	code, err := Exec(context.Background(), nitrod.NewNitroServiceClient(cc), start, ExecStreams{
+		Stdin:  strings.NewReader(input),
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code != 2 {
+		t.Errorf("expected the exit code to be 2, got %d", code)
+	}
+	if stdout.String() != input {
+		t.Errorf("expected the input to be echoed, got %d bytes", stdout.Len())
+	}
+	if fake.start.GetCommand() != "composer" || fake.start.GetDir() != "/home/ubuntu/sites/demo" {
+		t.Errorf("expected the command to start with the request, got %v", fake.start)
+	}
+}
@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/craftcms/nitro/internal/nitrod"
+)
+
+// importAttempts is the number of times an upload is
+// started, or resumed, before the import fails.
+const importAttempts = 5
+
+// importRetryDelay is multiplied by the attempt to wait between uploads.
+var importRetryDelay = time.Second
+
+// ImportCallbacks are called as nitrod reports the progress of an import.
+type ImportCallbacks struct {
+	// Progress is called with each update from nitrod.
+	Progress func(*nitrod.ImportDatabaseProgress)
+	// Select returns the dump to import when the
+	// archive contains more than one dump.
+	Select func(dumps []string) (string, error)
+}
+
+// ImportDatabase uploads the file to nitrod and calls the callbacks with each
+// update from the server. If the connection drops while uploading, a new stream
+// is started and the upload resumes from the last byte nitrod received. The
+// checksum and size in the header are set from the file when empty.
+func ImportDatabase(ctx context.Context, c nitrod.NitroServiceClient, file io.ReadSeeker, header *nitrod.ImportDatabaseHeader, callbacks ImportCallbacks) (*nitrod.ImportDatabaseProgress, error) {
+	if header.Checksum == "" {
+		checksum, size, err := Checksum(file)
+		if err != nil {
+			return nil, err
+		}
+		header.Checksum = checksum
+		header.Size = size
+	}
+
+	if callbacks.Progress == nil {
+		callbacks.Progress = func(*nitrod.ImportDatabaseProgress) {}
+	}
+	if callbacks.Select == nil {
+		callbacks.Select = func(dumps []string) (string, error) {
+			return "", fmt.Errorf("the archive contains %d dumps, select one of %s", len(dumps), strings.Join(dumps, ", "))
+		}
+	}
+
+	var err error
+	for attempt := 1; attempt <= importAttempts; attempt++ {
+		var res *nitrod.ImportDatabaseProgress
+		var uploading bool
+		res, uploading, err = importDatabase(ctx, c, file, header, callbacks)
+		if err == nil {
+			return res, nil
+		}
+
+		// once the upload is complete, retrying would import the database again
+		if !uploading || !retryable(err) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(importRetryDelay * time.Duration(attempt)):
+		}
+	}
+
+	return nil, err
+}
+
+// importDatabase runs a single import stream and reports if
+// the stream ended while the file was still uploading.
+func importDatabase(ctx context.Context, c nitrod.NitroServiceClient, file io.ReadSeeker, header *nitrod.ImportDatabaseHeader, callbacks ImportCallbacks) (*nitrod.ImportDatabaseProgress, bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.ImportDatabase(ctx)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if err := stream.Send(&nitrod.ImportDatabaseRequest{Request: &nitrod.ImportDatabaseRequest_Header{Header: header}}); err != nil && err != io.EOF {
+		return nil, true, err
+	}
+
+	// the first response has the offset to resume from
+	first, err := stream.Recv()
+	if err != nil {
+		return nil, true, err
+	}
+	callbacks.Progress(first)
+
+	if _, err := file.Seek(first.GetOffset(), io.SeekStart); err != nil {
+		return nil, false, err
+	}
+
+	sent := make(chan struct{})
+	go func() {
+		if err := sendChunks(stream, file); err != nil {
+			// stop waiting on a response that will never come
+			cancel()
+		}
+		close(sent)
+	}()
+
+	// wait for the sender to stop before the file is used again
+	defer func() {
+		cancel()
+		<-sent
+	}()
+
+	uploading := true
+	for {
+		p, err := stream.Recv()
+		if err == io.EOF {
+			return nil, uploading, errors.New("the import ended before it was complete")
+		}
+		if err != nil {
+			return nil, uploading, err
+		}
+
+		if p.GetStage() != nitrod.ImportStage_UPLOADING {
+			uploading = false
+		}
+
+		callbacks.Progress(p)
+
+		switch p.GetStage() {
+		case nitrod.ImportStage_SELECTING:
+			member, err := callbacks.Select(p.GetMembers())
+			if err != nil {
+				return nil, false, err
+			}
+
+			// the upload is complete, but wait so only one goroutine sends
+			<-sent
+			if err := stream.Send(&nitrod.ImportDatabaseRequest{Request: &nitrod.ImportDatabaseRequest_Member{Member: member}}); err != nil && err != io.EOF {
+				return nil, false, err
+			}
+		case nitrod.ImportStage_COMPLETE, nitrod.ImportStage_QUEUED:
+			return p, false, nil
+		}
+	}
+}
+
+// sendChunks sends the rest of the file to the stream, the stream is
+// left open in case nitrod asks which dump in an archive to import.
+func sendChunks(stream nitrod.NitroService_ImportDatabaseClient, file io.Reader) error {
+	buffer := make([]byte, chunkSize)
+	for {
+		n, err := file.Read(buffer)
+		if n > 0 {
+			if err := stream.Send(&nitrod.ImportDatabaseRequest{Request: &nitrod.ImportDatabaseRequest_Data{Data: buffer[:n]}}); err != nil {
+				// the server closed the stream, the error is returned by Recv
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Checksum returns the hex encoded sha256 and size of the
+// file and then seeks back to the start of the file.
+func Checksum(file io.ReadSeeker) (string, int64, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted:
+		return true
+	}
+
+	return false
+}
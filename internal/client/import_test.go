@@ -0,0 +1,99 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/craftcms/nitro/internal/nitrod"
+)
+
+// dropImportService drops the first upload after the first
+// chunk so the client has to resume the upload.
+type dropImportService struct {
+	nitrod.UnimplementedNitroServiceServer
+	received []byte
+	streams  int
+}
+
+func (s *dropImportService) ImportDatabase(stream nitrod.NitroService_ImportDatabaseServer) error {
+	s.streams++
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	header := req.GetHeader()
+
+	if err := stream.Send(&nitrod.ImportDatabaseProgress{Offset: int64(len(s.received)), Size: header.GetSize()}); err != nil {
+		return err
+	}
+
+	for int64(len(s.received)) < header.GetSize() {
+		req, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		s.received = append(s.received, req.GetData()...)
+
+		if s.streams == 1 {
+			return status.Error(codes.Aborted, "connection lost")
+		}
+	}
+
+	return stream.Send(&nitrod.ImportDatabaseProgress{Stage: nitrod.ImportStage_COMPLETE, Offset: header.GetSize(), Size: header.GetSize()})
+}
+
+func TestImportDatabase_Resumes(t *testing.T) {
+	importRetryDelay = 0
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	fake := &dropImportService{}
+	nitrod.RegisterNitroServiceServer(srv, fake)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	cc, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+
+	backup := bytes.Repeat([]byte("INSERT INTO a VALUES (1);\n"), chunkSize/10)
+
+	var offsets []int64
+	header := &nitrod.ImportDatabaseHeader{Engine: "mysql"}
+	res, err := ImportDatabase(context.Background(), nitrod.NewNitroServiceClient(cc), bytes.NewReader(backup), header, ImportCallbacks{Progress: func(p *nitrod.ImportDatabaseProgress) {
+		if p.GetStage() == nitrod.ImportStage_UPLOADING {
+			offsets = append(offsets, p.GetOffset())
+		}
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.GetStage() != nitrod.ImportStage_COMPLETE {
+		t.Errorf("expected the import to complete, got %v", res.GetStage())
+	}
+	if fake.streams != 2 {
+		t.Errorf("expected 2 streams, got %d", fake.streams)
+	}
+	if len(offsets) != 2 || offsets[0] != 0 || offsets[1] != chunkSize {
+		t.Errorf("expected the upload to resume after the first chunk, got offsets %v", offsets)
+	}
+	if !bytes.Equal(fake.received, backup) {
+		t.Errorf("expected the server to receive the backup once")
+	}
+	if header.Size != int64(len(backup)) || len(header.Checksum) != 64 {
+		t.Errorf("expected the header to have the size and checksum, got %v", header)
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/craftcms/nitro/internal/nitrod"
+)
+
+// watchAttempts is the number of times watching a job
+// is started, or resumed, before giving up.
+const watchAttempts = 5
+
+// WatchJob writes the log of the job to the writer until the job is done and
+// returns the finished job. If the connection drops, watching resumes from
+// the end of the log that was written. Canceling the context stops watching,
+// the job keeps running on the machine.
+func WatchJob(ctx context.Context, c nitrod.NitroServiceClient, id string, log io.Writer) (*nitrod.Job, error) {
+	var offset int64
+	var err error
+	for attempt := 1; attempt <= watchAttempts; attempt++ {
+		var job *nitrod.Job
+		job, offset, err = watchJob(ctx, c, id, offset, log)
+		if err == nil {
+			return job, nil
+		}
+
+		if !retryable(err) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(importRetryDelay * time.Duration(attempt)):
+		}
+	}
+
+	return nil, err
+}
+
+// watchJob runs a single watch stream and returns the offset of the log.
+func watchJob(ctx context.Context, c nitrod.NitroServiceClient, id string, offset int64, log io.Writer) (*nitrod.Job, int64, error) {
+	stream, err := c.WatchJob(ctx, &nitrod.WatchJobRequest{Id: id, Offset: offset})
+	if err != nil {
+		return nil, offset, err
+	}
+
+	var job *nitrod.Job
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			if job == nil || job.GetState() == nitrod.JobState_RUNNING {
+				return nil, offset, errors.New("stopped watching the job before it finished")
+			}
+
+			return job, offset, nil
+		}
+		if err != nil {
+			return nil, offset, err
+		}
+
+		if _, err := log.Write(resp.GetLog()); err != nil {
+			return nil, offset, err
+		}
+
+		job = resp.GetJob()
+		offset = resp.GetOffset()
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/craftcms/nitro/internal/nitrod"
+)
+
+// dropWatchService sends the log in two parts and drops
+// the first stream so the client has to resume watching.
+type dropWatchService struct {
+	nitrod.UnimplementedNitroServiceServer
+	offsets []int64
+}
+
+func (s *dropWatchService) WatchJob(req *nitrod.WatchJobRequest, stream nitrod.NitroService_WatchJobServer) error {
+	s.offsets = append(s.offsets, req.GetOffset())
+
+	log := []byte("Reading package lists...\nDone\n")
+	running := &nitrod.Job{Id: req.GetId(), State: nitrod.JobState_RUNNING}
+
+	if req.GetOffset() == 0 {
+		if err := stream.Send(&nitrod.WatchJobResponse{Job: running, Log: log[:25], Offset: 25}); err != nil {
+			return err
+		}
+
+		return status.Error(codes.Unavailable, "connection lost")
+	}
+
+	return stream.Send(&nitrod.WatchJobResponse{
+		Job:    &nitrod.Job{Id: req.GetId(), State: nitrod.JobState_SUCCEEDED},
+		Log:    log[req.GetOffset():],
+		Offset: int64(len(log)),
+	})
+}
+
+func TestWatchJob_Resumes(t *testing.T) {
+	importRetryDelay = 0
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	fake := &dropWatchService{}
+	nitrod.RegisterNitroServiceServer(srv, fake)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	cc, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+
+	var log bytes.Buffer
+	job, err := WatchJob(context.Background(), nitrod.NewNitroServiceClient(cc), "0badc0de", &log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if job.GetState() != nitrod.JobState_SUCCEEDED {
+		t.Errorf("expected the job to succeed, got %v", job.GetState())
+	}
+	if log.String() != "Reading package lists...\nDone\n" {
+		t.Errorf("expected the whole log once, got %q", log.String())
+	}
+	if len(fake.offsets) != 2 || fake.offsets[1] != 25 {
+		t.Errorf("expected watching to resume from 25, got %v", fake.offsets)
+	}
+}
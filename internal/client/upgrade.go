@@ -0,0 +1,56 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	"github.com/craftcms/nitro/internal/nitrod"
+)
+
+// chunkSize is the size of each message when streaming files to nitrod.
+const chunkSize = 64 * 1024
+
+// UpgradeDaemon streams the nitrod binary to the machine, nitrod verifies
+// the checksum and size before replacing itself and restarting.
+func UpgradeDaemon(ctx context.Context, c nitrod.SystemServiceClient, binary []byte, version string) (*nitrod.ServiceResponse, error) {
+	sum := sha256.Sum256(binary)
+
+	stream, err := c.UpgradeDaemon(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	header := &nitrod.UpgradeDaemonHeader{
+		Version:  version,
+		Checksum: hex.EncodeToString(sum[:]),
+		Size:     int64(len(binary)),
+	}
+	if err := stream.Send(&nitrod.UpgradeDaemonRequest{Request: &nitrod.UpgradeDaemonRequest_Header{Header: header}}); err != nil {
+		return nil, err
+	}
+
+	r := bytes.NewReader(binary)
+	buffer := make([]byte, chunkSize)
+	for {
+		n, err := r.Read(buffer)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := stream.Send(&nitrod.UpgradeDaemonRequest{Request: &nitrod.UpgradeDaemonRequest_Data{Data: buffer[:n]}}); err != nil {
+			// the server closed the stream, the error is returned by CloseAndRecv
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/craftcms/nitro/internal/nitrod"
+	"github.com/craftcms/nitro/internal/version"
+)
+
+// Version is the version of the nitro CLI, it is
+// compared with the nitrod version on connect.
+var Version string
+
+// Warnings is where version mismatch warnings are written.
+var Warnings io.Writer = os.Stderr
+
+// skipVersionCheck are the methods that must work with
+// any version of nitrod so it can always be upgraded.
+var skipVersionCheck = map[string]bool{
+	"/nitrod.SystemService/Version":       true,
+	"/nitrod.SystemService/UpgradeDaemon": true,
+}
+
+// versionCheck compares the nitrod version with the CLI
+// version once per connection. Incompatible major versions
+// return an error, other differences print a warning.
+type versionCheck struct {
+	cli  string
+	once sync.Once
+	err  error
+}
+
+func (v *versionCheck) unary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if err := v.check(ctx, method, cc); err != nil {
+		return err
+	}
+
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func (v *versionCheck) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if err := v.check(ctx, method, cc); err != nil {
+		return nil, err
+	}
+
+	return streamer(ctx, desc, cc, method, opts...)
+}
+
+func (v *versionCheck) check(ctx context.Context, method string, cc *grpc.ClientConn) error {
+	if skipVersionCheck[method] {
+		return nil
+	}
+
+	v.once.Do(func() {
+		resp, err := nitrod.NewSystemServiceClient(cc).Version(ctx, &nitrod.VersionRequest{})
+		switch {
+		case status.Code(err) == codes.Unimplemented:
+			// versions of nitrod before the handshake
+			fmt.Fprintln(Warnings, "Warning: nitrod on the machine is outdated, run `nitro daemon upgrade` to update it.")
+			return
+		case err != nil:
+			// let the request report connection errors
+			return
+		}
+
+		switch version.Compatible(v.cli, resp.GetVersion()) {
+		case version.Incompatible:
+			v.err = status.Errorf(codes.FailedPrecondition, "nitrod %s on the machine is not compatible with nitro %s, run `nitro daemon upgrade` to update it", resp.GetVersion(), v.cli)
+		case version.Mismatch:
+			fmt.Fprintf(Warnings, "Warning: nitrod %s on the machine does not match nitro %s, run `nitro daemon upgrade` to update it.\n", resp.GetVersion(), v.cli)
+		}
+	})
+
+	return v.err
+}
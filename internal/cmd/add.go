@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"path"
+	"strings"
 
 	"github.com/pixelandtonic/prompt"
 	"github.com/spf13/cobra"
@@ -10,6 +12,7 @@ import (
 	"github.com/craftcms/nitro/internal/config"
 	"github.com/craftcms/nitro/internal/helpers"
 	"github.com/craftcms/nitro/internal/nitro"
+	"github.com/craftcms/nitro/internal/preset"
 	"github.com/craftcms/nitro/internal/validate"
 	"github.com/craftcms/nitro/internal/webroot"
 )
@@ -66,15 +69,43 @@ var addCommand = &cobra.Command{
 			hostname = helpers.RemoveTrailingSlash(flagHostname)
 		}
 
+		// detect the type of the project, which sets the webroot, nginx template and env
+		var siteType string
+		switch flagSiteType {
+		case "":
+			detected := preset.Detect(absolutePath)
+			if detected == "" {
+				detected = preset.Default
+			}
+
+			siteType, err = p.Ask("Enter the site type ("+strings.Join(preset.Names(), ", ")+")", &prompt.InputOptions{
+				Default:   detected,
+				Validator: validate.SiteType,
+			})
+			if err != nil {
+				return err
+			}
+		default:
+			if err := validate.SiteType(flagSiteType); err != nil {
+				return err
+			}
+			siteType = flagSiteType
+		}
+		sitePreset, _ := preset.Lookup(siteType)
+
 		// set the webrootName var (e.g. web)
 		var webrootDir string
 		switch flagWebroot {
 		case "":
-			// look for the www,public,public_html,www using the absolutePath variable
-			foundDir, err := webroot.Find(absolutePath)
-			if err != nil {
-				fmt.Println("Unable to locate a webroot, setting to web.")
-				foundDir = "web"
+			// look for the webroots of the preset using the absolutePath variable
+			foundDir, ok := sitePreset.FindWebroot(absolutePath)
+			if !ok {
+				// look for the www,public,public_html,www
+				if dir, err := webroot.Find(absolutePath); err == nil {
+					foundDir = dir
+				} else {
+					fmt.Printf("Unable to locate a webroot, setting to %q.\n", foundDir)
+				}
 			}
 
 			webrootDir, err = p.Ask("Enter the webroot", &prompt.InputOptions{
@@ -88,7 +119,8 @@ var addCommand = &cobra.Command{
 			webrootDir = flagWebroot
 		}
 
-		webRootPath := fmt.Sprintf("/home/ubuntu/sites/%s/%s", directoryName, webrootDir)
+		// the webroot is the root of the project for "."
+		webRootPath := path.Join("/home/ubuntu/sites", directoryName, webrootDir)
 		// create a new mount
 		skipMount := true
 		mount := config.Mount{Source: absolutePath}
@@ -96,7 +128,7 @@ var addCommand = &cobra.Command{
 		if exists {
 			fmt.Println(mount.Source, "is already mounted at", found.Dest, ". Using existing instead of creating new mount.")
 
-			webRootPath = webroot.ForExistingMount(found, absolutePath, webrootDir)
+			webRootPath = path.Clean(webroot.ForExistingMount(found, absolutePath, webrootDir))
 
 			fmt.Println("Setting webroot to", webRootPath)
 		} else {
@@ -111,7 +143,7 @@ var addCommand = &cobra.Command{
 		// create a new site
 		// add site to config file
 		skipSite := true
-		site := config.Site{Hostname: hostname, Webroot: webRootPath}
+		site := config.Site{Hostname: hostname, Webroot: webRootPath, Type: siteType}
 		if configFile.SiteExists(site) {
 			fmt.Println(site.Hostname, "has already been set.")
 		} else {
@@ -155,5 +187,9 @@ var addCommand = &cobra.Command{
 func init() {
 	addCommand.Flags().StringVar(&flagHostname, "hostname", "", "Hostname of the site (e.g client.test)")
 	addCommand.Flags().StringVar(&flagWebroot, "webroot", "", "webroot of the site (e.g. web)")
+	addCommand.Flags().StringVar(&flagSiteType, "type", "", "type of the site (e.g. craft, laravel, wordpress, symfony, static or spa)")
+	_ = addCommand.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return preset.Names(), cobra.ShellCompDirectiveNoFileComp
+	})
 	addCommand.Flags().BoolVar(&flagSkipHosts, "skip-hosts", false, "Skip editing the hosts file.")
 }
@@ -2,7 +2,11 @@ package cmd
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"runtime"
 	"strings"
@@ -11,8 +15,11 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/craftcms/nitro/internal/certs"
+	"github.com/craftcms/nitro/internal/client"
 	"github.com/craftcms/nitro/internal/config"
 	"github.com/craftcms/nitro/internal/find"
+	"github.com/craftcms/nitro/internal/nginx"
 	"github.com/craftcms/nitro/internal/nitro"
 	"github.com/craftcms/nitro/internal/runas"
 	"github.com/craftcms/nitro/internal/scripts"
@@ -36,6 +43,11 @@ var applyCommand = &cobra.Command{
 			return err
 		}
 
+		printPHPWarning(configFile.PHP)
+		for _, db := range configFile.Databases {
+			printDatabaseWarning(db.Engine, db.Version)
+		}
+
 		// ABSTRACT
 		multipass, err := exec.LookPath("multipass")
 		if err != nil {
@@ -115,11 +127,42 @@ var applyCommand = &cobra.Command{
 			return err
 		}
 
-		actions, err := task.Apply(machine, configFile, mounts, sites, databases, php)
+		// the backups directory is mounted so it must exist
+		if configFile.Backups != nil {
+			dir, err := backupsDir(machine)
+			if err != nil {
+				return err
+			}
+
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return err
+			}
+		}
+
+		// issue the certificates and render the nginx
+		// configurations before they are copied to the machine
+		dir, err := issueCertificates(configFile.Sites)
 		if err != nil {
 			return err
 		}
 
+		if err := renderSiteConfigs(dir, machine, configFile, configFile.PHP); err != nil {
+			return err
+		}
+
+		actions, err := task.Apply(machine, configFile, mounts, sites, databases, php, dir)
+		if err != nil {
+			return err
+		}
+
+		// sites that are not changed still need the configuration or certificate
+		// when the template, the extra config or the certificate changed
+		updateActions, err := updateSites(machine, dir, configFile.Sites, sites, script)
+		if err != nil {
+			return err
+		}
+		actions = append(actions, updateActions...)
+
 		if flagDebug {
 			for _, a := range actions {
 				fmt.Println(a.Args)
@@ -134,6 +177,19 @@ var applyCommand = &cobra.Command{
 
 		fmt.Println("Applied changes from", viper.ConfigFileUsed())
 
+		if configFile.Xdebug != nil {
+			if err := xdebugConfigureCommand.RunE(cmd, args); err != nil {
+				return err
+			}
+		}
+
+		// an older nitrod cannot stop backups it does not have
+		if c, err := client.NewDefaultClient(machine); err == nil {
+			if err := configureBackups(cmd.Context(), c, configFile); err != nil && configFile.Backups != nil {
+				return err
+			}
+		}
+
 		if flagSkipHosts || len(configFile.Sites) == 0 {
 			fmt.Println("Skipping editing the hosts file.")
 			return nil
@@ -174,6 +230,93 @@ var applyCommand = &cobra.Command{
 	},
 }
 
+// updateSites returns the actions to copy the nginx configuration and
+// certificate of the sites on the machine that do not match the files on
+// the host, using the checksums of the files on the machine.
+func updateSites(machine, dir string, configSites, machineSites []config.Site, script *scripts.Script) ([]nitro.Action, error) {
+	checksums := make(map[string]string)
+	if output, err := script.Run(true, scripts.NginxSiteChecksums); err == nil {
+		for _, line := range strings.Split(output, "\n") {
+			sp := strings.Fields(line)
+			if len(sp) == 2 {
+				checksums[sp[1]] = sp[0]
+			}
+		}
+	}
+
+	var actions []nitro.Action
+	for _, site := range configSites {
+		if !siteOnMachine(machineSites, site) {
+			continue
+		}
+
+		conf := nginx.ConfPath(dir, machine, site.Hostname)
+		cert := certs.CertPath(dir, site.Hostname)
+		if fileChecksum(conf) == checksums["/etc/nginx/sites-available/"+site.Hostname] && fileChecksum(cert) == checksums[nitro.CertsDir+"/"+site.Hostname+".crt"] {
+			continue
+		}
+
+		transferConfigAction, err := nitro.TransferNginxConfig(machine, site.Hostname, conf)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, *transferConfigAction...)
+
+		transferCertAction, err := nitro.TransferSiteCertificate(machine, site.Hostname, cert, certs.KeyPath(dir, site.Hostname))
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, *transferCertAction...)
+
+		enableSiteAction, err := nitro.EnableNginxSite(machine, site.Hostname)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, *enableSiteAction)
+
+		fmt.Println("Updating site", site.Hostname, "on", machine)
+	}
+
+	if len(actions) == 0 {
+		return nil, nil
+	}
+
+	reloadNginxAction, err := nitro.NginxReload(machine)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(actions, *reloadNginxAction), nil
+}
+
+// siteOnMachine reports if the site is already on the machine without changes.
+func siteOnMachine(sites []config.Site, site config.Site) bool {
+	for _, s := range sites {
+		if s.IsExact(site) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fileChecksum returns the hex SHA-256 of the file, or an empty string
+// when it cannot be read.
+func fileChecksum(file string) string {
+	f, err := os.Open(file)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 func init() {
 	applyCommand.Flags().BoolVar(&flagSkipHosts, "skip-hosts", false, "Skip editing the hosts file.")
 }
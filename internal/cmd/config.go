@@ -11,6 +11,8 @@ packages:
   - software-properties-common
   - sshfs
   - pv
+  - xz-utils
+  - zstd
   - httpie
   - unzip
   - mysql-client
@@ -93,86 +95,38 @@ write_files:
       GRANT ALL PRIVILEGES ON *.* TO 'nitro'@'localhost' WITH GRANT OPTION;
       GRANT ALL PRIVILEGES ON *.* TO 'nitro'@'%' WITH GRANT OPTION;
       FLUSH PRIVILEGES;
+  - path: /home/ubuntu/.nitro/databases/mariadb/conf.d/mariadb.cnf
+    content: |
+      [mysqld]
+      max_allowed_packet=256M
+      wait_timeout=86400
+  - path: /home/ubuntu/.nitro/databases/mariadb/setup.sql
+    content: |
+      CREATE USER IF NOT EXISTS 'nitro'@'localhost' IDENTIFIED BY 'nitro';
+      CREATE USER IF NOT EXISTS 'nitro'@'%' IDENTIFIED BY 'nitro';
+      GRANT ALL PRIVILEGES ON *.* TO 'nitro'@'localhost' WITH GRANT OPTION;
+      GRANT ALL PRIVILEGES ON *.* TO 'nitro'@'%' WITH GRANT OPTION;
+      FLUSH PRIVILEGES;
   - path: /home/ubuntu/.nitro/databases/postgres/setup.sql
     content: |
       ALTER USER nitro WITH SUPERUSER;
-  - path: /opt/nitro/nginx/template.conf
+  - path: /etc/nginx/conf.d/nitro-status.conf
     content: |
-      # Hat tip to https://github.com/nystudio107/nginx-craft
-
+      # Status pages for the nitrod metrics, only available from the machine
       server {
-          # Listen for both IPv4 & IPv6 on port 80
-          listen 80;
-          listen [::]:80;
-
-          # General virtual host settings
-          server_name CHANGESERVERNAME;          
-          root CHANGEWEBROOTDIR;
-          index index.html index.htm index.php;
-          charset utf-8;
-          
-          # Enable serving of static gzip files as per: http://nginx.org/en/docs/http/ngx_http_gzip_static_module.html
-          gzip_static  on;
-
-          # Enable server-side includes as per: http://nginx.org/en/docs/http/ngx_http_ssi_module.html
-          ssi on;
-
-          # Disable limits on the maximum allowed size of the client request body
-          client_max_body_size 0;
-          
-          # 404 error handler
-          error_page 404 /index.php$is_args$args;
-
-          # Root directory location handler
-          location / {
-              try_files $uri/index.html $uri $uri/ /index.php$is_args$args;
-          }
-
-          # php-fpm configuration
-          location ~ [^/]\.php(/|$) {
-              include snippets/fastcgi-php.conf;
-
-              fastcgi_pass unix:/var/run/php/phpCHANGEPHPVERSION-fpm.sock;
+          listen 127.0.0.1:8080;
 
-              # FastCGI params
-              fastcgi_param CRAFT_NITRO 1;
-              fastcgi_param DB_USER nitro;
-              fastcgi_param DB_PASSWORD nitro;
-              fastcgi_param HTTP_PROXY "";
-              fastcgi_param HTTP_HOST CHANGESERVERNAME;
-    
-              # Don't allow browser caching of dynamically generated content
-              add_header Last-Modified $date_gmt;
-              add_header Cache-Control "no-store, no-cache, must-revalidate, proxy-revalidate, max-age=0";
-              if_modified_since off;
-              expires off;
-              etag off;
-    
-              fastcgi_intercept_errors off;
-              fastcgi_buffer_size 16k;
-              fastcgi_buffers 4 16k;
-              fastcgi_connect_timeout 240;
-              fastcgi_send_timeout 240;
-              fastcgi_read_timeout 240;
+          location = /nginx-status {
+              stub_status;
           }
 
-          # Disable reading of Apache .htaccess files
-          location ~ /\.ht {
-              deny all;
+          location ~ ^/fpm-status/(?<version>[0-9.]+)$ {
+              include fastcgi_params;
+              fastcgi_param SCRIPT_NAME /status;
+              fastcgi_param SCRIPT_FILENAME /status;
+              fastcgi_pass unix:/var/run/php/php$version-fpm.sock;
           }
-
-          # Misc settings
-          sendfile off;
       }
-  - path: /opt/nitro/php-xdebug.ini
-    content: |
-      zend_extension=xdebug.so
-      xdebug.remote_enable=1
-      xdebug.remote_connect_back=0
-      xdebug.remote_host=192.168.64.1
-      xdebug.remote_port=9000
-      xdebug.remote_autostart=1
-      xdebug.idekey=PHPSTORM
 runcmd:
   - sed -i 's|nameserver 127.0.0.53|nameserver 127.0.0.53\nnameserver 1.1.1.1\nnameserver 1.0.0.1\nnameserver 8.8.8.8\nnameserver 8.8.4.4|g' /etc/resolv.conf
   - add-apt-repository --no-update -y ppa:ondrej/php
@@ -188,11 +142,15 @@ runcmd:
   - usermod -aG docker ubuntu
   - mkdir -p /home/ubuntu/sites
   - mkdir -p /home/ubuntu/.nitro/databases/imports
+  - mkdir -p /home/ubuntu/.nitro/jobs
+  - mkdir -p /home/ubuntu/.nitro/certs
   - mkdir -p /home/ubuntu/.nitro/databases/mysql/conf.d
   - mkdir -p /home/ubuntu/.nitro/databases/mysql/backups
   - mkdir -p /home/ubuntu/.nitro/databases/postgres/conf.d
   - mkdir -p /home/ubuntu/.nitro/databases/mysql/conf.d
   - mkdir -p /home/ubuntu/.nitro/databases/postgres/backups
+  - mkdir -p /home/ubuntu/.nitro/databases/mariadb/conf.d
+  - mkdir -p /home/ubuntu/.nitro/databases/mariadb/backups
   - cp /etc/skel/.bashrc /home/ubuntu/.bashrc
   - cp /etc/skel/.profile /home/ubuntu/.profile
   - cp /etc/skel/.bash_logout /home/ubuntu/.bash_logout
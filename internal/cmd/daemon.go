@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/nitrod"
+	"github.com/craftcms/nitro/internal/version"
+)
+
+var daemonCommand = &cobra.Command{
+	Use:   "daemon",
+	Short: "Manage nitrod",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var daemonVersionCommand = &cobra.Command{
+	Use:   "version",
+	Short: "View nitrod version",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+		c, err := client.NewDefaultSystemClient(machine)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.Version(cmd.Context(), &nitrod.VersionRequest{})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("nitro %s\n", Version)
+		fmt.Printf("nitrod %s (%s/%s) on %s\n", resp.GetVersion(), resp.GetOs(), resp.GetArch(), machine)
+
+		switch version.Compatible(Version, resp.GetVersion()) {
+		case version.Match:
+			fmt.Println("nitro and nitrod are on the same version!")
+		case version.Unknown:
+			fmt.Println("Unable to compare development versions.")
+		default:
+			fmt.Println("Run `nitro daemon upgrade` to install nitrod", Version, "on", machine)
+		}
+
+		return nil
+	},
+}
+
+var daemonUpgradeCommand = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrade nitrod to match nitro",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// the version of a local binary cannot be known without running it
+		if flagDaemonFile != "" {
+			if flagDaemonVersion == "" {
+				return errors.New("--version is required with --file, e.g. --version 1.2.0")
+			}
+			if _, err := version.Parse(flagDaemonVersion); err != nil {
+				return err
+			}
+		}
+
+		machine := flagMachineName
+		c, err := client.NewDefaultSystemClient(machine)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.Version(cmd.Context(), &nitrod.VersionRequest{})
+		if err != nil {
+			return err
+		}
+
+		if flagDaemonFile == "" && version.Compatible(Version, resp.GetVersion()) == version.Match {
+			fmt.Println("nitrod on", machine, "is already on version", Version)
+			return nil
+		}
+
+		var binary []byte
+		upgradeVersion := Version
+		switch flagDaemonFile {
+		case "":
+			if _, err := version.Parse(Version); err != nil || Version == "0.0.0" {
+				return errors.New("development builds of nitro must use --file to upgrade nitrod")
+			}
+
+			url := version.DaemonURL(Version, resp.GetArch())
+			fmt.Println("Downloading", url)
+
+			binary, err = version.DownloadDaemon(http.DefaultClient, url)
+			if err != nil {
+				return err
+			}
+		default:
+			binary, err = ioutil.ReadFile(flagDaemonFile)
+			if err != nil {
+				return err
+			}
+			upgradeVersion = flagDaemonVersion
+		}
+
+		arch, err := version.BinaryArch(bytes.NewReader(binary))
+		if err != nil {
+			return err
+		}
+		if arch != resp.GetArch() {
+			return fmt.Errorf("the nitrod binary is for %s but %s is %s", arch, machine, resp.GetArch())
+		}
+
+		fmt.Printf("Upgrading nitrod on %s from %s to %s...\n", machine, resp.GetVersion(), upgradeVersion)
+
+		res, err := client.UpgradeDaemon(cmd.Context(), c, binary, upgradeVersion)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(res.GetMessage())
+
+		return nil
+	},
+}
+
+func init() {
+	daemonUpgradeCommand.Flags().StringVar(&flagDaemonFile, "file", "", "Path to a nitrod binary to use instead of the release")
+	daemonUpgradeCommand.Flags().StringVar(&flagDaemonVersion, "version", "", "The version of the nitrod binary from --file")
+	daemonCommand.AddCommand(daemonVersionCommand, daemonUpgradeCommand)
+}
@@ -7,12 +7,12 @@ import (
 var dbCommand = &cobra.Command{
 	Use:       "db",
 	Short:     "Manage databases",
-	ValidArgs: []string{"add", "backup", "import", "restart", "stop", "start"},
+	ValidArgs: []string{"add", "backup", "backups", "create", "drop", "import", "ls", "preflight", "query", "rename", "restart", "restore", "rollback", "sanitize", "shell", "snapshot", "snapshots", "stop", "start", "verify"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return cmd.Help()
 	},
 }
 
 func init() {
-	dbCommand.AddCommand(dbAddCommand, dbImportCommand, dbRestartCommand, dbStopCommand, dbStartCommand, dbRemoveCommand, dbBackupCommand)
+	dbCommand.AddCommand(dbAddCommand, dbImportCommand, dbRestartCommand, dbStopCommand, dbStartCommand, dbRemoveCommand, dbBackupCommand, dbBackupsCommand, dbLsCommand, dbCreateCommand, dbDropCommand, dbRenameCommand, dbRestoreCommand, dbSnapshotCommand, dbRollbackCommand, dbSnapshotsCommand, dbSanitizeCommand, dbPreflightCommand, dbShellCommand, dbQueryCommand, dbVerifyCommand)
 }
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"os/exec"
-	"strings"
 
 	"github.com/pixelandtonic/prompt"
 	"github.com/spf13/cobra"
@@ -67,7 +66,7 @@ var dbAddCommand = &cobra.Command{
 		fmt.Println("Creating database", database)
 
 		// run the scripts
-		if strings.Contains(container, "mysql") {
+		if config.ContainerEngine(container) != "postgres" {
 			_, err = script.Run(false, fmt.Sprintf(scripts.FmtDockerMysqlCreateDatabaseIfNotExists, container, database))
 			if err != nil {
 				return err
@@ -3,8 +3,11 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"os/exec"
-	"strings"
+	"path/filepath"
 	"time"
 
 	"github.com/mitchellh/go-homedir"
@@ -12,13 +15,20 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/craftcms/nitro/internal/backup"
+	"github.com/craftcms/nitro/internal/client"
 	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/database"
 	"github.com/craftcms/nitro/internal/datetime"
+	"github.com/craftcms/nitro/internal/encrypt"
 	"github.com/craftcms/nitro/internal/helpers"
 	"github.com/craftcms/nitro/internal/nitro"
 	"github.com/craftcms/nitro/internal/scripts"
 )
 
+// allDatabases is the name of backups with every database in the container.
+const allDatabases = "all-dbs"
+
 var dbBackupCommand = &cobra.Command{
 	Use:   "backup",
 	Short: "Backup database",
@@ -31,61 +41,34 @@ var dbBackupCommand = &cobra.Command{
 		p := prompt.NewPrompt()
 		script := scripts.New(mp, machine)
 
-		// create a list of containers
 		var cfg config.Config
 		if err := viper.Unmarshal(&cfg); err != nil {
 			return err
 		}
-		if len(cfg.Databases) == 0 {
-			return errors.New("there are no databases we can add to")
-		}
 
-		var containers []string
-		for _, db := range cfg.Databases {
-			containers = append(containers, db.Name())
+		db, err := selectDatabaseEngine(p, cfg, "Select database engine")
+		if err != nil {
+			return err
 		}
+		container := db.Name()
 
-		// if there is only one
-		var container string
-		switch len(containers) {
-		case 1:
-			container = containers[0]
-		default:
-			container, _, err = p.Select("Select database engine", containers, &prompt.SelectOptions{
-				Default: 1,
-			})
-			if err != nil {
-				return err
-			}
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
 		}
 
 		// get all of the databases from the container
-		dbs := []string{"all-dbs"}
-		switch strings.Contains(container, "mysql") {
-		case false:
-			if output, err := script.Run(false, fmt.Sprintf(scripts.FmtDockerPostgresShowAllDatabases, container)); err == nil {
-				sp := strings.Split(output, "\n")
-				for i, d := range sp {
-					if i == 0 || i == 1 || i == len(sp) || strings.Contains(d, "rows)") {
-						continue
-					}
-
-					dbs = append(dbs, strings.TrimSpace(d))
-				}
-			}
-		default:
-			if output, err := script.Run(false, fmt.Sprintf(scripts.FmtDockerMysqlShowAllDatabases, container)); err == nil {
-				for _, db := range strings.Split(output, "\n") {
-					// ignore the system defaults
-					if db == "Database" || db == "information_schema" || db == "performance_schema" || db == "sys" || strings.Contains(db, "password on the command line") || db == "mysql" {
-						continue
-					}
-					dbs = append(dbs, db)
-				}
-			}
+		databases, err := listDatabases(cmd.Context(), c, db)
+		if err != nil {
+			return err
+		}
+
+		dbs := []string{allDatabases}
+		for _, d := range databases {
+			dbs = append(dbs, d.GetName())
 		}
 
-		if len(dbs) == 0 {
+		if len(databases) == 0 {
 			return errors.New("no databases to backup in " + container)
 		}
 
@@ -94,50 +77,69 @@ var dbBackupCommand = &cobra.Command{
 			return err
 		}
 
+		replacements, err := dumpReplacements(p, cfg)
+		if err != nil {
+			return err
+		}
+
+		opts, err := backupOptions(cfg)
+		if err != nil {
+			return err
+		}
+
+		// the backup is uploaded to the destinations in the config
+		var destinations []backup.Destination
+		if flagDestination != "" || (cfg.Backups != nil && len(cfg.Backups.Destinations) > 0) {
+			destinations, err = uploadDestinations(cfg)
+			if err != nil {
+				return err
+			}
+		}
+
 		// task
 		var fullVmBackupPath string
 		backupFileName := database + "-" + datetime.Parse(time.Now()) + ".sql"
-		switch strings.Contains(container, "mysql") {
-		case true:
+		switch db.Engine {
+		case "postgres":
 			// create the backup directory if not found
-			if output, err := script.Run(false, fmt.Sprintf(scripts.FmtCreateDirectory, "/home/ubuntu/.nitro/databases/mysql/backups/")); err != nil {
+			if output, err := script.Run(false, fmt.Sprintf(scripts.FmtCreateDirectory, "/home/ubuntu/.nitro/databases/postgres/backups/")); err != nil {
 				fmt.Println(output)
 				return err
 			}
 
-			fullVmBackupPath = "/home/ubuntu/.nitro/databases/mysql/backups/" + backupFileName
+			fullVmBackupPath = "/home/ubuntu/.nitro/databases/postgres/backups/" + backupFileName
 
-			// if its everything, back them all up
-			if database == "all-dbs" {
-				if output, err := script.Run(false, fmt.Sprintf(scripts.FmtDockerBackupAllMysqlDatabases, container, fullVmBackupPath)); err != nil {
+			// if its all the databases
+			if database == allDatabases {
+				if output, err := script.Run(false, fmt.Sprintf(`docker exec -i %s pg_dumpall -U nitro > %s`, container, fullVmBackupPath)); err != nil {
 					fmt.Println(output)
 					return err
 				}
 			} else {
 				// backup a specific database
-				if output, err := script.Run(false, fmt.Sprintf(scripts.FmtDockerBackupIndividualMysqlDatabase, container, database, fullVmBackupPath)); err != nil {
+				if output, err := script.Run(false, fmt.Sprintf(scripts.FmtDockerBackupIndividualPostgresDatabase, container, database, fullVmBackupPath)); err != nil {
 					fmt.Println(output)
 					return err
 				}
 			}
 		default:
 			// create the backup directory if not found
-			if output, err := script.Run(false, fmt.Sprintf(scripts.FmtCreateDirectory, "/home/ubuntu/.nitro/databases/postgres/backups/")); err != nil {
+			if output, err := script.Run(false, fmt.Sprintf(scripts.FmtCreateDirectory, "/home/ubuntu/.nitro/databases/"+db.Engine+"/backups/")); err != nil {
 				fmt.Println(output)
 				return err
 			}
 
-			fullVmBackupPath = "/home/ubuntu/.nitro/databases/postgres/backups/" + backupFileName
+			fullVmBackupPath = "/home/ubuntu/.nitro/databases/" + db.Engine + "/backups/" + backupFileName
 
-			// if its all the databases
-			if database == "all-dbs" {
-				if output, err := script.Run(false, fmt.Sprintf(`docker exec -i %s pg_dumpall -U nitro > %s`, container, fullVmBackupPath)); err != nil {
+			// if its everything, back them all up
+			if database == allDatabases {
+				if output, err := script.Run(false, fmt.Sprintf(scripts.FmtDockerBackupAllMysqlDatabases, container, fullVmBackupPath)); err != nil {
 					fmt.Println(output)
 					return err
 				}
 			} else {
 				// backup a specific database
-				if output, err := script.Run(false, fmt.Sprintf(scripts.FmtDockerBackupIndividualPostgresDatabase, container, database, fullVmBackupPath)); err != nil {
+				if output, err := script.Run(false, fmt.Sprintf(scripts.FmtDockerBackupIndividualMysqlDatabase, container, database, fullVmBackupPath)); err != nil {
 					fmt.Println(output)
 					return err
 				}
@@ -179,9 +181,189 @@ var dbBackupCommand = &cobra.Command{
 			return err
 		}
 
-		fmt.Println(fmt.Sprintf("Backup completed and stored in %q.", backupsFolder+"/"+backupFileName))
+		if len(replacements) > 0 {
+			if err := replaceBackup(backupsFolder+"/"+backupFileName, db.Engine, replacements); err != nil {
+				return err
+			}
+		}
+
+		backupFile, err := finishBackup(backupsFolder+"/"+backupFileName, container, database, opts)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(fmt.Sprintf("Backup completed and stored in %q.", backupFile))
+
+		if len(destinations) > 0 {
+			if err := uploadBackup(cmd.Context(), destinations, machine, backup.Backup{Container: container, Path: backupFile}); err != nil {
+				return err
+			}
+		}
 		// end action
 
 		return nil
 	},
 }
+
+func init() {
+	dbBackupCommand.Flags().StringArrayVar(&flagReplace, "replace", nil, "Replace a string in the backup, in the form old=new")
+	dbBackupCommand.Flags().StringVar(&flagSite, "site", "", "Use the replacements of the site from the config")
+	dbBackupCommand.Flags().BoolVar(&flagDryRun, "dry-run", false, "Count the replacements without changing the backup")
+	dbBackupCommand.Flags().StringVar(&flagCompress, "compress", "", "Compress the backup with gzip or zstd, the compression of the automatic backups is used by default")
+	dbBackupCommand.Flags().StringVar(&flagEncrypt, "encrypt", "", "Encrypt the backup with an age recipient or the ID or email of a GPG key, the key of the automatic backups is used by default")
+	dbBackupCommand.Flags().BoolVar(&flagPassphrase, "passphrase", false, "Encrypt the backup with a passphrase, from "+passphraseEnv+" or a prompt")
+	dbBackupCommand.Flags().StringVar(&flagDestination, "destination", "", "Upload the backup only to the destination with the name")
+}
+
+// backupOptions returns how the backup is compressed and encrypted using the
+// flags, or the settings of the automatic backups in the config.
+func backupOptions(cfg config.Config) (backup.Options, error) {
+	var opts backup.Options
+	if cfg.Backups != nil {
+		opts.Compression = cfg.Backups.Compression
+		opts.Encryption.Recipient = cfg.Backups.Encrypt
+	}
+
+	if flagCompress != "" {
+		opts.Compression = flagCompress
+	}
+	if _, err := backup.CompressionFormat(opts.Compression); err != nil {
+		return opts, err
+	}
+
+	if flagEncrypt != "" {
+		opts.Encryption.Recipient = flagEncrypt
+	}
+
+	if flagPassphrase {
+		if flagEncrypt != "" {
+			return opts, errors.New("a backup cannot be encrypted with a passphrase and a key")
+		}
+
+		passphrase, err := backupPassphrase(true)
+		if err != nil {
+			return opts, err
+		}
+
+		opts.Encryption = encrypt.Options{Passphrase: passphrase}
+		return opts, nil
+	}
+
+	recipient, err := encrypt.Recipient(opts.Encryption.Recipient)
+	if err != nil {
+		return opts, err
+	}
+	opts.Encryption.Recipient = recipient
+
+	return opts, nil
+}
+
+// finishBackup compresses and encrypts the backup using the options, then
+// writes its manifest. The new backup replaces the dump and its path is
+// returned.
+func finishBackup(file, container, database string, opts backup.Options) (string, error) {
+	ext, err := opts.Ext()
+	if err != nil {
+		return "", err
+	}
+
+	dest := file
+	if ext != "" {
+		dest += "." + ext
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	tmp, err := ioutil.TempFile(filepath.Dir(file), ".backup-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	switch {
+	case opts.Encryption.Enabled():
+		fmt.Println("Encrypting the backup...")
+	case ext != "":
+		fmt.Println("Compressing the backup...")
+	}
+
+	m, err := backup.Write(tmp, opts, func(w io.Writer) error {
+		_, err := io.Copy(w, f)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to write the backup, error: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	f.Close()
+
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return "", err
+	}
+	if dest != file {
+		if err := os.Remove(file); err != nil {
+			return "", err
+		}
+	}
+
+	m.Container = container
+	if database != allDatabases {
+		m.Databases = []string{database}
+	}
+	if err := backup.WriteManifest(dest, m); err != nil {
+		return "", err
+	}
+
+	return dest, nil
+}
+
+// replaceBackup makes the replacements in the backup, the backup
+// is written to a temp file in the same directory and renamed.
+func replaceBackup(file, engine string, replacements []database.Replacement) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if flagDryRun {
+		counts, err := database.Replace(f, ioutil.Discard, engine, replacements)
+		if err != nil {
+			return err
+		}
+
+		printReplacements(replacements, counts, true)
+		return nil
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(file), ".replace-*.sql")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	counts, err := database.Replace(f, tmp, engine, replacements)
+	if err != nil {
+		return fmt.Errorf("unable to replace the strings in the backup, error: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	f.Close()
+
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		return err
+	}
+
+	printReplacements(replacements, counts, false)
+
+	return nil
+}
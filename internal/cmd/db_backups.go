@@ -0,0 +1,387 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/backup"
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/encrypt"
+	"github.com/craftcms/nitro/internal/nitrod"
+	"github.com/craftcms/nitro/internal/s3"
+)
+
+var dbBackupsCommand = &cobra.Command{
+	Use:       "backups",
+	Short:     "Manage database backups",
+	ValidArgs: []string{"ls", "upload"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var dbBackupsLsCommand = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "List database backups",
+	Long:    "Lists the database backups for a machine in ~/.nitro/backups and in each of the destinations in the config.",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+
+		var cfg config.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return err
+		}
+
+		destinations, err := backupDestinations(cfg)
+		if err != nil {
+			return err
+		}
+
+		backups := listBackups(cmd.Context(), destinations, machine)
+		if len(backups) == 0 {
+			fmt.Println("There are no backups for", machine)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		if len(destinations) == 1 {
+			fmt.Fprintln(w, "CONTAINER\tDATABASE\tDATE\tSIZE\tFILE")
+			for _, b := range backups {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", b.Container, b.Database, b.Time.Format("2006-01-02 15:04:05"), formatBytes(b.Size), filepath.Base(b.Path))
+			}
+
+			return w.Flush()
+		}
+
+		fmt.Fprintln(w, "CONTAINER\tDATABASE\tDATE\tSIZE\tFILE\tLOCATIONS")
+		for _, b := range backups {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", b.Container, b.Database, b.Time.Format("2006-01-02 15:04:05"), formatBytes(b.Size), filepath.Base(b.Path), strings.Join(b.Locations, ", "))
+		}
+
+		return w.Flush()
+	},
+}
+
+var dbBackupsUploadCommand = &cobra.Command{
+	Use:   "upload",
+	Short: "Upload database backups",
+	Long:  "Uploads the backups of a machine in ~/.nitro/backups, such as the automatic backups, that are not in each of the destinations in the config.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+
+		var cfg config.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return err
+		}
+
+		destinations, err := uploadDestinations(cfg)
+		if err != nil {
+			return err
+		}
+
+		dir, err := backupsDir(machine)
+		if err != nil {
+			return err
+		}
+
+		backups, err := backup.List(dir)
+		if err != nil {
+			return err
+		}
+
+		if len(backups) == 0 {
+			fmt.Println("There are no backups in", dir)
+			return nil
+		}
+
+		var failed []string
+		for _, d := range destinations {
+			existing, err := d.List(cmd.Context(), machine)
+			if err != nil {
+				fmt.Printf("Unable to list the backups in %s: %s\n", d.Name(), err)
+				failed = append(failed, d.Name())
+				continue
+			}
+
+			uploaded := make(map[string]bool)
+			for _, b := range existing {
+				uploaded[b.Container+"/"+filepath.Base(b.Path)] = true
+			}
+
+			n := 0
+			for _, b := range backups {
+				if uploaded[b.Container+"/"+filepath.Base(b.Path)] {
+					continue
+				}
+
+				fmt.Printf("Uploading %q to %s...\n", filepath.Base(b.Path), d.Name())
+				if err := d.Upload(cmd.Context(), machine, b); err != nil {
+					fmt.Printf("Unable to upload %q to %s: %s\n", filepath.Base(b.Path), d.Name(), err)
+					failed = append(failed, d.Name())
+					break
+				}
+				n++
+			}
+
+			fmt.Printf("Uploaded %d backups to %s\n", n, d.Name())
+		}
+
+		if len(failed) > 0 {
+			return fmt.Errorf("unable to upload the backups to %s", strings.Join(failed, ", "))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	dbBackupsCommand.AddCommand(dbBackupsLsCommand, dbBackupsUploadCommand)
+	dbBackupsUploadCommand.Flags().StringVar(&flagDestination, "destination", "", "Upload only to the destination with the name")
+}
+
+// backupsDir returns the directory on the host with the machine's backups.
+func backupsDir(machine string) (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".nitro", "backups", machine), nil
+}
+
+// backupDestinations returns the backups directory on the host
+// followed by the destinations in the config.
+func backupDestinations(cfg config.Config) ([]backup.Destination, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	destinations := []backup.Destination{backup.NewDir(backup.Local, filepath.Join(home, ".nitro", "backups"))}
+	if cfg.Backups == nil {
+		return destinations, nil
+	}
+
+	names := map[string]bool{backup.Local: true}
+	for _, d := range cfg.Backups.Destinations {
+		if names[d.Name] {
+			return nil, fmt.Errorf("the backup destination name %q must be unique and cannot be %q", d.Name, backup.Local)
+		}
+		names[d.Name] = true
+
+		destination, err := backupDestination(d, home)
+		if err != nil {
+			return nil, fmt.Errorf("the backup destination %q is not valid, %w", d.Name, err)
+		}
+
+		destinations = append(destinations, destination)
+	}
+
+	return destinations, nil
+}
+
+// backupDestination returns the directory or bucket of the destination.
+func backupDestination(d config.BackupDestination, home string) (backup.Destination, error) {
+	switch {
+	case d.Name == "":
+		return nil, errors.New("the name cannot be empty")
+	case d.Path != "" && d.Endpoint != "":
+		return nil, errors.New("it must have a path or an endpoint, not both")
+	case d.Path != "":
+		path, err := homedir.Expand(d.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(home, path)
+		}
+
+		return backup.NewDir(d.Name, path), nil
+	case d.Endpoint != "":
+		key, secret := d.Key, d.Secret
+		if key == "" {
+			key = os.Getenv("AWS_ACCESS_KEY_ID")
+		}
+		if secret == "" {
+			secret = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		}
+
+		client, err := s3.New(d.Endpoint, d.Region, d.Bucket, key, secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return backup.NewBucket(d.Name, client, d.Prefix), nil
+	}
+
+	return nil, errors.New("it must have a path or an endpoint")
+}
+
+// uploadDestinations returns the destinations in the config that backups are
+// uploaded to, or the destination of the --destination flag.
+func uploadDestinations(cfg config.Config) ([]backup.Destination, error) {
+	destinations, err := backupDestinations(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// the first destination is the backups directory on the host
+	destinations = destinations[1:]
+	if len(destinations) == 0 {
+		return nil, errors.New("there are no backup destinations in the config")
+	}
+
+	if flagDestination == "" {
+		return destinations, nil
+	}
+
+	for _, d := range destinations {
+		if d.Name() == flagDestination {
+			return []backup.Destination{d}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("there is no backup destination %q in the config", flagDestination)
+}
+
+// listBackups returns the backups of the machine in each destination, the
+// destinations that cannot be listed are shown and skipped.
+func listBackups(ctx context.Context, destinations []backup.Destination, machine string) []backup.Backup {
+	var lists [][]backup.Backup
+	for _, d := range destinations {
+		backups, err := d.List(ctx, machine)
+		if err != nil {
+			fmt.Printf("Unable to list the backups in %s: %s\n", d.Name(), err)
+			continue
+		}
+
+		lists = append(lists, backups)
+	}
+
+	return backup.Merge(lists...)
+}
+
+// listMachines returns the machines with backups in any of the destinations.
+func listMachines(ctx context.Context, destinations []backup.Destination) []string {
+	var machines []string
+	seen := make(map[string]bool)
+	for _, d := range destinations {
+		list, err := d.Machines(ctx)
+		if err != nil {
+			fmt.Printf("Unable to list the backups in %s: %s\n", d.Name(), err)
+			continue
+		}
+
+		for _, m := range list {
+			if !seen[m] {
+				seen[m] = true
+				machines = append(machines, m)
+			}
+		}
+	}
+	sort.Strings(machines)
+
+	return machines
+}
+
+// uploadBackup uploads the backup to each of the destinations.
+func uploadBackup(ctx context.Context, destinations []backup.Destination, machine string, b backup.Backup) error {
+	var failed []string
+	for _, d := range destinations {
+		fmt.Printf("Uploading the backup to %s...\n", d.Name())
+
+		if err := d.Upload(ctx, machine, b); err != nil {
+			fmt.Printf("Unable to upload the backup to %s: %s\n", d.Name(), err)
+			failed = append(failed, d.Name())
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("the backup is in %s but was not uploaded to %s", filepath.Dir(b.Path), strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// configureBackups sends the backup schedule from the config to nitrod, when
+// the config has no backups the automatic backups are stopped.
+func configureBackups(ctx context.Context, c nitrod.NitroServiceClient, cfg config.Config) error {
+	if cfg.Backups == nil {
+		_, err := c.ConfigureBackups(ctx, &nitrod.ConfigureBackupsRequest{})
+		return err
+	}
+
+	targets, err := backupTargets(cfg)
+	if err != nil {
+		return err
+	}
+
+	recipient, err := encrypt.Recipient(cfg.Backups.Encrypt)
+	if err != nil {
+		return err
+	}
+
+	_, offset := time.Now().Zone()
+
+	resp, err := c.ConfigureBackups(ctx, &nitrod.ConfigureBackupsRequest{
+		Schedule:    cfg.Backups.Schedule,
+		Targets:     targets,
+		Keep:        int32(cfg.Backups.Retention.Count),
+		Days:        int32(cfg.Backups.Retention.Days),
+		Compression: cfg.Backups.Compression,
+		UtcOffset:   int32(offset),
+		Recipient:   recipient,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(resp.GetMessage())
+
+	return nil
+}
+
+// backupTargets returns the database containers to backup, when the
+// config does not list containers every database engine is backed up.
+func backupTargets(cfg config.Config) ([]*nitrod.BackupTarget, error) {
+	if len(cfg.Backups.Containers) == 0 {
+		var targets []*nitrod.BackupTarget
+		for _, db := range cfg.Databases {
+			targets = append(targets, &nitrod.BackupTarget{Engine: db.Engine, Container: db.Name()})
+		}
+
+		return targets, nil
+	}
+
+	var targets []*nitrod.BackupTarget
+	for _, container := range cfg.Backups.Containers {
+		found := false
+		for _, db := range cfg.Databases {
+			if db.Name() == container.Name {
+				targets = append(targets, &nitrod.BackupTarget{Engine: db.Engine, Container: db.Name(), Databases: container.Databases})
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("the backup container %q is not one of the databases in the config", container.Name)
+		}
+	}
+
+	return targets, nil
+}
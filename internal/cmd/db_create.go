@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pixelandtonic/prompt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/nitrod"
+	"github.com/craftcms/nitro/internal/validate"
+)
+
+var dbCreateCommand = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Create database",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+		p := prompt.NewPrompt()
+
+		var cfg config.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return err
+		}
+
+		db, err := selectDatabaseEngine(p, cfg, "Select the database engine")
+		if err != nil {
+			return err
+		}
+
+		var database string
+		switch len(args) {
+		case 1:
+			database = args[0]
+		default:
+			database, err = p.Ask("Enter the database name", &prompt.InputOptions{Validator: validate.DatabaseName})
+			if err != nil {
+				return err
+			}
+		}
+
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.CreateDatabase(cmd.Context(), &nitrod.CreateDatabaseRequest{Engine: db.Engine, Container: db.Name(), Database: database})
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(resp.GetMessage())
+
+		return nil
+	},
+}
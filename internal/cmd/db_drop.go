@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pixelandtonic/prompt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/nitrod"
+)
+
+var dbDropCommand = &cobra.Command{
+	Use:   "drop [name]",
+	Short: "Drop database",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+		p := prompt.NewPrompt()
+
+		var cfg config.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return err
+		}
+
+		db, err := selectDatabaseEngine(p, cfg, "Select the database engine")
+		if err != nil {
+			return err
+		}
+
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
+		}
+
+		var database string
+		switch len(args) {
+		case 1:
+			database = args[0]
+		default:
+			database, err = selectDatabase(cmd.Context(), p, c, db, "Select database to drop")
+			if err != nil {
+				return err
+			}
+		}
+
+		// make sure the user wants to do this
+		drop, err := p.Confirm(fmt.Sprintf("Are you sure you want to permanently drop the database %q", database), &prompt.InputOptions{
+			Default:            "no",
+			AppendQuestionMark: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !drop {
+			return nil
+		}
+
+		resp, err := c.DropDatabase(cmd.Context(), &nitrod.DropDatabaseRequest{Engine: db.Engine, Container: db.Name(), Database: database})
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(resp.GetMessage())
+
+		return nil
+	},
+}
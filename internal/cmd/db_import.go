@@ -9,15 +9,16 @@ import (
 	"math"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/h2non/filetype"
 	"github.com/mitchellh/go-homedir"
 	"github.com/pixelandtonic/prompt"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/compress"
 	"github.com/craftcms/nitro/internal/config"
 	"github.com/craftcms/nitro/internal/database"
 	"github.com/craftcms/nitro/internal/helpers"
@@ -31,7 +32,7 @@ var dbImportCommand = &cobra.Command{
 	Short: "Import database",
 	Args:  cobra.MinimumNArgs(1),
 	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"sql", "gz", "zip"}, cobra.ShellCompDirectiveFilterFileExt
+		return []string{"sql", "dump", "gz", "tgz", "bz2", "xz", "zst", "zip"}, cobra.ShellCompDirectiveFilterFileExt
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		machine := flagMachineName
@@ -61,7 +62,7 @@ var dbImportCommand = &cobra.Command{
 		}
 
 		// create the request
-		req := &nitrod.ImportDatabaseRequest{}
+		req := &nitrod.ImportDatabaseHeader{}
 
 		// check if the file is compressed
 		if err := checkIfCompressed(filename, req); err != nil {
@@ -79,9 +80,32 @@ var dbImportCommand = &cobra.Command{
 		}
 		defer file.Close()
 
+		replacements, err := dumpReplacements(p, configFile)
+		if err != nil {
+			return err
+		}
+
+		var rules []database.SanitizeRule
+		if flagSanitize != "" {
+			if rules, err = sanitizeRules(configFile, flagSanitize, flagTablePrefix); err != nil {
+				return err
+			}
+		}
+
 		detected := ""
+		// dumps in archives are selected before the import when they are changed
+		dump := ""
 		// try to determine the database engine
-		if req.Compressed == false {
+		switch {
+		case flagConvert || len(replacements) > 0 || len(rules) > 0 || flagPreflight || flagFix:
+			dump, detected, err = selectDump(p, filename)
+			if err != nil {
+				return err
+			}
+			if flagConvert && detected == "" {
+				return errors.New("Unable to determine the database engine of the dump to convert it.")
+			}
+		case req.Compressed == false:
 			detected, err = database.DetermineEngine(file.Name())
 			if err != nil {
 				fmt.Println("Unable to determine the database engine from the file", filename)
@@ -92,8 +116,15 @@ var dbImportCommand = &cobra.Command{
 			fmt.Printf("Detected a %q backup file...\n", detected)
 		}
 
-		// get the databases as a list, limiting to the detected engine
-		engines := configFile.DatabaseEnginesAsList(detected)
+		// get the databases as a list, limiting to the engines that can import the detected engine
+		var engines []string
+		if flagConvert {
+			engines = configFile.DatabaseEnginesAsList("")
+		} else {
+			for _, e := range database.Compatible(detected) {
+				engines = append(engines, configFile.DatabaseEnginesAsList(e)...)
+			}
+		}
 		if len(engines) == 0 {
 			fmt.Println("Unable to get a list of the database engines")
 			return nil
@@ -115,22 +146,74 @@ var dbImportCommand = &cobra.Command{
 		req.Container = container
 
 		// set the request engine
-		switch strings.Contains(req.Container, "mysql") {
-		case true:
-			req.Engine = "mysql"
-		default:
-			req.Engine = "postgres"
+		req.Engine = config.ContainerEngine(req.Container)
+
+		// convert the dump when it is for an engine that cannot import it
+		converted := flagConvert && !compatible(detected, req.Engine)
+
+		// scan the dump for problems that would fail the import
+		var analysis *database.Analysis
+		var fixes []string
+		if flagPreflight || flagFix {
+			analysis, fixes, err = preflightImport(p, filename, dump, req.Container, converted)
+			if err != nil {
+				return err
+			}
 		}
 
-		// if the detect engine is mysql
+		transform := converted || len(fixes) > 0 || len(replacements) > 0 || len(rules) > 0
+		if flagDryRun {
+			if !transform {
+				fmt.Println("The dump does not need to be converted or fixed and there are no replacements or sanitize rules.")
+				return nil
+			}
+
+			return transformDump(ioutil.Discard, filename, dump, detected, req.Engine, converted, fixes, replacements, rules)
+		}
+
+		if transform {
+			// the transformed dump is written to a file instead of being piped
+			// to the upload, the import needs the checksum and size of the dump
+			// before it starts, an interrupted upload resumes by seeking to the
+			// offset the machine received and the prompt to create the database
+			// reads the dump
+			tmp, err := ioutil.TempFile("", "nitro-import-*.sql")
+			if err != nil {
+				return err
+			}
+			defer os.Remove(tmp.Name())
+			defer tmp.Close()
+
+			if err := transformDump(tmp, filename, dump, detected, req.Engine, converted, fixes, replacements, rules); err != nil {
+				return err
+			}
+			if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+
+			file = tmp
+			req.Compressed, req.CompressionType = false, ""
+		}
+
+		// if the detect engine is mysql or mariadb
 		showCreatePrompt := true
-		if detected == "mysql" {
+		if !converted && (detected == "mysql" || detected == "mariadb") {
 			// check if there is a create database statement
 			willCreate, err := database.HasCreateStatement(file.Name())
 			if err != nil {
 				fmt.Println(err.Error())
 			}
 
+			// the preflight scan reads the whole dump, not only the start of it
+			if analysis != nil {
+				willCreate = len(analysis.Databases) == 1
+				for _, fix := range fixes {
+					if fix == database.FixDatabases {
+						willCreate = false
+					}
+				}
+			}
+
 			if willCreate {
 				fmt.Printf("The file %q will create a database during import...\n", filename)
 				showCreatePrompt = false
@@ -149,61 +232,333 @@ var dbImportCommand = &cobra.Command{
 			fmt.Println("Will create the database", req.Database)
 		}
 
-		// create the stream
-		stream, err := c.ImportDatabase(cmd.Context())
+		// the import runs as a job so it continues if the terminal is closed
+		req.Background = true
+
+		fmt.Printf("Uploading %q into %q (large files may take a while)...\n", filename, machine)
+
+		start := time.Now()
+		res, err := client.ImportDatabase(cmd.Context(), c, file, req, client.ImportCallbacks{
+			Progress: printImportProgress,
+			Select: func(dumps []string) (string, error) {
+				if dump != "" {
+					return dump, nil
+				}
+
+				dump, _, err := p.Select("The archive contains several dumps, select one to import:", dumps, &prompt.SelectOptions{
+					Default: 1,
+				})
+				return dump, err
+			},
+		})
 		if err != nil {
-			fmt.Println("Error creating a connection to the nitro server, error:", err.Error())
+			fmt.Println()
+			fmt.Println(err.Error())
+			return err
+		}
+
+		if res.GetJob() == "" {
+			fmt.Println(res.Message+".", fmt.Sprintf("Imported %d statements in %f seconds...", res.Statements, math.Round(time.Since(start).Seconds()*100/100)))
 			return nil
 		}
 
-		fmt.Printf("Uploading %q into %q (large files may take a while)...\n", filename, machine)
+		return followJob(cmd.Context(), c, &nitrod.Job{Id: res.GetJob()})
+	},
+}
 
-		start := time.Now()
-		buffer := make([]byte, 1024*20)
-		reader := bufio.NewReader(file)
+func init() {
+	dbImportCommand.Flags().BoolVar(&flagDetach, "detach", false, "Import in the background without showing the output")
+	dbImportCommand.Flags().BoolVar(&flagConvert, "convert", false, "Convert the dump when it is for a different database engine")
+	dbImportCommand.Flags().StringArrayVar(&flagReplace, "replace", nil, "Replace a string in the dump, in the form old=new")
+	dbImportCommand.Flags().StringVar(&flagSite, "site", "", "Use the replacements of the site from the config")
+	dbImportCommand.Flags().BoolVar(&flagDryRun, "dry-run", false, "Count the replacements and sanitized values without importing the dump")
+	dbImportCommand.Flags().StringVar(&flagSanitize, "sanitize", "", "Sanitize the dump with the profile, such as craft, before it is imported")
+	dbImportCommand.Flags().StringVar(&flagTablePrefix, "table-prefix", "", "The prefix of the tables, such as craft_, added to the tables of the built-in sanitize profile")
+	dbImportCommand.Flags().BoolVar(&flagPreflight, "preflight", false, "Scan the dump for problems before importing it and prompt to fix them")
+	dbImportCommand.Flags().BoolVar(&flagFix, "fix", false, "Scan the dump for problems and fix them without prompting")
+}
+
+// preflightImport scans the dump for the problems importing it into the
+// container and returns the fixes to apply, which are confirmed unless
+// the --fix flag is set. The problems of converted dumps are for the
+// engine of the dump as they are fixed before the dump is converted.
+func preflightImport(p *prompt.Prompt, filename, dump, container string, converted bool) (*database.Analysis, []string, error) {
+	analysis, err := analyzeDump(filename, dump)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	problems := analysis.Problems(config.ContainerEngine(container), config.ContainerVersion(container))
+	if converted {
+		problems = analysis.Problems(analysis.Engine, "")
+	}
+
+	if len(problems) == 0 {
+		fmt.Printf("There are no problems importing the dump into %s\n", container)
+		return analysis, nil, nil
+	}
+
+	fmt.Printf("Problems importing the dump into %s:\n", container)
+	printProblems(problems)
+
+	fixes := problemFixes(problems)
+	if len(fixes) == 0 || flagFix {
+		return analysis, fixes, nil
+	}
+
+	fix, err := p.Confirm(fmt.Sprintf("Apply the fixes %s to the dump", strings.Join(fixes, ", ")), &prompt.InputOptions{
+		Default:            "yes",
+		AppendQuestionMark: true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !fix {
+		return analysis, nil, nil
+	}
+
+	return analysis, fixes, nil
+}
+
+// selectDump prompts for the dump to import when the file is an archive
+// with several dumps and determines the engine of the dump, the engine
+// is empty when it cannot be determined.
+func selectDump(p *prompt.Prompt, filename string) (string, string, error) {
+	dumps, err := compress.Dumps(filename)
+	if err != nil {
+		return "", "", err
+	}
+
+	var dump string
+	switch len(dumps) {
+	case 0:
+		return "", "", fmt.Errorf("the archive %q does not contain a database dump", filename)
+	case 1:
+		dump = dumps[0]
+	default:
+		dump, _, err = p.Select("The archive contains several dumps, select one to import:", dumps, &prompt.SelectOptions{
+			Default: 1,
+		})
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	r, err := compress.OpenDump(filename, dump)
+	if err != nil {
+		return "", "", err
+	}
+	defer r.Close()
 
-		for {
-			n, err := reader.Read(buffer)
-			if err == io.EOF {
-				break
+	br := bufio.NewReader(r)
+	if b, _ := br.Peek(5); compress.IsPostgresCustom(b) {
+		return "", "", errors.New("PostgreSQL custom format dumps cannot be converted or changed, create the dump with pg_dump --format=plain")
+	}
+
+	detected, _ := database.DetermineEngineFromReader(br)
+
+	return dump, detected, nil
+}
+
+// compatible reports if the engine can import dumps for the detected engine.
+func compatible(detected, engine string) bool {
+	for _, e := range database.Compatible(detected) {
+		if e == engine {
+			return true
+		}
+	}
+
+	return false
+}
+
+// printImportProgress shows the upload percentage on a
+// single line and each of the following stages.
+func printImportProgress(p *nitrod.ImportDatabaseProgress) {
+	switch p.Stage {
+	case nitrod.ImportStage_UPLOADING:
+		percent := 100.0
+		if p.Size > 0 {
+			percent = float64(p.Offset) / float64(p.Size) * 100
+		}
+		fmt.Printf("\rUploaded %.0f%%", percent)
+		if p.Offset == p.Size {
+			fmt.Println()
+		}
+	case nitrod.ImportStage_VERIFYING:
+		fmt.Println("Verifying the upload...")
+	case nitrod.ImportStage_DECOMPRESSING:
+		fmt.Printf("Extracting %q from the archive...\n", p.Message)
+	case nitrod.ImportStage_IMPORTING:
+		if p.Statements == 0 {
+			fmt.Println("Importing the database...")
+			return
+		}
+		fmt.Printf("\rImported %d statements", p.Statements)
+	case nitrod.ImportStage_COMPLETE:
+		if p.Statements >= 1000 {
+			fmt.Println()
+		}
+	case nitrod.ImportStage_QUEUED:
+		fmt.Println(p.Message + "...")
+	}
+}
+
+func checkIfCompressed(file string, req *nitrod.ImportDatabaseHeader) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// only the start of the file is needed to match the type
+	b := make([]byte, 8)
+	n, err := io.ReadFull(f, b)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return err
+	}
+	b = b[:n]
+
+	if format := compress.Detect(b); format != "" {
+		req.Compressed = true
+		req.CompressionType = format
+	}
+
+	return nil
+}
+
+// transformDump writes the dump to w after it is fixed, converted to the engine,
+// the replacements are made and it is sanitized. Each step is streamed to the
+// next and what each step changed is shown once the dump is written.
+func transformDump(w io.Writer, filename, dump, from, to string, convert bool, fixes []string, replacements []database.Replacement, rules []database.SanitizeRule) error {
+	r, err := compress.OpenDump(filename, dump)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	var steps []func(r io.Reader, w io.Writer) error
+
+	// the fixes are for the engine of the dump so they are applied first
+	fixed := -1
+	if len(fixes) > 0 {
+		engine := from
+		if engine == "" {
+			engine = to
+		}
+		steps = append(steps, func(r io.Reader, w io.Writer) (err error) {
+			if fixed, err = database.Fix(r, w, engine, fixes); err != nil {
+				return fmt.Errorf("unable to fix the dump, error: %w", err)
 			}
-			if err != nil {
-				return err
+			return nil
+		})
+	}
+
+	var report *database.Report
+	if convert {
+		fmt.Printf("Converting the %s dump to %s...\n", from, to)
+		steps = append(steps, func(r io.Reader, w io.Writer) (err error) {
+			if report, err = database.Convert(r, w, from, to); err != nil {
+				return fmt.Errorf("unable to convert the dump, error: %w", err)
 			}
+			return nil
+		})
+	}
 
-			req.Data = buffer[:n]
-			err = stream.Send(req)
-			if err == io.EOF {
-				return stream.CloseSend()
+	var counts []int
+	if len(replacements) > 0 {
+		steps = append(steps, func(r io.Reader, w io.Writer) (err error) {
+			if counts, err = database.Replace(r, w, to, replacements); err != nil {
+				return fmt.Errorf("unable to replace the strings in the dump, error: %w", err)
 			}
-			if err != nil {
-				return err
+			return nil
+		})
+	}
+
+	var sanitized *database.SanitizeReport
+	if len(rules) > 0 {
+		fmt.Println("Sanitizing the dump...")
+		steps = append(steps, func(r io.Reader, w io.Writer) (err error) {
+			if sanitized, err = database.Sanitize(r, w, to, rules); err != nil {
+				return fmt.Errorf("unable to sanitize the dump, error: %w", err)
 			}
-		}
+			return nil
+		})
+	}
 
-		res, err := stream.CloseAndRecv()
-		if err != nil {
-			fmt.Println(err.Error())
-			return err
+	if err := pipeline(r, w, steps); err != nil {
+		return err
+	}
+
+	if fixed >= 0 {
+		fmt.Printf("Fixed %d statements...\n", fixed)
+	}
+
+	if report != nil {
+		fmt.Printf("Converted %d statements...\n", report.Statements)
+		if untranslated := report.Untranslated(); len(untranslated) > 0 {
+			fmt.Println("These parts of the dump could not be converted:")
+			for _, u := range untranslated {
+				fmt.Println("  -", u)
+			}
 		}
+	}
 
-		fmt.Println(res.Message+".", fmt.Sprintf("Import took %f seconds...", math.Round(time.Since(start).Seconds()*100/100)))
+	if counts != nil {
+		printReplacements(replacements, counts, w == ioutil.Discard)
+	}
 
-		return nil
-	},
+	if sanitized != nil {
+		printSanitizeReport(sanitized)
+
+		// the personal data would be imported when the profile is for other tables
+		if len(sanitized.Tables) == 0 {
+			return errors.New("none of the tables in the dump match the sanitize profile so it was not imported, use --table-prefix if the tables have a prefix such as craft_")
+		}
+	}
+
+	return nil
 }
 
-func checkIfCompressed(file string, req *nitrod.ImportDatabaseRequest) error {
-	b, err := ioutil.ReadFile(file)
-	if err != nil {
+// pipeline streams the reader through each step to the writer, every step
+// but the last runs in a goroutine that writes to a pipe for the next step.
+// The error of the first step that failed is returned.
+func pipeline(r io.Reader, w io.Writer, steps []func(r io.Reader, w io.Writer) error) error {
+	if len(steps) == 0 {
+		_, err := io.Copy(w, r)
 		return err
 	}
 
-	kind, _ := filetype.Match(b)
+	errs := make([]error, len(steps))
+	var readers []*io.PipeReader
+	var wg sync.WaitGroup
+	for i, step := range steps[:len(steps)-1] {
+		pr, pw := io.Pipe()
+		readers = append(readers, pr)
+
+		wg.Add(1)
+		go func(i int, step func(io.Reader, io.Writer) error, r io.Reader) {
+			defer wg.Done()
+			errs[i] = step(r, pw)
+			pw.CloseWithError(errs[i])
+		}(i, step, r)
+
+		r = pr
+	}
 
-	if filetype.IsArchive(b) {
-		req.Compressed = true
-		req.CompressionType = kind.Extension
+	last := len(steps) - 1
+	errs[last] = steps[last](r, w)
+
+	// stop the earlier steps when a later step fails
+	for _, pr := range readers {
+		pr.CloseWithError(errs[last])
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/config"
+)
+
+var dbLsCommand = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "List databases",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+
+		var cfg config.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return err
+		}
+
+		if len(cfg.Databases) == 0 {
+			return errors.New("there are no database engines in the config")
+		}
+
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ENGINE\tDATABASE\tSIZE\tCHARSET\tTABLES")
+		for _, db := range cfg.Databases {
+			dbs, err := listDatabases(cmd.Context(), c, db)
+			if err != nil {
+				return err
+			}
+
+			for _, d := range dbs {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", db.Name(), d.GetName(), formatBytes(d.GetSize()), d.GetCharset(), d.GetTables())
+			}
+		}
+
+		return w.Flush()
+	},
+}
+
+// formatBytes returns the size using the largest unit, e.g. 1.5 MB.
+func formatBytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/pixelandtonic/prompt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/compress"
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/database"
+	"github.com/craftcms/nitro/internal/helpers"
+	"github.com/craftcms/nitro/internal/normalize"
+)
+
+var dbPreflightCommand = &cobra.Command{
+	Use:   "preflight my-backup.sql",
+	Short: "Check a database dump",
+	Long:  "Scans a database dump for what is in it, such as the databases it creates, the definers and the collations, and shows the problems importing it into each database engine.",
+	Args:  cobra.ExactArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"sql", "dump", "gz", "tgz", "bz2", "xz", "zst", "zip"}, cobra.ShellCompDirectiveFilterFileExt
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var cfg config.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return err
+		}
+
+		home, err := homedir.Dir()
+		if err != nil {
+			return err
+		}
+
+		_, fileAbsPath, err := normalize.Path(args[0], home)
+		if err != nil {
+			return err
+		}
+
+		if !helpers.FileExists(fileAbsPath) {
+			return errors.New(fmt.Sprintf("Unable to locate the file %q.", fileAbsPath))
+		}
+
+		p := prompt.NewPrompt()
+
+		dump, _, err := selectDump(p, fileAbsPath)
+		if err != nil {
+			return err
+		}
+
+		analysis, err := analyzeDump(fileAbsPath, dump)
+		if err != nil {
+			return err
+		}
+
+		engines := cfg.DatabaseEnginesAsList("")
+		if analysis.Engine != "" {
+			engines = nil
+			for _, e := range database.Compatible(analysis.Engine) {
+				engines = append(engines, cfg.DatabaseEnginesAsList(e)...)
+			}
+		}
+
+		if len(engines) == 0 {
+			fmt.Println("There are no database engines in the config that can import the dump")
+			return nil
+		}
+
+		for _, container := range engines {
+			fmt.Println()
+			problems := analysis.Problems(config.ContainerEngine(container), config.ContainerVersion(container))
+			if len(problems) == 0 {
+				fmt.Printf("There are no problems importing the dump into %s\n", container)
+				continue
+			}
+
+			fmt.Printf("Problems importing the dump into %s:\n", container)
+			printProblems(problems)
+		}
+
+		return nil
+	},
+}
+
+// analyzeDump runs a preflight scan of the dump, which is a file or a dump
+// in an archive, and shows what is in it.
+func analyzeDump(filename, dump string) (*database.Analysis, error) {
+	r, err := compress.OpenDump(filename, dump)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	fmt.Println("Scanning the dump...")
+
+	analysis, err := database.Analyze(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan the dump, error: %w", err)
+	}
+
+	engine := analysis.Engine
+	if engine == "" {
+		engine = "unknown"
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "Engine:\t%s\n", strings.TrimSpace(engine+" "+analysis.Version))
+	fmt.Fprintf(w, "Size:\t%s\n", formatBytes(analysis.Size))
+	fmt.Fprintf(w, "Statements:\t%d\n", analysis.Statements)
+	fmt.Fprintf(w, "Tables:\t%d\n", analysis.Tables)
+	fmt.Fprintf(w, "Creates databases:\t%s\n", orNone(analysis.Databases))
+	fmt.Fprintf(w, "Switches to databases:\t%s\n", orNone(analysis.Uses))
+	fmt.Fprintf(w, "Definers:\t%s\n", orNone(analysis.Definers))
+	fmt.Fprintf(w, "Collations:\t%s\n", orNone(analysis.Collations))
+	if analysis.GTIDPurged {
+		fmt.Fprintln(w, "Sets GTID_PURGED:\tyes")
+	}
+
+	return analysis, w.Flush()
+}
+
+// printProblems shows the problems and the fixes for them.
+func printProblems(problems []database.Problem) {
+	for _, problem := range problems {
+		if problem.Fix == "" {
+			fmt.Println("  -", problem.Message)
+			continue
+		}
+
+		fmt.Printf("  - %s (fix: %s)\n", problem.Message, problem.Fix)
+	}
+}
+
+// problemFixes returns the fixes for the problems.
+func problemFixes(problems []database.Problem) []string {
+	var fixes []string
+	for _, problem := range problems {
+		if problem.Fix != "" {
+			fixes = append(fixes, problem.Fix)
+		}
+	}
+
+	return fixes
+}
+
+func orNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pixelandtonic/prompt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/nitrod"
+)
+
+// the formats of the output of db query
+var queryOutputs = []string{"table", "json", "csv"}
+
+var dbQueryCommand = &cobra.Command{
+	Use:   `query "SELECT * FROM users"`,
+	Short: "Run a database query",
+	Long:  "Runs a query on a database and shows the rows returned by the last statement as a table, JSON or CSV.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+		p := prompt.NewPrompt()
+
+		valid := false
+		for _, o := range queryOutputs {
+			valid = valid || o == flagOutput
+		}
+		if !valid {
+			return fmt.Errorf("the output %q is not valid, use %s", flagOutput, strings.Join(queryOutputs, ", "))
+		}
+
+		var cfg config.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return err
+		}
+
+		db, err := findDatabaseEngine(p, cfg, flagDatabaseEngine, "Select the database engine")
+		if err != nil {
+			return err
+		}
+
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
+		}
+
+		name := flagDatabase
+		if name == "" {
+			name, err = selectDatabase(cmd.Context(), p, c, db, "Select the database")
+			if err != nil {
+				return err
+			}
+		}
+
+		resp, err := c.QueryDatabase(cmd.Context(), &nitrod.QueryDatabaseRequest{Engine: db.Engine, Container: db.Name(), Database: name, Query: args[0]})
+		if err != nil {
+			return err
+		}
+
+		return printQuery(os.Stdout, resp, flagOutput)
+	},
+}
+
+func init() {
+	dbQueryCommand.Flags().StringVar(&flagDatabaseEngine, "engine", "", "The database engine, such as mysql_8.0_3306")
+	dbQueryCommand.Flags().StringVar(&flagDatabase, "database", "", "The database to run the query on")
+	dbQueryCommand.Flags().StringVarP(&flagOutput, "output", "o", "table", "The format of the rows: table, json or csv")
+}
+
+// printQuery writes the rows in the format. The values are strings since the
+// types of the columns are not known, NULL values are null in JSON and empty
+// in CSV.
+func printQuery(w io.Writer, resp *nitrod.QueryDatabaseResponse, output string) error {
+	columns := resp.GetColumns()
+
+	switch output {
+	case "json":
+		// the objects are written by hand to keep the order of the columns
+		var b bytes.Buffer
+		b.WriteString("[")
+		for i, row := range resp.GetRows() {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n  {")
+			for j, v := range row.GetValues() {
+				if j > 0 {
+					b.WriteString(", ")
+				}
+
+				column := fmt.Sprintf("column%d", j+1)
+				if j < len(columns) {
+					column = columns[j]
+				}
+				key, _ := json.Marshal(column)
+				value := []byte("null")
+				if !v.GetNull() {
+					value, _ = json.Marshal(v.GetValue())
+				}
+
+				b.Write(key)
+				b.WriteString(": ")
+				b.Write(value)
+			}
+			b.WriteString("}")
+		}
+		if len(resp.GetRows()) > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("]\n")
+
+		_, err := w.Write(b.Bytes())
+		return err
+	case "csv":
+		cw := csv.NewWriter(w)
+		if len(columns) > 0 {
+			if err := cw.Write(columns); err != nil {
+				return err
+			}
+		}
+		for _, row := range resp.GetRows() {
+			var record []string
+			for _, v := range row.GetValues() {
+				record = append(record, v.GetValue())
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+
+		return cw.Error()
+	}
+
+	if len(columns) == 0 {
+		_, err := fmt.Fprintln(w, "The query did not return any rows")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	for _, row := range resp.GetRows() {
+		var values []string
+		for _, v := range row.GetValues() {
+			value := v.GetValue()
+			if v.GetNull() {
+				value = "NULL"
+			}
+			// values with tabs or line breaks would break the table
+			values = append(values, strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(value))
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pixelandtonic/prompt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/nitrod"
+	"github.com/craftcms/nitro/internal/validate"
+)
+
+var dbRenameCommand = &cobra.Command{
+	Use:   "rename [name] [new-name]",
+	Short: "Rename database",
+	Args:  cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+		p := prompt.NewPrompt()
+
+		var cfg config.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return err
+		}
+
+		db, err := selectDatabaseEngine(p, cfg, "Select the database engine")
+		if err != nil {
+			return err
+		}
+
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
+		}
+
+		var database string
+		switch len(args) {
+		case 0:
+			database, err = selectDatabase(cmd.Context(), p, c, db, "Select database to rename")
+			if err != nil {
+				return err
+			}
+		default:
+			database = args[0]
+		}
+
+		var name string
+		switch len(args) {
+		case 2:
+			name = args[1]
+		default:
+			name, err = p.Ask("Enter the new name for "+database, &prompt.InputOptions{Validator: validate.DatabaseName})
+			if err != nil {
+				return err
+			}
+		}
+
+		resp, err := c.RenameDatabase(cmd.Context(), &nitrod.RenameDatabaseRequest{Engine: db.Engine, Container: db.Name(), Database: database, Name: name})
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(resp.GetMessage())
+
+		return nil
+	},
+}
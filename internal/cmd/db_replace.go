@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pixelandtonic/prompt"
+
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/database"
+)
+
+// noSite is the option to not use the replacements of a site.
+const noSite = "none"
+
+// dumpReplacements returns the replacements of the site, which is selected
+// when the --site flag is not set and a site has replacements, followed by
+// the replacements from the --replace flags.
+func dumpReplacements(p *prompt.Prompt, cfg config.Config) ([]database.Replacement, error) {
+	var replacements []database.Replacement
+
+	site := flagSite
+	if site == "" {
+		sites := []string{noSite}
+		for _, s := range cfg.Sites {
+			if len(s.Replace) > 0 {
+				sites = append(sites, s.Hostname)
+			}
+		}
+
+		if len(sites) > 1 {
+			var err error
+			site, _, err = p.Select("Select the site to use the replacements of", sites, &prompt.SelectOptions{Default: 1})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if site != "" && site != noSite {
+		found := false
+		for _, s := range cfg.Sites {
+			if s.Hostname != site {
+				continue
+			}
+
+			found = true
+			for _, r := range s.Replace {
+				replacements = append(replacements, database.Replacement{From: r.From, To: r.To})
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("unable to find the site %q in the config", site)
+		}
+	}
+
+	for _, f := range flagReplace {
+		r, err := database.ParseReplacement(f)
+		if err != nil {
+			return nil, err
+		}
+		replacements = append(replacements, r)
+	}
+
+	return replacements, nil
+}
+
+// printReplacements shows the number of times each replacement was made.
+func printReplacements(replacements []database.Replacement, counts []int, dryRun bool) {
+	verb := "Replaced"
+	if dryRun {
+		verb = "Would replace"
+	}
+
+	for i, r := range replacements {
+		fmt.Printf("%s %q with %q %d times\n", verb, r.From, r.To, counts[i])
+	}
+}
@@ -0,0 +1,379 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pixelandtonic/prompt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/backup"
+	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/compress"
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/database"
+	"github.com/craftcms/nitro/internal/nitrod"
+	"github.com/craftcms/nitro/internal/validate"
+)
+
+var dbRestoreCommand = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore database from a backup",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+		p := prompt.NewPrompt()
+
+		var cfg config.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return err
+		}
+
+		destinations, err := backupDestinations(cfg)
+		if err != nil {
+			return err
+		}
+
+		// backups from other machines, such as a destroyed machine, can be restored
+		machines := listMachines(cmd.Context(), destinations)
+		if len(machines) == 0 {
+			return errors.New("there are no backups in ~/.nitro/backups or the backup destinations")
+		}
+
+		source := machines[0]
+		if len(machines) > 1 {
+			def := 1
+			for i, m := range machines {
+				if m == machine {
+					def = i + 1
+				}
+			}
+
+			source, _, err = p.Select("Select the machine to restore a backup from", machines, &prompt.SelectOptions{Default: def})
+			if err != nil {
+				return err
+			}
+		}
+
+		backups := listBackups(cmd.Context(), destinations, source)
+
+		b, err := selectBackup(p, backups)
+		if err != nil {
+			return err
+		}
+
+		// backups in a bucket are downloaded before they are restored
+		path, cleanupFetch, err := fetchBackup(cmd.Context(), destinations, b)
+		if err != nil {
+			return err
+		}
+		defer cleanupFetch()
+
+		// encrypted backups are decrypted to a temp file before they are restored
+		path, cleanup, err := decryptBackup(path)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		b.Path = path
+
+		db, err := restoreEngine(p, cfg, b.Container)
+		if err != nil {
+			return err
+		}
+
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
+		}
+
+		var dumps []database.Dump
+		if b.Database == allDatabases {
+			dir, err := ioutil.TempDir("", "nitro-restore-")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(dir)
+
+			fmt.Printf("Finding the databases in %q...\n", filepath.Base(b.Path))
+
+			dumps, err = splitBackup(b.Path, db.Engine, dir)
+			if err != nil {
+				return err
+			}
+
+			dumps, err = selectDumps(p, dumps)
+			if err != nil {
+				return err
+			}
+		} else {
+			name, err := p.Ask("Enter the database name to restore into", &prompt.InputOptions{Default: b.Database, Validator: validate.DatabaseName})
+			if err != nil {
+				return err
+			}
+
+			dumps = []database.Dump{{Database: name, Path: b.Path}}
+		}
+
+		existing, err := listDatabases(cmd.Context(), c, db)
+		if err != nil {
+			return err
+		}
+
+		for _, d := range dumps {
+			if err := restoreDump(cmd.Context(), p, c, db, existing, d); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	dbRestoreCommand.Flags().BoolVar(&flagDetach, "detach", false, "Restore in the background without showing the output")
+	dbRestoreCommand.Flags().StringVar(&flagIdentity, "identity", "", "The age identity file to decrypt the backup with")
+}
+
+// selectBackup asks for the container, database and date of the backup.
+func selectBackup(p *prompt.Prompt, backups []backup.Backup) (backup.Backup, error) {
+	if len(backups) == 0 {
+		return backup.Backup{}, errors.New("there are no backups")
+	}
+
+	container, err := selectUnique(p, "Select the database engine of the backup", backups, func(b backup.Backup) string {
+		return b.Container
+	})
+	if err != nil {
+		return backup.Backup{}, err
+	}
+
+	var filtered []backup.Backup
+	for _, b := range backups {
+		if b.Container == container {
+			filtered = append(filtered, b)
+		}
+	}
+
+	db, err := selectUnique(p, "Select the database of the backup", filtered, func(b backup.Backup) string {
+		return b.Database
+	})
+	if err != nil {
+		return backup.Backup{}, err
+	}
+
+	// the backups are sorted with the newest first
+	var dates []string
+	var matches []backup.Backup
+	for _, b := range filtered {
+		if b.Database == db {
+			location := formatBytes(b.Size)
+			if b.Destination != "" && b.Destination != backup.Local {
+				location += ", " + b.Destination
+			}
+			dates = append(dates, fmt.Sprintf("%s (%s)", b.Time.Format("2006-01-02 15:04:05"), location))
+			matches = append(matches, b)
+		}
+	}
+
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+
+	_, i, err := p.Select("Select the date of the backup", dates, &prompt.SelectOptions{Default: 1})
+	if err != nil {
+		return backup.Backup{}, err
+	}
+
+	return matches[i], nil
+}
+
+// selectUnique asks to select one of the unique values of the backups.
+func selectUnique(p *prompt.Prompt, message string, backups []backup.Backup, value func(backup.Backup) string) (string, error) {
+	var values []string
+	for _, b := range backups {
+		v := value(b)
+		if len(values) == 0 || values[len(values)-1] != v {
+			values = append(values, v)
+		}
+	}
+
+	if len(values) == 1 {
+		return values[0], nil
+	}
+
+	v, _, err := p.Select(message, values, &prompt.SelectOptions{Default: 1})
+
+	return v, err
+}
+
+// fetchBackup returns the path of the backup on the host, a backup in a
+// bucket is downloaded to a temp directory that the cleanup func removes.
+func fetchBackup(ctx context.Context, destinations []backup.Destination, b backup.Backup) (string, func(), error) {
+	for _, d := range destinations {
+		if d.Name() != b.Destination {
+			continue
+		}
+
+		// backups in directories are on the host
+		if _, ok := d.(*backup.Dir); ok {
+			return b.Path, func() {}, nil
+		}
+
+		dir, err := ioutil.TempDir("", "nitro-fetch-")
+		if err != nil {
+			return "", nil, err
+		}
+		cleanup := func() { os.RemoveAll(dir) }
+
+		fmt.Printf("Downloading %q from %s...\n", filepath.Base(b.Path), b.Destination)
+
+		path, err := d.Fetch(ctx, b, dir)
+		if err != nil {
+			cleanup()
+			return "", nil, fmt.Errorf("unable to download the backup from %s, error: %w", b.Destination, err)
+		}
+
+		return path, cleanup, nil
+	}
+
+	return b.Path, func() {}, nil
+}
+
+// restoreEngine returns the database engine to restore into, the engine with
+// the same container name is used when it is in the config.
+func restoreEngine(p *prompt.Prompt, cfg config.Config, container string) (config.Database, error) {
+	engine := config.ContainerEngine(container)
+
+	var dbs []config.Database
+	for _, db := range cfg.Databases {
+		if db.Name() == container {
+			return db, nil
+		}
+		if db.Engine == engine {
+			dbs = append(dbs, db)
+		}
+	}
+
+	if len(dbs) == 0 {
+		return config.Database{}, fmt.Errorf("there are no %s database engines in the config to restore %s into", engine, container)
+	}
+
+	return selectDatabaseEngine(p, config.Config{Databases: dbs}, "Select the database engine to restore into")
+}
+
+// splitBackup writes each database in a backup of every database to the directory.
+func splitBackup(file, engine, dir string) ([]database.Dump, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	header := &nitrod.ImportDatabaseHeader{}
+	if err := checkIfCompressed(file, header); err != nil {
+		return nil, err
+	}
+
+	r, err := compress.NewReader(f, header.CompressionType)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	dumps, err := database.Split(r, engine, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dumps) == 0 {
+		return nil, errors.New("the backup does not have any databases to restore")
+	}
+
+	return dumps, nil
+}
+
+// selectDumps asks which of the databases in a backup of every database to restore.
+func selectDumps(p *prompt.Prompt, dumps []database.Dump) ([]database.Dump, error) {
+	if len(dumps) == 1 {
+		return dumps, nil
+	}
+
+	options := []string{"All databases"}
+	for _, d := range dumps {
+		options = append(options, d.Database)
+	}
+
+	_, i, err := p.Select("Select the database to restore", options, &prompt.SelectOptions{Default: 1})
+	if err != nil {
+		return nil, err
+	}
+
+	if i == 0 {
+		return dumps, nil
+	}
+
+	return dumps[i-1 : i], nil
+}
+
+// restoreDump imports the dump into the database, an existing database
+// is only replaced when the user confirms.
+func restoreDump(ctx context.Context, p *prompt.Prompt, c nitrod.NitroServiceClient, db config.Database, existing []*nitrod.Database, d database.Dump) error {
+	for _, e := range existing {
+		if e.GetName() != d.Database {
+			continue
+		}
+
+		replace, err := p.Confirm(fmt.Sprintf("The database %q already exists in %s, replace it", d.Database, db.Name()), &prompt.InputOptions{
+			Default:            "no",
+			AppendQuestionMark: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !replace {
+			fmt.Printf("Skipping the database %q\n", d.Database)
+			return nil
+		}
+
+		if _, err := c.DropDatabase(ctx, &nitrod.DropDatabaseRequest{Engine: db.Engine, Container: db.Name(), Database: d.Database}); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.Open(d.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	req := &nitrod.ImportDatabaseHeader{
+		Engine:     db.Engine,
+		Container:  db.Name(),
+		Database:   d.Database,
+		Background: true,
+	}
+	if err := checkIfCompressed(d.Path, req); err != nil {
+		return err
+	}
+
+	fmt.Printf("Restoring %q into %s...\n", d.Database, db.Name())
+
+	res, err := client.ImportDatabase(ctx, c, file, req, client.ImportCallbacks{Progress: printImportProgress})
+	if err != nil {
+		return err
+	}
+
+	if res.GetJob() == "" {
+		fmt.Println(res.GetMessage())
+		return nil
+	}
+
+	return followJob(ctx, c, &nitrod.Job{Id: res.GetJob()})
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/pixelandtonic/prompt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/database"
+	"github.com/craftcms/nitro/internal/nitrod"
+)
+
+var dbSanitizeCommand = &cobra.Command{
+	Use:   "sanitize [name]",
+	Short: "Sanitize database",
+	Long:  "Anonymises the personal data in a database, such as the emails and names of users, using a sanitize profile from the config or the built-in craft profile.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+		p := prompt.NewPrompt()
+
+		var cfg config.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return err
+		}
+
+		rules, err := sanitizeRules(cfg, flagSanitizeProfile, flagTablePrefix)
+		if err != nil {
+			return err
+		}
+
+		db, err := selectDatabaseEngine(p, cfg, "Select the database engine")
+		if err != nil {
+			return err
+		}
+
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
+		}
+
+		var name string
+		switch len(args) {
+		case 1:
+			name = args[0]
+		default:
+			name, err = selectDatabase(cmd.Context(), p, c, db, "Select database to sanitize")
+			if err != nil {
+				return err
+			}
+		}
+
+		sanitize, err := p.Confirm(fmt.Sprintf("Are you sure you want to permanently change the data in %q with the %s profile", name, flagSanitizeProfile), &prompt.InputOptions{
+			Default:            "no",
+			AppendQuestionMark: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !sanitize {
+			return nil
+		}
+
+		req := &nitrod.SanitizeDatabaseRequest{Engine: db.Engine, Container: db.Name(), Database: name}
+		for _, r := range rules {
+			req.Rules = append(req.Rules, &nitrod.SanitizeRule{Table: r.Table, Column: r.Column, Action: r.Action, Value: r.Value})
+		}
+
+		resp, err := c.SanitizeDatabase(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		if len(resp.GetColumns()) == 0 {
+			return fmt.Errorf("there are no columns in %q that match the %s profile, use --table-prefix if the tables have a prefix", name, flagSanitizeProfile)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "TABLE\tCOLUMN\tROWS")
+		for _, c := range resp.GetColumns() {
+			fmt.Fprintf(w, "%s\t%s\t%d\n", c.GetTable(), c.GetColumn(), c.GetRows())
+		}
+
+		return w.Flush()
+	},
+}
+
+func init() {
+	dbSanitizeCommand.Flags().StringVar(&flagSanitizeProfile, "profile", database.CraftProfile, "The sanitize profile to use")
+	dbSanitizeCommand.Flags().StringVar(&flagTablePrefix, "table-prefix", "", "The prefix of the tables, such as craft_, added to the tables of the built-in profile")
+}
+
+// sanitizeRules returns the rules of the profile from the config, which can
+// extend a built-in profile, or the built-in profile with the name. The prefix
+// replaces the table prefix of the profile in the config.
+func sanitizeRules(cfg config.Config, name, prefix string) ([]database.SanitizeRule, error) {
+	for _, profile := range cfg.Sanitize {
+		if profile.Name != name {
+			continue
+		}
+
+		var rules []database.SanitizeRule
+		for _, r := range profile.Rules {
+			rule := database.SanitizeRule{Table: r.Table, Column: r.Column, Action: r.Action, Value: r.Value}
+			if err := rule.Validate(); err != nil {
+				return nil, fmt.Errorf("the sanitize profile %q is not valid, %w", name, err)
+			}
+			rules = append(rules, rule)
+		}
+
+		if prefix == "" {
+			prefix = profile.TablePrefix
+		}
+
+		// the rules of the profile are used before the rules it extends
+		if profile.Extends != "" {
+			extended, ok := database.Profile(profile.Extends, prefix)
+			if !ok {
+				return nil, fmt.Errorf("the sanitize profile %q extends the unknown profile %q", name, profile.Extends)
+			}
+			rules = append(rules, extended...)
+		}
+
+		return rules, nil
+	}
+
+	if rules, ok := database.Profile(name, prefix); ok {
+		return rules, nil
+	}
+
+	return nil, fmt.Errorf("unable to find the sanitize profile %q in the config", name)
+}
+
+// printSanitizeReport shows the number of values changed in each column.
+func printSanitizeReport(report *database.SanitizeReport) {
+	columns := report.Columns()
+	if len(columns) == 0 {
+		fmt.Println("There are no values in the dump to sanitize")
+	} else {
+		fmt.Println("Sanitized the values of:")
+		for _, c := range columns {
+			fmt.Println("  -", c)
+		}
+	}
+
+	for _, t := range report.Skipped {
+		fmt.Printf("Unable to sanitize the table %q since the dump does not have its columns\n", t)
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/pixelandtonic/prompt"
+
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/nitrod"
+)
+
+// selectDatabaseEngine returns the database engine from the config,
+// asking the user to select one when there is more than one.
+func selectDatabaseEngine(p *prompt.Prompt, cfg config.Config, message string) (config.Database, error) {
+	if len(cfg.Databases) == 0 {
+		return config.Database{}, errors.New("there are no database engines in the config")
+	}
+
+	if len(cfg.Databases) == 1 {
+		return cfg.Databases[0], nil
+	}
+
+	var containers []string
+	for _, db := range cfg.Databases {
+		containers = append(containers, db.Name())
+	}
+
+	_, i, err := p.Select(message, containers, &prompt.SelectOptions{Default: 1})
+	if err != nil {
+		return config.Database{}, err
+	}
+
+	return cfg.Databases[i], nil
+}
+
+// findDatabaseEngine returns the database engine with the container name,
+// such as mysql_8.0_3306, or asks the user to select one when it is empty.
+func findDatabaseEngine(p *prompt.Prompt, cfg config.Config, container, message string) (config.Database, error) {
+	if container == "" {
+		return selectDatabaseEngine(p, cfg, message)
+	}
+
+	for _, db := range cfg.Databases {
+		if db.Name() == container {
+			return db, nil
+		}
+	}
+
+	return config.Database{}, fmt.Errorf("unable to find the database engine %q in the config", container)
+}
+
+// listDatabases returns the databases in the engine using nitrod.
+func listDatabases(ctx context.Context, c nitrod.NitroServiceClient, db config.Database) ([]*nitrod.Database, error) {
+	resp, err := c.ListDatabases(ctx, &nitrod.ListDatabasesRequest{Engine: db.Engine, Container: db.Name()})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetDatabases(), nil
+}
+
+// selectDatabase asks the user to select one of the databases in the engine.
+func selectDatabase(ctx context.Context, p *prompt.Prompt, c nitrod.NitroServiceClient, db config.Database, message string) (string, error) {
+	dbs, err := listDatabases(ctx, c, db)
+	if err != nil {
+		return "", err
+	}
+
+	if len(dbs) == 0 {
+		return "", errors.New("there are no databases in " + db.Name())
+	}
+
+	var names []string
+	for _, d := range dbs {
+		names = append(names, d.GetName())
+	}
+
+	database, _, err := p.Select(message, names, &prompt.SelectOptions{Default: 1})
+	if err != nil {
+		return "", err
+	}
+
+	return database, nil
+}
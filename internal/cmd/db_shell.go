@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/pixelandtonic/prompt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/nitrod"
+	"github.com/craftcms/nitro/internal/terminal"
+)
+
+var dbShellCommand = &cobra.Command{
+	Use:   "shell [database]",
+	Short: "Open a database shell",
+	Long:  "Opens the mysql or psql client for a database in the container of the database engine.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+		p := prompt.NewPrompt()
+
+		var cfg config.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return err
+		}
+
+		db, err := findDatabaseEngine(p, cfg, flagDatabaseEngine, "Select the database engine")
+		if err != nil {
+			return err
+		}
+
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
+		}
+
+		var name string
+		switch len(args) {
+		case 1:
+			name = args[0]
+		default:
+			name, err = selectDatabase(cmd.Context(), p, c, db, "Select the database")
+			if err != nil {
+				return err
+			}
+		}
+
+		tty := terminal.IsTerminal(os.Stdin.Fd()) && terminal.IsTerminal(os.Stdout.Fd())
+
+		return runExec(cmd.Context(), c, &nitrod.ExecStart{Command: "docker", Args: databaseShell(db, name, tty)})
+	},
+}
+
+func init() {
+	dbShellCommand.Flags().StringVar(&flagDatabaseEngine, "engine", "", "The database engine, such as mysql_8.0_3306")
+}
+
+// databaseShell returns the docker arguments to run the client of the engine
+// in its container, docker only allocates a terminal when there is one.
+func databaseShell(db config.Database, name string, tty bool) []string {
+	args := []string{"exec", "-i"}
+	if tty {
+		args = []string{"exec", "-it"}
+	}
+
+	switch db.Engine {
+	case "postgres":
+		return append(args, db.Name(), "psql", "--username", "nitro", "--dbname", name)
+	default:
+		return append(args, "-e", "MYSQL_PWD=nitro", db.Name(), "mysql", "-unitro", name)
+	}
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pixelandtonic/prompt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/nitrod"
+	"github.com/craftcms/nitro/internal/validate"
+)
+
+var dbSnapshotCommand = &cobra.Command{
+	Use:   "snapshot <name>",
+	Short: "Snapshot database engine",
+	Long:  "Copies the data of a database engine to a named snapshot, the engine is stopped while the data is copied.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+		p := prompt.NewPrompt()
+
+		if err := validate.SnapshotName(args[0]); err != nil {
+			return err
+		}
+
+		var cfg config.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return err
+		}
+
+		db, err := selectDatabaseEngine(p, cfg, "Select the database engine to snapshot")
+		if err != nil {
+			return err
+		}
+
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Creating the snapshot %q of %s...\n", args[0], db.Name())
+
+		resp, err := c.CreateSnapshot(cmd.Context(), &nitrod.CreateSnapshotRequest{Container: db.Name(), Name: args[0]})
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(resp.GetMessage())
+
+		return nil
+	},
+}
+
+var dbRollbackCommand = &cobra.Command{
+	Use:   "rollback [name]",
+	Short: "Rollback database engine to a snapshot",
+	Long:  "Replaces the data of a database engine with a snapshot, the engine is stopped while the data is copied.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+		p := prompt.NewPrompt()
+
+		var cfg config.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return err
+		}
+
+		db, err := selectDatabaseEngine(p, cfg, "Select the database engine to rollback")
+		if err != nil {
+			return err
+		}
+
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
+		}
+
+		name, err := snapshotName(cmd.Context(), p, c, db, args, "Select the snapshot to rollback to")
+		if err != nil {
+			return err
+		}
+
+		rollback, err := p.Confirm(fmt.Sprintf("Are you sure you want to replace every database in %s with the snapshot %q", db.Name(), name), &prompt.InputOptions{
+			Default:            "no",
+			AppendQuestionMark: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !rollback {
+			return nil
+		}
+
+		fmt.Printf("Rolling back %s to the snapshot %q...\n", db.Name(), name)
+
+		resp, err := c.RollbackSnapshot(cmd.Context(), &nitrod.RollbackSnapshotRequest{Container: db.Name(), Name: name})
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(resp.GetMessage())
+
+		return nil
+	},
+}
+
+var dbSnapshotsCommand = &cobra.Command{
+	Use:       "snapshots",
+	Short:     "Manage database snapshots",
+	ValidArgs: []string{"ls", "rm"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var dbSnapshotsLsCommand = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "List database snapshots",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.ListSnapshots(cmd.Context(), &nitrod.ListSnapshotsRequest{})
+		if err != nil {
+			return err
+		}
+
+		if len(resp.GetSnapshots()) == 0 {
+			fmt.Println("There are no snapshots on", machine)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "CONTAINER\tSNAPSHOT\tDATE")
+		for _, s := range resp.GetSnapshots() {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", s.GetContainer(), s.GetName(), time.Unix(s.GetCreated(), 0).Format("2006-01-02 15:04:05"))
+		}
+
+		return w.Flush()
+	},
+}
+
+var dbSnapshotsRmCommand = &cobra.Command{
+	Use:     "rm [name]",
+	Aliases: []string{"remove"},
+	Short:   "Remove database snapshot",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+		p := prompt.NewPrompt()
+
+		var cfg config.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return err
+		}
+
+		db, err := selectDatabaseEngine(p, cfg, "Select the database engine")
+		if err != nil {
+			return err
+		}
+
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
+		}
+
+		name, err := snapshotName(cmd.Context(), p, c, db, args, "Select the snapshot to remove")
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.RemoveSnapshot(cmd.Context(), &nitrod.RemoveSnapshotRequest{Container: db.Name(), Name: name})
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(resp.GetMessage())
+
+		return nil
+	},
+}
+
+func init() {
+	dbSnapshotsCommand.AddCommand(dbSnapshotsLsCommand, dbSnapshotsRmCommand)
+}
+
+// snapshotName returns the snapshot from the arguments, or asks the
+// user to select one of the snapshots of the database engine.
+func snapshotName(ctx context.Context, p *prompt.Prompt, c nitrod.NitroServiceClient, db config.Database, args []string, message string) (string, error) {
+	if len(args) == 1 {
+		return args[0], nil
+	}
+
+	resp, err := c.ListSnapshots(ctx, &nitrod.ListSnapshotsRequest{Container: db.Name()})
+	if err != nil {
+		return "", err
+	}
+
+	if len(resp.GetSnapshots()) == 0 {
+		return "", errors.New("there are no snapshots of " + db.Name())
+	}
+
+	var names []string
+	for _, s := range resp.GetSnapshots() {
+		names = append(names, fmt.Sprintf("%s (%s)", s.GetName(), time.Unix(s.GetCreated(), 0).Format("2006-01-02 15:04:05")))
+	}
+
+	_, i, err := p.Select(message, names, &prompt.SelectOptions{Default: 1})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.GetSnapshots()[i].GetName(), nil
+}
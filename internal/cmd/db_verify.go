@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/pixelandtonic/prompt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/backup"
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/encrypt"
+	"github.com/craftcms/nitro/internal/helpers"
+	"github.com/craftcms/nitro/internal/normalize"
+	"github.com/craftcms/nitro/internal/terminal"
+)
+
+// passphraseEnv is the environment variable with the passphrase
+// of backups, so backups can be encrypted without a prompt.
+const passphraseEnv = "NITRO_BACKUP_PASSPHRASE"
+
+var dbVerifyCommand = &cobra.Command{
+	Use:   "verify [backup]",
+	Short: "Verify a database backup",
+	Long:  "Checks a backup matches the checksums in its manifest and that it can be decrypted, decompressed and read as a database dump. When there is no backup the backups of the machine are listed to select one.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+		p := prompt.NewPrompt()
+
+		var path string
+		switch len(args) {
+		case 1:
+			home, err := homedir.Dir()
+			if err != nil {
+				return err
+			}
+
+			_, path, err = normalize.Path(args[0], home)
+			if err != nil {
+				return err
+			}
+
+			if !helpers.FileExists(path) {
+				return fmt.Errorf("Unable to locate the file %q.", path)
+			}
+		default:
+			var cfg config.Config
+			if err := viper.Unmarshal(&cfg); err != nil {
+				return err
+			}
+
+			destinations, err := backupDestinations(cfg)
+			if err != nil {
+				return err
+			}
+
+			backups := listBackups(cmd.Context(), destinations, machine)
+			if len(backups) == 0 {
+				return fmt.Errorf("there are no backups for %s", machine)
+			}
+
+			b, err := selectBackup(p, backups)
+			if err != nil {
+				return err
+			}
+
+			// backups in a bucket are downloaded to verify them
+			fetched, cleanup, err := fetchBackup(cmd.Context(), destinations, b)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+			path = fetched
+		}
+
+		opts, err := decryptOptions(path)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Verifying %q...\n", filepath.Base(path))
+
+		v, err := backup.Verify(path, opts)
+		if err != nil {
+			return fmt.Errorf("the backup is not valid, %w", err)
+		}
+
+		manifest := "matches the manifest"
+		if v.Manifest == nil {
+			manifest = "there is no manifest"
+		}
+
+		engine := v.Analysis.Engine
+		if engine == "" {
+			engine = "unknown"
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintf(w, "Checksum:\t%s\n", manifest)
+		fmt.Fprintf(w, "Encryption:\t%s\n", noneIfEmpty(v.Encryption))
+		fmt.Fprintf(w, "Compression:\t%s\n", noneIfEmpty(v.Compression))
+		fmt.Fprintf(w, "Engine:\t%s\n", strings.TrimSpace(engine+" "+v.Analysis.Version))
+		if v.Manifest != nil {
+			fmt.Fprintf(w, "Databases:\t%s\n", orNone(v.Manifest.Databases))
+		}
+		fmt.Fprintf(w, "Size:\t%s\n", formatBytes(v.Analysis.Size))
+		fmt.Fprintf(w, "Tables:\t%d\n", v.Analysis.Tables)
+		fmt.Fprintf(w, "Statements:\t%d\n", v.Analysis.Statements)
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Println("The backup is valid")
+
+		return nil
+	},
+}
+
+func init() {
+	dbVerifyCommand.Flags().StringVar(&flagIdentity, "identity", "", "The age identity file to decrypt the backup with")
+}
+
+func noneIfEmpty(value string) string {
+	if value == "" {
+		return "none"
+	}
+
+	return value
+}
+
+// decryptOptions returns the options to decrypt the file, the passphrase
+// is asked for when the file is encrypted with a gpg passphrase.
+func decryptOptions(file string) (encrypt.Options, error) {
+	opts := encrypt.Options{Identity: flagIdentity}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return opts, err
+	}
+	defer f.Close()
+
+	head, _ := bufio.NewReader(f).Peek(512)
+	if encrypt.Detect(head) == encrypt.GPG && encrypt.IsSymmetric(head) {
+		opts.Passphrase, err = backupPassphrase(false)
+	}
+
+	return opts, err
+}
+
+// decryptBackup writes the backup decrypted to a temp directory, the backup
+// is returned as it is when it is not encrypted. The cleanup func removes
+// the decrypted backup.
+func decryptBackup(file string) (string, func(), error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	head, _ := br.Peek(512)
+	format := encrypt.Detect(head)
+	if format == "" {
+		return file, func() {}, nil
+	}
+
+	opts, err := decryptOptions(file)
+	if err != nil {
+		return "", nil, err
+	}
+
+	dir, err := ioutil.TempDir("", "nitro-decrypt-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	// the compression of the backup is kept in the name
+	decrypted := filepath.Join(dir, strings.TrimSuffix(filepath.Base(file), "."+format))
+	out, err := os.OpenFile(decrypted, os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	defer out.Close()
+
+	fmt.Printf("Decrypting %q...\n", filepath.Base(file))
+
+	r, err := encrypt.NewReader(br, format, opts)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	defer r.Close()
+
+	if _, err := io.Copy(out, r); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	if err := out.Close(); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	return decrypted, cleanup, nil
+}
+
+// backupPassphrase returns the passphrase of backups from the environment,
+// or asks for it. A new passphrase is asked for twice to confirm it.
+func backupPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(passphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	if !terminal.IsTerminal(os.Stdin.Fd()) {
+		return "", fmt.Errorf("unable to ask for the passphrase, set %s to the passphrase of the backup", passphraseEnv)
+	}
+
+	passphrase, err := askPassphrase("Enter the passphrase of the backup: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New("the passphrase cannot be empty")
+	}
+
+	if confirm {
+		again, err := askPassphrase("Enter the passphrase again: ")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", errors.New("the passphrases do not match")
+		}
+	}
+
+	return passphrase, nil
+}
+
+func askPassphrase(message string) (string, error) {
+	fmt.Print(message)
+	passphrase, err := terminal.ReadPassword(os.Stdin)
+	fmt.Println()
+
+	return passphrase, err
+}
@@ -5,7 +5,6 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
-	"strings"
 	"time"
 
 	"github.com/mitchellh/go-homedir"
@@ -15,6 +14,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/craftcms/nitro/internal/client"
 	"github.com/craftcms/nitro/internal/config"
 	"github.com/craftcms/nitro/internal/datetime"
 	"github.com/craftcms/nitro/internal/helpers"
@@ -61,35 +61,26 @@ var destroyCommand = &cobra.Command{
 
 		// if we have any containers to backup, do so now
 		if flagSkipBackup == false && len(cfg.Databases) != 0 {
+			c, err := client.NewDefaultClient(machine)
+			if err != nil {
+				return err
+			}
+
 			// backup the container
 			for _, db := range cfg.Databases {
 				container := db.Name()
 
-				// run the script to get all databases
+				// get all of the databases from nitrod
+				databases, err := listDatabases(cmd.Context(), c, db)
+				if err != nil {
+					fmt.Println(err)
+					fmt.Println("There was a problem listing the databases.\nIf you wish to destroy " + machine + " without backups use --skip-backup.")
+					return err
+				}
+
 				var dbs []string
-				switch db.Engine {
-				case "postgres":
-					if output, err := script.Run(false, fmt.Sprintf(scripts.FmtDockerPostgresShowAllDatabases, container)); err == nil {
-						sp := strings.Split(output, "\n")
-						for i, d := range sp {
-							d = strings.TrimSpace(d)
-							if i == 0 || i == 1 || i == len(sp) || strings.Contains(d, "rows)") || d == "mysql" {
-								continue
-							}
-
-							dbs = append(dbs, d)
-						}
-					}
-				default:
-					if output, err := script.Run(false, fmt.Sprintf(scripts.FmtDockerMysqlShowAllDatabases, container)); err == nil {
-						for _, db := range strings.Split(output, "\n") {
-							// ignore the system defaults
-							if db == "Database" || db == "information_schema" || db == "performance_schema" || db == "sys" || strings.Contains(db, "password on the command line") || db == "mysql" {
-								continue
-							}
-							dbs = append(dbs, db)
-						}
-					}
+				for _, d := range databases {
+					dbs = append(dbs, d.GetName())
 				}
 
 				if len(dbs) == 0 {
@@ -124,14 +115,14 @@ var destroyCommand = &cobra.Command{
 						}
 					default:
 						// create the backup directory if not found
-						if output, err := script.Run(false, fmt.Sprintf(scripts.FmtCreateDirectory, "/home/ubuntu/.nitro/databases/mysql/backups/")); err != nil {
+						if output, err := script.Run(false, fmt.Sprintf(scripts.FmtCreateDirectory, "/home/ubuntu/.nitro/databases/"+db.Engine+"/backups/")); err != nil {
 							fmt.Println(output)
 							fmt.Println(err)
 							fmt.Println(backupErrorMessage)
 							return err
 						}
 
-						fullVmBackupPath = "/home/ubuntu/.nitro/databases/mysql/backups/" + backupFileName
+						fullVmBackupPath = "/home/ubuntu/.nitro/databases/" + db.Engine + "/backups/" + backupFileName
 						if output, err := script.Run(false, fmt.Sprintf(scripts.FmtDockerBackupAllMysqlDatabases, container, fullVmBackupPath)); err != nil {
 							fmt.Println(output)
 							fmt.Println(err)
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/nginx"
+	"github.com/craftcms/nitro/internal/nitrod"
+	"github.com/craftcms/nitro/internal/preset"
+	"github.com/craftcms/nitro/internal/terminal"
+	"github.com/craftcms/nitro/internal/webroot"
+)
+
+var execCommand = &cobra.Command{
+	Use:   "exec -- command [args]",
+	Short: "Run a command in the current directory on the machine",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runOnMachine(cmd.Context(), args[0], args[1:])
+	},
+}
+
+var craftCommand = &cobra.Command{
+	Use:                "craft [args]",
+	Short:              "Run craft in the current directory on the machine",
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runOnMachine(cmd.Context(), "craft", args)
+	},
+}
+
+var execComposerCommand = &cobra.Command{
+	Use:                "composer [args]",
+	Short:              "Run composer in the current directory on the machine",
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runOnMachine(cmd.Context(), "composer", args)
+	},
+}
+
+func init() {
+	execCommand.Flags().StringVar(&flagPhpVersion, "php-version", "", "Version of PHP to run php, craft and composer with")
+}
+
+// runOnMachine runs the command on the machine in the directory mounted from
+// the current directory.
+func runOnMachine(ctx context.Context, command string, args []string) error {
+	machine := flagMachineName
+
+	var cfg config.Config
+	if err := viper.Unmarshal(&cfg); err != nil {
+		return err
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	start, err := execStart(cfg, wd, command, args)
+	if err != nil {
+		return err
+	}
+
+	c, err := client.NewDefaultClient(machine)
+	if err != nil {
+		return err
+	}
+
+	return runExec(ctx, c, start)
+}
+
+// execStart returns the command to run in the directory on the machine mounted
+// from the local directory. When the directory is in a site, the command gets
+// the same env as the site's web requests, the preset's env followed by the
+// site's.
+func execStart(cfg config.Config, wd, command string, args []string) (*nitrod.ExecStart, error) {
+	dir, err := webroot.MachinePath(cfg.Mounts, wd)
+	if err != nil {
+		return nil, err
+	}
+
+	php := flagPhpVersion
+	if php == "" {
+		php = cfg.PHP
+	}
+
+	start := &nitrod.ExecStart{
+		Command: command,
+		Args:    args,
+		Dir:     dir,
+		Php:     php,
+	}
+
+	if site, ok := webroot.Site(cfg.Sites, dir); ok {
+		p, ok := preset.Lookup(site.Type)
+		if !ok {
+			return nil, fmt.Errorf("the type %q of %s is not one of %s", site.Type, site.Hostname, strings.Join(preset.Names(), ", "))
+		}
+
+		env := p.Environment(site.Env)
+		if _, err := nginx.ParseEnv(env); err != nil {
+			return nil, fmt.Errorf("the env of %s is not valid, %w", site.Hostname, err)
+		}
+
+		// later variables replace earlier ones, as they do for nginx
+		start.Env = env
+	}
+
+	return start, nil
+}
+
+// runExec runs the command on the machine with the input and output of the
+// CLI. When the command is run from a terminal, the machine creates a
+// terminal for the command so it can be used interactively. The CLI
+// exits with the exit code of the command.
+func runExec(ctx context.Context, c nitrod.NitroServiceClient, start *nitrod.ExecStart) error {
+	streams := client.ExecStreams{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	in, out := os.Stdin.Fd(), os.Stdout.Fd()
+	var state *terminal.State
+	if terminal.IsTerminal(in) && terminal.IsTerminal(out) {
+		start.Tty = true
+		if term := os.Getenv("TERM"); term != "" {
+			start.Env = append(start.Env, "TERM="+term)
+		}
+		if size, err := terminal.GetSize(out); err == nil {
+			start.Size = &nitrod.TerminalSize{Rows: uint32(size.Rows), Cols: uint32(size.Cols)}
+		}
+
+		resize := make(chan *nitrod.TerminalSize)
+		go func() {
+			defer close(resize)
+			for size := range terminal.WatchSize(ctx, out) {
+				select {
+				case resize <- &nitrod.TerminalSize{Rows: uint32(size.Rows), Cols: uint32(size.Cols)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		streams.Resize = resize
+
+		var err error
+		state, err = terminal.MakeRaw(in)
+		if err != nil {
+			return err
+		}
+	}
+
+	code, err := client.Exec(ctx, c, start, streams)
+
+	// restore the terminal before exiting
+	if state != nil {
+		_ = terminal.Restore(in, state)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		os.Exit(code)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/craftcms/nitro/internal/config"
+)
+
+func Test_execStart(t *testing.T) {
+	cfg := config.Config{
+		PHP:    "7.4",
+		Mounts: []config.Mount{{Source: "/Users/someuser/dev", Dest: "/home/ubuntu/sites"}},
+		Sites: []config.Site{
+			{Hostname: "demo.test", Webroot: "/home/ubuntu/sites/demo/web", Env: []string{"DB_PASSWORD=secret", "APP_DEBUG=true"}},
+			{Hostname: "laravel.test", Webroot: "/home/ubuntu/sites/laravel/public", Type: "laravel"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		wd      string
+		wantDir string
+		wantEnv []string
+	}{
+		{
+			name:    "commands in a site get the env of the preset and the site",
+			wd:      "/Users/someuser/dev/demo",
+			wantDir: "/home/ubuntu/sites/demo",
+			wantEnv: []string{"CRAFT_NITRO=1", "DB_USER=nitro", "DB_PASSWORD=nitro", "DB_PASSWORD=secret", "APP_DEBUG=true"},
+		},
+		{
+			name:    "commands use the preset of the site",
+			wd:      "/Users/someuser/dev/laravel/config",
+			wantDir: "/home/ubuntu/sites/laravel/config",
+			wantEnv: []string{"APP_ENV=local", "DB_USERNAME=nitro", "DB_PASSWORD=nitro"},
+		},
+		{
+			name:    "commands outside of a site do not get an env",
+			wd:      "/Users/someuser/dev",
+			wantDir: "/home/ubuntu/sites",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, err := execStart(cfg, tt.wd, "craft", []string{"migrate/all"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if start.GetDir() != tt.wantDir || start.GetPhp() != "7.4" || start.GetCommand() != "craft" {
+				t.Errorf("unexpected command %v", start)
+			}
+			if !reflect.DeepEqual(start.GetEnv(), tt.wantEnv) {
+				t.Errorf("expected the env %v, got %v", tt.wantEnv, start.GetEnv())
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ var (
 	// flags for the add command
 	flagHostname string
 	flagWebroot  string
+	flagSiteType string
 
 	// flags for apply
 	flagSkipHosts bool
@@ -28,4 +29,49 @@ var (
 
 	// flag for overriding which config to use
 	flagConfigFile string
+
+	// flag for which PHP SAPI to change
+	flagSapi string
+
+	// flags for a local nitrod binary and its version
+	flagDaemonFile    string
+	flagDaemonVersion string
+
+	// flag for starting a job without watching it
+	flagDetach bool
+
+	// flag for converting a dump to the engine it is imported into
+	flagConvert bool
+
+	// flags for the replacements in database dumps
+	flagReplace []string
+	flagSite    string
+	flagDryRun  bool
+
+	// flags for the sanitize profile of imports and the db sanitize command
+	flagSanitize        string
+	flagSanitizeProfile string
+	flagTablePrefix     string
+
+	// flags for selecting the database of db shell and db query
+	flagDatabaseEngine string
+	flagDatabase       string
+
+	// flag for the format of the output of db query
+	flagOutput string
+
+	// flags for the preflight scan of imports
+	flagPreflight bool
+	flagFix       bool
+
+	// flags for compressing and encrypting backups
+	flagCompress   string
+	flagEncrypt    string
+	flagPassphrase bool
+
+	// flag for the age identity file used to decrypt backups
+	flagIdentity string
+
+	// flag for the destination backups are uploaded to
+	flagDestination string
 )
@@ -7,19 +7,17 @@ import (
 
 	"github.com/craftcms/nitro/internal/client"
 	"github.com/craftcms/nitro/internal/config"
-	"github.com/craftcms/nitro/internal/nitro"
 	"github.com/craftcms/nitro/internal/nitrod"
 )
 
 var inigetCommand = &cobra.Command{
-	Use:   "iniget",
-	Short: "Get PHP settings",
-	Args:  cobra.MinimumNArgs(1),
+	Use:     "iniget setting",
+	Short:   "Get PHP settings",
+	Example: "nitro php iniget memory_limit",
+	Args:    cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		machine := flagMachineName
-		runner := nitro.NewMultipassRunner("multipass")
-		ip := nitro.IP(machine, runner)
-		c, err := client.NewClient(ip, "50051")
+		c, err := client.NewDefaultClient(machine)
 		if err != nil {
 			return err
 		}
@@ -33,8 +31,43 @@ var inigetCommand = &cobra.Command{
 			return err
 		}
 
-		fmt.Println(resp.Message)
+		fmt.Printf("The setting %q for PHP %s is currently set to:\n", resp.GetSetting(), resp.GetVersion())
+		fmt.Println("  fpm:", iniValue(resp.GetFpm()))
+		fmt.Println("  cli:", iniValue(resp.GetCli()))
 
 		return nil
 	},
 }
+
+// iniValue formats a setting value for display, noting
+// when the value is overridden by nitro.
+func iniValue(v *nitrod.PhpIniValue) string {
+	var s string
+	switch v.GetType() {
+	case nitrod.PhpIniValueType_BOOLEAN:
+		s = "Off"
+		if v.GetBool() {
+			s = "On"
+		}
+	case nitrod.PhpIniValueType_BYTES:
+		s = v.GetRaw()
+		if v.GetInt() >= 0 {
+			s = fmt.Sprintf("%s (%d bytes)", v.GetRaw(), v.GetInt())
+		}
+	default:
+		s = fmt.Sprintf("%q", v.GetRaw())
+		if v.GetType() == nitrod.PhpIniValueType_INTEGER {
+			s = v.GetRaw()
+		}
+	}
+
+	if v.GetOverridden() {
+		s = s + " (set by nitro)"
+	}
+
+	return s
+}
+
+func init() {
+	inigetCommand.Flags().StringVar(&flagPhpVersion, "php-version", "", "which PHP version")
+}
@@ -1,24 +1,103 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/nitrod"
+)
 
 var inisetCommand = &cobra.Command{
-	Use:       "iniset",
+	Use:       "iniset setting value",
 	Short:     "Change PHP settings",
-	ValidArgs: []string{"display_errors", "max_execution_time", "max_input_vars", "max_input_time", "upload_max_filesize", "max_file_uploads", "memory_limit"},
+	Example:   "nitro php iniset memory_limit 512M",
+	Args:      cobra.ExactArgs(2),
+	ValidArgs: []string{"display_errors", "max_execution_time", "max_input_vars", "max_input_time", "upload_max_filesize", "post_max_size", "max_file_uploads", "memory_limit"},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return cmd.Help()
+		machine := flagMachineName
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
+		}
+
+		sapi, err := phpSapi(flagSapi)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.PhpIniSettings(cmd.Context(), &nitrod.ChangePhpIniSettingRequest{
+			Version: config.GetString("php", flagPhpVersion),
+			Setting: args[0],
+			Value:   args[1],
+			Sapi:    sapi,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !flagSilent {
+			fmt.Println(resp.Message)
+		}
+
+		return nil
 	},
 }
 
+var iniresetCommand = &cobra.Command{
+	Use:     "inireset setting",
+	Short:   "Reset PHP settings",
+	Example: "nitro php inireset memory_limit",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
+		}
+
+		sapi, err := phpSapi(flagSapi)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.ResetPhpIniSetting(cmd.Context(), &nitrod.ResetPhpIniSettingRequest{
+			Version: config.GetString("php", flagPhpVersion),
+			Setting: args[0],
+			Sapi:    sapi,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !flagSilent {
+			fmt.Println(resp.Message)
+		}
+
+		return nil
+	},
+}
+
+// phpSapi converts the --sapi flag into the SAPI for the request.
+func phpSapi(s string) (nitrod.PhpSapi, error) {
+	switch s {
+	case "", "all":
+		return nitrod.PhpSapi_ALL, nil
+	case "fpm":
+		return nitrod.PhpSapi_FPM, nil
+	case "cli":
+		return nitrod.PhpSapi_CLI, nil
+	}
+
+	return nitrod.PhpSapi_ALL, fmt.Errorf("unknown SAPI %q, must be fpm, cli or all", s)
+}
+
 func init() {
-	inisetCommand.AddCommand(
-		inisetMaxExecutionTimeCommand,
-		inisetMaxInputVarsCommand,
-		inisetUploadMaxFilesizeCommand,
-		inisetMaxInputTimeCommand,
-		inisetMaxFileUploadsCommand,
-		inisetMemoryLimitCommand,
-		inisetDisplayErrorsCommand,
-	)
+	for _, c := range []*cobra.Command{inisetCommand, iniresetCommand} {
+		c.Flags().StringVar(&flagPhpVersion, "php-version", "", "which PHP version")
+		c.Flags().StringVar(&flagSapi, "sapi", "all", "which PHP SAPI to change (fpm, cli or all)")
+		c.Flags().BoolVar(&flagSilent, "silent", false, "Run command with no output")
+	}
 }
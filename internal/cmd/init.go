@@ -11,7 +11,10 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/craftcms/nitro/internal/catalog"
+	"github.com/craftcms/nitro/internal/certs"
 	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/nginx"
 	"github.com/craftcms/nitro/internal/nitro"
 	"github.com/craftcms/nitro/internal/runas"
 	"github.com/craftcms/nitro/internal/suggest"
@@ -89,7 +92,7 @@ var initCommand = &cobra.Command{
 			var loop bool
 			for ok := true; ok; ok = !loop {
 				php, err := p.Ask("Which version of PHP", &prompt.InputOptions{
-					Default:            "7.4",
+					Default:            catalog.DefaultPHP,
 					Validator:          validate.PHPVersion,
 					AppendQuestionMark: true,
 				})
@@ -97,15 +100,16 @@ var initCommand = &cobra.Command{
 				if err == nil {
 					loop = true
 					cfg.PHP = php
+					printPHPWarning(php)
 				} else {
 					loop = false
-					fmt.Println("Invalid input. Possible PHP versions are:", strings.Join(nitro.PHPVersions, ", "))
+					fmt.Println("Invalid input. Possible PHP versions are:", strings.Join(catalog.PHPVersions(), ", "))
 				}
 			}
 		} else {
 			// double check from the major update
 			if cfg.PHP == "" {
-				cfg.PHP = "7.4"
+				cfg.PHP = catalog.DefaultPHP
 			}
 		}
 
@@ -123,7 +127,7 @@ var initCommand = &cobra.Command{
 				if err == nil {
 					dbEngineLoop = true
 				} else {
-					fmt.Println("Invalid input. Possible database engines are:", strings.Join(nitro.DBEngines, ", "))
+					fmt.Println("Invalid input. Possible database engines are:", strings.Join(catalog.Engines(), ", "))
 					dbEngineLoop = false
 				}
 			}
@@ -132,10 +136,9 @@ var initCommand = &cobra.Command{
 			var dbVersionLoop bool
 			var version string
 			for ok := true; ok; ok = !dbVersionLoop {
-				versions := nitro.DBVersions[engine]
-				defaultVersion := versions[0]
+				e, _ := catalog.LookupEngine(engine)
 				version, _ = p.Ask("Which version of "+engine, &prompt.InputOptions{
-					Default:            defaultVersion,
+					Default:            e.Default,
 					AppendQuestionMark: true,
 				})
 
@@ -144,22 +147,21 @@ var initCommand = &cobra.Command{
 				if err == nil {
 					dbVersionLoop = true
 				} else {
-					fmt.Println("Invalid input. Possible database versions are:", strings.Join(nitro.DBVersions[engine], ", "))
+					fmt.Println("Invalid input. Possible database versions are:", strings.Join(e.VersionNames(), ", "))
 					dbVersionLoop = false
 				}
 			}
 
-			// get the port for the engine
-			port := "3306"
-			if strings.Contains(engine, "postgres") {
-				port = "5432"
-			}
+			printDatabaseWarning(engine, version)
+
+			// get the default port for the engine
+			e, _ := catalog.LookupEngine(engine)
 
 			cfg.Databases = []config.Database{
 				{
 					Engine:  engine,
 					Version: version,
-					Port:    port,
+					Port:    e.Port,
 				},
 			}
 		} else {
@@ -207,7 +209,17 @@ var initCommand = &cobra.Command{
 			return err
 		}
 
-		actions, err := createActions(machine, memory, disk, cpuCoresInt, cfg.PHP, cfg.Databases, mounts, sites)
+		dir, err := issueCertificates(sites)
+		if err != nil {
+			return err
+		}
+
+		siteConfig := config.Config{Sites: sites, NginxTemplate: viper.GetString("nginx_template")}
+		if err := renderSiteConfigs(dir, machine, siteConfig, cfg.PHP); err != nil {
+			return err
+		}
+
+		actions, err := createActions(machine, memory, disk, cpuCoresInt, cfg.PHP, cfg.Databases, mounts, sites, dir)
 		if err != nil {
 			return err
 		}
@@ -252,10 +264,22 @@ var initCommand = &cobra.Command{
 		}
 
 		// enable displaying errors
-		if err := inisetDisplayErrorsCommand.RunE(cmd, append(args, "On")); err != nil {
+		if err := inisetCommand.RunE(cmd, []string{"display_errors", "On"}); err != nil {
 			fmt.Println("Unable to enable display_errors, err: ", err.Error())
 		}
 
+		// the machine installs the latest nitrod, make sure it matches this version of nitro
+		if Version != "0.0.0" {
+			if err := daemonUpgradeCommand.RunE(cmd, args); err != nil {
+				fmt.Println("Unable to upgrade nitrod, err: ", err.Error())
+			}
+		}
+
+		// write the xdebug settings for the installed version of xdebug
+		if err := xdebugConfigureCommand.RunE(cmd, args); err != nil {
+			fmt.Println("Unable to configure xdebug, err: ", err.Error())
+		}
+
 		return infoCommand.RunE(cmd, args)
 	},
 }
@@ -267,7 +291,7 @@ func init() {
 	initCommand.Flags().StringVar(&flagPhpVersion, "php-version", "", "Version of PHP to make default")
 }
 
-func createActions(machine, memory, disk string, cpus int, phpVersion string, databases []config.Database, mounts []config.Mount, sites []config.Site) ([]nitro.Action, error) {
+func createActions(machine, memory, disk string, cpus int, phpVersion string, databases []config.Database, mounts []config.Mount, sites []config.Site, dir string) ([]nitro.Action, error) {
 	var actions []nitro.Action
 	launchAction, err := nitro.Launch(machine, cpus, memory, disk, CloudConfig)
 	if err != nil {
@@ -294,11 +318,11 @@ func createActions(machine, memory, disk string, cpus int, phpVersion string, da
 	}
 	actions = append(actions, *configureExecutionTimeAction)
 
-	xdebugConfigureAction, err := nitro.ConfigureXdebug(machine, phpVersion)
+	fpmStatusAction, err := nitro.ConfigurePHPFpmStatus(machine, phpVersion)
 	if err != nil {
 		return nil, err
 	}
-	actions = append(actions, *xdebugConfigureAction)
+	actions = append(actions, *fpmStatusAction)
 
 	restartPhpFpmAction, err := nitro.RestartPhpFpm(machine, phpVersion)
 	if err != nil {
@@ -332,32 +356,26 @@ func createActions(machine, memory, disk string, cpus int, phpVersion string, da
 	var siteErrs []error
 
 	for _, site := range sites {
-		copyTemplateAction, err := nitro.CopyNginxTemplate(machine, site.Hostname)
+		transferConfigActions, err := nitro.TransferNginxConfig(machine, site.Hostname, nginx.ConfPath(dir, machine, site.Hostname))
 		if err != nil {
 			siteErrs = append(siteErrs, err)
 			continue
 		}
-		actions = append(actions, *copyTemplateAction)
-
-		if site.Webroot == "" {
-			site.Webroot = "web"
-		}
+		actions = append(actions, *transferConfigActions...)
 
-		changeVarsActions, err := nitro.ChangeTemplateVariables(machine, site.Webroot, site.Hostname, phpVersion, site.Aliases)
+		transferCertActions, err := nitro.TransferSiteCertificate(machine, site.Hostname, certs.CertPath(dir, site.Hostname), certs.KeyPath(dir, site.Hostname))
 		if err != nil {
 			siteErrs = append(siteErrs, err)
 			continue
 		}
-		for _, a := range *changeVarsActions {
-			actions = append(actions, a)
-		}
+		actions = append(actions, *transferCertActions...)
 
-		createSymlinkAction, err := nitro.CreateSiteSymllink(machine, site.Hostname)
+		enableSiteAction, err := nitro.EnableNginxSite(machine, site.Hostname)
 		if err != nil {
 			siteErrs = append(siteErrs, err)
 			continue
 		}
-		actions = append(actions, *createSymlinkAction)
+		actions = append(actions, *enableSiteAction)
 
 		reloadNginxAction, err := nitro.NginxReload(machine)
 		if err != nil {
@@ -8,12 +8,12 @@ import (
 var installCommand = &cobra.Command{
 	Use:       "install",
 	Short:     "Install software",
-	ValidArgs: []string{"composer", "mailhog", "postgres", "mysql"},
+	ValidArgs: []string{"composer", "mailhog", "postgres", "mysql", "mariadb"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return cmd.Help()
 	},
 }
 
 func init() {
-	installCommand.AddCommand(mailhogCommand, composerCommand, postgresCommand, mysqlCommand)
+	installCommand.AddCommand(mailhogCommand, composerCommand, postgresCommand, mysqlCommand, mariadbCommand)
 }
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pixelandtonic/prompt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/validate"
+)
+
+type newMariaDBValidator struct {
+	cfg *config.Config
+}
+
+func (v newMariaDBValidator) ValidateVersion(version string) error {
+	if err := validate.DatabaseEngineAndVersion("mariadb", version); err != nil {
+		return err
+	}
+
+	for _, db := range v.cfg.Databases {
+		if db.Engine == "mariadb" && version == db.Version {
+			return fmt.Errorf("MariaDB version %q is already installed.", version)
+		}
+	}
+
+	return nil
+}
+
+func (v newMariaDBValidator) ValidatePort(port string) error {
+	for _, db := range v.cfg.Databases {
+		if port == db.Port {
+			return fmt.Errorf("MariaDB port %q is already in use.", port)
+		}
+	}
+
+	return nil
+}
+
+var mariadbCommand = &cobra.Command{
+	Use:   "mariadb",
+	Short: "Install MariaDB",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p := prompt.NewPrompt()
+
+		// get the config
+		cfg, err := config.Read()
+		if err != nil {
+			return err
+		}
+
+		validator := newMariaDBValidator{cfg: cfg}
+
+		// ask for the version
+		version, err := p.Ask("Enter the MariaDB version to install", &prompt.InputOptions{
+			Default:   defaultDatabaseVersion("mariadb"),
+			Validator: validator.ValidateVersion,
+		})
+		if err != nil {
+			return err
+		}
+
+		printDatabaseWarning("mariadb", version)
+
+		// ask for the port assignment
+		port, err := p.Ask("Enter the MariaDB port number", &prompt.InputOptions{
+			Validator: validator.ValidatePort,
+		})
+		if err != nil {
+			return err
+		}
+
+		// save to the config file
+		cfg.Databases = append(cfg.Databases, config.Database{
+			Engine:  "mariadb",
+			Version: version,
+			Port:    port,
+		})
+
+		// save the file
+		if err := cfg.Save(viper.ConfigFileUsed()); err != nil {
+			fmt.Println("Error saving the config file.")
+			return err
+		}
+
+		fmt.Println(fmt.Sprintf("Adding MariaDB version %q on port %q", version, port))
+
+		// prompt for the apply command
+		apply, err := p.Confirm("Apply changes from config now", &prompt.InputOptions{
+			Default:            "yes",
+			AppendQuestionMark: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if apply {
+			return applyCommand.RunE(cmd, args)
+		}
+
+		return nil
+	},
+}
@@ -65,12 +65,15 @@ var mysqlCommand = &cobra.Command{
 
 		// ask for the version
 		version, err := p.Ask(fmt.Sprintf("Enter the MySQL version to install"), &prompt.InputOptions{
+			Default:   defaultDatabaseVersion("mysql"),
 			Validator: validator.ValidateVersion,
 		})
 		if err != nil {
 			return err
 		}
 
+		printDatabaseWarning("mysql", version)
+
 		// ask for the port assignment
 		port, err := p.Ask(fmt.Sprintf("Enter the MySQL port number"), &prompt.InputOptions{
 			Validator: validator.ValidatePort,
@@ -66,12 +66,15 @@ var postgresCommand = &cobra.Command{
 
 		// ask for the version
 		version, err := p.Ask(fmt.Sprintf("Enter the PostgreSQL version to install"), &prompt.InputOptions{
+			Default:   defaultDatabaseVersion("postgres"),
 			Validator: validator.ValidateVersion,
 		})
 		if err != nil {
 			return err
 		}
 
+		printDatabaseWarning("postgres", version)
+
 		// ask for the port assignment
 		port, err := p.Ask(fmt.Sprintf("Enter the PostgreSQL port number"), &prompt.InputOptions{
 			Validator: validator.ValidatePort,
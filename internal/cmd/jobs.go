@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/nitrod"
+)
+
+var jobsCommand = &cobra.Command{
+	Use:       "jobs",
+	Short:     "List background jobs",
+	ValidArgs: []string{"cancel", "status", "watch"},
+	Args:      cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewDefaultClient(flagMachineName)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.ListJobs(cmd.Context(), &nitrod.ListJobsRequest{})
+		if err != nil {
+			return err
+		}
+
+		if len(resp.GetJobs()) == 0 {
+			fmt.Println("There are no jobs on", flagMachineName)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tKIND\tSTATE\tSTARTED\tDESCRIPTION")
+		for _, job := range resp.GetJobs() {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", job.GetId(), job.GetKind(), jobState(job), formatJobTime(job.GetCreated()), job.GetDescription())
+		}
+
+		return w.Flush()
+	},
+}
+
+var jobsStatusCommand = &cobra.Command{
+	Use:   "status <id>",
+	Short: "Show job status",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewDefaultClient(flagMachineName)
+		if err != nil {
+			return err
+		}
+
+		job, err := c.GetJob(cmd.Context(), &nitrod.GetJobRequest{Id: args[0]})
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintf(w, "ID:\t%s\n", job.GetId())
+		fmt.Fprintf(w, "Kind:\t%s\n", job.GetKind())
+		fmt.Fprintf(w, "Description:\t%s\n", job.GetDescription())
+		fmt.Fprintf(w, "State:\t%s\n", jobState(job))
+		fmt.Fprintf(w, "Started:\t%s\n", formatJobTime(job.GetCreated()))
+		if job.GetFinished() > 0 {
+			fmt.Fprintf(w, "Finished:\t%s\n", formatJobTime(job.GetFinished()))
+		}
+		if job.GetMessage() != "" {
+			fmt.Fprintf(w, "Message:\t%s\n", job.GetMessage())
+		}
+
+		return w.Flush()
+	},
+}
+
+var jobsWatchCommand = &cobra.Command{
+	Use:   "watch <id>",
+	Short: "Show job output until it finishes",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewDefaultClient(flagMachineName)
+		if err != nil {
+			return err
+		}
+
+		return watchJob(cmd.Context(), c, args[0])
+	},
+}
+
+var jobsCancelCommand = &cobra.Command{
+	Use:   "cancel <id>",
+	Short: "Cancel a running job",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewDefaultClient(flagMachineName)
+		if err != nil {
+			return err
+		}
+
+		job, err := c.CancelJob(cmd.Context(), &nitrod.CancelJobRequest{Id: args[0]})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Canceling job %s: %s\n", job.GetId(), job.GetDescription())
+
+		return nil
+	},
+}
+
+func init() {
+	jobsCommand.AddCommand(jobsStatusCommand, jobsWatchCommand, jobsCancelCommand)
+}
+
+// followJob watches a job that was just started, unless the
+// detach flag is set, and explains how to check on it later.
+func followJob(ctx context.Context, c nitrod.NitroServiceClient, job *nitrod.Job) error {
+	if flagDetach {
+		fmt.Printf("Started job %s, run `nitro jobs watch %s` to see the output.\n", job.GetId(), job.GetId())
+		return nil
+	}
+
+	fmt.Printf("Started job %s, stopping with Ctrl+C does not stop the job.\n", job.GetId())
+
+	return watchJob(ctx, c, job.GetId())
+}
+
+// watchJob shows the output of the job until it is done and
+// returns an error when the job did not succeed.
+func watchJob(ctx context.Context, c nitrod.NitroServiceClient, id string) error {
+	job, err := client.WatchJob(ctx, c, id, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	switch job.GetState() {
+	case nitrod.JobState_SUCCEEDED:
+		fmt.Printf("Job %s succeeded: %s\n", job.GetId(), job.GetDescription())
+		return nil
+	case nitrod.JobState_CANCELED:
+		return fmt.Errorf("job %s was canceled", job.GetId())
+	}
+
+	return errors.New(job.GetMessage())
+}
+
+func jobState(job *nitrod.Job) string {
+	return strings.ToLower(job.GetState().String())
+}
+
+func formatJobTime(unix int64) string {
+	return time.Unix(unix, 0).Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/nginx"
+	"github.com/craftcms/nitro/internal/preset"
+)
+
+// renderSiteConfigs renders the nginx configuration of each site to the
+// nitro directory using the template of the site, the template of the
+// site's preset, the template of the machine or the default preset.
+func renderSiteConfigs(dir, machine string, cfg config.Config, php string) error {
+	var machineTemplate *template.Template
+	if cfg.NginxTemplate != "" {
+		var err error
+		if machineTemplate, err = parseNginxTemplate(cfg.NginxTemplate); err != nil {
+			return err
+		}
+	}
+
+	for _, site := range cfg.Sites {
+		p, ok := preset.Lookup(site.Type)
+		if !ok {
+			return fmt.Errorf("the type %q of %s is not one of %s", site.Type, site.Hostname, strings.Join(preset.Names(), ", "))
+		}
+
+		var t *template.Template
+		var err error
+		switch {
+		case site.NginxTemplate != "":
+			t, err = parseNginxTemplate(site.NginxTemplate)
+		case site.Type == "" && machineTemplate != nil:
+			t = machineTemplate
+		default:
+			t, err = nginx.Parse(p.Name, p.Template)
+		}
+		if err != nil {
+			return err
+		}
+
+		// the env of the site replaces the defaults of the preset
+		site.Env = p.Environment(site.Env)
+
+		if site.Webroot == "" {
+			site.Webroot = "web"
+		}
+
+		vars, err := nginx.SiteVars(site, php)
+		if err != nil {
+			return err
+		}
+
+		if err := nginx.Write(dir, machine, t, vars); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseNginxTemplate parses the template file, relative paths are
+// relative to the directory of the config file.
+func parseNginxTemplate(file string) (*template.Template, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case strings.HasPrefix(file, "~/"):
+		file = filepath.Join(home, file[2:])
+	case !filepath.IsAbs(file) && viper.ConfigFileUsed() != "":
+		file = filepath.Join(filepath.Dir(viper.ConfigFileUsed()), file)
+	}
+
+	t, err := nginx.ParseFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the nginx template, %w", err)
+	}
+
+	return t, nil
+}
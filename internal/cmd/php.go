@@ -20,6 +20,28 @@ var phpCommand = &cobra.Command{
 	},
 }
 
+var phpInstallCommand = &cobra.Command{
+	Use:   "install",
+	Short: "Install PHP packages",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewDefaultClient(flagMachineName)
+		if err != nil {
+			return err
+		}
+		php := config.GetString("php", flagPhpVersion)
+		printPHPWarning(php)
+
+		job, err := c.StartJob(cmd.Context(), &nitrod.StartJobRequest{Job: &nitrod.StartJobRequest_InstallPackages{
+			InstallPackages: &nitrod.InstallPackagesJob{Version: php},
+		}})
+		if err != nil {
+			return err
+		}
+
+		return followJob(cmd.Context(), c, job)
+	},
+}
+
 var phpRestartCommand = &cobra.Command{
 	Use:   "restart",
 	Short: "Restart php-fpm",
@@ -97,6 +119,8 @@ var phpStopCommand = &cobra.Command{
 }
 
 func init() {
+	phpInstallCommand.Flags().StringVar(&flagPhpVersion, "php-version", "", "which PHP version")
+	phpInstallCommand.Flags().BoolVar(&flagDetach, "detach", false, "Install in the background without showing the output")
 	phpRestartCommand.Flags().StringVar(&flagPhpVersion, "php-version", "", "which PHP version")
 	phpStartCommand.Flags().StringVar(&flagPhpVersion, "php-version", "", "which PHP version")
 	phpStopCommand.Flags().StringVar(&flagPhpVersion, "php-version", "", "which PHP version")
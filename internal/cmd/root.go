@@ -6,6 +6,8 @@ import (
 	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/client"
 )
 
 var rootCmd = &cobra.Command{
@@ -18,6 +20,9 @@ var rootCmd = &cobra.Command{
 func init() {
 	cobra.OnInitialize(loadConfig)
 
+	// the version is compared with nitrod when connecting
+	client.Version = Version
+
 	// set persistent flags on the root command
 	rootCmd.PersistentFlags().StringVarP(&flagMachineName, "machine", "m", "", "Name of a machine.")
 	rootCmd.PersistentFlags().BoolVarP(&flagDebug, "debug", "d", false, "Show command output and do not execute.")
@@ -53,10 +58,16 @@ func init() {
 		xoffCommand,
 		supportCommand,
 		createcommand,
+		daemonCommand,
+		execCommand,
+		craftCommand,
+		execComposerCommand,
+		jobsCommand,
+		trustCommand,
 	)
-	phpCommand.AddCommand(phpRestartCommand, phpStartCommand, phpStopCommand, inisetCommand, inigetCommand)
+	phpCommand.AddCommand(phpInstallCommand, phpRestartCommand, phpStartCommand, phpStopCommand, inisetCommand, inigetCommand, iniresetCommand)
 	nginxCommand.AddCommand(nginxStartCommand, nginxStopCommand, nginxRestartCommand)
-	xdebugCommand.AddCommand(xdebugOnCommand, xdebugOffCommand)
+	xdebugCommand.AddCommand(xdebugOnCommand, xdebugOffCommand, xdebugConfigureCommand)
 }
 
 func New() *cobra.Command  {
@@ -64,6 +75,9 @@ func New() *cobra.Command  {
 }
 
 func Execute() {
+	// the flags are added in the init of each command, so this runs once they all exist
+	registerPHPVersionCompletion(rootCmd)
+
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
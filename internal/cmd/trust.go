@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+
+	"github.com/craftcms/nitro/internal/certs"
+	"github.com/craftcms/nitro/internal/config"
+)
+
+var trustCommand = &cobra.Command{
+	Use:   "trust",
+	Short: "Trust the Nitro CA",
+	Long:  "Adds the certificate authority that issues the HTTPS certificates of the sites to the trust store of the system and the browsers, so the sites can be visited over https without a warning. The CA is created when it does not exist.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := nitroDir()
+		if err != nil {
+			return err
+		}
+
+		if _, _, err := certs.LoadOrCreate(dir); err != nil {
+			return err
+		}
+
+		if flagDebug {
+			fmt.Println("Adding", certs.CAPath(dir), "to the trust stores.")
+			return nil
+		}
+
+		fmt.Println("Adding the Nitro CA to the trust stores, you may be asked for your password...")
+
+		stores, err := certs.Trust(certs.CAPath(dir))
+		for _, store := range stores {
+			fmt.Println("Trusted the Nitro CA in", store)
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("Restart your browser to visit the sites over https.")
+
+		return nil
+	},
+}
+
+// nitroDir returns the directory with the Nitro CA, the certificates
+// and the rendered nginx configurations of the sites, ~/.nitro.
+func nitroDir() (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".nitro"), nil
+}
+
+// issueCertificates issues the certificates of the sites using the Nitro
+// CA, which is created the first time. It returns the directory with the
+// certificates.
+func issueCertificates(sites []config.Site) (string, error) {
+	dir, err := nitroDir()
+	if err != nil {
+		return "", err
+	}
+
+	if len(sites) == 0 {
+		return dir, nil
+	}
+
+	ca, created, err := certs.LoadOrCreate(dir)
+	if err != nil {
+		return "", err
+	}
+	if created {
+		fmt.Println("Created the Nitro CA for https, run `nitro trust` to trust it in your browser.")
+	}
+
+	for _, site := range sites {
+		if _, err := ca.Issue(dir, site.Hostname, site.Aliases); err != nil {
+			return "", fmt.Errorf("unable to issue a certificate for %s, %w", site.Hostname, err)
+		}
+	}
+
+	return dir, nil
+}
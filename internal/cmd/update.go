@@ -1,40 +1,34 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
-	"github.com/craftcms/nitro/internal/nitro"
+	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/nitrod"
 )
 
-// TODO move this to the nitrod API
 var updateCommand = &cobra.Command{
 	Use:     "update",
 	Aliases: []string{"upgrade"},
 	Short:   "Update machine",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		machine := flagMachineName
-
-		var actions []nitro.Action
-		updateAction, err := nitro.Update(machine)
+		c, err := client.NewDefaultClient(flagMachineName)
 		if err != nil {
 			return err
 		}
-		actions = append(actions, *updateAction)
 
-		upgradeAction, err := nitro.Upgrade(machine)
+		// upgrades can take a while, so nitrod runs them as a job
+		job, err := c.StartJob(cmd.Context(), &nitrod.StartJobRequest{Job: &nitrod.StartJobRequest_UpgradePackages{
+			UpgradePackages: &nitrod.UpgradePackagesJob{},
+		}})
 		if err != nil {
 			return err
 		}
-		actions = append(actions, *upgradeAction)
-
-		if err := nitro.Run(nitro.NewMultipassRunner("multipass"), actions); err != nil {
-			return err
-		}
-
-		fmt.Println("Updated", machine)
 
-		return nil
+		return followJob(cmd.Context(), c, job)
 	},
 }
+
+func init() {
+	updateCommand.Flags().BoolVar(&flagDetach, "detach", false, "Run the update in the background without showing the output")
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/craftcms/nitro/internal/catalog"
+)
+
+// completePHPVersions completes the php-version flags with the versions in the catalog.
+func completePHPVersions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return catalog.PHPVersions(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// registerPHPVersionCompletion adds the completion to every
+// command with a php-version flag, including sub commands.
+func registerPHPVersionCompletion(cmd *cobra.Command) {
+	if cmd.Flags().Lookup("php-version") != nil {
+		_ = cmd.RegisterFlagCompletionFunc("php-version", completePHPVersions)
+	}
+
+	for _, c := range cmd.Commands() {
+		registerPHPVersionCompletion(c)
+	}
+}
+
+// printPHPWarning warns when the version of PHP has reached its end of life.
+func printPHPWarning(version string) {
+	if w := catalog.PHPWarning(version, time.Now()); w != "" {
+		fmt.Println("Warning:", w)
+	}
+}
+
+// printDatabaseWarning warns when the version of the database engine has reached its end of life.
+func printDatabaseWarning(engine, version string) {
+	if w := catalog.DatabaseWarning(engine, version, time.Now()); w != "" {
+		fmt.Println("Warning:", w)
+	}
+}
+
+// defaultDatabaseVersion returns the version suggested when adding the engine.
+func defaultDatabaseVersion(engine string) string {
+	e, _ := catalog.LookupEngine(engine)
+
+	return e.Default
+}
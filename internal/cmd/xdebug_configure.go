@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/craftcms/nitro/internal/client"
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/nitrod"
+)
+
+var xdebugConfigureCommand = &cobra.Command{
+	Use:   "configure",
+	Short: "Apply the Xdebug settings from the config",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machine := flagMachineName
+		php := config.GetString("php", flagPhpVersion)
+
+		var cfg config.Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			return err
+		}
+
+		xdebug := config.Xdebug{}
+		if cfg.Xdebug != nil {
+			xdebug = *cfg.Xdebug
+		}
+
+		start := nitrod.XdebugStart_AUTOSTART
+		if xdebug.Trigger {
+			start = nitrod.XdebugStart_TRIGGER
+		}
+
+		c, err := client.NewDefaultClient(machine)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.ConfigureXdebug(cmd.Context(), &nitrod.ConfigureXdebugRequest{
+			Version:    php,
+			Mode:       xdebug.Mode,
+			ClientHost: xdebug.Host,
+			ClientPort: int32(xdebug.Port),
+			Idekey:     xdebug.IdeKey,
+			Start:      start,
+		})
+		if err != nil {
+			return err
+		}
+
+		if flagSilent {
+			return nil
+		}
+
+		state := "disabled"
+		if resp.GetEnabled() {
+			state = "enabled"
+		}
+
+		fmt.Printf("Configured Xdebug %s for PHP %s (%s):\n", resp.GetXdebugVersion(), resp.GetVersion(), state)
+		for _, s := range resp.GetSettings() {
+			fmt.Printf("  %s = %s\n", s.GetName(), s.GetValue())
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	xdebugConfigureCommand.Flags().StringVar(&flagPhpVersion, "php-version", "", "Version of PHP to configure Xdebug")
+	xdebugConfigureCommand.Flags().BoolVar(&flagSilent, "silent", false, "Run command with no output")
+}
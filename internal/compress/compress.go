@@ -1,9 +1,14 @@
 package compress
 
 import (
+	"bytes"
 	"compress/gzip"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"os/exec"
+	"strings"
 	"time"
 )
 
@@ -35,4 +40,55 @@ func ToTempFile(file, pattern string) (*os.File, error)  {
 	}
 
 	return t, nil
-}
\ No newline at end of file
+}
+// NewWriter returns a writer that compresses what is written to w using
+// the format, gz or zst. The writer must be closed to finish the file.
+func NewWriter(w io.Writer, format string) (io.WriteCloser, error) {
+	switch format {
+	case "gz":
+		return gzip.NewWriter(w), nil
+	case "zst":
+		return commandWriter(w, "zstd", "--compress", "--stdout", "--quiet")
+	}
+
+	return nil, fmt.Errorf("unable to compress %q files", format)
+}
+
+// cmdWriter writes to the stdin of a command that compresses it.
+type cmdWriter struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stderr bytes.Buffer
+}
+
+func commandWriter(w io.Writer, name string, args ...string) (io.WriteCloser, error) {
+	c := &cmdWriter{cmd: exec.Command(name, args...)}
+	c.cmd.Stdout = w
+	c.cmd.Stderr = &c.stderr
+
+	stdin, err := c.cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	c.stdin = stdin
+
+	if err := c.cmd.Start(); err != nil {
+		return nil, fmt.Errorf("unable to run %s: %w", name, err)
+	}
+
+	return c, nil
+}
+
+func (c *cmdWriter) Write(p []byte) (int, error) {
+	return c.stdin.Write(p)
+}
+
+// Close waits for the command to finish writing the file.
+func (c *cmdWriter) Close() error {
+	err := c.stdin.Close()
+	if werr := c.cmd.Wait(); werr != nil {
+		return fmt.Errorf("unable to compress the file: %s", strings.TrimSpace(c.stderr.String()))
+	}
+
+	return err
+}
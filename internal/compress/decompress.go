@@ -0,0 +1,113 @@
+package compress
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os/exec"
+	"strings"
+)
+
+// Detect returns the compression format of the data using the magic
+// bytes at the start of the file. It returns an empty string if the
+// data is not compressed or the format is unknown.
+func Detect(b []byte) string {
+	switch {
+	case bytes.HasPrefix(b, []byte{0x1f, 0x8b}):
+		return "gz"
+	case bytes.HasPrefix(b, []byte("BZh")):
+		return "bz2"
+	case bytes.HasPrefix(b, []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}):
+		return "xz"
+	case bytes.HasPrefix(b, []byte{0x28, 0xb5, 0x2f, 0xfd}):
+		return "zst"
+	case bytes.HasPrefix(b, []byte("PK\x03\x04")):
+		return "zip"
+	}
+
+	return ""
+}
+
+// NewReader returns a reader that decompresses r using the format
+// returned by Detect. Formats without a decoder in the standard
+// library are decompressed using the xz and zstd commands.
+func NewReader(r io.Reader, format string) (io.ReadCloser, error) {
+	switch format {
+	case "":
+		return ioutil.NopCloser(r), nil
+	case "gz":
+		return gzip.NewReader(r)
+	case "bz2":
+		return ioutil.NopCloser(bzip2.NewReader(r)), nil
+	case "xz":
+		return commandReader(r, "xz", "--decompress", "--stdout")
+	case "zst":
+		return commandReader(r, "zstd", "--decompress", "--stdout")
+	}
+
+	return nil, fmt.Errorf("unable to decompress %q files", format)
+}
+
+// cmdReader reads the output of a command that decompresses its stdin.
+type cmdReader struct {
+	cmd    *exec.Cmd
+	stdout io.ReadCloser
+	stderr bytes.Buffer
+	done   bool
+	err    error
+}
+
+func commandReader(r io.Reader, name string, args ...string) (io.ReadCloser, error) {
+	c := &cmdReader{cmd: exec.Command(name, args...)}
+	c.cmd.Stdin = r
+	c.cmd.Stderr = &c.stderr
+
+	stdout, err := c.cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	c.stdout = stdout
+
+	if err := c.cmd.Start(); err != nil {
+		return nil, fmt.Errorf("unable to run %s: %w", name, err)
+	}
+
+	return c, nil
+}
+
+// Read returns the error from the command, instead of io.EOF,
+// when the command fails so corrupt files are not imported.
+func (c *cmdReader) Read(p []byte) (int, error) {
+	// the output is closed once the command has finished
+	if c.done {
+		return 0, c.err
+	}
+
+	n, err := c.stdout.Read(p)
+	if err == io.EOF {
+		c.done = true
+		if werr := c.cmd.Wait(); werr != nil {
+			err = fmt.Errorf("unable to decompress the file: %s", strings.TrimSpace(c.stderr.String()))
+		}
+		c.err = err
+	}
+
+	return n, err
+}
+
+// Close stops the command if the output was not read to the end.
+func (c *cmdReader) Close() error {
+	if c.done {
+		return nil
+	}
+	c.done = true
+	c.err = io.ErrClosedPipe
+
+	_ = c.cmd.Process.Kill()
+	_ = c.cmd.Wait()
+
+	return nil
+}
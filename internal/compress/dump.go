@@ -0,0 +1,194 @@
+package compress
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dumpExtensions are the file extensions of database dumps in archives.
+var dumpExtensions = map[string]bool{
+	".sql":    true,
+	".dump":   true,
+	".pgdump": true,
+}
+
+// IsPostgresCustom reports if the data is the start of a
+// pg_dump custom format (-Fc) archive, which must be
+// imported with pg_restore instead of psql.
+func IsPostgresCustom(b []byte) bool {
+	return bytes.HasPrefix(b, []byte("PGDMP"))
+}
+
+// isDump checks the name of a file in an archive, ignoring
+// hidden files and the files macOS adds to zip archives.
+func isDump(name string) bool {
+	base := filepath.Base(name)
+	if strings.HasPrefix(base, ".") || strings.Contains(name, "__MACOSX") {
+		return false
+	}
+
+	return dumpExtensions[strings.ToLower(filepath.Ext(base))]
+}
+
+// multiCloser closes each of the closers in reverse order.
+type multiCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (m *multiCloser) Close() error {
+	var err error
+	for i := len(m.closers) - 1; i >= 0; i-- {
+		if cerr := m.closers[i].Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
+
+// open returns the decompressed content of the file, whether it is a tar
+// archive, and the format of the file. Zip files are not opened since
+// they need to be read using the file.
+func open(path string) (*multiCloser, bool, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false, "", err
+	}
+
+	br := bufio.NewReader(f)
+	b, _ := br.Peek(512)
+	format := Detect(b)
+
+	if format == "zip" {
+		f.Close()
+		return nil, false, format, nil
+	}
+
+	dr, err := NewReader(br, format)
+	if err != nil {
+		f.Close()
+		return nil, false, format, err
+	}
+
+	r := bufio.NewReader(dr)
+	b, _ = r.Peek(512)
+	isTar := len(b) >= 262 && string(b[257:262]) == "ustar"
+
+	return &multiCloser{Reader: r, closers: []io.Closer{f, dr}}, isTar, format, nil
+}
+
+// Dumps returns the names of the database dumps in the archive. Files
+// that are not archives, even when compressed, are a single dump with
+// an empty name.
+func Dumps(path string) ([]string, error) {
+	r, isTar, format, err := open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var dumps []string
+	switch {
+	case format == "zip":
+		z, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, err
+		}
+		defer z.Close()
+
+		for _, f := range z.File {
+			if !f.FileInfo().IsDir() && isDump(f.Name) {
+				dumps = append(dumps, f.Name)
+			}
+		}
+	case isTar:
+		defer r.Close()
+
+		t := tar.NewReader(r)
+		for {
+			h, err := t.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if h.Typeflag == tar.TypeReg && isDump(h.Name) {
+				dumps = append(dumps, h.Name)
+			}
+		}
+	default:
+		r.Close()
+		dumps = []string{""}
+	}
+
+	return dumps, nil
+}
+
+// OpenDump returns a reader for the decompressed dump in the archive
+// using a name returned by Dumps. The content is streamed from the
+// archive so large dumps are never extracted to disk.
+func OpenDump(path, name string) (io.ReadCloser, error) {
+	r, isTar, format, err := open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case format == "zip":
+		z, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range z.File {
+			if f.Name != name {
+				continue
+			}
+
+			rc, err := f.Open()
+			if err != nil {
+				z.Close()
+				return nil, err
+			}
+
+			return &multiCloser{Reader: rc, closers: []io.Closer{z, rc}}, nil
+		}
+
+		z.Close()
+	case isTar:
+		t := tar.NewReader(r)
+		for {
+			h, err := t.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				r.Close()
+				return nil, err
+			}
+
+			if h.Typeflag == tar.TypeReg && h.Name == name {
+				return &multiCloser{Reader: t, closers: r.closers}, nil
+			}
+		}
+
+		r.Close()
+	default:
+		if name == "" {
+			return r, nil
+		}
+
+		r.Close()
+	}
+
+	return nil, fmt.Errorf("unable to find %q in the archive", name)
+}
@@ -0,0 +1,198 @@
+package compress
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeTarGz(t *testing.T, path string, files map[string]string, order []string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range order {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(files[name])), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(files[name])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeZip(t *testing.T, path string, files map[string]string, order []string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range order {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(files[name])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readDump(t *testing.T, path, name string) string {
+	t.Helper()
+
+	r, err := OpenDump(path, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(b)
+}
+
+func TestDumps(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nitro-dumps")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"README.md":            "not a dump",
+		"backups/craft.sql":    "SELECT 1;\n",
+		"backups/other.dump":   "PGDMP",
+		"__MACOSX/._craft.sql": "junk",
+	}
+	order := []string{"README.md", "backups/craft.sql", "__MACOSX/._craft.sql", "backups/other.dump"}
+
+	tgz := filepath.Join(dir, "backup.tar.gz")
+	writeTarGz(t, tgz, files, order)
+
+	zipped := filepath.Join(dir, "backup.zip")
+	writeZip(t, zipped, files, order)
+
+	plain := filepath.Join(dir, "backup.sql")
+	if err := ioutil.WriteFile(plain, []byte("SELECT 2;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{name: "tar.gz archives list the dumps", path: tgz, want: []string{"backups/craft.sql", "backups/other.dump"}},
+		{name: "zip archives list the dumps", path: zipped, want: []string{"backups/craft.sql", "backups/other.dump"}},
+		{name: "files that are not archives are a single dump", path: plain, want: []string{""}},
+		{name: "compressed files are a single dump", path: "testdata/dump.sql.bz2", want: []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Dumps(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dumps() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := readDump(t, tgz, "backups/craft.sql"); got != "SELECT 1;\n" {
+		t.Errorf("expected the tar member content, got %q", got)
+	}
+	if got := readDump(t, zipped, "backups/other.dump"); got != "PGDMP" {
+		t.Errorf("expected the zip member content, got %q", got)
+	}
+	if got := readDump(t, plain, ""); got != "SELECT 2;\n" {
+		t.Errorf("expected the file content, got %q", got)
+	}
+	if got := readDump(t, "testdata/dump.sql.bz2", ""); got != "SELECT 1;\n" {
+		t.Errorf("expected the decompressed content, got %q", got)
+	}
+
+	if _, err := OpenDump(tgz, "missing.sql"); err == nil {
+		t.Errorf("expected an error for a missing dump")
+	}
+}
+
+func TestNewReader_Commands(t *testing.T) {
+	for _, format := range []struct {
+		name    string
+		command []string
+	}{
+		{name: "xz", command: []string{"xz", "--compress", "--stdout"}},
+		{name: "zst", command: []string{"zstd", "--compress", "--stdout", "--quiet"}},
+	} {
+		t.Run(format.name, func(t *testing.T) {
+			if _, err := exec.LookPath(format.command[0]); err != nil {
+				t.Skipf("%s is not installed", format.command[0])
+			}
+
+			cmd := exec.Command(format.command[0], format.command[1:]...)
+			cmd.Stdin = bytes.NewReader([]byte("SELECT 1;\n"))
+			compressed, err := cmd.Output()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := Detect(compressed); got != format.name {
+				t.Fatalf("Detect() got = %v, want %v", got, format.name)
+			}
+
+			r, err := NewReader(bytes.NewReader(compressed), format.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			b, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != "SELECT 1;\n" {
+				t.Errorf("expected the decompressed content, got %q", string(b))
+			}
+
+			// corrupt files return an error instead of a partial dump
+			r, err = NewReader(bytes.NewReader(compressed[:len(compressed)-4]), format.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			if _, err := io.Copy(ioutil.Discard, r); err == nil {
+				t.Errorf("expected an error for a corrupt file")
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package config
+
+// BackupsDir is where the machine's backups directory on
+// the host is mounted, nitrod writes the backups there.
+const BackupsDir = "/home/ubuntu/.nitro/backups"
+
+// Backups is the representation of the automatic database backups in the
+// config file. The backups are stored in ~/.nitro/backups/<machine>/.
+type Backups struct {
+	// Schedule is hourly, daily, weekly or a duration such as 12h
+	Schedule string `yaml:"schedule"`
+	// Containers limits the backups to the database containers and
+	// databases, when empty every database in each container is backed up
+	Containers []BackupContainer `yaml:"containers,omitempty"`
+	Retention  BackupRetention   `yaml:"retention,omitempty"`
+	// Compression is gzip, zstd or none
+	Compression string `yaml:"compression,omitempty"`
+	// Encrypt is the public key the backups are encrypted with, an age
+	// recipient such as age1... or the ID or email of a GPG key on the host
+	Encrypt string `yaml:"encrypt,omitempty"`
+	// Destinations are where backups are uploaded to by the CLI,
+	// the backups are always kept in ~/.nitro/backups as well
+	Destinations []BackupDestination `yaml:"destinations,omitempty"`
+}
+
+// BackupDestination is a directory, such as a NAS mount, or a bucket in S3
+// compatible storage, such as MinIO, that backups are uploaded to. The
+// backups of each machine are stored in <machine>/<container>/.
+type BackupDestination struct {
+	Name string `yaml:"name"`
+	// Path is the directory of a destination on the host
+	Path string `yaml:"path,omitempty"`
+	// Endpoint is the URL of S3 compatible storage, e.g. http://localhost:9000
+	Endpoint string `yaml:"endpoint,omitempty"`
+	Region   string `yaml:"region,omitempty"`
+	Bucket   string `yaml:"bucket,omitempty"`
+	// Prefix is added to the keys of the backups in the bucket
+	Prefix string `yaml:"prefix,omitempty"`
+	// Key and Secret are the access key of the bucket, when they are empty the
+	// AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables are used
+	Key    string `yaml:"key,omitempty"`
+	Secret string `yaml:"secret,omitempty"`
+}
+
+// BackupContainer is a database container to backup,
+// when there are no databases all of them are backed up.
+type BackupContainer struct {
+	Name      string   `yaml:"name"`
+	Databases []string `yaml:"databases,omitempty"`
+}
+
+// BackupRetention is how many backups of each database are kept and for
+// how many days. When both are empty the last 7 backups are kept.
+type BackupRetention struct {
+	Count int `yaml:"count,omitempty"`
+	Days  int `yaml:"days,omitempty"`
+}
+
+// BackupsMount returns the mount of the machine's backups directory.
+func BackupsMount(machine string) Mount {
+	return Mount{Source: "~/.nitro/backups/" + machine, Dest: BackupsDir}
+}
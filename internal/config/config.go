@@ -15,10 +15,16 @@ import (
 )
 
 type Config struct {
-	PHP       string     `yaml:"php"`
-	Mounts    []Mount    `yaml:"mounts,omitempty"`
-	Databases []Database `yaml:"databases"`
-	Sites     []Site     `yaml:"sites,omitempty"`
+	PHP       string            `yaml:"php"`
+	Mounts    []Mount           `yaml:"mounts,omitempty"`
+	Databases []Database        `yaml:"databases"`
+	Sites     []Site            `yaml:"sites,omitempty"`
+	Xdebug    *Xdebug           `yaml:"xdebug,omitempty"`
+	Backups   *Backups          `yaml:"backups,omitempty"`
+	Sanitize  []SanitizeProfile `yaml:"sanitize,omitempty"`
+	// NginxTemplate is the template file of the nginx configuration of
+	// sites without their own template or a type, relative to the config file
+	NginxTemplate string `yaml:"nginx_template,omitempty" mapstructure:"nginx_template"`
 }
 
 func (c *Config) AddSite(site Site) error {
@@ -195,8 +201,13 @@ func (c *Config) RenameSite(site Site, hostname string) error {
 	for i, s := range c.Sites {
 		if s.Hostname == site.Hostname {
 			c.Sites[i] = Site{
-				Hostname: hostname,
-				Webroot:  strings.Replace(s.Webroot, s.Hostname, hostname, 1),
+				Hostname:      hostname,
+				Webroot:       strings.Replace(s.Webroot, s.Hostname, hostname, 1),
+				Type:          s.Type,
+				Replace:       s.Replace,
+				NginxTemplate: s.NginxTemplate,
+				Extra:         s.Extra,
+				Env:           s.Env,
 			}
 
 			return nil
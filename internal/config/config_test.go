@@ -523,6 +523,33 @@ func TestConfig_RenameSite(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "keeps the replacements of the site",
+			args: args{
+				site: Site{
+					Hostname: "old.test",
+					Webroot:  "/nitro/sites/old.test",
+				},
+				hostname: "new.test",
+			},
+			fields: fields{
+				Sites: []Site{
+					{
+						Hostname: "old.test",
+						Webroot:  "/nitro/sites/old.test",
+						Replace:  []Replacement{{From: "https://example.com", To: "http://old.test"}},
+					},
+				},
+			},
+			want: []Site{
+				{
+					Hostname: "new.test",
+					Webroot:  "/nitro/sites/new.test",
+					Replace:  []Replacement{{From: "https://example.com", To: "http://old.test"}},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1,6 +1,9 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type Database struct {
 	Engine  string `yaml:"engine"`
@@ -8,6 +11,21 @@ type Database struct {
 	Port    string `yaml:"port"`
 }
 
+// ContainerEngine returns the engine from the name of a database container.
+func ContainerEngine(container string) string {
+	return strings.SplitN(container, "_", 2)[0]
+}
+
+// ContainerVersion returns the version from the name of a database container.
+func ContainerVersion(container string) string {
+	sp := strings.SplitN(container, "_", 3)
+	if len(sp) < 2 {
+		return ""
+	}
+
+	return sp[1]
+}
+
 // Name converts a database into a name used for the container
 func (d *Database) Name() string {
 	return fmt.Sprintf("%s_%s_%s", d.Engine, d.Version, d.Port)
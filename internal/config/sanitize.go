@@ -0,0 +1,25 @@
+package config
+
+// SanitizeProfile is a named list of rules to anonymise the personal data in
+// database dumps and databases. A profile can extend a built-in profile,
+// such as craft, and a profile with the name of a built-in profile
+// replaces it.
+type SanitizeProfile struct {
+	Name    string `yaml:"name"`
+	Extends string `yaml:"extends,omitempty"`
+	// TablePrefix is added to the tables of the built-in profile,
+	// for databases with a table prefix such as craft_
+	TablePrefix string         `yaml:"table_prefix,omitempty" mapstructure:"table_prefix"`
+	Rules       []SanitizeRule `yaml:"rules,omitempty"`
+}
+
+// SanitizeRule changes the values of the columns that match the table
+// and column, which can be patterns such as fmc_*.
+type SanitizeRule struct {
+	Table  string `yaml:"table"`
+	Column string `yaml:"column"`
+	// Action is email, hash, null or constant
+	Action string `yaml:"action"`
+	// Value is the value for the constant action
+	Value string `yaml:"value,omitempty"`
+}
@@ -6,6 +6,27 @@ type Site struct {
 	Hostname string   `yaml:"hostname"`
 	Webroot  string   `yaml:"webroot"`
 	Aliases  []string `yaml:"aliases,omitempty"`
+	// Type is the preset of the site, such as craft or laravel, which
+	// sets the nginx template and the env, it defaults to craft
+	Type string `yaml:"type,omitempty"`
+	// NginxTemplate is the template file of the nginx configuration,
+	// relative to the config file, instead of the template of the type
+	NginxTemplate string `yaml:"nginx_template,omitempty" mapstructure:"nginx_template"`
+	// Extra is added to the server block of the nginx configuration,
+	// such as location blocks, redirects and headers
+	Extra string `yaml:"extra,omitempty"`
+	// Env are NAME=value environment variables passed to PHP
+	Env []string `yaml:"env,omitempty"`
+	// Replace is made in the values of the database dumps
+	// imported or backed up for the site, in order
+	Replace []Replacement `yaml:"replace,omitempty"`
+}
+
+// Replacement is a string to replace in the database dumps
+// of a site, such as the production URL of the site.
+type Replacement struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
 }
 
 // IsExact verifies the current site and the provided
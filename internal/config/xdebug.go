@@ -0,0 +1,16 @@
+package config
+
+// Xdebug is the representation of the xdebug settings in the config file. The
+// settings use the Xdebug 3 names and are converted for PHP with Xdebug 2.
+type Xdebug struct {
+	// Mode is a comma separated list of modes, e.g. debug,develop
+	Mode string `yaml:"mode,omitempty"`
+	// Host is the address of the IDE, when empty it
+	// is detected from the machine's route to the host
+	Host   string `yaml:"host,omitempty"`
+	Port   int    `yaml:"port,omitempty"`
+	IdeKey string `yaml:"idekey,omitempty"`
+	// Trigger only starts xdebug for requests with the
+	// XDEBUG_TRIGGER (or XDEBUG_SESSION) parameter
+	Trigger bool `yaml:"trigger,omitempty"`
+}
@@ -0,0 +1,332 @@
+package database
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Report lists the constructs in a dump that Convert could not translate.
+type Report struct {
+	// Statements is the number of statements that were converted
+	Statements int
+	counts     map[string]int
+}
+
+func (r *Report) add(format string, args ...interface{}) {
+	if r.counts == nil {
+		r.counts = make(map[string]int)
+	}
+
+	r.counts[fmt.Sprintf(format, args...)]++
+}
+
+// Untranslated returns each construct that could not be translated,
+// with the number of times it was found when it is more than once.
+func (r *Report) Untranslated() []string {
+	var messages []string
+	for m, n := range r.counts {
+		if n > 1 {
+			m = fmt.Sprintf("%s (%d times)", m, n)
+		}
+		messages = append(messages, m)
+	}
+	sort.Strings(messages)
+
+	return messages
+}
+
+// converter translates the statements of a dump.
+type converter interface {
+	header(w io.Writer) error
+	statement(w io.Writer, sql string) error
+	footer(w io.Writer) error
+}
+
+// Convert translates a MySQL or MariaDB dump to PostgreSQL or a PostgreSQL
+// dump to MySQL. The dump is read one statement at a time and written to w,
+// the table definitions, quoting, auto increments and sequences, booleans
+// and binary data are translated. Statements that cannot be translated,
+// such as views, triggers and functions, are skipped and listed in the report.
+func Convert(r io.Reader, w io.Writer, from, to string) (*Report, error) {
+	report := &Report{}
+
+	var c converter
+	s := newStatements(r, from == "postgres")
+	switch {
+	case isMySQL(from) && to == "postgres":
+		c = &mysqlToPostgres{report: report, tables: make(map[string]*table), indexes: make(map[string]bool)}
+	case from == "postgres" && isMySQL(to):
+		// postgres COPY statements are followed by the rows
+		c = &postgresToMySQL{report: report, tables: make(map[string]*table), rows: s.line}
+	default:
+		return nil, fmt.Errorf("unable to convert %s dumps to %s", from, to)
+	}
+
+	bw := bufio.NewWriterSize(w, 64*1024)
+	if err := c.header(bw); err != nil {
+		return nil, err
+	}
+
+	for {
+		stmt, err := s.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.statement(bw, stmt); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.footer(bw); err != nil {
+		return nil, err
+	}
+
+	return report, bw.Flush()
+}
+
+func isMySQL(engine string) bool {
+	return engine == "mysql" || engine == "mariadb"
+}
+
+// columnKind is used to translate the values of a column.
+type columnKind int
+
+const (
+	kindOther columnKind = iota
+	kindBool
+	kindBinary
+	kindDate
+	// kindText columns need a prefix length in MySQL indexes
+	kindText
+	// kindTimeZone values have an offset that MySQL does not accept
+	kindTimeZone
+)
+
+type table struct {
+	name    string
+	columns []*column
+}
+
+type column struct {
+	name string
+	kind columnKind
+	// def is the translated type and attributes, without the name
+	def    string
+	serial bool
+}
+
+func (t *table) column(name string) *column {
+	if t == nil {
+		return nil
+	}
+
+	for _, c := range t.columns {
+		if c.name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// kinds returns the kinds of the named columns, or every column when there are no names.
+func (t *table) kinds(names []string) []columnKind {
+	var kinds []columnKind
+	if len(names) == 0 && t != nil {
+		for _, c := range t.columns {
+			kinds = append(kinds, c.kind)
+		}
+		return kinds
+	}
+
+	for _, name := range names {
+		kind := kindOther
+		if c := t.column(name); c != nil {
+			kind = c.kind
+		}
+		kinds = append(kinds, kind)
+	}
+
+	return kinds
+}
+
+func kindAt(kinds []columnKind, i int) columnKind {
+	if i < len(kinds) {
+		return kinds[i]
+	}
+
+	return kindOther
+}
+
+var leadingComment = regexp.MustCompile(`^(\s+|--[^\n]*(\n|$)|#[^\n]*(\n|$)|/\*(?s:.*?)\*/)`)
+
+// trimComments returns the comments before the statement and the statement.
+func trimComments(stmt string, postgres bool) (string, string) {
+	var comments strings.Builder
+	for {
+		m := leadingComment.FindString(stmt)
+		if m == "" || (postgres && strings.HasPrefix(m, "#")) {
+			break
+		}
+
+		comments.WriteString(m)
+		stmt = stmt[len(m):]
+	}
+
+	return comments.String(), strings.TrimSpace(stmt)
+}
+
+// keyword returns the first words of the statement in upper case
+// separated by single spaces, e.g. "CREATE UNIQUE INDEX".
+func keyword(sql string, words int) string {
+	fields := strings.Fields(sql)
+	if len(fields) > words {
+		fields = fields[:words]
+	}
+
+	return strings.ToUpper(strings.Join(fields, " "))
+}
+
+// splitList splits the list at the commas that are not in
+// parentheses, quotes or identifiers and trims each item.
+func splitList(s string, postgres bool) []string {
+	var items []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '\'' && !postgres {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case c == ',' && depth == 0:
+			items = append(items, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+
+	if rest := strings.TrimSpace(s[start:]); rest != "" {
+		items = append(items, rest)
+	}
+
+	return items
+}
+
+// tokens splits column attributes into words, quoted strings and identifiers,
+// parentheses are kept with the word before them, e.g. CURRENT_TIMESTAMP(3).
+func tokens(s string, postgres bool) []string {
+	var toks []string
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(s) {
+			c = s[i]
+			if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+				break
+			}
+
+			switch c {
+			case '\'', '"', '`':
+				i = skipQuoted(s, i, postgres)
+				continue
+			case '(':
+				i = skipParens(s, i, postgres)
+				continue
+			}
+			i++
+		}
+
+		toks = append(toks, s[start:i])
+	}
+
+	return toks
+}
+
+// skipQuoted returns the index after the quoted string that starts at i.
+func skipQuoted(s string, i int, postgres bool) int {
+	quote := s[i]
+	for i++; i < len(s); i++ {
+		if s[i] == '\\' && quote == '\'' && !postgres {
+			i++
+			continue
+		}
+		if s[i] == quote {
+			// doubled quotes are escaped quotes
+			if i+1 < len(s) && s[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+
+	return len(s)
+}
+
+// skipParens returns the index after the parentheses that start at i.
+func skipParens(s string, i int, postgres bool) int {
+	depth := 0
+	for i < len(s) {
+		switch s[i] {
+		case '\'', '"', '`':
+			i = skipQuoted(s, i, postgres)
+			continue
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+		i++
+	}
+
+	return len(s)
+}
+
+// pgQuote returns the postgres identifier.
+func pgQuote(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// mysqlQuote returns the mysql identifier.
+func mysqlQuote(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// pgString returns the postgres string literal, the dump
+// sets standard_conforming_strings so backslashes are literal.
+func pgString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+var mysqlEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, `"`, `\"`, "\x00", `\0`, "\n", `\n`, "\r", `\r`, "\x1a", `\Z`)
+
+// mysqlString returns the mysql string literal.
+func mysqlString(s string) string {
+	return "'" + mysqlEscaper.Replace(s) + "'"
+}
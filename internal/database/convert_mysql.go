@@ -0,0 +1,558 @@
+package database
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// mysqlToPostgres translates a mysqldump to PostgreSQL. Indexes are created
+// with the tables, foreign keys and sequence values are set after the data.
+type mysqlToPostgres struct {
+	report      *Report
+	tables      map[string]*table
+	indexes     map[string]bool
+	foreignKeys []string
+	sequences   []string
+	databases   int
+}
+
+const mysqlIdent = "`(?:[^`]|``)+`"
+
+var (
+	mysqlCreateTable = regexp.MustCompile(`(?is)^CREATE\s+(?:TEMPORARY\s+)?TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?(` + mysqlIdent + `|\w+)\s*\((.*)\)[^)]*$`)
+	mysqlColumn      = regexp.MustCompile(`(?s)^(` + mysqlIdent + `)\s+(\w+)\s*(\((?:[^()']|'(?:[^'\\]|\\.|'')*')*\))?(.*)$`)
+	mysqlIndex       = regexp.MustCompile(`(?is)^(UNIQUE\s+)?(?:KEY|INDEX)\s*(` + mysqlIdent + `)?\s*(?:USING\s+\w+\s*)?\((.*)\)`)
+	mysqlIndexColumn = regexp.MustCompile(`(?is)^(` + mysqlIdent + `)\s*(?:\(\d+\))?\s*(ASC|DESC)?$`)
+	mysqlInsert      = regexp.MustCompile(`(?is)^(INSERT(?:\s+IGNORE)?|REPLACE)\s+INTO\s+(` + mysqlIdent + `|\w+)\s*(\([^)]*\))?\s*VALUES\s*`)
+	mysqlDropTable   = regexp.MustCompile(`(?is)^DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?(.*)$`)
+	backticks        = regexp.MustCompile(mysqlIdent)
+)
+
+func (c *mysqlToPostgres) header(w io.Writer) error {
+	_, err := io.WriteString(w, "-- Converted from a MySQL dump by Nitro\n\nSET standard_conforming_strings = on;\nSET client_encoding = 'UTF8';\n\n")
+	return err
+}
+
+func (c *mysqlToPostgres) statement(w io.Writer, stmt string) error {
+	comments, sql := trimComments(stmt, false)
+	if sql == "" {
+		// versioned comments such as /*!50001 CREATE VIEW ... */ are only run by mysql
+		if upper := strings.ToUpper(comments); strings.Contains(upper, "/*!") && strings.Contains(upper, "CREATE") {
+			for _, kind := range []string{"VIEW", "TRIGGER", "PROCEDURE", "FUNCTION", "EVENT"} {
+				if strings.Contains(upper, kind) {
+					c.report.add("%s definitions are not converted", strings.ToLower(kind))
+					break
+				}
+			}
+		}
+		return nil
+	}
+
+	switch kw := keyword(sql, 3); {
+	case strings.HasPrefix(kw, "CREATE TABLE"), strings.HasPrefix(kw, "CREATE TEMPORARY TABLE"):
+		return c.createTable(w, sql)
+	case strings.HasPrefix(kw, "INSERT"), strings.HasPrefix(kw, "REPLACE"):
+		return c.insert(w, sql)
+	case strings.HasPrefix(kw, "DROP TABLE"):
+		m := mysqlDropTable.FindStringSubmatch(sql)
+		c.report.Statements++
+		_, err := fmt.Fprintf(w, "DROP TABLE IF EXISTS %s CASCADE;\n", backticksToQuotes(m[1]))
+		return err
+	case strings.HasPrefix(kw, "CREATE DATABASE"):
+		c.databases++
+		if c.databases == 2 {
+			c.report.add("the dump has more than one database, the tables are imported into the same database")
+		}
+	case strings.HasPrefix(kw, "SET"), strings.HasPrefix(kw, "USE"), strings.HasPrefix(kw, "LOCK TABLES"),
+		strings.HasPrefix(kw, "UNLOCK TABLES"), strings.HasPrefix(kw, "DELIMITER"), strings.HasPrefix(kw, "DROP"):
+	default:
+		c.report.add("%s statements are not converted", strings.ToLower(keyword(sql, 2)))
+	}
+
+	return nil
+}
+
+func (c *mysqlToPostgres) footer(w io.Writer) error {
+	for _, s := range c.foreignKeys {
+		if _, err := fmt.Fprintln(w, s); err != nil {
+			return err
+		}
+	}
+
+	for _, s := range c.sequences {
+		if _, err := fmt.Fprintln(w, s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *mysqlToPostgres) createTable(w io.Writer, sql string) error {
+	m := mysqlCreateTable.FindStringSubmatch(sql)
+	if m == nil {
+		c.report.add("unable to parse the create table statement %q", truncate(sql))
+		return nil
+	}
+
+	t := &table{name: mysqlUnquote(m[1])}
+	c.tables[t.name] = t
+
+	var defs, indexes []string
+	for _, def := range splitList(m[2], false) {
+		switch kw := keyword(def, 2); {
+		case strings.HasPrefix(def, "`"):
+			if col := c.column(t, def); col != "" {
+				defs = append(defs, col)
+			}
+		case kw == "PRIMARY KEY":
+			if cols, ok := c.indexColumns(t, def[strings.Index(def, "("):]); ok {
+				defs = append(defs, "PRIMARY KEY "+cols)
+			}
+		case strings.HasPrefix(kw, "UNIQUE"), strings.HasPrefix(kw, "KEY"), strings.HasPrefix(kw, "INDEX"):
+			if index := c.index(t, def); index != "" {
+				indexes = append(indexes, index)
+			}
+		case strings.HasPrefix(kw, "FULLTEXT"), strings.HasPrefix(kw, "SPATIAL"):
+			c.report.add("%s indexes are not converted", strings.ToLower(strings.Fields(kw)[0]))
+		case strings.HasPrefix(kw, "CONSTRAINT") && strings.Contains(strings.ToUpper(def), "FOREIGN KEY"):
+			c.foreignKeys = append(c.foreignKeys, fmt.Sprintf("ALTER TABLE %s ADD %s;", pgQuote(t.name), backticksToQuotes(def)))
+		default:
+			c.report.add("table definitions such as %q are not converted", truncate(def))
+		}
+	}
+
+	c.report.Statements++
+	if _, err := fmt.Fprintf(w, "CREATE TABLE %s (\n    %s\n);\n", pgQuote(t.name), strings.Join(defs, ",\n    ")); err != nil {
+		return err
+	}
+
+	for _, index := range indexes {
+		if _, err := fmt.Fprintln(w, index); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// column translates the column definition and adds it to the table.
+func (c *mysqlToPostgres) column(t *table, def string) string {
+	m := mysqlColumn.FindStringSubmatch(def)
+	if m == nil {
+		c.report.add("unable to parse the column %q", truncate(def))
+		return ""
+	}
+
+	col := &column{name: mysqlUnquote(m[1])}
+	t.columns = append(t.columns, col)
+
+	typ, args := strings.ToLower(m[2]), strings.Trim(m[3], "()")
+	attrs := tokens(m[4], false)
+
+	unsigned := false
+	for _, a := range attrs {
+		if strings.EqualFold(a, "unsigned") {
+			unsigned = true
+		}
+	}
+
+	pgType, kind := c.columnType(t, col, typ, args, unsigned)
+	col.kind = kind
+
+	var parts []string
+	autoIncrement, notNull := false, false
+	var value string
+	for i := 0; i < len(attrs); i++ {
+		switch a := strings.ToUpper(attrs[i]); {
+		case a == "UNSIGNED", a == "SIGNED", a == "ZEROFILL", a == "NULL":
+		case a == "NOT" && i+1 < len(attrs) && strings.EqualFold(attrs[i+1], "NULL"):
+			notNull = true
+			i++
+		case a == "AUTO_INCREMENT":
+			autoIncrement = true
+		case a == "DEFAULT" && i+1 < len(attrs):
+			i++
+			value = c.defaultValue(t, col, attrs[i])
+		case (a == "COMMENT" || a == "COLLATE" || a == "CHARSET") && i+1 < len(attrs):
+			i++
+		case a == "CHARACTER" && i+2 < len(attrs):
+			i += 2
+		case a == "ON" && i+2 < len(attrs):
+			c.report.add("ON UPDATE %s for %s.%s is not converted", attrs[i+2], t.name, col.name)
+			i += 2
+		case a == "PRIMARY" && i+1 < len(attrs):
+			parts = append(parts, "PRIMARY KEY")
+			i++
+		case a == "UNIQUE":
+			parts = append(parts, "UNIQUE")
+			if i+1 < len(attrs) && strings.EqualFold(attrs[i+1], "KEY") {
+				i++
+			}
+		case a == "GENERATED", a == "AS":
+			c.report.add("the generated column %s.%s is not converted", t.name, col.name)
+			i = len(attrs)
+		default:
+			c.report.add("the column attribute %s is not converted", attrs[i])
+		}
+	}
+
+	if autoIncrement {
+		switch pgType {
+		case "smallint":
+			pgType = "smallserial"
+		case "bigint":
+			pgType = "bigserial"
+		default:
+			pgType = "serial"
+		}
+		value = ""
+		col.serial = true
+
+		name := strings.ReplaceAll(pgQuote(t.name), "'", "''")
+		c.sequences = append(c.sequences, fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', '%s'), COALESCE((SELECT MAX(%s) FROM %s), 0) + 1, false);", name, strings.ReplaceAll(col.name, "'", "''"), pgQuote(col.name), pgQuote(t.name)))
+	}
+
+	col.def = pgType
+	if notNull {
+		col.def += " NOT NULL"
+	}
+	if value != "" {
+		col.def += " DEFAULT " + value
+	}
+	if len(parts) > 0 {
+		col.def += " " + strings.Join(parts, " ")
+	}
+
+	return pgQuote(col.name) + " " + col.def
+}
+
+// columnType returns the postgres type for the mysql type.
+func (c *mysqlToPostgres) columnType(t *table, col *column, typ, args string, unsigned bool) (string, columnKind) {
+	switch typ {
+	case "tinyint":
+		if args == "1" {
+			return "boolean", kindBool
+		}
+		return "smallint", kindOther
+	case "bool", "boolean":
+		return "boolean", kindBool
+	case "smallint":
+		if unsigned {
+			return "integer", kindOther
+		}
+		return "smallint", kindOther
+	case "mediumint":
+		return "integer", kindOther
+	case "int", "integer":
+		if unsigned {
+			return "bigint", kindOther
+		}
+		return "integer", kindOther
+	case "bigint":
+		if unsigned {
+			return "numeric(20)", kindOther
+		}
+		return "bigint", kindOther
+	case "decimal", "numeric", "dec", "fixed":
+		if args == "" {
+			return "numeric", kindOther
+		}
+		return "numeric(" + args + ")", kindOther
+	case "float":
+		return "real", kindOther
+	case "double", "real":
+		return "double precision", kindOther
+	case "char":
+		return "char(" + orDefault(args, "1") + ")", kindOther
+	case "varchar":
+		return "varchar(" + args + ")", kindOther
+	case "tinytext", "text", "mediumtext", "longtext":
+		return "text", kindOther
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+		return "bytea", kindBinary
+	case "date":
+		return "date", kindDate
+	case "datetime", "timestamp":
+		return "timestamp(" + orDefault(args, "0") + ") without time zone", kindDate
+	case "time":
+		return "time(" + orDefault(args, "0") + ") without time zone", kindOther
+	case "year":
+		return "smallint", kindOther
+	case "json":
+		return "json", kindOther
+	case "enum":
+		return fmt.Sprintf("varchar(255) CHECK (%s IN (%s))", pgQuote(col.name), c.stringList(args)), kindOther
+	case "set":
+		c.report.add("the set column %s.%s is converted to varchar", t.name, col.name)
+		return "varchar(255)", kindOther
+	case "bit":
+		if args == "" || args == "1" {
+			return "boolean", kindBool
+		}
+	}
+
+	c.report.add("the %s column %s.%s is converted to text", typ, t.name, col.name)
+
+	return "text", kindOther
+}
+
+// stringList translates the mysql strings in a list, e.g. the values of an enum.
+func (c *mysqlToPostgres) stringList(list string) string {
+	var values []string
+	for _, v := range splitList(list, false) {
+		l, _, err := parseLiteral(v, 0, false)
+		if err != nil {
+			values = append(values, v)
+			continue
+		}
+		values = append(values, pgString(l.text))
+	}
+
+	return strings.Join(values, ", ")
+}
+
+func (c *mysqlToPostgres) defaultValue(t *table, col *column, value string) string {
+	l, _, err := parseLiteral(value, 0, false)
+	if err != nil {
+		c.report.add("the default %s for %s.%s is not converted", value, t.name, col.name)
+		return ""
+	}
+
+	upper := strings.ToUpper(l.text)
+	switch {
+	case l.null():
+		return "NULL"
+	case !l.quoted && (strings.HasPrefix(upper, "CURRENT_TIMESTAMP") || strings.HasPrefix(upper, "NOW(")):
+		return "CURRENT_TIMESTAMP"
+	case !l.quoted && strings.HasPrefix(l.text, "("):
+		c.report.add("the default %s for %s.%s is not converted", value, t.name, col.name)
+		return ""
+	}
+
+	v, ok := c.value(col.kind, l)
+	if !ok {
+		c.report.add("the default %s for %s.%s is not converted", value, t.name, col.name)
+		return ""
+	}
+
+	return v
+}
+
+// index returns the create index statement for the key definition.
+func (c *mysqlToPostgres) index(t *table, def string) string {
+	m := mysqlIndex.FindStringSubmatch(def)
+	if m == nil {
+		c.report.add("unable to parse the index %q", truncate(def))
+		return ""
+	}
+
+	cols, ok := c.indexColumns(t, "("+m[3]+")")
+	if !ok {
+		return ""
+	}
+
+	// index names are unique for each schema in postgres instead of each table
+	name := t.name + "_" + strings.Join(strings.Fields(strings.Trim(cols, "()\"")), "_")
+	if m[2] != "" {
+		name = mysqlUnquote(m[2])
+	}
+	if c.indexes[name] {
+		name = t.name + "_" + name
+	}
+	c.indexes[name] = true
+
+	unique := ""
+	if m[1] != "" {
+		unique = "UNIQUE "
+	}
+
+	return fmt.Sprintf("CREATE %sINDEX %s ON %s %s;", unique, pgQuote(name), pgQuote(t.name), cols)
+}
+
+// indexColumns translates the columns of an index, prefix
+// lengths are removed since postgres indexes whole values.
+func (c *mysqlToPostgres) indexColumns(t *table, list string) (string, bool) {
+	var cols []string
+	for _, item := range splitList(strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(list), "("), ")"), false) {
+		m := mysqlIndexColumn.FindStringSubmatch(item)
+		if m == nil {
+			c.report.add("indexes on expressions such as %q are not converted", truncate(item))
+			return "", false
+		}
+
+		col := pgQuote(mysqlUnquote(m[1]))
+		if m[2] != "" {
+			col += " " + strings.ToUpper(m[2])
+		}
+		cols = append(cols, col)
+	}
+
+	return "(" + strings.Join(cols, ", ") + ")", true
+}
+
+func (c *mysqlToPostgres) insert(w io.Writer, sql string) error {
+	loc := mysqlInsert.FindStringSubmatchIndex(sql)
+	if loc == nil {
+		c.report.add("unable to parse the insert %q", truncate(sql))
+		return nil
+	}
+
+	verb := strings.ToUpper(strings.Join(strings.Fields(sql[loc[2]:loc[3]]), " "))
+	name := mysqlUnquote(sql[loc[4]:loc[5]])
+	t := c.tables[name]
+
+	var names []string
+	columns := ""
+	if loc[6] >= 0 {
+		for _, n := range splitList(strings.Trim(sql[loc[6]:loc[7]], "()"), false) {
+			names = append(names, mysqlUnquote(n))
+		}
+		var quoted []string
+		for _, n := range names {
+			quoted = append(quoted, pgQuote(n))
+		}
+		columns = " (" + strings.Join(quoted, ", ") + ")"
+	}
+
+	tuples, rest, err := parseTuples(sql[loc[1]:], false)
+	if err != nil {
+		c.report.add("unable to parse the insert into %s: %s", name, err)
+		return nil
+	}
+
+	if rest != "" {
+		c.report.add("%q in inserts is not converted", truncate(rest))
+	}
+
+	kinds := t.kinds(names)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "INSERT INTO %s%s VALUES ", pgQuote(name), columns)
+	for i, tuple := range tuples {
+		if i > 0 {
+			b.WriteString(",")
+		}
+
+		b.WriteString("(")
+		for j, l := range tuple {
+			if j > 0 {
+				b.WriteString(",")
+			}
+
+			v, ok := c.value(kindAt(kinds, j), l)
+			if !ok {
+				c.report.add("the value %q in %s is not converted", truncate(l.text), name)
+				v = "NULL"
+			}
+			b.WriteString(v)
+		}
+		b.WriteString(")")
+	}
+
+	switch verb {
+	case "INSERT IGNORE":
+		b.WriteString(" ON CONFLICT DO NOTHING")
+	case "REPLACE":
+		c.report.add("REPLACE statements are converted to inserts")
+	}
+	b.WriteString(";\n")
+
+	c.report.Statements++
+	_, err = io.WriteString(w, b.String())
+
+	return err
+}
+
+// value returns the postgres literal for the mysql value in a column of the kind.
+func (c *mysqlToPostgres) value(kind columnKind, l literal) (string, bool) {
+	if l.null() {
+		return "NULL", true
+	}
+
+	if !l.quoted {
+		switch {
+		case kind == kindBool:
+			return pgBool(l.text != "0"), true
+		case strings.HasPrefix(l.text, "0x") || strings.HasPrefix(l.text, "0X"):
+			return pgBytes(kind, l.text[2:])
+		}
+		return l.text, true
+	}
+
+	switch {
+	case l.prefix == "x":
+		return pgBytes(kind, l.text)
+	case l.prefix == "b":
+		if kind == kindBool {
+			return pgBool(strings.Contains(l.text, "1")), true
+		}
+		return "", false
+	case kind == kindBool:
+		return pgBool(l.text != "0" && l.text != ""), true
+	case kind == kindBinary:
+		return `'\x` + hex.EncodeToString([]byte(l.text)) + "'", true
+	case kind == kindDate && strings.HasPrefix(l.text, "0000-00-00"):
+		c.report.add("zero dates are converted to NULL")
+		return "NULL", true
+	case strings.ContainsRune(l.text, 0):
+		c.report.add("NUL characters are removed from strings")
+		return pgString(strings.ReplaceAll(l.text, "\x00", "")), true
+	}
+
+	return pgString(l.text), true
+}
+
+// pgBytes returns the hex digits as bytea, or as a string for other columns.
+func pgBytes(kind columnKind, digits string) (string, bool) {
+	b, err := hex.DecodeString(digits)
+	if err != nil {
+		return "", false
+	}
+
+	if kind == kindBinary {
+		return `'\x` + strings.ToLower(digits) + "'", true
+	}
+
+	return pgString(string(b)), true
+}
+
+func pgBool(b bool) string {
+	if b {
+		return "true"
+	}
+
+	return "false"
+}
+
+// backticksToQuotes replaces the mysql identifiers with postgres identifiers.
+func backticksToQuotes(sql string) string {
+	return backticks.ReplaceAllStringFunc(sql, func(ident string) string {
+		return pgQuote(mysqlUnquote(ident))
+	})
+}
+
+func mysqlUnquote(ident string) string {
+	return unquote(strings.TrimSpace(ident), '`')
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+
+	return s
+}
+
+// truncate shortens the sql for the report.
+func truncate(sql string) string {
+	sql = strings.Join(strings.Fields(sql), " ")
+	if len(sql) > 60 {
+		return sql[:57] + "..."
+	}
+
+	return sql
+}
@@ -0,0 +1,843 @@
+package database
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// copyBatch is the number of COPY rows in each insert.
+const copyBatch = 100
+
+// postgresToMySQL translates a plain pg_dump to MySQL. The rows of COPY
+// statements become inserts and sequences become auto increments when the
+// primary key is added, pg_dump adds the keys after the data.
+type postgresToMySQL struct {
+	report    *Report
+	tables    map[string]*table
+	databases int
+	// rows returns the next line of the dump for COPY statements
+	rows func() (string, error)
+}
+
+var (
+	pgCast       = regexp.MustCompile(`(?i)::\s*("[^"]+"|[a-z_][a-z0-9_ ]*)(\(\d+(,\s*\d+)?\))?(\[\])*`)
+	pgNumber     = regexp.MustCompile(`^\(?-?\d+(\.\d+)?([eE][-+]?\d+)?\)?$`)
+	pgTypeLength = regexp.MustCompile(`^([a-z ]+?)\s*(?:\(([\d, ]+)\))?((?:\s+with(?:out)?\s+time\s+zone)?)((?:\[\])*)$`)
+	timeZone     = regexp.MustCompile(`([+-]\d\d(:?\d\d)?)$`)
+)
+
+// pgKeywords end the type of a column definition.
+var pgKeywords = map[string]bool{
+	"NOT": true, "NULL": true, "DEFAULT": true, "CONSTRAINT": true, "PRIMARY": true, "UNIQUE": true,
+	"REFERENCES": true, "CHECK": true, "COLLATE": true, "GENERATED": true,
+}
+
+func (c *postgresToMySQL) header(w io.Writer) error {
+	_, err := io.WriteString(w, "-- Converted from a PostgreSQL dump by Nitro\n\nSET NAMES utf8mb4;\nSET FOREIGN_KEY_CHECKS = 0;\nSET SQL_MODE = 'NO_AUTO_VALUE_ON_ZERO';\n\n")
+	return err
+}
+
+func (c *postgresToMySQL) footer(w io.Writer) error {
+	for _, t := range c.tables {
+		for _, col := range t.columns {
+			if col.serial {
+				c.report.add("the sequence for %s.%s is not converted since the column is not a primary key", t.name, col.name)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, "SET FOREIGN_KEY_CHECKS = 1;\n")
+
+	return err
+}
+
+func (c *postgresToMySQL) statement(w io.Writer, stmt string) error {
+	_, sql := trimComments(stmt, true)
+	if sql == "" {
+		return nil
+	}
+
+	switch kw := keyword(sql, 3); {
+	case strings.HasPrefix(kw, `\CONNECT`), strings.HasPrefix(kw, "CREATE DATABASE"):
+		c.databases++
+		if c.databases == 2 {
+			c.report.add("the dump has more than one database, the tables are imported into the same database")
+		}
+	case strings.HasPrefix(kw, "CREATE TABLE"), strings.HasPrefix(kw, "CREATE UNLOGGED TABLE"):
+		return c.createTable(w, sql)
+	case strings.HasPrefix(kw, "ALTER TABLE"):
+		return c.alterTable(w, sql)
+	case strings.HasPrefix(kw, "CREATE INDEX"), strings.HasPrefix(kw, "CREATE UNIQUE INDEX"):
+		return c.createIndex(w, sql)
+	case strings.HasPrefix(kw, "INSERT INTO"):
+		return c.insert(w, sql)
+	case strings.HasPrefix(kw, "COPY"):
+		return c.copy(w, sql)
+	case strings.HasPrefix(kw, "DROP TABLE"):
+		name, _ := pgName(strings.TrimSpace(sql[len("DROP TABLE"):]), "IF EXISTS")
+		c.report.Statements++
+		_, err := fmt.Fprintf(w, "DROP TABLE IF EXISTS %s;\n", mysqlQuote(name))
+		return err
+	case strings.HasPrefix(kw, `\`), strings.HasPrefix(kw, "SET"), strings.HasPrefix(kw, "SELECT PG_CATALOG."),
+		strings.HasPrefix(kw, "SELECT SETVAL"), strings.HasPrefix(kw, "COMMENT ON"), strings.HasPrefix(kw, "GRANT"),
+		strings.HasPrefix(kw, "REVOKE"), strings.HasPrefix(kw, "CREATE EXTENSION"), strings.HasPrefix(kw, "CREATE SCHEMA"),
+		strings.HasPrefix(kw, "ALTER SCHEMA"), strings.HasPrefix(kw, "CREATE SEQUENCE"), strings.HasPrefix(kw, "ALTER SEQUENCE"),
+		strings.HasPrefix(kw, "ALTER DEFAULT PRIVILEGES"), strings.HasPrefix(kw, "ALTER DATABASE"), strings.HasPrefix(kw, "DROP"),
+		strings.HasPrefix(kw, "CREATE ROLE"), strings.HasPrefix(kw, "ALTER ROLE"):
+	case strings.HasPrefix(kw, "CREATE"), strings.HasPrefix(kw, "ALTER"):
+		c.report.add("%s statements are not converted", strings.ToLower(objectKind(sql)))
+	default:
+		c.report.add("%s statements are not converted", strings.ToLower(keyword(sql, 1)))
+	}
+
+	return nil
+}
+
+func (c *postgresToMySQL) createTable(w io.Writer, sql string) error {
+	rest := sql[strings.Index(strings.ToUpper(sql), "TABLE")+len("TABLE"):]
+	name, rest := pgName(strings.TrimSpace(rest), "IF NOT EXISTS")
+
+	open := strings.Index(rest, "(")
+	end := skipParens(rest, open, true)
+	if open < 0 || end > len(rest) || rest[end-1] != ')' {
+		c.report.add("unable to parse the create table statement %q", truncate(sql))
+		return nil
+	}
+
+	t := &table{name: name}
+	c.tables[name] = t
+
+	var defs []string
+	// columns is the index of the definition of each column
+	columns := make(map[*column]int)
+	for _, def := range splitList(rest[open+1:end-1], true) {
+		switch kw := keyword(def, 2); {
+		case strings.HasPrefix(kw, "CONSTRAINT"), strings.HasPrefix(kw, "PRIMARY KEY"), strings.HasPrefix(kw, "UNIQUE"),
+			strings.HasPrefix(kw, "FOREIGN KEY"), strings.HasPrefix(kw, "CHECK"):
+			constraint := c.constraint(t, def)
+			if constraint == "" {
+				continue
+			}
+			defs = append(defs, constraint)
+
+			// mysql auto increments must be keys, so they are added with the primary key
+			if strings.HasPrefix(constraint, "PRIMARY KEY") {
+				for col, i := range columns {
+					if col.serial && strings.Contains(constraint, mysqlQuote(col.name)) {
+						col.serial = false
+						col.def += " AUTO_INCREMENT"
+						defs[i] = mysqlQuote(col.name) + " " + col.def
+					}
+				}
+			}
+		case strings.HasPrefix(kw, "LIKE"):
+			c.report.add("LIKE in create table statements is not converted")
+		default:
+			if col := c.column(t, def); col != "" {
+				columns[t.columns[len(t.columns)-1]] = len(defs)
+				defs = append(defs, col)
+			}
+		}
+	}
+
+	c.report.Statements++
+	_, err := fmt.Fprintf(w, "CREATE TABLE %s (\n  %s\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n", mysqlQuote(name), strings.Join(defs, ",\n  "))
+
+	return err
+}
+
+// column translates the column definition and adds it to the table.
+func (c *postgresToMySQL) column(t *table, def string) string {
+	name, rest := pgIdent(def)
+	if name == "" {
+		c.report.add("unable to parse the column %q", truncate(def))
+		return ""
+	}
+
+	col := &column{name: name}
+	t.columns = append(t.columns, col)
+
+	toks := tokens(rest, true)
+	i := 0
+	var typ []string
+	for ; i < len(toks) && !pgKeywords[strings.ToUpper(toks[i])]; i++ {
+		typ = append(typ, toks[i])
+	}
+
+	mysqlType, kind, serial := c.columnType(t, col, strings.ToLower(strings.Join(typ, " ")))
+	col.kind = kind
+	col.serial = serial
+
+	var parts []string
+	primary := false
+	for ; i < len(toks); i++ {
+		switch a := strings.ToUpper(toks[i]); {
+		case a == "NOT" && i+1 < len(toks) && strings.EqualFold(toks[i+1], "NULL"):
+			parts = append(parts, "NOT NULL")
+			i++
+		case a == "NULL":
+		case a == "DEFAULT" && i+1 < len(toks) && strings.EqualFold(toks[i+1], "NULL"):
+			parts = append(parts, "DEFAULT NULL")
+			i++
+		case a == "DEFAULT":
+			var expr []string
+			for i+1 < len(toks) && !pgKeywords[strings.ToUpper(toks[i+1])] {
+				i++
+				expr = append(expr, toks[i])
+			}
+			if value := c.defaultValue(t, col, strings.Join(expr, " ")); value != "" {
+				parts = append(parts, "DEFAULT "+value)
+			}
+		case a == "COLLATE" && i+1 < len(toks):
+			i++
+		case a == "PRIMARY" && i+1 < len(toks):
+			primary = true
+			i++
+		case a == "UNIQUE":
+			parts = append(parts, "UNIQUE")
+		case a == "GENERATED":
+			identity := i
+			for identity < len(toks) && !strings.EqualFold(toks[identity], "IDENTITY") {
+				identity++
+			}
+			if identity == len(toks) {
+				c.report.add("the generated column %s.%s is not converted", t.name, col.name)
+				i = len(toks)
+				break
+			}
+
+			// skip the options of the sequence
+			col.serial = true
+			i = identity
+			if i+1 < len(toks) && strings.HasPrefix(toks[i+1], "(") {
+				i++
+			}
+		default:
+			c.report.add("the column attribute %s is not converted", toks[i])
+		}
+	}
+
+	// mysql auto increments must be keys, so the sequence is converted with the primary key
+	if primary {
+		if col.serial {
+			col.serial = false
+			parts = append(parts, "AUTO_INCREMENT")
+		}
+		parts = append(parts, "PRIMARY KEY")
+	}
+
+	col.def = strings.Join(append([]string{mysqlType}, parts...), " ")
+
+	return mysqlQuote(col.name) + " " + col.def
+}
+
+// columnType returns the mysql type for the postgres type, and whether it is a serial.
+func (c *postgresToMySQL) columnType(t *table, col *column, typ string) (string, columnKind, bool) {
+	m := pgTypeLength.FindStringSubmatch(typ)
+	if m == nil {
+		c.report.add("the %s column %s.%s is converted to longtext", typ, t.name, col.name)
+		return "longtext", kindText, false
+	}
+
+	base, args, zone, array := m[1], strings.ReplaceAll(m[2], " ", ""), strings.TrimSpace(m[3]), m[4]
+	if array != "" {
+		c.report.add("the array column %s.%s is converted to longtext", t.name, col.name)
+		return "longtext", kindText, false
+	}
+
+	switch base {
+	case "smallint", "int2":
+		return "smallint", kindOther, false
+	case "integer", "int", "int4":
+		return "int", kindOther, false
+	case "bigint", "int8":
+		return "bigint", kindOther, false
+	case "smallserial", "serial2":
+		return "smallint", kindOther, true
+	case "serial", "serial4":
+		return "int", kindOther, true
+	case "bigserial", "serial8":
+		return "bigint", kindOther, true
+	case "boolean", "bool":
+		return "tinyint(1)", kindBool, false
+	case "numeric", "decimal":
+		if args == "" {
+			return "decimal(65,30)", kindOther, false
+		}
+		return "decimal(" + args + ")", kindOther, false
+	case "real", "float4":
+		return "float", kindOther, false
+	case "double precision", "float8":
+		return "double", kindOther, false
+	case "character varying", "varchar":
+		if args == "" {
+			return "longtext", kindText, false
+		}
+		return "varchar(" + args + ")", kindOther, false
+	case "character", "char", "bpchar":
+		return "char(" + orDefault(args, "1") + ")", kindOther, false
+	case "text", "citext":
+		return "longtext", kindText, false
+	case "bytea":
+		return "longblob", kindBinary, false
+	case "date":
+		return "date", kindOther, false
+	case "timestamp", "timestamptz":
+		if zone == "with time zone" || base == "timestamptz" {
+			c.report.add("the time zone of %s.%s is not converted", t.name, col.name)
+			return mysqlFraction("datetime", args), kindTimeZone, false
+		}
+		return mysqlFraction("datetime", args), kindOther, false
+	case "time", "timetz":
+		return mysqlFraction("time", args), kindOther, false
+	case "json", "jsonb":
+		return "json", kindText, false
+	case "uuid":
+		return "char(36)", kindOther, false
+	case "inet", "cidr", "macaddr":
+		return "varchar(45)", kindOther, false
+	}
+
+	c.report.add("the %s column %s.%s is converted to longtext", typ, t.name, col.name)
+
+	return "longtext", kindText, false
+}
+
+// mysqlFraction adds the fractional seconds precision to the type.
+func mysqlFraction(typ, precision string) string {
+	if precision == "" || precision == "0" {
+		return typ
+	}
+
+	return typ + "(" + precision + ")"
+}
+
+func (c *postgresToMySQL) defaultValue(t *table, col *column, expr string) string {
+	expr = strings.TrimSpace(pgCast.ReplaceAllString(expr, ""))
+	upper := strings.ToUpper(expr)
+
+	switch {
+	case strings.HasPrefix(upper, "NEXTVAL("):
+		col.serial = true
+		return ""
+	case upper == "NULL":
+		return "NULL"
+	case upper == "NOW()", upper == "CURRENT_TIMESTAMP", upper == "LOCALTIMESTAMP", upper == "TRANSACTION_TIMESTAMP()":
+		return "CURRENT_TIMESTAMP"
+	case col.kind == kindText || col.kind == kindBinary:
+		// text and blob columns cannot have defaults in mysql 5
+		c.report.add("the default %s for %s.%s is not converted", expr, t.name, col.name)
+		return ""
+	case upper == "TRUE", upper == "FALSE":
+		return mysqlBool(upper == "TRUE")
+	case pgNumber.MatchString(expr):
+		return strings.Trim(expr, "()")
+	case strings.HasPrefix(expr, "'"):
+		l, _, err := parseLiteral(expr, 0, true)
+		if err == nil {
+			return c.value(col.kind, l)
+		}
+	}
+
+	c.report.add("the default %s for %s.%s is not converted", expr, t.name, col.name)
+
+	return ""
+}
+
+// constraint translates a table constraint in a create table statement.
+func (c *postgresToMySQL) constraint(t *table, def string) string {
+	name := ""
+	if strings.EqualFold(keyword(def, 1), "CONSTRAINT") {
+		name, def = pgIdent(strings.TrimSpace(def[len("CONSTRAINT"):]))
+		def = strings.TrimSpace(def)
+	}
+
+	switch kw := keyword(def, 2); {
+	case kw == "PRIMARY KEY":
+		cols, ok := c.indexColumns(t, def[len(kw):], false)
+		if !ok {
+			return ""
+		}
+		return "PRIMARY KEY " + cols
+	case strings.HasPrefix(kw, "UNIQUE"):
+		cols, ok := c.indexColumns(t, def[len("UNIQUE"):], true)
+		if !ok {
+			return ""
+		}
+		if name == "" {
+			return "UNIQUE KEY " + cols
+		}
+		return "UNIQUE KEY " + mysqlQuote(name) + " " + cols
+	case kw == "FOREIGN KEY":
+		fk, ok := c.foreignKey(def)
+		if !ok {
+			return ""
+		}
+		if name == "" {
+			return fk
+		}
+		return "CONSTRAINT " + mysqlQuote(name) + " " + fk
+	}
+
+	c.report.add("%s constraints are not converted", strings.ToLower(keyword(def, 1)))
+
+	return ""
+}
+
+// foreignKey translates FOREIGN KEY (a) REFERENCES public.t(id) ON DELETE CASCADE.
+func (c *postgresToMySQL) foreignKey(def string) (string, bool) {
+	rest := strings.TrimSpace(def[len("FOREIGN KEY"):])
+	cols, rest, ok := identList(rest)
+	if !ok || !strings.EqualFold(keyword(rest, 1), "REFERENCES") {
+		c.report.add("unable to parse the foreign key %q", truncate(def))
+		return "", false
+	}
+
+	ref, rest := pgName(strings.TrimSpace(rest[len("REFERENCES"):]), "")
+	refCols, rest, ok := identList(rest)
+	if !ok {
+		c.report.add("unable to parse the foreign key %q", truncate(def))
+		return "", false
+	}
+
+	fk := fmt.Sprintf("FOREIGN KEY %s REFERENCES %s %s", mysqlList(cols), mysqlQuote(ref), mysqlList(refCols))
+
+	// keep the actions, mysql does not support deferrable constraints
+	toks := strings.Fields(rest)
+	for i := 0; i+2 < len(toks); i++ {
+		if strings.EqualFold(toks[i], "ON") {
+			action := strings.ToUpper(toks[i+2])
+			if (action == "SET" || action == "NO") && i+3 < len(toks) {
+				action += " " + strings.ToUpper(toks[i+3])
+			}
+			fk += " ON " + strings.ToUpper(toks[i+1]) + " " + action
+		}
+	}
+
+	return fk, true
+}
+
+func (c *postgresToMySQL) alterTable(w io.Writer, sql string) error {
+	rest := strings.TrimSpace(sql[len("ALTER TABLE"):])
+	for _, prefix := range []string{"IF EXISTS ", "ONLY "} {
+		if strings.HasPrefix(strings.ToUpper(rest), prefix) {
+			rest = strings.TrimSpace(rest[len(prefix):])
+		}
+	}
+
+	name, rest := pgName(rest, "")
+	rest = strings.TrimSpace(rest)
+	t := c.tables[name]
+
+	upper := strings.ToUpper(rest)
+	switch {
+	case strings.HasPrefix(upper, "OWNER TO"), strings.HasPrefix(upper, "REPLICA IDENTITY"):
+		return nil
+	case strings.HasPrefix(upper, "ALTER COLUMN"):
+		colName, action := pgIdent(strings.TrimSpace(rest[len("ALTER COLUMN"):]))
+		action = strings.ToUpper(strings.TrimSpace(action))
+		if col := t.column(colName); col != nil && (strings.HasPrefix(action, "SET DEFAULT NEXTVAL(") || strings.HasPrefix(action, "ADD GENERATED")) {
+			col.serial = true
+			return nil
+		}
+	case strings.HasPrefix(upper, "ADD CONSTRAINT"):
+		constraint := c.constraint(t, strings.TrimSpace(rest[len("ADD "):]))
+		if constraint == "" {
+			return nil
+		}
+
+		c.report.Statements++
+		if _, err := fmt.Fprintf(w, "ALTER TABLE %s ADD %s;\n", mysqlQuote(name), constraint); err != nil {
+			return err
+		}
+
+		// mysql auto increments must be keys, so they are added with the primary key
+		if strings.HasPrefix(constraint, "PRIMARY KEY") && t != nil {
+			for _, col := range t.columns {
+				if col.serial && strings.Contains(constraint, mysqlQuote(col.name)) {
+					col.serial = false
+					if _, err := fmt.Fprintf(w, "ALTER TABLE %s MODIFY %s %s AUTO_INCREMENT;\n", mysqlQuote(name), mysqlQuote(col.name), col.def); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		return nil
+	}
+
+	c.report.add("alter table statements such as %q are not converted", truncate(sql))
+
+	return nil
+}
+
+func (c *postgresToMySQL) createIndex(w io.Writer, sql string) error {
+	unique := strings.HasPrefix(keyword(sql, 2), "CREATE UNIQUE")
+
+	upper := strings.ToUpper(sql)
+	on := strings.Index(upper, " ON ")
+	if on < 0 {
+		c.report.add("unable to parse the index %q", truncate(sql))
+		return nil
+	}
+
+	head := strings.Fields(sql[:on])
+	index, _ := pgIdent(head[len(head)-1])
+
+	rest := strings.TrimSpace(sql[on+len(" ON "):])
+	if strings.HasPrefix(strings.ToUpper(rest), "ONLY ") {
+		rest = strings.TrimSpace(rest[len("ONLY "):])
+	}
+	name, rest := pgName(rest, "")
+	rest = strings.TrimSpace(rest)
+
+	if strings.HasPrefix(strings.ToUpper(rest), "USING ") {
+		fields := strings.Fields(rest)
+		if method := strings.ToLower(strings.TrimRight(fields[1], "(")); method != "btree" && method != "hash" {
+			c.report.add("%s indexes are not converted", method)
+			return nil
+		}
+		rest = strings.TrimSpace(rest[strings.Index(rest, fields[1])+len(strings.TrimRight(fields[1], "(")):])
+	}
+
+	if strings.Contains(strings.ToUpper(rest[skipParens(rest, 0, true):]), "WHERE") {
+		c.report.add("partial indexes are not converted")
+		return nil
+	}
+
+	cols, ok := c.indexColumns(c.tables[name], rest, true)
+	if !ok {
+		return nil
+	}
+
+	kind := "INDEX"
+	if unique {
+		kind = "UNIQUE INDEX"
+	}
+
+	c.report.Statements++
+	_, err := fmt.Fprintf(w, "CREATE %s %s ON %s %s;\n", kind, mysqlQuote(index), mysqlQuote(name), cols)
+
+	return err
+}
+
+// indexColumns translates the columns of an index, text columns get a prefix
+// length since mysql can only index the start of them.
+func (c *postgresToMySQL) indexColumns(t *table, list string, prefix bool) (string, bool) {
+	list = strings.TrimSpace(list)
+	end := skipParens(list, 0, true)
+	if !strings.HasPrefix(list, "(") || end > len(list) {
+		c.report.add("unable to parse the index columns %q", truncate(list))
+		return "", false
+	}
+
+	var cols []string
+	for _, item := range splitList(list[1:end-1], true) {
+		name, rest := pgIdent(item)
+		order := strings.ToUpper(strings.TrimSpace(rest))
+		if name == "" || (order != "" && order != "ASC" && order != "DESC" && !strings.HasPrefix(order, "NULLS") && !strings.HasPrefix(order, "ASC ") && !strings.HasPrefix(order, "DESC ")) {
+			c.report.add("indexes on expressions such as %q are not converted", truncate(item))
+			return "", false
+		}
+
+		ident := mysqlQuote(name)
+		if col := t.column(name); prefix && col != nil && (col.kind == kindText || col.kind == kindBinary) {
+			ident += "(191)"
+		}
+		if strings.HasPrefix(order, "DESC") {
+			ident += " DESC"
+		}
+		cols = append(cols, ident)
+	}
+
+	return "(" + strings.Join(cols, ", ") + ")", true
+}
+
+func (c *postgresToMySQL) insert(w io.Writer, sql string) error {
+	rest := strings.TrimSpace(sql[len("INSERT INTO"):])
+	name, rest := pgName(rest, "")
+	rest = strings.TrimSpace(rest)
+
+	var names []string
+	if strings.HasPrefix(rest, "(") {
+		var ok bool
+		names, rest, ok = identList(rest)
+		if !ok {
+			c.report.add("unable to parse the insert %q", truncate(sql))
+			return nil
+		}
+	}
+
+	rest = strings.TrimSpace(rest)
+	if !strings.EqualFold(keyword(rest, 1), "VALUES") {
+		c.report.add("inserts without values are not converted")
+		return nil
+	}
+
+	tuples, tail, err := parseTuples(rest[len("VALUES"):], true)
+	if err != nil {
+		c.report.add("unable to parse the insert into %s: %s", name, err)
+		return nil
+	}
+	if tail != "" {
+		c.report.add("%q in inserts is not converted", truncate(tail))
+	}
+
+	kinds := c.tables[name].kinds(names)
+	var rows [][]string
+	for _, tuple := range tuples {
+		var row []string
+		for j, l := range tuple {
+			row = append(row, c.value(kindAt(kinds, j), l))
+		}
+		rows = append(rows, row)
+	}
+
+	return c.writeInsert(w, name, names, rows)
+}
+
+// copy translates the rows of a COPY into inserts.
+func (c *postgresToMySQL) copy(w io.Writer, sql string) error {
+	name, rest := pgName(strings.TrimSpace(sql[len("COPY"):]), "")
+
+	var names []string
+	if rest = strings.TrimSpace(rest); strings.HasPrefix(rest, "(") {
+		names, rest, _ = identList(rest)
+	}
+
+	if !strings.Contains(strings.ToUpper(rest), "FROM STDIN") {
+		c.report.add("copy statements that do not read from stdin are not converted")
+		return nil
+	}
+
+	kinds := c.tables[name].kinds(names)
+
+	// the rows start on the line after the statement
+	if _, err := c.rows(); err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for {
+		line, err := c.rows()
+		if err == io.EOF || line == `\.` {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		var row []string
+		for j, field := range strings.Split(line, "\t") {
+			if field == `\N` {
+				row = append(row, "NULL")
+				continue
+			}
+			row = append(row, c.value(kindAt(kinds, j), literal{quoted: true, text: copyUnescape(field)}))
+		}
+		rows = append(rows, row)
+
+		if len(rows) == copyBatch {
+			if err := c.writeInsert(w, name, names, rows); err != nil {
+				return err
+			}
+			rows = rows[:0]
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	return c.writeInsert(w, name, names, rows)
+}
+
+func (c *postgresToMySQL) writeInsert(w io.Writer, name string, names []string, rows [][]string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "INSERT INTO %s ", mysqlQuote(name))
+	if len(names) > 0 {
+		b.WriteString(mysqlList(names) + " ")
+	}
+	b.WriteString("VALUES ")
+
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("(" + strings.Join(row, ",") + ")")
+	}
+	b.WriteString(";\n")
+
+	c.report.Statements++
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// value returns the mysql literal for the postgres value in a column of the kind.
+func (c *postgresToMySQL) value(kind columnKind, l literal) string {
+	if l.null() {
+		return "NULL"
+	}
+
+	if !l.quoted {
+		switch strings.ToLower(l.text) {
+		case "true":
+			return "1"
+		case "false":
+			return "0"
+		}
+		return l.text
+	}
+
+	switch {
+	case kind == kindBool:
+		return mysqlBool(l.text == "t" || l.text == "true" || l.text == "1")
+	case kind == kindBinary && strings.HasPrefix(l.text, `\x`):
+		return "X'" + l.text[2:] + "'"
+	case kind == kindTimeZone:
+		return mysqlString(timeZone.ReplaceAllString(l.text, ""))
+	}
+
+	return mysqlString(l.text)
+}
+
+func mysqlBool(b bool) string {
+	if b {
+		return "1"
+	}
+
+	return "0"
+}
+
+// mysqlList returns the names as a list of mysql identifiers.
+func mysqlList(names []string) string {
+	var quoted []string
+	for _, n := range names {
+		quoted = append(quoted, mysqlQuote(n))
+	}
+
+	return "(" + strings.Join(quoted, ", ") + ")"
+}
+
+// copyUnescape returns the value of a field in the COPY text format.
+func copyUnescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		switch c := s[i]; {
+		case c == 'b':
+			b.WriteByte('\b')
+		case c == 'f':
+			b.WriteByte('\f')
+		case c == 'n':
+			b.WriteByte('\n')
+		case c == 'r':
+			b.WriteByte('\r')
+		case c == 't':
+			b.WriteByte('\t')
+		case c == 'v':
+			b.WriteByte('\v')
+		case c >= '0' && c <= '7':
+			n := 0
+			for j := 0; j < 3 && i < len(s) && s[i] >= '0' && s[i] <= '7'; j++ {
+				n = n*8 + int(s[i]-'0')
+				i++
+			}
+			i--
+			b.WriteByte(byte(n))
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+// pgIdent returns the identifier at the start of s and the rest of s.
+func pgIdent(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, `"`) {
+		end := skipQuoted(s, 0, true)
+		return strings.ReplaceAll(s[1:end-1], `""`, `"`), s[end:]
+	}
+
+	i := 0
+	for i < len(s) && isIdentByte(s[i]) {
+		i++
+	}
+
+	return s[:i], s[i:]
+}
+
+// pgName returns the table name at the start of s without the schema,
+// and the rest of s. The optional words before the name are skipped.
+func pgName(s, optional string) (string, string) {
+	if optional != "" && strings.HasPrefix(strings.ToUpper(s), optional+" ") {
+		s = strings.TrimSpace(s[len(optional):])
+	}
+
+	name, rest := pgIdent(s)
+	for strings.HasPrefix(rest, ".") {
+		name, rest = pgIdent(rest[1:])
+	}
+
+	return name, rest
+}
+
+// identList parses a list of identifiers in parentheses.
+func identList(s string) ([]string, string, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "(") {
+		return nil, s, false
+	}
+
+	end := skipParens(s, 0, true)
+	if end > len(s) || s[end-1] != ')' {
+		return nil, s, false
+	}
+
+	var names []string
+	for _, item := range splitList(s[1:end-1], true) {
+		name, rest := pgIdent(item)
+		if name == "" || strings.TrimSpace(rest) != "" {
+			return nil, s, false
+		}
+		names = append(names, name)
+	}
+
+	return names, s[end:], true
+}
+
+// objectKind returns the statement and the kind of object it creates or
+// alters, e.g. "CREATE FUNCTION" or "CREATE MATERIALIZED VIEW".
+func objectKind(sql string) string {
+	words := strings.Fields(keyword(sql, 5))
+	if len(words) > 3 && words[1] == "OR" && words[2] == "REPLACE" {
+		words = append(words[:1], words[3:]...)
+	}
+
+	n := 2
+	if len(words) > 2 {
+		switch words[1] {
+		case "MATERIALIZED", "UNIQUE", "UNLOGGED", "TEMPORARY", "TEMP", "EVENT", "CONSTRAINT":
+			n = 3
+		}
+	}
+	if len(words) > n {
+		words = words[:n]
+	}
+
+	return strings.Join(words, " ")
+}
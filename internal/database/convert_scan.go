@@ -0,0 +1,181 @@
+package database
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+)
+
+// statements reads a dump one statement at a time. Semicolons in quotes,
+// comments and postgres dollar quotes do not end a statement, client
+// commands such as psql's \connect and mysql's DELIMITER end at the
+// end of the line.
+type statements struct {
+	r         *bufio.Reader
+	postgres  bool
+	delimiter string
+	// end is the delimiter or line ending after the last statement
+	end string
+}
+
+func newStatements(r io.Reader, postgres bool) *statements {
+	return &statements{r: bufio.NewReaderSize(r, 64*1024), postgres: postgres, delimiter: ";"}
+}
+
+// next returns the next statement without the delimiter, or io.EOF when there are
+// no more statements, along with any spaces and comments after the last one.
+func (s *statements) next() (string, error) {
+	var buf bytes.Buffer
+	var quote byte
+	var dollar string
+	lineComment, blockComment := false, false
+	// started is set after the first character that is not a space or comment
+	started := false
+
+	for {
+		c, err := s.r.ReadByte()
+		if err == io.EOF {
+			s.end = ""
+			if strings.TrimSpace(buf.String()) == "" {
+				return buf.String(), io.EOF
+			}
+			return buf.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		buf.WriteByte(c)
+
+		switch {
+		case lineComment:
+			lineComment = c != '\n'
+			continue
+		case blockComment:
+			blockComment = !bytes.HasSuffix(buf.Bytes(), []byte("*/"))
+			continue
+		case dollar != "":
+			if c == '$' && bytes.HasSuffix(buf.Bytes(), []byte(dollar)) {
+				dollar = ""
+			}
+			continue
+		case quote != 0:
+			if c == '\\' && quote == '\'' && !s.postgres {
+				// mysql escapes quotes with backslashes
+				if next, err := s.r.ReadByte(); err == nil {
+					buf.WriteByte(next)
+				}
+				continue
+			}
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		// client commands are only at the start of a statement
+		if !started && (c == '\\' || c == 'D' || c == 'd') {
+			if line, ok, err := s.command(c); err != nil {
+				return "", err
+			} else if ok {
+				return buf.String()[:buf.Len()-1] + line, nil
+			}
+		}
+
+		switch c {
+		case ' ', '\t', '\r', '\n', '-', '/', '*', '#':
+		default:
+			started = true
+		}
+
+		switch c {
+		case '\'', '"', '`':
+			quote = c
+		case '-':
+			lineComment = bytes.HasSuffix(buf.Bytes(), []byte("--"))
+		case '#':
+			lineComment = !s.postgres
+		case '*':
+			blockComment = bytes.HasSuffix(buf.Bytes(), []byte("/*"))
+		case '$':
+			if s.postgres {
+				dollar = s.dollarTag(buf.Bytes())
+			}
+		}
+
+		if bytes.HasSuffix(buf.Bytes(), []byte(s.delimiter)) {
+			s.end = s.delimiter
+			return buf.String()[:buf.Len()-len(s.delimiter)], nil
+		}
+	}
+}
+
+// command reads the rest of a line that starts with the character if it is a
+// client command, mysql's DELIMITER changes the delimiter of the statements.
+func (s *statements) command(c byte) (string, bool, error) {
+	if c != '\\' {
+		b, _ := s.r.Peek(9)
+		if !strings.EqualFold(string(c)+string(b), "DELIMITER ") || s.postgres {
+			return "", false, nil
+		}
+	}
+
+	line, err := s.r.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", false, err
+	}
+
+	trimmed := strings.TrimRight(line, "\r\n")
+	s.end = line[len(trimmed):]
+	line = string(c) + trimmed
+	if c != '\\' {
+		if d := strings.TrimSpace(line[len("DELIMITER"):]); d != "" {
+			s.delimiter = d
+		}
+	}
+
+	return line, true, nil
+}
+
+// dollarTag returns the dollar quote tag that ends with the last byte,
+// e.g. $$ or $body$, or an empty string when it is not a dollar quote.
+func (s *statements) dollarTag(b []byte) string {
+	i := len(b) - 2
+	for i >= 0 && (isIdentByte(b[i]) && b[i] != '$') {
+		i--
+	}
+
+	if i < 0 || b[i] != '$' {
+		return ""
+	}
+
+	// numbered parameters such as $1 are not quotes
+	tag := string(b[i:])
+	if len(tag) > 2 && tag[1] >= '0' && tag[1] <= '9' {
+		return ""
+	}
+
+	return tag
+}
+
+// line returns the next line without the line ending, it is used
+// to read the rows of a postgres COPY.
+func (s *statements) line() (string, error) {
+	line, err := s.rawLine()
+
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+// rawLine returns the next line with the line ending.
+func (s *statements) rawLine() (string, error) {
+	line, err := s.r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+
+	return line, err
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c >= 0x80
+}
@@ -0,0 +1,170 @@
+package database
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name         string
+		file         string
+		from, to     string
+		want         []string
+		absent       []string
+		untranslated []string
+	}{
+		{
+			name: "mysql dumps are converted to postgres",
+			file: "./testdata/convert-mysql.sql",
+			from: "mysql",
+			to:   "postgres",
+			want: []string{
+				`DROP TABLE IF EXISTS "entries" CASCADE;`,
+				`"id" serial NOT NULL,`,
+				`"enabled" boolean NOT NULL DEFAULT true,`,
+				`"status" varchar(255) CHECK ("status" IN ('live', 'pending')) NOT NULL DEFAULT 'live',`,
+				`"data" bytea,`,
+				`"authorId" bigint DEFAULT NULL,`,
+				`PRIMARY KEY ("id")`,
+				`CREATE UNIQUE INDEX "idx_uid" ON "entries" ("uid");`,
+				`CREATE INDEX "idx_title" ON "entries" ("title");`,
+				"INSERT INTO \"entries\" VALUES (1,'It''s; here','line\none',true,'live',NULL,'a','\\x6162',NULL,NULL)",
+				`ALTER TABLE "entries" ADD CONSTRAINT "fk_author" FOREIGN KEY ("authorId") REFERENCES "users" ("id") ON DELETE CASCADE;`,
+				`SELECT setval(pg_get_serial_sequence('"entries"', 'id')`,
+			},
+			absent: []string{"`", "ENGINE=", "LOCK TABLES", "_binary", "0000-00-00", "VIEW", "TRIGGER"},
+			untranslated: []string{
+				"ON UPDATE CURRENT_TIMESTAMP for entries.dateUpdated is not converted",
+				"fulltext indexes are not converted",
+				"trigger definitions are not converted",
+				"view definitions are not converted",
+				"zero dates are converted to NULL",
+			},
+		},
+		{
+			name: "postgres dumps are converted to mysql",
+			file: "./testdata/convert-postgres.sql",
+			from: "postgres",
+			to:   "mariadb",
+			want: []string{
+				"CREATE TABLE `entries` (",
+				"`enabled` tinyint(1) DEFAULT 1 NOT NULL,",
+				"`data` longblob",
+				"VALUES ('1','It\\'s; here','line\\none',1,'2020-05-01 10:00:00','2020-05-01 10:00:00','0b6e1e6a-4f4e-4b0f-9a6e-2a4d4e5f6a7b',X'6162'),('2','Two',NULL,0,NULL,'2020-05-02 10:00:00',NULL,NULL);",
+				"INSERT INTO `entries` VALUES (3,'Three',NULL,0,NULL,'2020-05-03 10:00:00',NULL,NULL);",
+				"ALTER TABLE `entries` ADD PRIMARY KEY (`id`);",
+				"ALTER TABLE `entries` MODIFY `id` int NOT NULL AUTO_INCREMENT;",
+				"CREATE INDEX `idx_title` ON `entries` (`title`);",
+				"SET FOREIGN_KEY_CHECKS = 1;",
+			},
+			absent: []string{"public.", "::", "OWNER TO", "nextval", "SEQUENCE", `\.`, "plpgsql", "idx_lower"},
+			untranslated: []string{
+				"create function statements are not converted",
+				"create trigger statements are not converted",
+				`indexes on expressions such as "lower(title)" are not converted`,
+				"the time zone of entries.dateUpdated is not converted",
+			},
+		},
+		{
+			name: "postgres primary keys in create table statements keep the sequences",
+			file: "./testdata/convert-postgres-keys.sql",
+			from: "postgres",
+			to:   "mysql",
+			want: []string{
+				"`id` int AUTO_INCREMENT PRIMARY KEY,",
+				"`email` varchar(255) DEFAULT NULL,",
+				"`lastLogin` datetime DEFAULT NULL",
+				"`id` bigint AUTO_INCREMENT PRIMARY KEY,",
+				"`id` int NOT NULL AUTO_INCREMENT,",
+				"PRIMARY KEY (`id`)",
+				"INSERT INTO `users` VALUES (1,'one@example.test',NULL);",
+			},
+			absent: []string{"nextval", "IDENTITY", "START WITH"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			var buf bytes.Buffer
+			report, err := Convert(f, &buf, tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := buf.String()
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("expected the dump to contain %q, got:\n%s", w, got)
+				}
+			}
+
+			for _, a := range tt.absent {
+				if strings.Contains(got, a) {
+					t.Errorf("expected the dump not to contain %q, got:\n%s", a, got)
+				}
+			}
+
+			if u := report.Untranslated(); strings.Join(u, "\n") != strings.Join(tt.untranslated, "\n") {
+				t.Errorf("expected the untranslated constructs to be\n%v\ngot\n%v", tt.untranslated, u)
+			}
+		})
+	}
+}
+
+func TestConvertUnsupported(t *testing.T) {
+	if _, err := Convert(strings.NewReader(""), &bytes.Buffer{}, "mysql", "mariadb"); err == nil {
+		t.Error("expected an error converting between mysql and mariadb")
+	}
+}
+
+func TestStatements(t *testing.T) {
+	tests := []struct {
+		name     string
+		dump     string
+		postgres bool
+		want     []string
+	}{
+		{
+			name: "semicolons in strings and comments do not end statements",
+			dump: "INSERT INTO `a` VALUES ('x;\\'y');\n-- comment; here\n/* block; */ SELECT 1;",
+			want: []string{"INSERT INTO `a` VALUES ('x;\\'y')", "-- comment; here\n/* block; */ SELECT 1"},
+		},
+		{
+			name: "mysql delimiters are changed",
+			dump: "DELIMITER ;;\nCREATE TRIGGER t BEGIN SET a = 1; END;;\nDELIMITER ;\nSELECT 1;",
+			want: []string{"DELIMITER ;;", "CREATE TRIGGER t BEGIN SET a = 1; END", "DELIMITER ;", "SELECT 1"},
+		},
+		{
+			name:     "postgres dollar quotes and client commands",
+			dump:     "\\connect craft\nCREATE FUNCTION f() AS $body$ SELECT 1; $body$;\nSELECT $1;",
+			postgres: true,
+			want:     []string{"\\connect craft", "CREATE FUNCTION f() AS $body$ SELECT 1; $body$", "SELECT $1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStatements(strings.NewReader(tt.dump), tt.postgres)
+
+			var got []string
+			for {
+				stmt, err := s.next()
+				if err != nil {
+					break
+				}
+				got = append(got, strings.TrimSpace(stmt))
+			}
+
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("expected the statements %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package database
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// literal is one of the values in an INSERT.
+type literal struct {
+	quoted bool
+	// text is the unescaped string when the value is
+	// quoted, otherwise the value as it is in the dump
+	text string
+	// prefix is the lower case introducer before a string,
+	// such as _binary in mysql or E in postgres
+	prefix string
+	// raw is the value as it is in the dump
+	raw string
+}
+
+func (l literal) null() bool {
+	return !l.quoted && strings.EqualFold(l.text, "NULL")
+}
+
+// parseTuples parses the rows of an INSERT, e.g. (1,'a'),(2,'b'), and returns the
+// rest of the statement. Postgres casts such as 'a'::text are removed.
+func parseTuples(s string, postgres bool) ([][]literal, string, error) {
+	var tuples [][]literal
+	i := 0
+	for {
+		i = skipSpace(s, i)
+		if i >= len(s) || s[i] != '(' {
+			if len(tuples) == 0 {
+				return nil, "", errors.New("expected the values of the insert")
+			}
+			return tuples, strings.TrimSpace(s[i:]), nil
+		}
+		i++
+
+		var tuple []literal
+		for {
+			i = skipSpace(s, i)
+			if i < len(s) && s[i] == ')' && len(tuple) == 0 {
+				i++
+				break
+			}
+
+			start := i
+			l, end, err := parseLiteral(s, i, postgres)
+			if err != nil {
+				return nil, "", err
+			}
+			l.raw, i = s[start:end], end
+			tuple = append(tuple, l)
+
+			i = skipSpace(s, i)
+			if i >= len(s) {
+				return nil, "", errors.New("unexpected end of the insert values")
+			}
+			if s[i] == ')' {
+				i++
+				break
+			}
+			if s[i] != ',' {
+				return nil, "", errors.New("unexpected " + strconv.Quote(s[i:i+1]) + " in the insert values")
+			}
+			i++
+		}
+		tuples = append(tuples, tuple)
+
+		i = skipSpace(s, i)
+		if i < len(s) && s[i] == ',' {
+			i++
+		}
+	}
+}
+
+// parseLiteral parses the value that starts at i and returns the index after it.
+func parseLiteral(s string, i int, postgres bool) (literal, int, error) {
+	var l literal
+
+	// introducers such as _binary 'x', E'x' and X'ab'
+	if j := scanWord(s, i); j > i {
+		word := s[i:j]
+		k := skipSpace(s, j)
+		if k < len(s) && s[k] == '\'' && (word[0] == '_' || len(word) == 1) {
+			l.prefix = strings.ToLower(word)
+			i = k
+		}
+	}
+
+	if i < len(s) && s[i] == '\'' {
+		end := skipQuoted(s, i, postgres && l.prefix != "e")
+		if end > len(s) || s[end-1] != '\'' || end-1 == i {
+			return l, i, errors.New("unterminated string in the insert values")
+		}
+
+		raw := s[i+1 : end-1]
+		l.quoted = true
+		switch {
+		case l.prefix == "x" || l.prefix == "b":
+			l.text = raw
+		case postgres && l.prefix != "e":
+			l.text = strings.ReplaceAll(raw, "''", "'")
+		default:
+			l.text = unescape(raw)
+		}
+
+		i = end
+	} else {
+		start := i
+		depth := 0
+	token:
+		for i < len(s) {
+			switch s[i] {
+			case '(', '[':
+				depth++
+			case ')', ']':
+				if depth == 0 {
+					break token
+				}
+				depth--
+			case ',':
+				if depth == 0 {
+					break token
+				}
+			case ':':
+				if postgres && depth == 0 && strings.HasPrefix(s[i:], "::") {
+					break token
+				}
+			case '\'', '"':
+				i = skipQuoted(s, i, postgres)
+				continue
+			}
+			i++
+		}
+		l.text = strings.TrimSpace(s[start:i])
+		if l.text == "" {
+			return l, i, errors.New("missing value in the insert values")
+		}
+	}
+
+	// casts are not needed in mysql
+	if postgres {
+		for strings.HasPrefix(s[i:], "::") {
+			i += 2
+			depth := 0
+		cast:
+			for i < len(s) {
+				switch s[i] {
+				case '(', '[':
+					depth++
+				case ')', ']':
+					if depth == 0 {
+						break cast
+					}
+					depth--
+				case ',':
+					if depth == 0 {
+						break cast
+					}
+				case ':':
+					if depth == 0 {
+						break cast
+					}
+				case '"':
+					i = skipQuoted(s, i, true)
+					continue
+				}
+				i++
+			}
+		}
+	}
+
+	return l, i, nil
+}
+
+// unescape returns the string with the backslash escapes used
+// by mysql and postgres escape strings replaced.
+func unescape(s string) string {
+	if !strings.ContainsAny(s, `\'`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\'' && i+1 < len(s) && s[i+1] == '\'' {
+			b.WriteByte('\'')
+			i++
+			continue
+		}
+		if c != '\\' || i+1 >= len(s) {
+			b.WriteByte(c)
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case '0':
+			b.WriteByte(0)
+		case 'b':
+			b.WriteByte('\b')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'Z':
+			b.WriteByte('\x1a')
+		case '%', '_':
+			// only escaped in LIKE patterns
+			b.WriteByte('\\')
+			b.WriteByte(s[i])
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String()
+}
+
+func skipSpace(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r') {
+		i++
+	}
+
+	return i
+}
+
+// scanWord returns the index after the letters, digits and underscores at i.
+func scanWord(s string, i int) int {
+	for i < len(s) && (s[i] == '_' || (s[i] >= 'a' && s[i] <= 'z') || (s[i] >= 'A' && s[i] <= 'Z') || (s[i] >= '0' && s[i] <= '9')) {
+		i++
+	}
+
+	return i
+}
@@ -3,14 +3,15 @@ package database
 import (
 	"bufio"
 	"errors"
+	"io"
 	"os"
 	"strings"
 )
 
 // DetermineEngine takes a file and will check if the
 // content of the file is for mysql or postgres db
-// imports. It will return the engine "mysql" or
-// "postgres" if it can determine the engine.
+// imports. It will return the engine "mysql", "mariadb"
+// or "postgres" if it can determine the engine.
 // If it cannot, it will return an error.
 func DetermineEngine(file string) (string, error) {
 	f, err := os.Open(file)
@@ -19,10 +20,22 @@ func DetermineEngine(file string) (string, error) {
 	}
 	defer f.Close()
 
+	return DetermineEngineFromReader(f)
+}
+
+// DetermineEngineFromReader checks the start of the dump, such as a
+// decompressed dump from an archive, the same way as DetermineEngine.
+func DetermineEngineFromReader(r io.Reader) (string, error) {
 	engine := ""
 	line := 1
 
-	s := bufio.NewScanner(f)
+	// pg_dump custom format backups are binary
+	br := bufio.NewReader(r)
+	if b, _ := br.Peek(5); string(b) == "PGDMP" {
+		return "postgres", nil
+	}
+
+	s := bufio.NewScanner(br)
 	for s.Scan() {
 		// check if its postgres
 		if strings.Contains(s.Text(), "PostgreSQL") || strings.Contains(s.Text(), "pg_dump") {
@@ -30,8 +43,14 @@ func DetermineEngine(file string) (string, error) {
 			break
 		}
 
+		// mariadb dumps also mention MySQL, so check for mariadb first
+		if strings.Contains(s.Text(), "MariaDB") || strings.Contains(s.Text(), "mariadb") {
+			engine = "mariadb"
+			break
+		}
+
 		// check if its mysql
-		if strings.Contains(s.Text(), "MySQL") || strings.Contains(s.Text(), "mysqldump") || strings.Contains(s.Text(), "mariadb") {
+		if strings.Contains(s.Text(), "MySQL") || strings.Contains(s.Text(), "mysqldump") {
 			engine = "mysql"
 			break
 		}
@@ -51,6 +70,20 @@ func DetermineEngine(file string) (string, error) {
 	return engine, nil
 }
 
+// Compatible returns the engines a dump for the engine can be imported
+// into, starting with the engine. MySQL and MariaDB dumps can be
+// imported into either engine.
+func Compatible(engine string) []string {
+	switch engine {
+	case "mysql":
+		return []string{"mysql", "mariadb"}
+	case "mariadb":
+		return []string{"mariadb", "mysql"}
+	}
+
+	return []string{engine}
+}
+
 // HasCreateStatement takes a file and will determine
 // if the file will create a database during import.
 // If it creates a database, it will return true
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -16,16 +17,28 @@ func TestDetermineEngine(t *testing.T) {
 	}{
 		{
 			name:    "can detect mysql database backup files",
-			args:    args{file: "./testdata/mysql-backup.sql"},
+			args:    args{file: "./testdata/mysql-backup-example-two.sql"},
 			want:    "mysql",
 			wantErr: false,
 		},
+		{
+			name:    "can detect mariadb database backup files",
+			args:    args{file: "./testdata/mysql-backup.sql"},
+			want:    "mariadb",
+			wantErr: false,
+		},
 		{
 			name:    "can detect postgres database backup files",
 			args:    args{file: "./testdata/postgres-backup.sql"},
 			want:    "postgres",
 			wantErr: false,
 		},
+		{
+			name:    "can detect postgres custom format backup files",
+			args:    args{file: "./testdata/postgres-custom.dump"},
+			want:    "postgres",
+			wantErr: false,
+		},
 		{
 			name:    "non mysql or postgres files return an error",
 			args:    args{file: "./testdata/random.txt"},
@@ -89,3 +102,21 @@ func TestHasCreateStatement(t *testing.T) {
 		})
 	}
 }
+
+func TestCompatible(t *testing.T) {
+	tests := []struct {
+		engine string
+		want   []string
+	}{
+		{engine: "mysql", want: []string{"mysql", "mariadb"}},
+		{engine: "mariadb", want: []string{"mariadb", "mysql"}},
+		{engine: "postgres", want: []string{"postgres"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.engine, func(t *testing.T) {
+			if got := Compatible(tt.engine); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Compatible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
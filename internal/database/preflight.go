@@ -0,0 +1,545 @@
+package database
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/craftcms/nitro/internal/version"
+)
+
+// the fixes that can be applied to a dump before it is imported
+const (
+	// FixDefiners removes the definers of mysql views, triggers and routines
+	// and the owners of postgres objects, so the importing user owns them
+	FixDefiners = "definers"
+	// FixCollations replaces the collations of MySQL 8 and MariaDB 10.10
+	// with collations that every version supports
+	FixCollations = "collations"
+	// FixDatabases removes the statements that create, drop and switch
+	// databases so the dump is imported into the database nitro creates
+	FixDatabases = "databases"
+	// FixGTID removes the statement that sets the GTIDs of the server
+	FixGTID = "gtid"
+)
+
+// Analysis is what a preflight scan found in a dump.
+type Analysis struct {
+	Engine string
+	// Version is the version of the server the dump is from
+	Version string
+	// Size is the size of the dump in bytes, once it is decompressed
+	Size       int64
+	Tables     int
+	Statements int
+	// Databases are created by the dump
+	Databases []string
+	// Uses are the databases the dump switches to with USE or \connect
+	Uses []string
+	// Definers are the definers of mysql objects or the owners of postgres objects
+	Definers   []string
+	Collations []string
+	// GTIDPurged is set when the dump sets @@GLOBAL.GTID_PURGED
+	GTIDPurged bool
+}
+
+// Problem is something in the dump that can make the import fail. Fix is
+// the fix that solves the problem, or empty when it cannot be fixed.
+type Problem struct {
+	Message string
+	Fix     string
+}
+
+var (
+	// the versions in the headers of mysqldump, mariadb-dump and pg_dump
+	serverVersion  = regexp.MustCompile(`(?m)^-- Server version\s+(\d+(?:\.\d+){0,2})`)
+	distribVersion = regexp.MustCompile(`(?m)^-- (?:MySQL|MariaDB) dump [\d.]+\s+Distrib (\d+(?:\.\d+){0,2})`)
+	pgVersion      = regexp.MustCompile(`(?m)^-- Dumped from database version (\d+(?:\.\d+){0,2})`)
+
+	// versionedComment matches mysql's comments that are run by newer versions, e.g. /*!50013 DEFINER=... */
+	versionedComment = regexp.MustCompile(`(?s)/\*!\d*(.*?)\*/`)
+	definer          = regexp.MustCompile("(?i)\\s*\\bDEFINER\\s*=\\s*(CURRENT_USER(?:\\(\\))?|`(?:[^`]|``)*`|'(?:[^'\\\\]|\\\\.)*'|[\\w.$-]+)(?:\\s*@\\s*(`(?:[^`]|``)*`|'(?:[^'\\\\]|\\\\.)*'|[\\w.%:-]+))?")
+	ownerTo          = regexp.MustCompile(`(?i)\bOWNER TO\s+("(?:[^"]|"")*"|\w+)`)
+	collation        = regexp.MustCompile(`(?i)\b(?:COLLATE|collation_connection|collation_server|collation_database)\s*=?\s*'?(\w+)`)
+	// newCollation matches the collations of MySQL 8 and MariaDB 10.10, e.g. utf8mb4_0900_ai_ci
+	newCollation = regexp.MustCompile(`(?i)\b\w*(?:_0900_|uca1400_)\w+`)
+	gtidPurged   = regexp.MustCompile(`(?i)@@GLOBAL\.GTID_PURGED`)
+	connectDB    = regexp.MustCompile(`dbname='?([^' ]+)`)
+)
+
+// Analyze scans the whole dump for what is in it and what can make the
+// import fail, such as the databases it creates or switches to, the
+// definers, the collations and the GTIDs. The values of the inserts and
+// postgres COPY rows are not scanned.
+func Analyze(r io.Reader) (*Analysis, error) {
+	cr := &countReader{r: r}
+	br := bufio.NewReaderSize(cr, 64*1024)
+
+	// the engine is determined from the start of the dump
+	head, _ := br.Peek(64 * 1024)
+	if bytes.HasPrefix(head, []byte("PGDMP")) {
+		return nil, errors.New("pg_dump custom format dumps cannot be analyzed")
+	}
+	engine, _ := DetermineEngineFromReader(bytes.NewReader(head))
+
+	a := &Analysis{Engine: engine}
+	postgres := engine == "postgres"
+	s := newStatements(br, postgres)
+	for {
+		stmt, err := s.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		a.Statements++
+
+		// the version is in the comments at the start of the dump
+		if a.Version == "" && a.Statements <= 10 {
+			a.Version = headerVersion(stmt)
+		}
+
+		sql := a.statement(stmt, postgres)
+		if postgres && keyword(sql, 1) == "COPY" && strings.Contains(strings.ToUpper(sql), "FROM STDIN") {
+			if err := copyRows(s, ioutil.Discard); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	a.Size = cr.n
+	sort.Strings(a.Definers)
+	sort.Strings(a.Collations)
+
+	return a, nil
+}
+
+// statement adds what is in the statement to the analysis and returns the statement
+// without the comments, mysql's versioned comments are treated as statements.
+func (a *Analysis) statement(stmt string, postgres bool) string {
+	_, sql := trimComments(stmt, postgres)
+	switch keyword(sql, 1) {
+	case "INSERT", "REPLACE", "COPY":
+		return sql
+	}
+
+	_, sql = trimComments(unversion(stmt), postgres)
+	switch kw := keyword(sql, 3); {
+	case strings.HasPrefix(kw, "CREATE TABLE"), strings.HasPrefix(kw, "CREATE TEMPORARY TABLE"), strings.HasPrefix(kw, "CREATE UNLOGGED TABLE"):
+		a.Tables++
+	}
+
+	switch kind, name := databaseStatement(sql, postgres); kind {
+	case "create":
+		a.Databases = appendUnique(a.Databases, name)
+	case "use":
+		a.Uses = appendUnique(a.Uses, name)
+	}
+
+	if postgres {
+		if m := ownerTo.FindStringSubmatch(sql); m != nil && keyword(sql, 1) == "ALTER" {
+			owner, _ := pgIdent(m[1])
+			a.Definers = appendUnique(a.Definers, owner)
+		}
+
+		return sql
+	}
+
+	for _, m := range definer.FindAllStringSubmatch(sql, -1) {
+		if strings.HasPrefix(strings.ToUpper(m[1]), "CURRENT_USER") {
+			continue
+		}
+
+		user := unquoteAccount(m[1])
+		if m[2] != "" {
+			user += "@" + unquoteAccount(m[2])
+		}
+		a.Definers = appendUnique(a.Definers, user)
+	}
+
+	for _, m := range collation.FindAllStringSubmatch(sql, -1) {
+		a.Collations = appendUnique(a.Collations, strings.ToLower(m[1]))
+	}
+
+	if gtidPurged.MatchString(sql) {
+		a.GTIDPurged = true
+	}
+
+	return sql
+}
+
+// Problems returns the problems importing the dump into the version of the engine.
+func (a *Analysis) Problems(engine, v string) []Problem {
+	var problems []Problem
+
+	if a.Version != "" && compatible(a.Engine, engine) && newerVersion(engine, a.Version, v) {
+		problems = append(problems, Problem{
+			Message: fmt.Sprintf("The dump is from %s %s, which is newer than %s %s, so it may use features that are not supported", a.Engine, a.Version, engine, v),
+		})
+	}
+
+	var unsupported []string
+	for _, c := range a.Collations {
+		if !supportsCollation(engine, v, c) {
+			unsupported = append(unsupported, c)
+		}
+	}
+	if len(unsupported) > 0 {
+		problems = append(problems, Problem{
+			Message: fmt.Sprintf("The dump uses the collations %s, which %s %s does not support", strings.Join(unsupported, ", "), engine, v),
+			Fix:     FixCollations,
+		})
+	}
+
+	if len(a.Definers) > 0 {
+		message := fmt.Sprintf("The dump has views, triggers or routines with the definers %s, which may not exist on the server", strings.Join(a.Definers, ", "))
+		if engine == "postgres" {
+			message = fmt.Sprintf("The dump sets the owners of objects to %s, which may not exist on the server", strings.Join(a.Definers, ", "))
+		}
+		problems = append(problems, Problem{Message: message, Fix: FixDefiners})
+	}
+
+	if len(a.Databases) > 1 {
+		problems = append(problems, Problem{
+			Message: fmt.Sprintf("The dump creates the databases %s, only one database can be imported at a time", strings.Join(a.Databases, ", ")),
+		})
+	}
+
+	var others []string
+	for _, u := range a.Uses {
+		if !contains(a.Databases, u) {
+			others = append(others, u)
+		}
+	}
+	if len(others) > 0 {
+		problem := Problem{
+			Message: fmt.Sprintf("The dump switches to the databases %s, which it does not create, so the tables will not be imported into the new database", strings.Join(others, ", ")),
+		}
+		// the statements can only be removed when the dump is for one database
+		if len(a.Databases)+len(others) == 1 {
+			problem.Fix = FixDatabases
+		}
+		problems = append(problems, problem)
+	}
+
+	if a.GTIDPurged && engine != "postgres" {
+		problems = append(problems, Problem{
+			Message: "The dump sets @@GLOBAL.GTID_PURGED, which fails when the server has GTIDs or does not support them",
+			Fix:     FixGTID,
+		})
+	}
+
+	return problems
+}
+
+// Fix copies the dump for the engine to w with the fixes applied and
+// returns the number of statements that were changed or removed.
+// The values of the inserts and postgres COPY rows are not changed.
+func Fix(r io.Reader, w io.Writer, engine string, fixes []string) (int, error) {
+	f := &fixer{postgres: engine == "postgres"}
+	for _, fix := range fixes {
+		switch fix {
+		case FixDefiners:
+			f.definers = true
+		case FixCollations:
+			f.collations = true
+		case FixDatabases:
+			f.databases = true
+		case FixGTID:
+			f.gtid = true
+		default:
+			return 0, fmt.Errorf("unknown fix %q, the fixes are %s", fix, strings.Join([]string{FixDefiners, FixCollations, FixDatabases, FixGTID}, ", "))
+		}
+	}
+
+	bw := bufio.NewWriterSize(w, 64*1024)
+	s := newStatements(r, f.postgres)
+	for {
+		stmt, err := s.next()
+		if err == io.EOF {
+			if _, err := bw.WriteString(stmt); err != nil {
+				return 0, err
+			}
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		fixed, keep := f.statement(stmt)
+		if fixed != stmt || !keep {
+			f.changed++
+		}
+		if !keep {
+			// keep the comments before the removed statement, such
+			// as the header of the dump, but not the blank lines
+			if comments, _ := trimComments(stmt, f.postgres); strings.TrimSpace(comments) != "" {
+				if _, err := bw.WriteString(comments); err != nil {
+					return 0, err
+				}
+			}
+			continue
+		}
+
+		if _, err := bw.WriteString(fixed + s.end); err != nil {
+			return 0, err
+		}
+
+		if _, sql := trimComments(stmt, f.postgres); f.postgres && keyword(sql, 1) == "COPY" && strings.Contains(strings.ToUpper(sql), "FROM STDIN") {
+			if err := copyRows(s, bw); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return f.changed, bw.Flush()
+}
+
+type fixer struct {
+	postgres   bool
+	definers   bool
+	collations bool
+	databases  bool
+	gtid       bool
+	changed    int
+}
+
+// statement returns the fixed statement and false when it is removed.
+func (f *fixer) statement(stmt string) (string, bool) {
+	_, sql := trimComments(stmt, f.postgres)
+	switch keyword(sql, 1) {
+	case "INSERT", "REPLACE", "COPY":
+		return stmt, true
+	}
+
+	_, sql = trimComments(unversion(stmt), f.postgres)
+	if kind, _ := databaseStatement(sql, f.postgres); f.databases && kind != "" {
+		return "", false
+	}
+
+	if f.postgres {
+		if f.definers && keyword(sql, 1) == "ALTER" && ownerTo.MatchString(sql) {
+			return "", false
+		}
+
+		return stmt, true
+	}
+
+	if f.gtid && gtidPurged.MatchString(sql) {
+		return "", false
+	}
+
+	if f.definers {
+		stmt = definer.ReplaceAllString(stmt, "")
+	}
+
+	if f.collations {
+		stmt = newCollation.ReplaceAllStringFunc(stmt, portableCollation)
+	}
+
+	return stmt, true
+}
+
+// databaseStatement returns the kind of statement, create, drop, alter or use, when
+// the statement creates, drops, alters or switches to a database and the name of it.
+func databaseStatement(sql string, postgres bool) (string, string) {
+	words := strings.Fields(keyword(sql, 2))
+	if len(words) == 0 {
+		return "", ""
+	}
+
+	switch {
+	case postgres && (words[0] == `\CONNECT` || words[0] == `\C`):
+		fields := strings.Fields(sql)
+		if len(fields) < 2 {
+			return "use", ""
+		}
+		if strings.HasPrefix(fields[1], "-reuse-previous") && len(fields) > 2 {
+			if m := connectDB.FindStringSubmatch(sql); m != nil {
+				return "use", m[1]
+			}
+		}
+		name, _ := pgIdent(fields[1])
+		return "use", name
+	case !postgres && words[0] == "USE":
+		name, _ := dumpIdent(sql[len("USE"):], false)
+		return "use", name
+	case len(words) < 2 || (words[1] != "DATABASE" && (postgres || words[1] != "SCHEMA")):
+		return "", ""
+	}
+
+	kind := strings.ToLower(words[0])
+	switch kind {
+	case "create", "drop", "alter":
+	default:
+		return "", ""
+	}
+
+	rest := strings.TrimSpace(sql[len(strings.Fields(sql)[0]):])
+	rest = strings.TrimSpace(rest[len(strings.Fields(rest)[0]):])
+	for _, optional := range []string{"IF NOT EXISTS ", "IF EXISTS "} {
+		if strings.HasPrefix(strings.ToUpper(rest), optional) {
+			rest = strings.TrimSpace(rest[len(optional):])
+		}
+	}
+	name, _ := dumpIdent(rest, postgres)
+
+	return kind, name
+}
+
+// copyRows copies the rows of a postgres COPY as they are.
+func copyRows(s *statements, w io.Writer) error {
+	for {
+		line, err := s.rawLine()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+
+		if strings.TrimRight(line, "\r\n") == `\.` {
+			return nil
+		}
+	}
+}
+
+// headerVersion returns the version of the server from the comments of the dump.
+func headerVersion(stmt string) string {
+	for _, re := range []*regexp.Regexp{serverVersion, pgVersion, distribVersion} {
+		if m := re.FindStringSubmatch(stmt); m != nil {
+			return m[1]
+		}
+	}
+
+	return ""
+}
+
+// unversion removes the markers of mysql's versioned comments so
+// the statements in them can be read.
+func unversion(stmt string) string {
+	if !strings.Contains(stmt, "/*!") {
+		return stmt
+	}
+
+	return versionedComment.ReplaceAllString(stmt, "$1")
+}
+
+// unquoteAccount returns the user or host of a mysql account without the quotes.
+func unquoteAccount(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+
+	switch s[0] {
+	case '`':
+		return mysqlUnquote(s)
+	case '\'':
+		return unescape(s[1 : len(s)-1])
+	}
+
+	return s
+}
+
+// portableCollation returns a collation that every version supports for the
+// collations of MySQL 8 and MariaDB 10.10, e.g. utf8mb4_0900_ai_ci.
+func portableCollation(name string) string {
+	lower := strings.ToLower(name)
+
+	charset := "utf8mb4"
+	if strings.HasPrefix(lower, "utf8mb3_") || strings.HasPrefix(lower, "utf8_") {
+		charset = "utf8"
+	}
+
+	if strings.HasSuffix(lower, "_bin") {
+		return charset + "_bin"
+	}
+
+	return charset + "_unicode_ci"
+}
+
+// supportsCollation reports if the version of the engine supports the collation, the
+// 0900 collations were added in MySQL 8 and the uca1400 collations in MariaDB 10.10.
+func supportsCollation(engine, v, name string) bool {
+	switch {
+	case engine == "postgres":
+		// mysql dumps are converted for postgres
+		return true
+	case strings.Contains(name, "_0900_"):
+		return engine == "mysql" && !olderVersion(v, 8, 0)
+	case strings.Contains(name, "uca1400"):
+		return engine == "mariadb" && !olderVersion(v, 10, 10)
+	}
+
+	return true
+}
+
+// olderVersion reports if the version is older than the major and minor version,
+// versions that cannot be parsed, such as latest, are never older.
+func olderVersion(v string, major, minor int) bool {
+	parsed, err := version.Parse(v)
+	if err != nil {
+		return false
+	}
+
+	return parsed[0] < major || (parsed[0] == major && parsed[1] < minor)
+}
+
+// newerVersion reports if the version of the dump is newer than the version of
+// the engine, the minor versions of postgres are not compared.
+func newerVersion(engine, dump, v string) bool {
+	d, err := version.Parse(dump)
+	if err != nil {
+		return false
+	}
+
+	server, err := version.Parse(v)
+	if err != nil {
+		return false
+	}
+
+	if engine == "postgres" {
+		return d[0] > server[0]
+	}
+
+	return d[0] > server[0] || (d[0] == server[0] && d[1] > server[1])
+}
+
+// compatible reports if a dump for the engine can be imported into the other engine.
+func compatible(engine, other string) bool {
+	return contains(Compatible(engine), other)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// countReader counts the bytes read from the reader.
+type countReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+
+	return n, err
+}
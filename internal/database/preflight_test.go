@@ -0,0 +1,222 @@
+package database
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want *Analysis
+	}{
+		{
+			name: "mysql dumps with databases, definers, collations and gtids",
+			file: "testdata/preflight-mysql.sql",
+			want: &Analysis{
+				Engine:     "mysql",
+				Version:    "8.0.23",
+				Size:       1711,
+				Tables:     2,
+				Statements: 20,
+				Databases:  []string{"craft"},
+				Uses:       []string{"craft", "other"},
+				Definers:   []string{"craft@%", "root@localhost"},
+				Collations: []string{"utf8mb4_0900_ai_ci", "utf8mb4_unicode_ci"},
+				GTIDPurged: true,
+			},
+		},
+		{
+			name: "postgres dumps with owners",
+			file: "testdata/preflight-postgres.sql",
+			want: &Analysis{
+				Engine:     "postgres",
+				Version:    "13.2",
+				Size:       721,
+				Tables:     2,
+				Statements: 11,
+				Databases:  []string{"craft"},
+				Uses:       []string{"craft"},
+				Definers:   []string{"Deploy", "craft"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			got, err := Analyze(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected the analysis\n%+v\ngot\n%+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAnalysis_Problems(t *testing.T) {
+	mysql := &Analysis{
+		Engine:     "mysql",
+		Version:    "8.0.23",
+		Databases:  []string{"craft"},
+		Uses:       []string{"craft"},
+		Definers:   []string{"root@localhost"},
+		Collations: []string{"utf8mb4_0900_ai_ci", "utf8mb4_unicode_ci"},
+		GTIDPurged: true,
+	}
+
+	tests := []struct {
+		name     string
+		analysis *Analysis
+		engine   string
+		version  string
+		want     []string
+	}{
+		{
+			name:     "mysql 8 dumps into mysql 5.7",
+			analysis: mysql,
+			engine:   "mysql",
+			version:  "5.7",
+			want:     []string{"", FixCollations, FixDefiners, FixGTID},
+		},
+		{
+			name:     "mysql 8 dumps into mysql 8",
+			analysis: mysql,
+			engine:   "mysql",
+			version:  "8.0",
+			want:     []string{FixDefiners, FixGTID},
+		},
+		{
+			name:     "mysql 8 dumps into mariadb",
+			analysis: mysql,
+			engine:   "mariadb",
+			version:  "10.5",
+			want:     []string{FixCollations, FixDefiners, FixGTID},
+		},
+		{
+			name:     "dumps that switch to another database can be fixed",
+			analysis: &Analysis{Engine: "mariadb", Version: "10.5.8", Uses: []string{"production"}},
+			engine:   "mariadb",
+			version:  "latest",
+			want:     []string{FixDatabases},
+		},
+		{
+			name:     "dumps with several databases cannot be fixed",
+			analysis: &Analysis{Engine: "mysql", Databases: []string{"one", "two"}, Uses: []string{"one", "two", "three"}},
+			engine:   "mysql",
+			version:  "8.0",
+			want:     []string{"", ""},
+		},
+		{
+			name:     "postgres minor versions are not compared",
+			analysis: &Analysis{Engine: "postgres", Version: "13.2", Definers: []string{"craft"}},
+			engine:   "postgres",
+			version:  "13",
+			want:     []string{FixDefiners},
+		},
+		{
+			name:     "newer postgres dumps",
+			analysis: &Analysis{Engine: "postgres", Version: "13.2"},
+			engine:   "postgres",
+			version:  "12",
+			want:     []string{""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, p := range tt.analysis.Problems(tt.engine, tt.version) {
+				if p.Message == "" {
+					t.Errorf("expected the problem with the fix %q to have a message", p.Fix)
+				}
+				got = append(got, p.Fix)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected the fixes %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFix(t *testing.T) {
+	tests := []struct {
+		name    string
+		dump    string
+		engine  string
+		fixes   []string
+		want    string
+		changed int
+		wantErr bool
+	}{
+		{
+			name:    "mysql definers, collations, databases and gtids",
+			dump:    "SET @@GLOBAL.GTID_PURGED=/*!80000 '+'*/ 'a:1-5';\nCREATE DATABASE /*!32312 IF NOT EXISTS*/ `craft`;\nUSE `craft`;\nCREATE TABLE `t` (\n  `a` text COLLATE utf8mb4_0900_ai_ci\n) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;\nINSERT INTO `t` VALUES ('DEFINER=`a`@`b` utf8mb4_0900_ai_ci');\n/*!50001 CREATE ALGORITHM=UNDEFINED */\n/*!50013 DEFINER=`root`@`localhost` SQL SECURITY DEFINER */\n/*!50001 VIEW `v` AS select 1 */;\n",
+			engine:  "mysql",
+			fixes:   []string{FixDefiners, FixCollations, FixDatabases, FixGTID},
+			want:    "\nCREATE TABLE `t` (\n  `a` text COLLATE utf8mb4_unicode_ci\n) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;\nINSERT INTO `t` VALUES ('DEFINER=`a`@`b` utf8mb4_0900_ai_ci');\n/*!50001 CREATE ALGORITHM=UNDEFINED */\n/*!50013 SQL SECURITY DEFINER */\n/*!50001 VIEW `v` AS select 1 */;\n",
+			changed: 5,
+		},
+		{
+			name:    "only the selected fixes are applied",
+			dump:    "USE `craft`;\nCREATE DEFINER=`root`@`%` PROCEDURE `p`() SELECT 1;\n",
+			engine:  "mariadb",
+			fixes:   []string{FixDefiners},
+			want:    "USE `craft`;\nCREATE PROCEDURE `p`() SELECT 1;\n",
+			changed: 1,
+		},
+		{
+			name:    "postgres owners and databases",
+			dump:    "CREATE DATABASE craft WITH TEMPLATE = template0;\nALTER DATABASE craft OWNER TO craft;\n\\connect craft\nCREATE TABLE public.t (a text);\nALTER TABLE public.t OWNER TO craft;\nCOPY public.t (a) FROM stdin;\nALTER TABLE x OWNER TO y;\n\\.\n",
+			engine:  "postgres",
+			fixes:   []string{FixDefiners, FixDatabases},
+			want:    "CREATE TABLE public.t (a text);\nCOPY public.t (a) FROM stdin;\nALTER TABLE x OWNER TO y;\n\\.\n",
+			changed: 4,
+		},
+		{
+			name:    "the comments before removed statements are kept",
+			dump:    "-- MySQL dump 10.13  Distrib 8.0.21, for Linux (x86_64)\n--\n-- Host: localhost    Database: craft\n\nSET @@GLOBAL.GTID_PURGED=/*!80000 '+'*/ 'a:1-5';\n\n--\n-- Current Database: `craft`\n--\n\nCREATE DATABASE `craft`;\n\nUSE `craft`;\nCREATE TABLE `t` (`a` text);\n",
+			engine:  "mysql",
+			fixes:   []string{FixDatabases, FixGTID},
+			want:    "-- MySQL dump 10.13  Distrib 8.0.21, for Linux (x86_64)\n--\n-- Host: localhost    Database: craft\n\n\n\n--\n-- Current Database: `craft`\n--\n\n\nCREATE TABLE `t` (`a` text);\n",
+			changed: 3,
+		},
+		{
+			name:    "unknown fixes",
+			engine:  "mysql",
+			fixes:   []string{"everything"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			changed, err := Fix(strings.NewReader(tt.dump), &buf, tt.engine, tt.fixes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fix() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("expected the dump\n%q\ngot\n%q", tt.want, got)
+			}
+
+			if changed != tt.changed {
+				t.Errorf("expected %d statements to be changed, got %d", tt.changed, changed)
+			}
+		})
+	}
+}
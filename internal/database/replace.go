@@ -0,0 +1,272 @@
+package database
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Replacement replaces a string, such as a production URL, in the
+// values of a dump.
+type Replacement struct {
+	From string
+	To   string
+}
+
+// ParseReplacement parses a replacement in the form old=new.
+func ParseReplacement(s string) (Replacement, error) {
+	i := strings.Index(s, "=")
+	if i <= 0 {
+		return Replacement{}, errors.New("the replacement " + strconv.Quote(s) + " must be in the form old=new")
+	}
+
+	return Replacement{From: s[:i], To: s[i+1:]}, nil
+}
+
+// Replace copies the dump for the engine to w with the replacements made
+// in order in the string values. The dump is read one statement at a time
+// and only the values are changed, so the statements and identifiers are
+// left as they are. The lengths of PHP serialized strings are updated so
+// the values can still be unserialized. It returns the number of times
+// each replacement was made, the dump can be discarded to count them.
+func Replace(r io.Reader, w io.Writer, engine string, replacements []Replacement) ([]int, error) {
+	rp := &replacer{replacements: replacements, counts: make([]int, len(replacements)), postgres: engine == "postgres"}
+
+	bw := bufio.NewWriterSize(w, 64*1024)
+	s := newStatements(r, rp.postgres)
+	for {
+		stmt, err := s.next()
+		if err == io.EOF {
+			if _, err := bw.WriteString(stmt); err != nil {
+				return nil, err
+			}
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := bw.WriteString(rp.statement(stmt) + s.end); err != nil {
+			return nil, err
+		}
+
+		// postgres COPY statements are followed by the rows
+		if _, sql := trimComments(stmt, rp.postgres); rp.postgres && keyword(sql, 1) == "COPY" && strings.Contains(strings.ToUpper(sql), "FROM STDIN") {
+			if err := rp.copy(s, bw); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return rp.counts, bw.Flush()
+}
+
+type replacer struct {
+	replacements []Replacement
+	counts       []int
+	postgres     bool
+}
+
+// statement makes the replacements in the string literals of the statement,
+// the comments, identifiers and postgres dollar quoted bodies are skipped.
+func (rp *replacer) statement(stmt string) string {
+	var b strings.Builder
+	start := 0
+	for i := 0; i < len(stmt); {
+		c := stmt[i]
+		switch {
+		case c == '-' && strings.HasPrefix(stmt[i:], "--"), c == '#' && !rp.postgres:
+			i = lineEnd(stmt, i)
+		case c == '/' && strings.HasPrefix(stmt[i:], "/*"):
+			// mysql's versioned comments, such as /*!40000 ALTER TABLE */, do not have values
+			if end := strings.Index(stmt[i+2:], "*/"); end >= 0 {
+				i += end + 4
+			} else {
+				i = len(stmt)
+			}
+		case c == '`' || c == '"':
+			i = skipQuoted(stmt, i, rp.postgres)
+		case c == '$' && rp.postgres:
+			i = rp.skipDollar(stmt, i)
+		case c == '\'':
+			end := skipQuoted(stmt, i, rp.postgres && !escapeString(stmt, i))
+			if value := rp.literal(stmt, i, end); value != stmt[i:end] {
+				b.WriteString(stmt[start:i])
+				b.WriteString(value)
+				start = end
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+
+	if start == 0 {
+		return stmt
+	}
+	b.WriteString(stmt[start:])
+
+	return b.String()
+}
+
+// literal returns the quoted string between i and end with the replacements.
+func (rp *replacer) literal(stmt string, i, end int) string {
+	raw := stmt[i:end]
+	if len(raw) < 2 || raw[len(raw)-1] != '\'' {
+		return raw
+	}
+
+	escaped := !rp.postgres || escapeString(stmt, i)
+	value := raw[1 : len(raw)-1]
+	if escaped {
+		value = unescape(value)
+	} else {
+		value = strings.ReplaceAll(value, "''", "'")
+	}
+
+	replaced := rp.value(value)
+	if replaced == value {
+		return raw
+	}
+
+	switch {
+	case !rp.postgres:
+		return mysqlString(replaced)
+	case escaped:
+		return "'" + pgEscaper.Replace(replaced) + "'"
+	default:
+		return pgString(replaced)
+	}
+}
+
+// copy makes the replacements in the rows of a postgres COPY.
+func (rp *replacer) copy(s *statements, w io.Writer) error {
+	for {
+		line, err := s.rawLine()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		row := strings.TrimRight(line, "\r\n")
+		if row != `\.` && row != "" {
+			fields := strings.Split(row, "\t")
+			for i, f := range fields {
+				if f == `\N` {
+					continue
+				}
+				value := copyUnescape(f)
+				if replaced := rp.value(value); replaced != value {
+					fields[i] = copyEscaper.Replace(replaced)
+				}
+			}
+			line = strings.Join(fields, "\t") + line[len(row):]
+		}
+
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+
+		if row == `\.` {
+			return nil
+		}
+	}
+}
+
+var pgEscaper = strings.NewReplacer(`\`, `\\`, `'`, `''`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+var copyEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// serializedString matches the start of a PHP serialized string, e.g. s:5:"
+var serializedString = regexp.MustCompile(`(?:^|[{;])s:(\d+):"`)
+
+// value makes the replacements in the value, the lengths of PHP serialized
+// strings are updated when the replacements change the length.
+func (rp *replacer) value(v string) string {
+	if !strings.Contains(v, `s:`) {
+		return rp.replace(v)
+	}
+
+	var b strings.Builder
+	start := 0
+	for _, m := range serializedString.FindAllStringSubmatchIndex(v, -1) {
+		// the match can include the { or ; before the s
+		s := m[2] - len("s:")
+		if s < start {
+			continue
+		}
+
+		n, err := strconv.Atoi(v[m[2]:m[3]])
+		end := m[1] + n
+		if err != nil || end+2 > len(v) || v[end:end+2] != `";` {
+			continue
+		}
+
+		// serialized values can have serialized strings in them
+		replaced := rp.value(v[m[1]:end])
+
+		b.WriteString(rp.replace(v[start:s]))
+		b.WriteString(`s:` + strconv.Itoa(len(replaced)) + `:"` + replaced + `";`)
+		start = end + 2
+	}
+
+	if start == 0 {
+		return rp.replace(v)
+	}
+	b.WriteString(rp.replace(v[start:]))
+
+	return b.String()
+}
+
+// replace makes the replacements in order and counts them.
+func (rp *replacer) replace(s string) string {
+	for i, r := range rp.replacements {
+		if r.From == "" {
+			continue
+		}
+		if n := strings.Count(s, r.From); n > 0 {
+			rp.counts[i] += n
+			s = strings.ReplaceAll(s, r.From, r.To)
+		}
+	}
+
+	return s
+}
+
+// skipDollar returns the index after the postgres dollar quoted string at i.
+func (rp *replacer) skipDollar(stmt string, i int) int {
+	j := i + 1
+	for j < len(stmt) && isIdentByte(stmt[j]) && stmt[j] != '$' {
+		j++
+	}
+
+	if j >= len(stmt) || stmt[j] != '$' || (j > i+1 && stmt[i+1] >= '0' && stmt[i+1] <= '9') || (i > 0 && isIdentByte(stmt[i-1])) {
+		return i + 1
+	}
+
+	tag := stmt[i : j+1]
+	if end := strings.Index(stmt[j+1:], tag); end >= 0 {
+		return j + 1 + end + len(tag)
+	}
+
+	return len(stmt)
+}
+
+// escapeString reports if the postgres string at i is an escape string, e.g. E'a\nb'.
+func escapeString(stmt string, i int) bool {
+	return i > 0 && (stmt[i-1] == 'E' || stmt[i-1] == 'e') && (i == 1 || !isIdentByte(stmt[i-2]))
+}
+
+// lineEnd returns the index of the end of the line at i.
+func lineEnd(s string, i int) int {
+	if end := strings.IndexByte(s[i:], '\n'); end >= 0 {
+		return i + end + 1
+	}
+
+	return len(s)
+}
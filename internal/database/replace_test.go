@@ -0,0 +1,119 @@
+package database
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReplace(t *testing.T) {
+	replacements := []Replacement{
+		{From: "https://example.com", To: "http://example.test"},
+		{From: "/var/www/example", To: "/home/ubuntu/sites/example"},
+	}
+
+	tests := []struct {
+		name         string
+		dump         string
+		engine       string
+		replacements []Replacement
+		want         string
+		counts       []int
+	}{
+		{
+			name:   "mysql values are replaced",
+			dump:   "-- https://example.com\nINSERT INTO `https://example.com` VALUES (1,'https://example.com/a','it\\'s /var/www/example');\n",
+			engine: "mysql",
+			want:   "-- https://example.com\nINSERT INTO `https://example.com` VALUES (1,'http://example.test/a','it\\'s /home/ubuntu/sites/example');\n",
+			counts: []int{1, 1},
+		},
+		{
+			name:   "php serialized string lengths are updated",
+			dump:   `INSERT INTO a VALUES ('a:2:{s:3:\"url\";s:23:\"https://example.com/a;b\";i:0;s:27:\"s:19:\"https://example.com\";\";}');`,
+			engine: "mariadb",
+			replacements: []Replacement{
+				{From: "https://example.com", To: "http://example.nitro:80"},
+			},
+			want:   `INSERT INTO a VALUES ('a:2:{s:3:\"url\";s:27:\"http://example.nitro:80/a;b\";i:0;s:31:\"s:23:\"http://example.nitro:80\";\";}');`,
+			counts: []int{2},
+		},
+		{
+			name:   "invalid serialized strings are replaced as text",
+			dump:   `INSERT INTO a VALUES ('s:1:"https://example.com";');`,
+			engine: "mysql",
+			want:   `INSERT INTO a VALUES ('s:1:\"http://example.test\";');`,
+			counts: []int{1, 0},
+		},
+		{
+			name:   "postgres copy rows and escape strings are replaced",
+			dump:   "COPY public.a (id, url) FROM stdin;\n1\thttps://example.com\\t/var/www/example\n2\t\\N\n\\.\n\nINSERT INTO public.a VALUES (3, E'https://example.com\\n', 'it''s https://example.com');\nCREATE FUNCTION f() AS $$ SELECT 'https://example.com'; $$;\n",
+			engine: "postgres",
+			want:   "COPY public.a (id, url) FROM stdin;\n1\thttp://example.test\\t/home/ubuntu/sites/example\n2\t\\N\n\\.\n\nINSERT INTO public.a VALUES (3, E'http://example.test\\n', 'it''s http://example.test');\nCREATE FUNCTION f() AS $$ SELECT 'https://example.com'; $$;\n",
+			counts: []int{3, 1},
+		},
+		{
+			name:   "dumps without the strings are unchanged",
+			dump:   "DELIMITER ;;\nCREATE TRIGGER t BEGIN SET a = 'x'; END;;\nDELIMITER ;\n/*!40101 SET NAMES utf8 */;\n-- end",
+			engine: "mysql",
+			want:   "DELIMITER ;;\nCREATE TRIGGER t BEGIN SET a = 'x'; END;;\nDELIMITER ;\n/*!40101 SET NAMES utf8 */;\n-- end",
+			counts: []int{0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.replacements == nil {
+				tt.replacements = replacements
+			}
+
+			var buf bytes.Buffer
+			counts, err := Replace(strings.NewReader(tt.dump), &buf, tt.engine, tt.replacements)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("expected the dump\n%s\ngot\n%s", tt.want, got)
+			}
+
+			if !reflect.DeepEqual(counts, tt.counts) {
+				t.Errorf("expected the counts %v, got %v", tt.counts, counts)
+			}
+
+			// the counts are the same when the dump is discarded
+			dry, err := Replace(strings.NewReader(tt.dump), ioutil.Discard, tt.engine, tt.replacements)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(dry, tt.counts) {
+				t.Errorf("expected the dry run counts %v, got %v", tt.counts, dry)
+			}
+		})
+	}
+}
+
+func TestParseReplacement(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Replacement
+		wantErr bool
+	}{
+		{value: "https://example.com=http://example.test", want: Replacement{From: "https://example.com", To: "http://example.test"}},
+		{value: "a=b=c", want: Replacement{From: "a", To: "b=c"}},
+		{value: "remove=", want: Replacement{From: "remove"}},
+		{value: "=b", wantErr: true},
+		{value: "missing", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseReplacement(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseReplacement() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseReplacement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
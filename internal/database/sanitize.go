@@ -0,0 +1,507 @@
+package database
+
+import (
+	"bufio"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// the actions of the sanitize rules
+const (
+	// SanitizeEmail replaces the value with a fake email address
+	SanitizeEmail = "email"
+	// SanitizeHash replaces the value with a hash of it
+	SanitizeHash = "hash"
+	// SanitizeNull sets the value to NULL
+	SanitizeNull = "null"
+	// SanitizeConstant sets the value to the value of the rule
+	SanitizeConstant = "constant"
+)
+
+// SanitizeRule anonymises a column, such as the email addresses of users.
+// The fake emails and hashes are made from the value so unique columns stay
+// unique and the same value is always replaced with the same value, whether
+// the rule is applied to a dump or the database.
+type SanitizeRule struct {
+	// Table and Column can be patterns, e.g. fmc_* matches
+	// every table with the prefix fmc_
+	Table  string
+	Column string
+	Action string
+	// Value is used by the constant action
+	Value string
+}
+
+// CraftProfile is the built-in profile for Craft, it removes the
+// personal data of users, their sessions and Formie submissions.
+const CraftProfile = "craft"
+
+// Profile returns the rules of a built-in profile, the prefix is added to
+// the tables of the rules for databases with a table prefix, e.g. craft_.
+func Profile(name, prefix string) ([]SanitizeRule, bool) {
+	if name != CraftProfile {
+		return nil, false
+	}
+
+	rules := []SanitizeRule{
+		{Table: "users", Column: "email", Action: SanitizeEmail},
+		{Table: "users", Column: "unverifiedEmail", Action: SanitizeEmail},
+		// usernames are often the email address
+		{Table: "users", Column: "username", Action: SanitizeEmail},
+		{Table: "users", Column: "firstName", Action: SanitizeNull},
+		{Table: "users", Column: "lastName", Action: SanitizeNull},
+		{Table: "users", Column: "fullName", Action: SanitizeNull},
+		{Table: "users", Column: "password", Action: SanitizeNull},
+		{Table: "users", Column: "verificationCode", Action: SanitizeNull},
+		{Table: "users", Column: "lastLoginAttemptIp", Action: SanitizeNull},
+		{Table: "sessions", Column: "token", Action: SanitizeHash},
+		{Table: "formie_submissions", Column: "title", Action: SanitizeConstant, Value: "Submission"},
+		{Table: "formie_submissions", Column: "ipAddress", Action: SanitizeNull},
+		// Formie 1 stores the fields of the submissions in content tables
+		{Table: "fmc_*", Column: "field_*", Action: SanitizeNull},
+		{Table: "formie_sentnotifications", Column: "to", Action: SanitizeEmail},
+		{Table: "formie_sentnotifications", Column: "cc", Action: SanitizeNull},
+		{Table: "formie_sentnotifications", Column: "bcc", Action: SanitizeNull},
+		{Table: "formie_sentnotifications", Column: "replyTo", Action: SanitizeNull},
+		{Table: "formie_sentnotifications", Column: "body", Action: SanitizeNull},
+		{Table: "formie_sentnotifications", Column: "htmlBody", Action: SanitizeNull},
+		{Table: "formie_sentnotifications", Column: "info", Action: SanitizeNull},
+	}
+
+	for i := range rules {
+		rules[i].Table = prefix + rules[i].Table
+	}
+
+	return rules, true
+}
+
+// Validate checks the table and column patterns and the action.
+func (r SanitizeRule) Validate() error {
+	if r.Table == "" || r.Column == "" {
+		return errors.New("sanitize rules must have a table and a column")
+	}
+
+	for _, p := range []string{r.Table, r.Column} {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("the pattern %q is not valid", p)
+		}
+	}
+
+	switch r.Action {
+	case SanitizeEmail, SanitizeHash, SanitizeNull, SanitizeConstant:
+		return nil
+	}
+
+	return fmt.Errorf("the action %q for %s.%s is not valid, it must be %s, %s, %s or %s", r.Action, r.Table, r.Column, SanitizeEmail, SanitizeHash, SanitizeNull, SanitizeConstant)
+}
+
+// Matches reports if the rule is for the column of the table.
+func (r SanitizeRule) Matches(table, column string) bool {
+	t, _ := path.Match(r.Table, table)
+	c, _ := path.Match(r.Column, column)
+
+	return t && c
+}
+
+// Expression returns the SQL to update the column of the engine, the column is quoted.
+func (r SanitizeRule) Expression(engine, column string) string {
+	postgres := engine == "postgres"
+	switch r.Action {
+	case SanitizeEmail:
+		if postgres {
+			return "'user-' || left(md5(lower(" + column + "::text)), 12) || '@example.test'"
+		}
+		return "CONCAT('user-', LEFT(MD5(LOWER(" + column + ")), 12), '@example.test')"
+	case SanitizeHash:
+		if postgres {
+			return "md5(" + column + "::text)"
+		}
+		return "MD5(" + column + ")"
+	case SanitizeConstant:
+		if postgres {
+			return pgString(r.Value)
+		}
+		return mysqlString(r.Value)
+	}
+
+	return "NULL"
+}
+
+// sanitize returns the new value, nil values are NULL.
+func (r SanitizeRule) sanitize(value *string) *string {
+	switch r.Action {
+	case SanitizeEmail:
+		if value != nil {
+			email := "user-" + md5Hex(strings.ToLower(*value))[:12] + "@example.test"
+			return &email
+		}
+	case SanitizeHash:
+		if value != nil {
+			hash := md5Hex(*value)
+			return &hash
+		}
+	case SanitizeConstant:
+		return &r.Value
+	}
+
+	return nil
+}
+
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// SanitizeReport is the number of values that were changed in each column.
+type SanitizeReport struct {
+	values map[string]int
+	// Tables are the tables in the dump that match the rules
+	Tables []string
+	// Skipped are the tables with rules that have inserts
+	// without a column list or a create table statement
+	Skipped []string
+}
+
+func (r *SanitizeReport) add(table, column string) {
+	if r.values == nil {
+		r.values = make(map[string]int)
+	}
+
+	r.values[table+"."+column]++
+}
+
+// Columns returns the sanitized columns as table.column with the number of values changed.
+func (r *SanitizeReport) Columns() []string {
+	var columns []string
+	for c, n := range r.values {
+		columns = append(columns, fmt.Sprintf("%s (%d)", c, n))
+	}
+	sort.Strings(columns)
+
+	return columns
+}
+
+// Sanitize copies the dump for the engine to w with the rules applied to the
+// values of the inserts and postgres COPY rows. The columns of the tables
+// are read from the create table statements, which are before the data in
+// the dumps, or the column lists of the inserts. The first rule that
+// matches a column is used.
+func Sanitize(r io.Reader, w io.Writer, engine string, rules []SanitizeRule) (*SanitizeReport, error) {
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	sz := &sanitizer{rules: rules, postgres: engine == "postgres", tables: make(map[string][]string), report: &SanitizeReport{}}
+
+	bw := bufio.NewWriterSize(w, 64*1024)
+	s := newStatements(r, sz.postgres)
+	for {
+		stmt, err := s.next()
+		if err == io.EOF {
+			if _, err := bw.WriteString(stmt); err != nil {
+				return nil, err
+			}
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		comments, sql := trimComments(stmt, sz.postgres)
+		switch kw := keyword(sql, 3); {
+		case strings.HasPrefix(kw, "CREATE TABLE"), strings.HasPrefix(kw, "CREATE TEMPORARY TABLE"), strings.HasPrefix(kw, "CREATE UNLOGGED TABLE"):
+			sz.createTable(sql)
+		case strings.HasPrefix(kw, "INSERT"), strings.HasPrefix(kw, "REPLACE"):
+			if sanitized := sz.insert(sql); sanitized != sql {
+				stmt = comments + sanitized
+			}
+		}
+
+		if _, err := bw.WriteString(stmt + s.end); err != nil {
+			return nil, err
+		}
+
+		if sz.postgres && keyword(sql, 1) == "COPY" && strings.Contains(strings.ToUpper(sql), "FROM STDIN") {
+			if err := sz.copy(s, bw, sql); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Strings(sz.report.Tables)
+	sort.Strings(sz.report.Skipped)
+
+	return sz.report, bw.Flush()
+}
+
+type sanitizer struct {
+	rules    []SanitizeRule
+	postgres bool
+	// tables are the columns of the tables in the dump
+	tables map[string][]string
+	report *SanitizeReport
+}
+
+// createTable stores the names of the columns of the table.
+func (sz *sanitizer) createTable(sql string) {
+	rest := sql[strings.Index(strings.ToUpper(sql), "TABLE")+len("TABLE"):]
+	name, rest := sz.name(strings.TrimSpace(rest))
+	sz.matchTable(name)
+
+	open := strings.Index(rest, "(")
+	if open < 0 {
+		return
+	}
+	end := skipParens(rest, open, sz.postgres)
+
+	var columns []string
+	for _, def := range splitList(rest[open+1:end-1], sz.postgres) {
+		switch strings.Fields(strings.ToUpper(def))[0] {
+		case "CONSTRAINT", "PRIMARY", "UNIQUE", "KEY", "INDEX", "FULLTEXT", "SPATIAL", "FOREIGN", "CHECK", "EXCLUDE", "LIKE":
+			continue
+		}
+
+		if column, _ := sz.ident(def); column != "" {
+			columns = append(columns, column)
+		}
+	}
+
+	sz.tables[name] = columns
+}
+
+// insert applies the rules to the values of the insert.
+func (sz *sanitizer) insert(sql string) string {
+	into := strings.Index(strings.ToUpper(sql), "INTO")
+	if into < 0 {
+		return sql
+	}
+
+	start := into + len("INTO")
+	name, rest := sz.name(strings.TrimSpace(sql[start:]))
+	sz.matchTable(name)
+	columns, rest, ok := sz.identList(rest)
+	if !ok {
+		columns = sz.tables[name]
+	}
+
+	rules := sz.columnRules(name, columns)
+	if rules == nil {
+		if columns == nil && sz.hasRules(name) {
+			sz.report.Skipped = appendUnique(sz.report.Skipped, name)
+		}
+		return sql
+	}
+
+	rest = strings.TrimSpace(rest)
+	if !strings.EqualFold(keyword(rest, 1), "VALUES") {
+		return sql
+	}
+	values := strings.TrimSpace(rest[len("VALUES"):])
+
+	tuples, after, err := parseTuples(values, sz.postgres)
+	if err != nil {
+		return sql
+	}
+
+	var rows []string
+	for _, tuple := range tuples {
+		var row []string
+		for i, l := range tuple {
+			if i >= len(rules) || rules[i] == nil {
+				row = append(row, l.raw)
+				continue
+			}
+			rule := rules[i]
+
+			var value *string
+			if !l.null() {
+				value = &l.text
+			}
+
+			sanitized := rule.sanitize(value)
+			switch {
+			case sanitized == nil && value == nil:
+				row = append(row, l.raw)
+				continue
+			case sanitized == nil:
+				row = append(row, "NULL")
+			case sz.postgres:
+				row = append(row, pgString(*sanitized))
+			default:
+				row = append(row, mysqlString(*sanitized))
+			}
+			sz.report.add(name, columns[i])
+		}
+		rows = append(rows, "("+strings.Join(row, ",")+")")
+	}
+
+	prefix := sql[:len(sql)-len(rest)]
+	if after != "" {
+		after = " " + after
+	}
+
+	return prefix + "VALUES " + strings.Join(rows, ",") + after
+}
+
+// copy applies the rules to the rows of a postgres COPY.
+func (sz *sanitizer) copy(s *statements, w io.Writer, sql string) error {
+	name, rest := pgName(strings.TrimSpace(sql[len("COPY"):]), "")
+	sz.matchTable(name)
+	columns, _, ok := identList(rest)
+	if !ok {
+		columns = sz.tables[name]
+	}
+	rules := sz.columnRules(name, columns)
+
+	for {
+		line, err := s.rawLine()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		row := strings.TrimRight(line, "\r\n")
+		if rules != nil && row != `\.` && row != "" {
+			fields := strings.Split(row, "\t")
+			for i, f := range fields {
+				if i >= len(rules) || rules[i] == nil {
+					continue
+				}
+
+				var value *string
+				if f != `\N` {
+					v := copyUnescape(f)
+					value = &v
+				}
+
+				sanitized := rules[i].sanitize(value)
+				switch {
+				case sanitized == nil && value == nil:
+					continue
+				case sanitized == nil:
+					fields[i] = `\N`
+				default:
+					fields[i] = copyEscaper.Replace(*sanitized)
+				}
+				sz.report.add(name, columns[i])
+			}
+			line = strings.Join(fields, "\t") + line[len(row):]
+		}
+
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+
+		if row == `\.` {
+			return nil
+		}
+	}
+}
+
+// columnRules returns the rule for each column, or nil when there are none.
+func (sz *sanitizer) columnRules(table string, columns []string) []*SanitizeRule {
+	var rules []*SanitizeRule
+	found := false
+	for _, c := range columns {
+		var match *SanitizeRule
+		for i := range sz.rules {
+			if sz.rules[i].Matches(table, c) {
+				match = &sz.rules[i]
+				found = true
+				break
+			}
+		}
+		rules = append(rules, match)
+	}
+
+	if !found {
+		return nil
+	}
+
+	return rules
+}
+
+// matchTable adds the table to the report when it matches the rules.
+func (sz *sanitizer) matchTable(table string) {
+	if sz.hasRules(table) {
+		sz.report.Tables = appendUnique(sz.report.Tables, table)
+	}
+}
+
+func (sz *sanitizer) hasRules(table string) bool {
+	for _, r := range sz.rules {
+		if ok, _ := path.Match(r.Table, table); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// name returns the table name without the schema and the rest of the statement.
+func (sz *sanitizer) name(s string) (string, string) {
+	if strings.HasPrefix(strings.ToUpper(s), "IF NOT EXISTS ") {
+		s = strings.TrimSpace(s[len("IF NOT EXISTS"):])
+	}
+
+	name, rest := sz.ident(s)
+	for strings.HasPrefix(rest, ".") {
+		name, rest = sz.ident(rest[1:])
+	}
+
+	return name, rest
+}
+
+// ident returns the quoted or unquoted identifier at the start of s.
+func (sz *sanitizer) ident(s string) (string, string) {
+	return dumpIdent(s, sz.postgres)
+}
+
+// dumpIdent returns the identifier at the start of s, mysql
+// identifiers can be quoted with backticks.
+func dumpIdent(s string, postgres bool) (string, string) {
+	s = strings.TrimSpace(s)
+	if !postgres && strings.HasPrefix(s, "`") {
+		end := skipQuoted(s, 0, false)
+		return mysqlUnquote(s[:end]), s[end:]
+	}
+
+	return pgIdent(s)
+}
+
+// identList returns the identifiers in parentheses at the start of s.
+func (sz *sanitizer) identList(s string) ([]string, string, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "(") {
+		return nil, s, false
+	}
+
+	end := skipParens(s, 0, sz.postgres)
+	var names []string
+	for _, item := range splitList(s[1:end-1], sz.postgres) {
+		name, _ := sz.ident(item)
+		names = append(names, name)
+	}
+
+	return names, s[end:], true
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
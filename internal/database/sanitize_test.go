@@ -0,0 +1,144 @@
+package database
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	rules := []SanitizeRule{
+		{Table: "users", Column: "email", Action: SanitizeEmail},
+		{Table: "users", Column: "password", Action: SanitizeNull},
+		{Table: "users", Column: "firstName", Action: SanitizeConstant, Value: "O'Brien"},
+		{Table: "sessions", Column: "token", Action: SanitizeHash},
+		{Table: "fmc_*", Column: "field_*", Action: SanitizeNull},
+	}
+
+	email := "'user-" + md5Hex("admin@example.com")[:12] + "@example.test'"
+
+	tests := []struct {
+		name    string
+		dump    string
+		engine  string
+		want    string
+		columns []string
+		tables  []string
+		skipped []string
+	}{
+		{
+			name:    "mysql inserts use the columns of the create table statement",
+			dump:    "CREATE TABLE `users` (\n  `id` int(11) NOT NULL,\n  `email` varchar(255) NOT NULL,\n  `password` varchar(255),\n  `firstName` varchar(255),\n  PRIMARY KEY (`id`)\n);\nINSERT INTO `users` VALUES (1,'Admin@example.com','$2y$13$hash',NULL),(2,'it\\'s','x','Jo');\nINSERT INTO `entries` VALUES (1,'admin@example.com');\n",
+			engine:  "mysql",
+			want:    "CREATE TABLE `users` (\n  `id` int(11) NOT NULL,\n  `email` varchar(255) NOT NULL,\n  `password` varchar(255),\n  `firstName` varchar(255),\n  PRIMARY KEY (`id`)\n);\nINSERT INTO `users` VALUES (1," + email + ",NULL,'O\\'Brien'),(2,'user-" + md5Hex("it's")[:12] + "@example.test',NULL,'O\\'Brien');\nINSERT INTO `entries` VALUES (1,'admin@example.com');\n",
+			columns: []string{"users.email (2)", "users.firstName (2)", "users.password (2)"},
+			tables:  []string{"users"},
+		},
+		{
+			name:    "mysql inserts with column lists and patterns",
+			dump:    "INSERT INTO `fmc_contact` (`id`, `field_name`, `title`) VALUES (1,'Jane','Hi');\nINSERT INTO `users` VALUES (1,'a@b.c');",
+			engine:  "mariadb",
+			want:    "INSERT INTO `fmc_contact` (`id`, `field_name`, `title`) VALUES (1,NULL,'Hi');\nINSERT INTO `users` VALUES (1,'a@b.c');",
+			columns: []string{"fmc_contact.field_name (1)"},
+			tables:  []string{"fmc_contact", "users"},
+			skipped: []string{"users"},
+		},
+		{
+			name:    "postgres copy rows and inserts",
+			dump:    "CREATE TABLE public.sessions (\n    id integer NOT NULL,\n    token character(100) NOT NULL\n);\nCOPY public.sessions (id, token) FROM stdin;\n1\tsecret\n2\t\\N\n\\.\n\nINSERT INTO public.users (id, email, password) VALUES (1, 'Admin@example.com', 'x'::text);\n",
+			engine:  "postgres",
+			want:    "CREATE TABLE public.sessions (\n    id integer NOT NULL,\n    token character(100) NOT NULL\n);\nCOPY public.sessions (id, token) FROM stdin;\n1\t" + md5Hex("secret") + "\n2\t\\N\n\\.\n\nINSERT INTO public.users (id, email, password) VALUES (1," + email + ",NULL);\n",
+			columns: []string{"sessions.token (1)", "users.email (1)", "users.password (1)"},
+			tables:  []string{"sessions", "users"},
+		},
+		{
+			name:   "tables with a prefix do not match",
+			dump:   "CREATE TABLE `craft_users` (\n  `id` int(11) NOT NULL,\n  `email` varchar(255) NOT NULL\n);\nINSERT INTO `craft_users` VALUES (1,'admin@example.com');\n",
+			engine: "mysql",
+			want:   "CREATE TABLE `craft_users` (\n  `id` int(11) NOT NULL,\n  `email` varchar(255) NOT NULL\n);\nINSERT INTO `craft_users` VALUES (1,'admin@example.com');\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			report, err := Sanitize(strings.NewReader(tt.dump), &buf, tt.engine, rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("expected the dump\n%s\ngot\n%s", tt.want, got)
+			}
+
+			if got := strings.Join(report.Columns(), ", "); got != strings.Join(tt.columns, ", ") {
+				t.Errorf("expected the columns %v, got %v", tt.columns, report.Columns())
+			}
+
+			if got := strings.Join(report.Tables, ", "); got != strings.Join(tt.tables, ", ") {
+				t.Errorf("expected the tables %v, got %v", tt.tables, report.Tables)
+			}
+
+			if got := strings.Join(report.Skipped, ", "); got != strings.Join(tt.skipped, ", ") {
+				t.Errorf("expected the skipped tables %v, got %v", tt.skipped, report.Skipped)
+			}
+		})
+	}
+}
+
+func TestSanitizeRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    SanitizeRule
+		wantErr bool
+	}{
+		{name: "valid rules", rule: SanitizeRule{Table: "fmc_*", Column: "field_*", Action: SanitizeNull}},
+		{name: "unknown actions", rule: SanitizeRule{Table: "users", Column: "email", Action: "shuffle"}, wantErr: true},
+		{name: "missing columns", rule: SanitizeRule{Table: "users", Action: SanitizeNull}, wantErr: true},
+		{name: "invalid patterns", rule: SanitizeRule{Table: "users[", Column: "email", Action: SanitizeNull}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProfile(t *testing.T) {
+	rules, ok := Profile(CraftProfile, "")
+	if !ok || len(rules) == 0 {
+		t.Fatal("expected the craft profile to have rules")
+	}
+
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if _, ok := Profile("unknown", ""); ok {
+		t.Error("expected unknown profiles to not exist")
+	}
+}
+
+func TestProfile_TablePrefix(t *testing.T) {
+	rules, _ := Profile(CraftProfile, "craft_")
+
+	dump := "CREATE TABLE `craft_users` (\n  `id` int(11) NOT NULL,\n  `email` varchar(255) NOT NULL\n);\nINSERT INTO `craft_users` VALUES (1,'admin@example.com');\nINSERT INTO `craft_fmc_contact` (`id`, `field_name`) VALUES (1,'Jane');\n"
+
+	var buf bytes.Buffer
+	report, err := Sanitize(strings.NewReader(dump), &buf, "mysql", rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(buf.String(), "admin@example.com") || strings.Contains(buf.String(), "Jane") {
+		t.Errorf("expected the tables with the prefix to be sanitized, got\n%s", buf.String())
+	}
+
+	want := "craft_fmc_contact, craft_users"
+	if got := strings.Join(report.Tables, ", "); got != want {
+		t.Errorf("expected the tables %v, got %v", want, got)
+	}
+}
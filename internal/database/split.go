@@ -0,0 +1,196 @@
+package database
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// Dump is one of the databases split from a dump of every database.
+type Dump struct {
+	Database string
+	Path     string
+}
+
+// systemDatabases are not split from dumps of every database.
+var systemDatabases = map[string][]string{
+	"mysql":    {"information_schema", "mysql", "performance_schema", "sys"},
+	"mariadb":  {"information_schema", "mysql", "performance_schema", "sys"},
+	"postgres": {"postgres", "template0", "template1"},
+}
+
+// dropped are the statements removed from each database so
+// the database can be imported using any name.
+var dropped = map[string][]string{
+	"mysql":    {"CREATE DATABASE ", "USE `"},
+	"mariadb":  {"CREATE DATABASE ", "USE `"},
+	"postgres": {"CREATE DATABASE ", "ALTER DATABASE ", "DROP DATABASE ", "COMMENT ON DATABASE "},
+}
+
+// Split writes each database in a dump of every database, created with
+// mysqldump --all-databases or pg_dumpall, to a file in the directory. The
+// statements that create and select the database are removed and system
+// databases are skipped. MySQL databases also get the header of the dump
+// with the session settings. The files are left for the caller to remove.
+func Split(r io.Reader, engine, dir string) ([]Dump, error) {
+	if _, ok := systemDatabases[engine]; !ok {
+		return nil, fmt.Errorf("unable to split %q dumps", engine)
+	}
+
+	s := &splitter{engine: engine, dir: dir, files: make(map[string]*os.File)}
+	defer s.close()
+
+	br := bufio.NewReaderSize(r, 64*1024)
+	start := true
+	skip := false
+	for {
+		// long lines are read in parts, only the start of a line is checked
+		line, err := br.ReadSlice('\n')
+		if len(line) > 0 {
+			if start {
+				var lineErr error
+				if skip, lineErr = s.line(line); lineErr != nil {
+					return nil, lineErr
+				}
+			}
+
+			if !skip && s.current != nil {
+				if _, err := s.current.Write(line); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		start = err != bufio.ErrBufferFull
+		if err == io.EOF {
+			break
+		}
+		if err != nil && err != bufio.ErrBufferFull {
+			return nil, err
+		}
+	}
+
+	if err := s.close(); err != nil {
+		return nil, err
+	}
+
+	return s.dumps, nil
+}
+
+type splitter struct {
+	engine  string
+	dir     string
+	header  bytes.Buffer
+	started bool
+	current *os.File
+	files   map[string]*os.File
+	dumps   []Dump
+}
+
+// line checks the start of a line for a new database and returns
+// true when the line is not written to the current database.
+func (s *splitter) line(line []byte) (bool, error) {
+	text := string(line)
+
+	var database string
+	switch s.engine {
+	case "mysql", "mariadb":
+		if !strings.HasPrefix(text, "-- Current Database: ") {
+			if !s.started {
+				s.header.Write(line)
+			}
+			break
+		}
+		database = unquote(strings.TrimSpace(strings.TrimPrefix(text, "-- Current Database: ")), '`')
+	default:
+		if !strings.HasPrefix(text, `\connect `) {
+			break
+		}
+		database = connectDatabase(strings.TrimSpace(strings.TrimPrefix(text, `\connect `)))
+	}
+
+	if database != "" {
+		s.started = true
+		return true, s.open(database)
+	}
+
+	for _, prefix := range dropped[s.engine] {
+		if strings.HasPrefix(text, prefix) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// open makes the database the current file, the lines
+// of system databases are skipped.
+func (s *splitter) open(database string) error {
+	s.current = nil
+	for _, system := range systemDatabases[s.engine] {
+		if database == system {
+			return nil
+		}
+	}
+
+	if f, ok := s.files[database]; ok {
+		s.current = f
+		return nil
+	}
+
+	f, err := ioutil.TempFile(s.dir, "dump-*.sql")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(s.header.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+
+	s.files[database] = f
+	s.current = f
+	s.dumps = append(s.dumps, Dump{Database: database, Path: f.Name()})
+
+	return nil
+}
+
+func (s *splitter) close() error {
+	var err error
+	for name, f := range s.files {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		delete(s.files, name)
+	}
+
+	return err
+}
+
+// connectDatabase returns the database from the arguments of a psql \connect,
+// pg_dumpall uses -reuse-previous=on "dbname='name'" for some names.
+func connectDatabase(args string) string {
+	if strings.HasPrefix(args, "-reuse-previous=on ") {
+		conn := unquote(strings.TrimSpace(strings.TrimPrefix(args, "-reuse-previous=on ")), '"')
+		conn = strings.TrimPrefix(conn, "dbname=")
+
+		return strings.ReplaceAll(unquote(conn, '\''), `\'`, "'")
+	}
+
+	return unquote(args, '"')
+}
+
+// unquote removes the quotes around a name and the quotes escaped by doubling.
+func unquote(name string, quote byte) string {
+	if len(name) < 2 || name[0] != quote || name[len(name)-1] != quote {
+		return name
+	}
+
+	q := string(quote)
+
+	return strings.ReplaceAll(name[1:len(name)-1], q+q, q)
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		engine string
+		want   map[string][]string
+		absent []string
+	}{
+		{
+			name:   "mysql dumps of every database are split",
+			file:   "./testdata/mysql-all-dbs.sql",
+			engine: "mysql",
+			want: map[string][]string{
+				"craft":   {"/*!40101 SET NAMES utf8 */;", "INSERT INTO `entries` VALUES (1),(2);"},
+				"my`blog": {"/*!40101 SET NAMES utf8 */;", "CREATE TABLE `posts` (`id` int(11) NOT NULL);"},
+			},
+			absent: []string{"CREATE DATABASE", "USE `", "`user`", "-- Current Database"},
+		},
+		{
+			name:   "postgres dumps of every database are split",
+			file:   "./testdata/postgres-all-dbs.sql",
+			engine: "postgres",
+			want: map[string][]string{
+				"craft":   {"CREATE TABLE public.entries (id integer NOT NULL);", "\\."},
+				"my blog": {"CREATE TABLE public.posts (id integer NOT NULL);"},
+			},
+			absent: []string{"CREATE DATABASE", "ALTER DATABASE", "\\connect", "CREATE ROLE", "ignored"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "nitro-split-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			f, err := os.Open(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			dumps, err := Split(f, tt.engine, dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+			for _, d := range dumps {
+				names = append(names, d.Database)
+			}
+
+			var wantNames []string
+			for name := range tt.want {
+				wantNames = append(wantNames, name)
+			}
+			if len(names) != len(wantNames) {
+				t.Fatalf("Split() databases = %v, want %v", names, wantNames)
+			}
+
+			for _, d := range dumps {
+				b, err := ioutil.ReadFile(d.Path)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				want, ok := tt.want[d.Database]
+				if !ok {
+					t.Errorf("unexpected database %q", d.Database)
+					continue
+				}
+
+				for _, line := range want {
+					if !strings.Contains(string(b), line+"\n") {
+						t.Errorf("expected %q to contain %q, got:\n%s", d.Database, line, b)
+					}
+				}
+				for _, s := range tt.absent {
+					if strings.Contains(string(b), s) {
+						t.Errorf("expected %q to not contain %q, got:\n%s", d.Database, s, b)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSplit_LongLines(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nitro-split-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// extended inserts can be longer than the read buffer
+	insert := "INSERT INTO `entries` VALUES " + strings.Repeat("(1),", 100000) + "(1);\n"
+	dump := "-- Current Database: `craft`\nUSE `craft`;\n" + insert
+
+	dumps, err := Split(strings.NewReader(dump), "mysql", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dumps) != 1 {
+		t.Fatalf("expected one database, got %v", dumps)
+	}
+
+	b, err := ioutil.ReadFile(dumps[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != insert {
+		t.Errorf("expected the insert to be written in full, got %d bytes", len(b))
+	}
+}
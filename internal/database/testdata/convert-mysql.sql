@@ -0,0 +1,41 @@
+-- MySQL dump 10.13  Distrib 5.7.29, for Linux (x86_64)
+--
+-- Host: localhost    Database: craft
+-- ------------------------------------------------------
+
+/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;
+/*!40101 SET NAMES utf8 */;
+/*!40014 SET @OLD_FOREIGN_KEY_CHECKS=@@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS=0 */;
+
+DROP TABLE IF EXISTS `entries`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+CREATE TABLE `entries` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `title` varchar(255) COLLATE utf8_unicode_ci DEFAULT NULL,
+  `body` text COLLATE utf8_unicode_ci,
+  `enabled` tinyint(1) NOT NULL DEFAULT '1',
+  `status` enum('live','pending') NOT NULL DEFAULT 'live',
+  `postDate` datetime DEFAULT NULL,
+  `uid` char(36) NOT NULL DEFAULT '0',
+  `data` blob,
+  `dateUpdated` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `authorId` int(11) unsigned DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_uid` (`uid`),
+  KEY `idx_title` (`title`(191)),
+  FULLTEXT KEY `idx_body` (`body`),
+  CONSTRAINT `fk_author` FOREIGN KEY (`authorId`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB AUTO_INCREMENT=3 DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+LOCK TABLES `entries` WRITE;
+/*!40000 ALTER TABLE `entries` DISABLE KEYS */;
+INSERT INTO `entries` VALUES (1,'It\'s; here','line\none',1,'live','0000-00-00 00:00:00','a',_binary 'ab',NULL,NULL),(2,'Two','',0,'pending','2020-05-01 10:00:00','b',NULL,'2020-05-01 10:00:00',1);
+/*!40000 ALTER TABLE `entries` ENABLE KEYS */;
+UNLOCK TABLES;
+
+/*!50001 CREATE ALGORITHM=UNDEFINED VIEW `live` AS select `id` from `entries` */;
+
+DELIMITER ;;
+/*!50003 CREATE TRIGGER `touch` BEFORE UPDATE ON `entries` FOR EACH ROW SET NEW.title = 'x' */;;
+DELIMITER ;
@@ -0,0 +1,22 @@
+--
+-- PostgreSQL tables with the primary keys in the create table statements
+--
+
+CREATE TABLE public.users (
+    id serial PRIMARY KEY,
+    email character varying(255) DEFAULT NULL,
+    "lastLogin" timestamp without time zone DEFAULT NULL
+);
+
+CREATE TABLE public.sessions (
+    id bigint GENERATED BY DEFAULT AS IDENTITY (START WITH 1 INCREMENT BY 1) PRIMARY KEY,
+    token character(32) NOT NULL
+);
+
+CREATE TABLE public.tags (
+    id integer NOT NULL DEFAULT nextval('public.tags_id_seq'::regclass),
+    name character varying(255) NOT NULL,
+    CONSTRAINT tags_pkey PRIMARY KEY (id)
+);
+
+INSERT INTO public.users VALUES (1, 'one@example.test', NULL);
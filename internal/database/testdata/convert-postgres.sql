@@ -0,0 +1,59 @@
+--
+-- PostgreSQL database dump
+--
+
+SET statement_timeout = 0;
+SET standard_conforming_strings = on;
+SELECT pg_catalog.set_config('search_path', '', false);
+
+CREATE FUNCTION public.touch() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    NEW.title := 'x;y';
+    RETURN NEW;
+END;
+$$;
+
+CREATE TABLE public.entries (
+    id integer NOT NULL,
+    title character varying(255) DEFAULT NULL::character varying,
+    body text,
+    enabled boolean DEFAULT true NOT NULL,
+    "postDate" timestamp(0) without time zone,
+    "dateUpdated" timestamp with time zone DEFAULT now() NOT NULL,
+    uid uuid,
+    data bytea
+);
+
+ALTER TABLE public.entries OWNER TO nitro;
+
+CREATE SEQUENCE public.entries_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE public.entries_id_seq OWNED BY public.entries.id;
+
+ALTER TABLE ONLY public.entries ALTER COLUMN id SET DEFAULT nextval('public.entries_id_seq'::regclass);
+
+COPY public.entries (id, title, body, enabled, "postDate", "dateUpdated", uid, data) FROM stdin;
+1	It's; here	line\none	t	2020-05-01 10:00:00	2020-05-01 10:00:00+00	0b6e1e6a-4f4e-4b0f-9a6e-2a4d4e5f6a7b	\\x6162
+2	Two	\N	f	\N	2020-05-02 10:00:00+02	\N	\N
+\.
+
+INSERT INTO public.entries VALUES (3, 'Three', NULL, false, NULL, '2020-05-03 10:00:00+00', NULL, NULL);
+
+SELECT pg_catalog.setval('public.entries_id_seq', 3, true);
+
+ALTER TABLE ONLY public.entries
+    ADD CONSTRAINT entries_pkey PRIMARY KEY (id);
+
+CREATE INDEX idx_title ON public.entries USING btree (title);
+
+CREATE INDEX idx_lower ON public.entries USING btree (lower(title));
+
+CREATE TRIGGER touch BEFORE UPDATE ON public.entries FOR EACH ROW EXECUTE PROCEDURE public.touch();
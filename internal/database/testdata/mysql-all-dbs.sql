@@ -0,0 +1,44 @@
+-- MySQL dump 10.13  Distrib 5.7.32, for Linux (x86_64)
+--
+-- Host: localhost    Database: 
+-- ------------------------------------------------------
+-- Server version	5.7.32
+
+/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;
+/*!40101 SET NAMES utf8 */;
+
+--
+-- Current Database: `craft`
+--
+
+CREATE DATABASE /*!32312 IF NOT EXISTS*/ `craft` /*!40100 DEFAULT CHARACTER SET utf8mb4 */;
+
+USE `craft`;
+
+DROP TABLE IF EXISTS `entries`;
+CREATE TABLE `entries` (`id` int(11) NOT NULL);
+INSERT INTO `entries` VALUES (1),(2);
+
+--
+-- Current Database: `mysql`
+--
+
+CREATE DATABASE /*!32312 IF NOT EXISTS*/ `mysql` /*!40100 DEFAULT CHARACTER SET latin1 */;
+
+USE `mysql`;
+
+DROP TABLE IF EXISTS `user`;
+
+--
+-- Current Database: `my``blog`
+--
+
+CREATE DATABASE /*!32312 IF NOT EXISTS*/ `my``blog` /*!40100 DEFAULT CHARACTER SET utf8mb4 */;
+
+USE `my``blog`;
+
+DROP TABLE IF EXISTS `posts`;
+CREATE TABLE `posts` (`id` int(11) NOT NULL);
+/*!40101 SET CHARACTER_SET_CLIENT=@OLD_CHARACTER_SET_CLIENT */;
+
+-- Dump completed on 2020-11-18  2:03:04
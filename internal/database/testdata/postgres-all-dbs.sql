@@ -0,0 +1,73 @@
+--
+-- PostgreSQL database cluster dump
+--
+
+SET default_transaction_read_only = off;
+
+--
+-- Roles
+--
+
+CREATE ROLE nitro;
+ALTER ROLE nitro WITH SUPERUSER INHERIT CREATEROLE CREATEDB LOGIN REPLICATION BYPASSRLS;
+
+\connect template1
+
+--
+-- PostgreSQL database dump
+--
+
+SET statement_timeout = 0;
+
+--
+-- Database "craft" dump
+--
+
+--
+-- PostgreSQL database dump
+--
+
+SET statement_timeout = 0;
+
+--
+-- Name: craft; Type: DATABASE; Schema: -; Owner: nitro
+--
+
+CREATE DATABASE craft WITH TEMPLATE = template0 ENCODING = 'UTF8' LC_COLLATE = 'en_US.utf8' LC_CTYPE = 'en_US.utf8';
+
+
+ALTER DATABASE craft OWNER TO nitro;
+
+\connect craft
+
+SET statement_timeout = 0;
+CREATE TABLE public.entries (id integer NOT NULL);
+COPY public.entries (id) FROM stdin;
+1
+2
+\.
+
+--
+-- Database "my blog" dump
+--
+
+CREATE DATABASE "my blog" WITH TEMPLATE = template0 ENCODING = 'UTF8';
+
+
+ALTER DATABASE "my blog" OWNER TO nitro;
+
+\connect -reuse-previous=on "dbname='my blog'"
+
+CREATE TABLE public.posts (id integer NOT NULL);
+
+--
+-- Database "postgres" dump
+--
+
+\connect postgres
+
+CREATE TABLE public.ignored (id integer NOT NULL);
+
+--
+-- PostgreSQL database cluster dump complete
+--
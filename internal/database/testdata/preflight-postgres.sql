@@ -0,0 +1,34 @@
+--
+-- PostgreSQL database dump
+--
+
+-- Dumped from database version 13.2 (Debian 13.2-1.pgdg100+1)
+-- Dumped by pg_dump version 13.2 (Debian 13.2-1.pgdg100+1)
+
+SET statement_timeout = 0;
+SET client_encoding = 'UTF8';
+
+CREATE DATABASE craft WITH TEMPLATE = template0 ENCODING = 'UTF8' LOCALE = 'en_US.utf8';
+
+ALTER DATABASE craft OWNER TO craft;
+
+\connect craft
+
+SET default_table_access_method = heap;
+
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    email character varying(255) NOT NULL
+);
+
+ALTER TABLE public.users OWNER TO craft;
+
+COPY public.users (id, email) FROM stdin;
+1	ALTER TABLE x OWNER TO y;
+\.
+
+CREATE TABLE public.entries (
+    id integer NOT NULL
+);
+
+ALTER TABLE public.entries OWNER TO "Deploy";
@@ -21,6 +21,12 @@ func Parse(t time.Time) string {
 	return y[len(y)-2:] + m + d + "_" + h + mi + s
 }
 
+// Time returns the local time from the format created by Parse,
+// e.g. when reading the date from the name of a backup.
+func Time(s string) (time.Time, error) {
+	return time.ParseInLocation("060102_150405", s, time.Local)
+}
+
 func prepend(s string) string {
 	if len(s) == 1 {
 		return "0" + s
@@ -32,3 +32,20 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestTime(t *testing.T) {
+	want := time.Date(2020, 1, 2, 8, 1, 2, 0, time.Local)
+
+	got, err := Time(Parse(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Equal(want) {
+		t.Errorf("Time() = %v, want %v", got, want)
+	}
+
+	if _, err := Time("2020-01-02"); err == nil {
+		t.Error("expected an error for an invalid format")
+	}
+}
@@ -0,0 +1,325 @@
+// Package encrypt encrypts and decrypts backups using the age and gpg
+// commands. Backups are encrypted with a public key, either an age
+// recipient or a GPG key, or with a passphrase using gpg.
+package encrypt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// Age is the format of files encrypted with age.
+	Age = "age"
+	// GPG is the format of files encrypted with gpg.
+	GPG = "gpg"
+)
+
+// Options are the keys used to encrypt or decrypt a file.
+type Options struct {
+	// Recipient is the public key to encrypt with, an age recipient
+	// such as age1... or an SSH public key, or an armored GPG key
+	Recipient string
+	// Passphrase encrypts the file with gpg instead of a public key
+	Passphrase string
+	// Identity is the age identity file used to decrypt, files encrypted
+	// with a GPG key are decrypted using the keys in the GPG keyring
+	Identity string
+}
+
+// Enabled reports if the options encrypt the file.
+func (o Options) Enabled() bool {
+	return o.Recipient != "" || o.Passphrase != ""
+}
+
+// Format returns the format the options encrypt with.
+func (o Options) Format() string {
+	switch {
+	case o.Passphrase != "":
+		return GPG
+	case o.Recipient == "":
+		return ""
+	case IsAgeRecipient(o.Recipient):
+		return Age
+	}
+
+	return GPG
+}
+
+// Method describes how the options encrypt the file, for the manifest.
+func (o Options) Method() string {
+	switch {
+	case o.Passphrase != "":
+		return "gpg-passphrase"
+	case o.Recipient == "":
+		return ""
+	}
+
+	return o.Format() + "-public-key"
+}
+
+// IsAgeRecipient reports if the recipient is an age
+// recipient or an SSH public key used by age.
+func IsAgeRecipient(recipient string) bool {
+	return strings.HasPrefix(recipient, "age1") || strings.HasPrefix(recipient, "ssh-")
+}
+
+// Recipient returns the recipient to encrypt with. Age recipients are
+// returned as they are, otherwise the recipient is the ID or email of
+// a key in the GPG keyring and the armored public key is returned so
+// the key is not needed in the keyring of the machine.
+func Recipient(recipient string) (string, error) {
+	if recipient == "" || IsAgeRecipient(recipient) || strings.HasPrefix(recipient, "-----BEGIN PGP") {
+		return recipient, nil
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("gpg", "--batch", "--armor", "--export", recipient)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("unable to export the GPG key %q: %s", recipient, strings.TrimSpace(stderr.String()))
+	}
+	if len(out) == 0 {
+		return "", fmt.Errorf("there is no GPG key %q in the keyring", recipient)
+	}
+
+	return string(out), nil
+}
+
+// Detect returns the encryption format of the data using the
+// start of the file, or an empty string if it is not encrypted.
+func Detect(b []byte) string {
+	switch {
+	case bytes.HasPrefix(b, []byte("age-encryption.org/")), bytes.HasPrefix(b, []byte("-----BEGIN AGE ENCRYPTED FILE-----")):
+		return Age
+	case bytes.HasPrefix(b, []byte("-----BEGIN PGP MESSAGE-----")):
+		return GPG
+	case len(b) > 0 && b[0]&0x80 != 0:
+		// a binary OpenPGP message starts with a public key or a
+		// passphrase encrypted session key packet, tag 1 or 3
+		tag := (b[0] >> 2) & 0x0f
+		if b[0]&0x40 != 0 {
+			tag = b[0] & 0x3f
+		}
+		if tag == 1 || tag == 3 {
+			return GPG
+		}
+	}
+
+	return ""
+}
+
+// IsSymmetric reports if the start of a file encrypted with gpg
+// is a session key encrypted with a passphrase, tag 3.
+func IsSymmetric(b []byte) bool {
+	if len(b) == 0 || b[0]&0x80 == 0 {
+		return false
+	}
+
+	if b[0]&0x40 != 0 {
+		return b[0]&0x3f == 3
+	}
+
+	return (b[0]>>2)&0x0f == 3
+}
+
+// NewWriter returns a writer that encrypts what is written to w. The
+// writer must be closed to finish the file.
+func NewWriter(w io.Writer, opts Options) (io.WriteCloser, error) {
+	dir, err := ioutil.TempDir("", "nitro-encrypt-")
+	if err != nil {
+		return nil, err
+	}
+
+	var cmd *exec.Cmd
+	switch opts.Format() {
+	case Age:
+		cmd = exec.Command("age", "--encrypt", "--recipient", opts.Recipient)
+	case GPG:
+		// a new home directory is used so the key does not need to
+		// be imported and the keyring is not changed
+		args := []string{"--batch", "--yes", "--quiet", "--homedir", filepath.Join(dir, "gnupg")}
+		if opts.Passphrase != "" {
+			file, err := writeSecret(dir, "passphrase", opts.Passphrase)
+			if err != nil {
+				os.RemoveAll(dir)
+				return nil, err
+			}
+			args = append(args, "--pinentry-mode", "loopback", "--passphrase-file", file, "--symmetric", "--cipher-algo", "AES256")
+		} else {
+			file, err := writeSecret(dir, "key.asc", opts.Recipient)
+			if err != nil {
+				os.RemoveAll(dir)
+				return nil, err
+			}
+			args = append(args, "--trust-model", "always", "--recipient-file", file, "--encrypt")
+		}
+
+		if err := os.Mkdir(filepath.Join(dir, "gnupg"), 0700); err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+		cmd = exec.Command("gpg", args...)
+	default:
+		os.RemoveAll(dir)
+		return nil, errors.New("there is no recipient or passphrase to encrypt with")
+	}
+
+	c := &cmdWriter{cmd: cmd, dir: dir}
+	cmd.Stdout = w
+	cmd.Stderr = &c.stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	c.stdin = stdin
+
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("unable to run %s: %w", cmd.Args[0], err)
+	}
+
+	return c, nil
+}
+
+// NewReader returns a reader that decrypts r using the format returned by
+// Detect. Files encrypted with a gpg passphrase need the passphrase, age
+// asks for the passphrase itself when there is no identity.
+func NewReader(r io.Reader, format string, opts Options) (io.ReadCloser, error) {
+	dir, err := ioutil.TempDir("", "nitro-decrypt-")
+	if err != nil {
+		return nil, err
+	}
+
+	var cmd *exec.Cmd
+	switch format {
+	case Age:
+		args := []string{"--decrypt"}
+		if opts.Identity != "" {
+			args = append(args, "--identity", opts.Identity)
+		}
+		cmd = exec.Command("age", args...)
+	case GPG:
+		args := []string{"--batch", "--quiet"}
+		if opts.Passphrase != "" {
+			file, err := writeSecret(dir, "passphrase", opts.Passphrase)
+			if err != nil {
+				os.RemoveAll(dir)
+				return nil, err
+			}
+			args = append(args, "--pinentry-mode", "loopback", "--passphrase-file", file)
+		}
+		cmd = exec.Command("gpg", append(args, "--decrypt")...)
+	default:
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("unable to decrypt %q files", format)
+	}
+
+	c := &cmdReader{cmd: cmd, dir: dir}
+	cmd.Stdin = r
+	cmd.Stderr = &c.stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	c.stdout = stdout
+
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("unable to run %s: %w", cmd.Args[0], err)
+	}
+
+	return c, nil
+}
+
+// writeSecret writes the value to a file only the user can read.
+func writeSecret(dir, name, value string) (string, error) {
+	file := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(file, []byte(value), 0600); err != nil {
+		return "", err
+	}
+
+	return file, nil
+}
+
+// cmdWriter writes to the stdin of a command that encrypts it.
+type cmdWriter struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stderr bytes.Buffer
+	dir    string
+}
+
+func (c *cmdWriter) Write(p []byte) (int, error) {
+	return c.stdin.Write(p)
+}
+
+// Close waits for the command to finish writing the file.
+func (c *cmdWriter) Close() error {
+	defer os.RemoveAll(c.dir)
+
+	err := c.stdin.Close()
+	if werr := c.cmd.Wait(); werr != nil {
+		return fmt.Errorf("unable to encrypt the file: %s", strings.TrimSpace(c.stderr.String()))
+	}
+
+	return err
+}
+
+// cmdReader reads the output of a command that decrypts its stdin.
+type cmdReader struct {
+	cmd    *exec.Cmd
+	stdout io.ReadCloser
+	stderr bytes.Buffer
+	dir    string
+	done   bool
+	err    error
+}
+
+// Read returns the error from the command, instead of io.EOF, when the
+// command fails so a wrong key or a corrupt file is not used.
+func (c *cmdReader) Read(p []byte) (int, error) {
+	// the output is closed once the command has finished
+	if c.done {
+		return 0, c.err
+	}
+
+	n, err := c.stdout.Read(p)
+	if err == io.EOF {
+		c.done = true
+		defer os.RemoveAll(c.dir)
+		if werr := c.cmd.Wait(); werr != nil {
+			err = fmt.Errorf("unable to decrypt the file: %s", strings.TrimSpace(c.stderr.String()))
+		}
+		c.err = err
+	}
+
+	return n, err
+}
+
+// Close stops the command if the output was not read to the end.
+func (c *cmdReader) Close() error {
+	if c.done {
+		return nil
+	}
+	c.done = true
+	c.err = io.ErrClosedPipe
+
+	_ = c.cmd.Process.Kill()
+	_ = c.cmd.Wait()
+
+	return os.RemoveAll(c.dir)
+}
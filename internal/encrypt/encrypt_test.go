@@ -0,0 +1,110 @@
+package encrypt
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os/exec"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{name: "age files", data: []byte("age-encryption.org/v1\n-> X25519"), want: Age},
+		{name: "armored age files", data: []byte("-----BEGIN AGE ENCRYPTED FILE-----\n"), want: Age},
+		{name: "gpg files encrypted with a public key", data: []byte{0x85, 0x01, 0x8c, 0x03}, want: GPG},
+		{name: "gpg files encrypted with a passphrase", data: []byte{0x8c, 0x0d, 0x04, 0x09}, want: GPG},
+		{name: "new format gpg packets", data: []byte{0xc1, 0x4c, 0x03}, want: GPG},
+		{name: "armored gpg files", data: []byte("-----BEGIN PGP MESSAGE-----\n"), want: GPG},
+		{name: "gzip files are not encrypted", data: []byte{0x1f, 0x8b, 0x08}},
+		{name: "dumps are not encrypted", data: []byte("-- MySQL dump 10.13")},
+		{name: "empty files are not encrypted"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.data); got != tt.want {
+				t.Errorf("Detect() = %q, want %q", got, tt.want)
+			}
+			if got, want := IsSymmetric(tt.data), tt.want == GPG && (tt.data[0] == 0x8c || tt.data[0] == 0xc3); got != want {
+				t.Errorf("IsSymmetric() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestOptions_Format(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       Options
+		wantFormat string
+		wantMethod string
+	}{
+		{name: "no encryption"},
+		{name: "age recipients", opts: Options{Recipient: "age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"}, wantFormat: Age, wantMethod: "age-public-key"},
+		{name: "ssh keys use age", opts: Options{Recipient: "ssh-ed25519 AAAAC3Nza"}, wantFormat: Age, wantMethod: "age-public-key"},
+		{name: "gpg keys", opts: Options{Recipient: "-----BEGIN PGP PUBLIC KEY BLOCK-----"}, wantFormat: GPG, wantMethod: "gpg-public-key"},
+		{name: "passphrases use gpg", opts: Options{Passphrase: "secret"}, wantFormat: GPG, wantMethod: "gpg-passphrase"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.Format(); got != tt.wantFormat {
+				t.Errorf("Format() = %q, want %q", got, tt.wantFormat)
+			}
+			if got := tt.opts.Method(); got != tt.wantMethod {
+				t.Errorf("Method() = %q, want %q", got, tt.wantMethod)
+			}
+			if got := tt.opts.Enabled(); got != (tt.wantFormat != "") {
+				t.Errorf("Enabled() = %v", got)
+			}
+		})
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	if _, err := exec.LookPath("gpg"); err != nil {
+		t.Skip("gpg is not installed")
+	}
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Options{Passphrase: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("SELECT 1;\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if format := Detect(buf.Bytes()); format != GPG {
+		t.Fatalf("Detect() = %q, want %q", format, GPG)
+	}
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), GPG, Options{Passphrase: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	got, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "SELECT 1;\n" {
+		t.Errorf("got %q after decrypting", got)
+	}
+
+	wrong, err := NewReader(bytes.NewReader(buf.Bytes()), GPG, Options{Passphrase: "wrong"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wrong.Close()
+
+	if _, err := ioutil.ReadAll(wrong); err == nil {
+		t.Error("expected an error decrypting with the wrong passphrase")
+	}
+}
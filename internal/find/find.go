@@ -136,7 +136,7 @@ func AllDatabases(f Finder) ([]config.Database, error) {
 	var databases []config.Database
 	sc := bufio.NewScanner(strings.NewReader(string(out)))
 	for sc.Scan() {
-		if strings.Contains(sc.Text(), "mysql") || strings.Contains(sc.Text(), "postgres") {
+		if strings.Contains(sc.Text(), "mysql") || strings.Contains(sc.Text(), "mariadb") || strings.Contains(sc.Text(), "postgres") {
 			sp := strings.Split(sc.Text(), "_")
 			db := config.Database{
 				Engine:  strings.TrimLeft(sp[0], "'"),
@@ -0,0 +1,325 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Prefix is the path prefix for every route in the gateway.
+const Prefix = "/v1/"
+
+// forwardHeaders are the HTTP headers sent to the
+// gRPC server as metadata with every request.
+var forwardHeaders = []string{"authorization"}
+
+// Gateway exposes every method of the gRPC services as JSON over HTTP. The
+// routes are built from the service descriptors, so new methods added to
+// the proto file are available without changes to the gateway. Requests are
+// sent to the gRPC server over a client connection so they go through the
+// same interceptors as any other client.
+type Gateway struct {
+	conn   *grpc.ClientConn
+	routes map[string]Route
+
+	marshal   protojson.MarshalOptions
+	unmarshal protojson.UnmarshalOptions
+}
+
+// Route is a single gRPC method exposed by the gateway.
+type Route struct {
+	Path            string `json:"path"`
+	Method          string `json:"method"`
+	Input           string `json:"input"`
+	Output          string `json:"output"`
+	ClientStreaming bool   `json:"clientStreaming"`
+	ServerStreaming bool   `json:"serverStreaming"`
+
+	input  protoreflect.MessageType
+	output protoreflect.MessageType
+}
+
+// New creates a gateway for the services using the
+// connection to send requests to the gRPC server.
+func New(conn *grpc.ClientConn, services protoreflect.ServiceDescriptors) (*Gateway, error) {
+	g := &Gateway{
+		conn:      conn,
+		routes:    map[string]Route{},
+		marshal:   protojson.MarshalOptions{EmitUnpopulated: true},
+		unmarshal: protojson.UnmarshalOptions{DiscardUnknown: true},
+	}
+
+	for i := 0; i < services.Len(); i++ {
+		service := services.Get(i)
+		methods := service.Methods()
+
+		for j := 0; j < methods.Len(); j++ {
+			method := methods.Get(j)
+
+			input, err := protoregistry.GlobalTypes.FindMessageByName(method.Input().FullName())
+			if err != nil {
+				return nil, fmt.Errorf("unable to find the input for %s: %w", method.FullName(), err)
+			}
+			output, err := protoregistry.GlobalTypes.FindMessageByName(method.Output().FullName())
+			if err != nil {
+				return nil, fmt.Errorf("unable to find the output for %s: %w", method.FullName(), err)
+			}
+
+			r := Route{
+				Path:            Prefix + string(service.Name()) + "/" + string(method.Name()),
+				Method:          "/" + string(service.FullName()) + "/" + string(method.Name()),
+				Input:           string(method.Input().FullName()),
+				Output:          string(method.Output().FullName()),
+				ClientStreaming: method.IsStreamingClient(),
+				ServerStreaming: method.IsStreamingServer(),
+				input:           input,
+				output:          output,
+			}
+			g.routes[r.Path] = r
+		}
+	}
+
+	return g, nil
+}
+
+// Routes returns the routes sorted by path.
+func (g *Gateway) Routes() []Route {
+	var routes []Route
+	for _, r := range g.routes {
+		routes = append(routes, r)
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Path < routes[j].Path
+	})
+
+	return routes
+}
+
+// ServeHTTP lists the routes for GET requests to the prefix
+// and calls the gRPC method for POST requests to a route.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if path+"/" == Prefix {
+		if r.Method != http.MethodGet {
+			writeError(w, status.Error(codes.Unimplemented, "only GET is supported for "+Prefix))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(g.Routes())
+		return
+	}
+
+	route, ok := g.routes[path]
+	if !ok {
+		writeError(w, status.Errorf(codes.NotFound, "unknown route %s, GET %s lists every route", r.URL.Path, Prefix))
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		writeError(w, status.Errorf(codes.Unimplemented, "only POST is supported for %s", route.Path))
+		return
+	}
+
+	ctx := outgoingContext(r)
+
+	var err error
+	switch {
+	case route.ClientStreaming || route.ServerStreaming:
+		err = g.stream(ctx, w, r, route)
+	default:
+		err = g.unary(ctx, w, r, route)
+	}
+
+	if err != nil {
+		writeError(w, err)
+	}
+}
+
+// unary reads a single JSON message from the body
+// and writes the response message as JSON.
+func (g *Gateway) unary(ctx context.Context, w http.ResponseWriter, r *http.Request, route Route) error {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "unable to read the request: %s", err)
+	}
+
+	in := route.input.New().Interface()
+	if len(strings.TrimSpace(string(body))) > 0 {
+		if err := g.unmarshal.Unmarshal(body, in); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid %s: %s", route.Input, err)
+		}
+	}
+
+	out := route.output.New().Interface()
+	if err := g.conn.Invoke(ctx, route.Method, in, out); err != nil {
+		return err
+	}
+
+	return g.write(w, out)
+}
+
+// stream reads newline delimited JSON messages from the body for client
+// streams and writes newline delimited JSON messages for server streams.
+func (g *Gateway) stream(ctx context.Context, w http.ResponseWriter, r *http.Request, route Route) error {
+	desc := &grpc.StreamDesc{
+		StreamName:    route.Method,
+		ClientStreams: route.ClientStreaming,
+		ServerStreams: route.ServerStreaming,
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := g.conn.NewStream(ctx, desc, route.Method)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(r.Body)
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			break
+		} else if err != nil {
+			return status.Errorf(codes.InvalidArgument, "unable to read the request: %s", err)
+		}
+
+		in := route.input.New().Interface()
+		if err := g.unmarshal.Unmarshal(raw, in); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid %s: %s", route.Input, err)
+		}
+
+		if err := stream.SendMsg(in); err == io.EOF {
+			// the server ended the stream, the error is returned by RecvMsg
+			break
+		} else if err != nil {
+			return err
+		}
+
+		// unary requests only have a single message
+		if !route.ClientStreaming {
+			break
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+
+	wrote := false
+	for {
+		out := route.output.New().Interface()
+		err := stream.RecvMsg(out)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			// the status code was sent with the first message
+			if wrote {
+				b, _ := json.Marshal(errorResponse(err))
+				_, _ = w.Write(append(b, '\n'))
+				return nil
+			}
+			return err
+		}
+
+		if err := g.write(w, out); err != nil {
+			return nil
+		}
+		wrote = true
+
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+}
+
+func (g *Gateway) write(w http.ResponseWriter, m proto.Message) error {
+	b, err := g.marshal.Marshal(m)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to encode the response: %s", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(append(b, '\n'))
+
+	return err
+}
+
+// outgoingContext forwards the request headers
+// to the gRPC server as metadata.
+func outgoingContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for _, h := range forwardHeaders {
+		if v := r.Header.Values(h); len(v) > 0 {
+			md.Set(h, v...)
+		}
+	}
+
+	return metadata.NewOutgoingContext(r.Context(), md)
+}
+
+type errorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func errorResponse(err error) errorBody {
+	s := status.Convert(err)
+
+	return errorBody{Code: s.Code().String(), Message: s.Message()}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPStatus(status.Code(err)))
+	_ = json.NewEncoder(w).Encode(errorResponse(err))
+}
+
+// HTTPStatus converts a gRPC status code into the closest HTTP status code.
+func HTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,200 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/craftcms/nitro/internal/nitrod"
+)
+
+type fakeSystemService struct {
+	nitrod.UnimplementedSystemServiceServer
+	authorization []string
+	chunks        int
+}
+
+func (s *fakeSystemService) Version(ctx context.Context, request *nitrod.VersionRequest) (*nitrod.VersionResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.authorization = md.Get("authorization")
+
+	return &nitrod.VersionResponse{Version: "1.0.0", Os: "linux", Arch: "amd64"}, nil
+}
+
+func (s *fakeSystemService) Nginx(ctx context.Context, request *nitrod.NginxServiceRequest) (*nitrod.ServiceResponse, error) {
+	return nil, status.Errorf(codes.InvalidArgument, "unknown action")
+}
+
+func (s *fakeSystemService) UpgradeDaemon(stream nitrod.SystemService_UpgradeDaemonServer) error {
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		s.chunks++
+	}
+
+	return stream.SendAndClose(&nitrod.ServiceResponse{Message: "done"})
+}
+
+func testGateway(t *testing.T) (*Gateway, *fakeSystemService) {
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	fake := &fakeSystemService{}
+	nitrod.RegisterSystemServiceServer(srv, fake)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	cc, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+
+	g, err := New(cc, nitrod.File_internal_nitrod_nitrod_proto.Services())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g, fake
+}
+
+func TestGateway_Unary(t *testing.T) {
+	g, fake := testGateway(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/SystemService/Version", strings.NewReader("{}"))
+	req.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	got := map[string]string{}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["version"] != "1.0.0" || got["arch"] != "amd64" {
+		t.Errorf("unexpected response %v", got)
+	}
+
+	if len(fake.authorization) != 1 || fake.authorization[0] != "Bearer secret" {
+		t.Errorf("expected the authorization header to be forwarded, got %v", fake.authorization)
+	}
+}
+
+func TestGateway_ClientStream(t *testing.T) {
+	g, fake := testGateway(t)
+
+	body := `{"header": {"version": "1.0.0", "size": "3"}}
+{"data": "YWJj"}
+`
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/SystemService/UpgradeDaemon", strings.NewReader(body)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if fake.chunks != 2 {
+		t.Errorf("expected 2 messages to be sent, got %d", fake.chunks)
+	}
+	if !strings.Contains(w.Body.String(), `"message":"done"`) {
+		t.Errorf("unexpected response %s", w.Body.String())
+	}
+}
+
+func TestGateway_Errors(t *testing.T) {
+	g, _ := testGateway(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{
+			name:   "errors from the server are converted",
+			method: http.MethodPost,
+			path:   "/v1/SystemService/Nginx",
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "unknown routes are not found",
+			method: http.MethodPost,
+			path:   "/v1/SystemService/Nope",
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "invalid json is a bad request",
+			method: http.MethodPost,
+			path:   "/v1/SystemService/Version",
+			body:   "{",
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "only post is supported for methods",
+			method: http.MethodGet,
+			path:   "/v1/SystemService/Version",
+			want:   http.StatusNotImplemented,
+		},
+		{
+			name:   "unregistered services are unimplemented",
+			method: http.MethodPost,
+			path:   "/v1/NitroService/EnableXdebug",
+			want:   http.StatusNotImplemented,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGateway_Routes(t *testing.T) {
+	g, _ := testGateway(t)
+
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/", nil))
+
+	var routes []Route
+	if err := json.Unmarshal(w.Body.Bytes(), &routes); err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, r := range routes {
+		if r.Path == "/v1/SystemService/UpgradeDaemon" {
+			found = true
+			if !r.ClientStreaming || r.Method != "/nitrod.SystemService/UpgradeDaemon" {
+				t.Errorf("unexpected route %+v", r)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected the UpgradeDaemon route in %s", w.Body.String())
+	}
+}
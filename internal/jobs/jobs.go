@@ -0,0 +1,438 @@
+// Package jobs runs long operations, such as database imports and package
+// installs, in the background of nitrod. Each job has an ID, its state is
+// saved to disk and its output is captured in a log that can be watched.
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// State is the state of a job.
+type State string
+
+const (
+	Running   State = "running"
+	Succeeded State = "succeeded"
+	Failed    State = "failed"
+	Canceled  State = "canceled"
+)
+
+// maxFinished is the number of finished jobs, and their logs,
+// that are kept. The oldest jobs are removed first.
+const maxFinished = 50
+
+// logChunkSize is the largest part of a log sent by Watch at once.
+const logChunkSize = 32 * 1024
+
+var (
+	// ErrNotFound is returned when there is no job with the ID.
+	ErrNotFound = errors.New("the job does not exist")
+	// ErrFinished is returned when canceling a job that is not running.
+	ErrFinished = errors.New("the job has already finished")
+	// ErrConflict is returned by StartExclusive when a job of one
+	// of the kinds is already running.
+	ErrConflict = errors.New("a conflicting job is already running")
+)
+
+// Job is the saved state of a job.
+type Job struct {
+	ID          string `json:"id"`
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+	State       State  `json:"state"`
+	// Message is the error when the job fails
+	Message  string    `json:"message,omitempty"`
+	Created  time.Time `json:"created"`
+	Finished time.Time `json:"finished,omitempty"`
+}
+
+// Done returns true when the job is no longer running.
+func (j Job) Done() bool {
+	return j.State != Running
+}
+
+// Func is the work done by a job. It should stop when the context is
+// canceled, anything written to the log is saved with the job.
+type Func func(ctx context.Context, log io.Writer) error
+
+// Manager starts the jobs and keeps track of their state.
+type Manager struct {
+	dir    string
+	logger *log.Logger
+
+	mu     sync.Mutex
+	jobs   map[string]Job
+	cancel map[string]context.CancelFunc
+	// changed is closed, and replaced, each time a job or log changes
+	changed chan struct{}
+}
+
+// NewManager creates a manager that saves the jobs in the directory and
+// loads the jobs that were saved. Jobs that were running when nitrod
+// stopped cannot be resumed, so they are marked as failed.
+func NewManager(dir string, logger *log.Logger) *Manager {
+	m := &Manager{
+		dir:     dir,
+		logger:  logger,
+		jobs:    make(map[string]Job),
+		cancel:  make(map[string]context.CancelFunc),
+		changed: make(chan struct{}),
+	}
+
+	if err := m.load(); err != nil {
+		logger.Println("error loading the jobs, error:", err)
+	}
+
+	return m
+}
+
+// Start runs the func in the background as a new job.
+func (m *Manager) Start(kind, description string, fn Func) (Job, error) {
+	return m.start(kind, description, fn, nil)
+}
+
+// StartExclusive runs the func in the background as a new job unless a job
+// of the same kind, or one of the other kinds, is running. The check and the
+// start happen under the same lock so two calls cannot both start a job. When
+// a job is running it is returned along with ErrConflict.
+func (m *Manager) StartExclusive(kind, description string, fn Func, kinds ...string) (Job, error) {
+	return m.start(kind, description, fn, append([]string{kind}, kinds...))
+}
+
+func (m *Manager) start(kind, description string, fn Func, exclusive []string) (Job, error) {
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		return Job{}, err
+	}
+
+	id, err := newID()
+	if err != nil {
+		return Job{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, j := range m.jobs {
+		if j.Done() {
+			continue
+		}
+		for _, k := range exclusive {
+			if j.Kind == k {
+				return j, ErrConflict
+			}
+		}
+	}
+
+	f, err := os.OpenFile(m.logFile(id), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return Job{}, err
+	}
+
+	job := Job{
+		ID:          id,
+		Kind:        kind,
+		Description: description,
+		State:       Running,
+		Created:     time.Now(),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m.jobs[id] = job
+	m.cancel[id] = cancel
+	m.save(job)
+	m.prune()
+
+	m.logger.Printf("Started job %s: %s", id, description)
+
+	go m.run(ctx, job, fn, f)
+
+	return job, nil
+}
+
+// Get returns the job with the ID.
+func (m *Manager) Get(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job{}, ErrNotFound
+	}
+
+	return job, nil
+}
+
+// List returns all of the jobs, the oldest first.
+func (m *Manager) List() []Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.list()
+}
+
+// Cancel stops a running job, the job is canceled once the func returns.
+func (m *Manager) Cancel(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job{}, ErrNotFound
+	}
+
+	cancel, ok := m.cancel[id]
+	if !ok {
+		return job, ErrFinished
+	}
+
+	m.logger.Printf("Canceling job %s", id)
+	cancel()
+
+	return job, nil
+}
+
+// Watch calls send with the log, starting from the offset, as it is written
+// and the current state of the job. It returns once the job is done and the
+// whole log is sent or when the context is canceled.
+func (m *Manager) Watch(ctx context.Context, id string, offset int64, send func(job Job, log []byte, offset int64) error) error {
+	first := true
+	for {
+		m.mu.Lock()
+		job, ok := m.jobs[id]
+		changed := m.changed
+		m.mu.Unlock()
+
+		if !ok {
+			return ErrNotFound
+		}
+
+		// the job is read first, so once it is done the log is complete
+		b, err := m.readLog(id, offset)
+		if err != nil {
+			return err
+		}
+		offset += int64(len(b))
+
+		more := len(b) == logChunkSize
+		if first || len(b) > 0 || (job.Done() && !more) {
+			if err := send(job, b, offset); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		if more {
+			continue
+		}
+
+		if job.Done() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+func (m *Manager) run(ctx context.Context, job Job, fn Func, f *os.File) {
+	err := fn(ctx, &logWriter{file: f, notify: m.notify})
+	if err := f.Close(); err != nil {
+		m.logger.Println("error closing the job log, error:", err)
+	}
+
+	switch {
+	case err != nil && ctx.Err() != nil:
+		job.State = Canceled
+		job.Message = "the job was canceled"
+	case err != nil:
+		job.State = Failed
+		job.Message = err.Error()
+	default:
+		job.State = Succeeded
+	}
+	job.Finished = time.Now()
+
+	m.logger.Printf("Job %s %s", job.ID, job.State)
+
+	m.mu.Lock()
+	m.cancel[job.ID]()
+	delete(m.cancel, job.ID)
+	m.jobs[job.ID] = job
+	m.save(job)
+	m.mu.Unlock()
+
+	m.notify()
+}
+
+// notify wakes up everything watching the jobs.
+func (m *Manager) notify() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	close(m.changed)
+	m.changed = make(chan struct{})
+}
+
+func (m *Manager) list() []Job {
+	var jobs []Job
+	for _, j := range m.jobs {
+		jobs = append(jobs, j)
+	}
+
+	sort.Slice(jobs, func(i, k int) bool {
+		return jobs[i].Created.Before(jobs[k].Created)
+	})
+
+	return jobs
+}
+
+// prune removes the oldest finished jobs over maxFinished.
+func (m *Manager) prune() {
+	var finished []Job
+	for _, j := range m.list() {
+		if j.Done() {
+			finished = append(finished, j)
+		}
+	}
+
+	for len(finished) > maxFinished {
+		j := finished[0]
+		finished = finished[1:]
+
+		delete(m.jobs, j.ID)
+		for _, file := range []string{m.stateFile(j.ID), m.logFile(j.ID)} {
+			if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+				m.logger.Println("error removing the job, error:", err)
+			}
+		}
+	}
+}
+
+// save writes the job state to a temporary file and
+// renames it, so a partial file is never loaded.
+func (m *Manager) save(job Job) {
+	b, err := json.Marshal(job)
+	if err != nil {
+		m.logger.Println("error saving the job, error:", err)
+		return
+	}
+
+	tmp := m.stateFile(job.ID) + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0644); err != nil {
+		m.logger.Println("error saving the job, error:", err)
+		return
+	}
+
+	if err := os.Rename(tmp, m.stateFile(job.ID)); err != nil {
+		m.logger.Println("error saving the job, error:", err)
+	}
+}
+
+func (m *Manager) load() error {
+	files, err := filepath.Glob(filepath.Join(m.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		var job Job
+		if err := json.Unmarshal(b, &job); err != nil {
+			m.logger.Printf("error reading the job %s, error: %s", file, err)
+			continue
+		}
+
+		if job.ID != strings.TrimSuffix(filepath.Base(file), ".json") {
+			continue
+		}
+
+		if !job.Done() {
+			job.State = Failed
+			job.Message = "nitrod stopped before the job finished"
+			job.Finished = time.Now()
+			m.save(job)
+		}
+
+		m.jobs[job.ID] = job
+	}
+
+	return nil
+}
+
+func (m *Manager) readLog(id string, offset int64) ([]byte, error) {
+	f, err := os.Open(m.logFile(id))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, logChunkSize)
+	n, err := io.ReadFull(f, b)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+
+	return b[:n], nil
+}
+
+func (m *Manager) stateFile(id string) string {
+	return filepath.Join(m.dir, id+".json")
+}
+
+func (m *Manager) logFile(id string) string {
+	return filepath.Join(m.dir, id+".log")
+}
+
+// logWriter writes to the job log and notifies the watchers.
+type logWriter struct {
+	mu     sync.Mutex
+	file   *os.File
+	notify func()
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	n, err := w.file.Write(p)
+	w.mu.Unlock()
+
+	w.notify()
+
+	return n, err
+}
+
+func newID() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
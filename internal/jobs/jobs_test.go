@@ -0,0 +1,307 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testManager(t *testing.T) (*Manager, func()) {
+	dir, err := ioutil.TempDir("", "nitrod-jobs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewManager(dir, log.New(ioutil.Discard, "testing", 0)), func() { os.RemoveAll(dir) }
+}
+
+// wait watches the job until it is done and returns the job and log.
+func wait(t *testing.T, m *Manager, id string) (Job, string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var job Job
+	var logs string
+	err := m.Watch(ctx, id, 0, func(j Job, b []byte, offset int64) error {
+		job = j
+		logs += string(b)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return job, logs
+}
+
+func TestManager_Start(t *testing.T) {
+	tests := []struct {
+		name        string
+		fn          Func
+		wantState   State
+		wantMessage string
+		wantLog     string
+	}{
+		{
+			name: "jobs that return nil succeed",
+			fn: func(ctx context.Context, log io.Writer) error {
+				fmt.Fprintln(log, "installing")
+				fmt.Fprintln(log, "done")
+				return nil
+			},
+			wantState: Succeeded,
+			wantLog:   "installing\ndone\n",
+		},
+		{
+			name: "jobs that return an error fail",
+			fn: func(ctx context.Context, log io.Writer) error {
+				fmt.Fprintln(log, "importing")
+				return errors.New("table already exists")
+			},
+			wantState:   Failed,
+			wantMessage: "table already exists",
+			wantLog:     "importing\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, cleanup := testManager(t)
+			defer cleanup()
+
+			started, err := m.Start("test", tt.name, tt.fn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if started.State != Running {
+				t.Errorf("expected the job to be running, got %s", started.State)
+			}
+
+			job, logs := wait(t, m, started.ID)
+			if job.State != tt.wantState {
+				t.Errorf("expected the state %s, got %s", tt.wantState, job.State)
+			}
+			if job.Message != tt.wantMessage {
+				t.Errorf("expected the message %q, got %q", tt.wantMessage, job.Message)
+			}
+			if logs != tt.wantLog {
+				t.Errorf("expected the log %q, got %q", tt.wantLog, logs)
+			}
+			if job.Finished.IsZero() {
+				t.Error("expected the finished time to be set")
+			}
+
+			// the saved state is loaded by a new manager
+			loaded, err := NewManager(m.dir, m.logger).Get(started.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loaded.State != tt.wantState {
+				t.Errorf("expected the saved state %s, got %s", tt.wantState, loaded.State)
+			}
+		})
+	}
+}
+
+func TestManager_StartExclusive(t *testing.T) {
+	m, cleanup := testManager(t)
+	defer cleanup()
+
+	release := make(chan struct{})
+	fn := func(ctx context.Context, log io.Writer) error {
+		<-release
+		return nil
+	}
+
+	// jobs of other kinds do not conflict
+	if _, err := m.Start("import", "import", fn); err != nil {
+		t.Fatal(err)
+	}
+
+	// start the jobs at the same time, only one of them can win
+	var wg sync.WaitGroup
+	started := make(chan Job, 10)
+	conflicts := make(chan Job, 10)
+	for i := 0; i < 10; i++ {
+		kind := "install"
+		if i%2 == 0 {
+			kind = "upgrade"
+		}
+
+		wg.Add(1)
+		go func(kind string) {
+			defer wg.Done()
+
+			job, err := m.StartExclusive(kind, kind, fn, "install", "upgrade")
+			switch {
+			case errors.Is(err, ErrConflict):
+				conflicts <- job
+			case err != nil:
+				t.Error(err)
+			default:
+				started <- job
+			}
+		}(kind)
+	}
+	wg.Wait()
+	close(started)
+	close(conflicts)
+
+	if len(started) != 1 || len(conflicts) != 9 {
+		t.Fatalf("expected one job to start and 9 conflicts, got %d and %d", len(started), len(conflicts))
+	}
+
+	running := <-started
+	for j := range conflicts {
+		if j.ID != running.ID {
+			t.Errorf("expected the conflict to be the running job %s, got %s", running.ID, j.ID)
+		}
+	}
+
+	// once the job is done another can start
+	close(release)
+	wait(t, m, running.ID)
+	if _, err := m.StartExclusive("install", "install", fn, "upgrade"); err != nil {
+		t.Errorf("expected the job to start, got %v", err)
+	}
+}
+
+func TestManager_Cancel(t *testing.T) {
+	m, cleanup := testManager(t)
+	defer cleanup()
+
+	started := make(chan struct{})
+	job, err := m.Start("test", "waits to be canceled", func(ctx context.Context, log io.Writer) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	if _, err := m.Cancel(job.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	job, _ = wait(t, m, job.ID)
+	if job.State != Canceled {
+		t.Errorf("expected the job to be canceled, got %s", job.State)
+	}
+
+	if _, err := m.Cancel(job.ID); err != ErrFinished {
+		t.Errorf("expected canceling a finished job to return %v, got %v", ErrFinished, err)
+	}
+
+	if _, err := m.Cancel("missing"); err != ErrNotFound {
+		t.Errorf("expected canceling a missing job to return %v, got %v", ErrNotFound, err)
+	}
+}
+
+func TestManager_Watch(t *testing.T) {
+	m, cleanup := testManager(t)
+	defer cleanup()
+
+	next := make(chan struct{})
+	job, err := m.Start("test", "writes the log", func(ctx context.Context, log io.Writer) error {
+		fmt.Fprint(log, "first ")
+		<-next
+		fmt.Fprint(log, "second")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var last Job
+	var logs string
+	var end int64
+	err = m.Watch(context.Background(), job.ID, 0, func(j Job, b []byte, offset int64) error {
+		last, end = j, offset
+		logs += string(b)
+		if logs == "first " {
+			close(next)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if last.State != Succeeded || logs != "first second" || end != 12 {
+		t.Errorf("expected the watch to end with the job and whole log, got %s %q %d", last.State, logs, end)
+	}
+
+	// watching from an offset only sends the rest of the log
+	logs = ""
+	err = m.Watch(context.Background(), job.ID, 6, func(j Job, b []byte, offset int64) error {
+		logs += string(b)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logs != "second" {
+		t.Errorf("expected the log from the offset to be %q, got %q", "second", logs)
+	}
+
+	if err := m.Watch(context.Background(), "missing", 0, nil); err != ErrNotFound {
+		t.Errorf("expected watching a missing job to return %v, got %v", ErrNotFound, err)
+	}
+}
+
+func TestNewManager(t *testing.T) {
+	m, cleanup := testManager(t)
+	defer cleanup()
+
+	// a job that was running when nitrod stopped
+	running := Job{ID: "0badc0de", Kind: "import", State: Running, Created: time.Now()}
+	m.save(running)
+
+	loaded, err := NewManager(m.dir, m.logger).Get(running.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.State != Failed {
+		t.Errorf("expected the running job to fail, got %s", loaded.State)
+	}
+	if loaded.Message != "nitrod stopped before the job finished" {
+		t.Errorf("unexpected message %q", loaded.Message)
+	}
+}
+
+func TestManager_prune(t *testing.T) {
+	m, cleanup := testManager(t)
+	defer cleanup()
+
+	created := time.Now().Add(-time.Hour)
+	for i := 0; i < maxFinished+2; i++ {
+		job := Job{ID: fmt.Sprintf("%08x", i), State: Succeeded, Created: created.Add(time.Duration(i) * time.Second)}
+		m.jobs[job.ID] = job
+		m.save(job)
+	}
+
+	m.prune()
+
+	if len(m.jobs) != maxFinished {
+		t.Errorf("expected %d jobs, got %d", maxFinished, len(m.jobs))
+	}
+
+	for _, id := range []string{"00000000", "00000001"} {
+		if _, ok := m.jobs[id]; ok {
+			t.Errorf("expected the oldest job %s to be removed", id)
+		}
+		if _, err := os.Stat(filepath.Join(m.dir, id+".json")); !os.IsNotExist(err) {
+			t.Errorf("expected the state of %s to be removed", id)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StatusAddr is the loopback address of the nginx server that exposes
+// the nginx stub_status and php-fpm status pages to nitrod.
+const StatusAddr = "127.0.0.1:8080"
+
+// Runner is an interface to run commands.
+type Runner interface {
+	Run(command string, args []string) ([]byte, error)
+}
+
+var defaultClient = &http.Client{Timeout: 5 * time.Second}
+
+func get(client *http.Client, url string) ([]byte, error) {
+	if client == nil {
+		client = defaultClient
+	}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s from %s", resp.Status, url)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+func gauge(name, help string) Family {
+	return Family{Name: name, Help: help, Type: "gauge"}
+}
+
+func counter(name, help string) Family {
+	return Family{Name: name, Help: help, Type: "counter"}
+}
+
+// FPMCollector scrapes the status page of each php-fpm pool. The pools
+// are found using the sockets, so only running versions are scraped.
+type FPMCollector struct {
+	Client *http.Client
+	// URL is the status page for a PHP version, the
+	// version is added to the end of the URL.
+	URL string
+	// Sockets is the glob pattern for the php-fpm sockets.
+	Sockets string
+}
+
+// NewFPMCollector creates a collector using the default status page and sockets.
+func NewFPMCollector() *FPMCollector {
+	return &FPMCollector{
+		URL:     "http://" + StatusAddr + "/fpm-status/",
+		Sockets: "/var/run/php/php*-fpm.sock",
+	}
+}
+
+type fpmStatus struct {
+	Pool               string  `json:"pool"`
+	AcceptedConn       float64 `json:"accepted conn"`
+	ListenQueue        float64 `json:"listen queue"`
+	IdleProcesses      float64 `json:"idle processes"`
+	ActiveProcesses    float64 `json:"active processes"`
+	TotalProcesses     float64 `json:"total processes"`
+	MaxChildrenReached float64 `json:"max children reached"`
+	SlowRequests       float64 `json:"slow requests"`
+}
+
+var fpmSocket = regexp.MustCompile(`php([0-9.]+)-fpm\.sock$`)
+
+// Collect implements the Collector interface.
+func (c *FPMCollector) Collect() ([]Family, error) {
+	sockets, err := filepath.Glob(c.Sockets)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(sockets)
+
+	families := []Family{
+		gauge("nitrod_phpfpm_active_processes", "Number of active php-fpm processes."),
+		gauge("nitrod_phpfpm_idle_processes", "Number of idle php-fpm processes."),
+		gauge("nitrod_phpfpm_total_processes", "Total number of php-fpm processes."),
+		gauge("nitrod_phpfpm_listen_queue", "Number of requests waiting for a free php-fpm process."),
+		counter("nitrod_phpfpm_accepted_connections_total", "Total number of requests accepted by php-fpm."),
+		counter("nitrod_phpfpm_max_children_reached_total", "Number of times php-fpm reached the maximum number of processes."),
+		counter("nitrod_phpfpm_slow_requests_total", "Total number of requests that exceeded request_slowlog_timeout."),
+	}
+
+	var errs []string
+	for _, socket := range sockets {
+		m := fpmSocket.FindStringSubmatch(socket)
+		if m == nil {
+			continue
+		}
+		version := m[1]
+
+		body, err := get(c.Client, c.URL+version+"?json")
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		s := fpmStatus{}
+		if err := json.Unmarshal(body, &s); err != nil {
+			errs = append(errs, fmt.Sprintf("unable to read the php-fpm %s status: %s", version, err))
+			continue
+		}
+
+		labels := []Label{{Name: "version", Value: version}, {Name: "pool", Value: s.Pool}}
+		for i, v := range []float64{s.ActiveProcesses, s.IdleProcesses, s.TotalProcesses, s.ListenQueue, s.AcceptedConn, s.MaxChildrenReached, s.SlowRequests} {
+			families[i].Samples = append(families[i].Samples, Sample{Labels: labels, Value: v})
+		}
+	}
+
+	if len(errs) > 0 {
+		return families, errors.New(strings.Join(errs, ", "))
+	}
+
+	return families, nil
+}
+
+// NginxCollector scrapes the nginx stub_status page.
+type NginxCollector struct {
+	Client *http.Client
+	URL    string
+}
+
+// NewNginxCollector creates a collector using the default status page.
+func NewNginxCollector() *NginxCollector {
+	return &NginxCollector{URL: "http://" + StatusAddr + "/nginx-status"}
+}
+
+// Collect implements the Collector interface.
+func (c *NginxCollector) Collect() ([]Family, error) {
+	body, err := get(c.Client, c.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := parseStubStatus(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return []Family{
+		{Name: "nitrod_nginx_connections_active", Help: "Number of active client connections.", Type: "gauge", Samples: []Sample{{Value: s[0]}}},
+		{Name: "nitrod_nginx_connections_accepted_total", Help: "Total number of accepted client connections.", Type: "counter", Samples: []Sample{{Value: s[1]}}},
+		{Name: "nitrod_nginx_connections_handled_total", Help: "Total number of handled client connections.", Type: "counter", Samples: []Sample{{Value: s[2]}}},
+		{Name: "nitrod_nginx_http_requests_total", Help: "Total number of client requests.", Type: "counter", Samples: []Sample{{Value: s[3]}}},
+		{Name: "nitrod_nginx_connections_reading", Help: "Number of connections where nginx is reading the request header.", Type: "gauge", Samples: []Sample{{Value: s[4]}}},
+		{Name: "nitrod_nginx_connections_writing", Help: "Number of connections where nginx is writing the response.", Type: "gauge", Samples: []Sample{{Value: s[5]}}},
+		{Name: "nitrod_nginx_connections_waiting", Help: "Number of idle client connections waiting for a request.", Type: "gauge", Samples: []Sample{{Value: s[6]}}},
+	}, nil
+}
+
+var stubStatusNumber = regexp.MustCompile(`\d+`)
+
+// parseStubStatus returns the active, accepts, handled, requests,
+// reading, writing, and waiting values from the stub_status page.
+func parseStubStatus(b []byte) ([7]float64, error) {
+	var s [7]float64
+
+	numbers := stubStatusNumber.FindAll(b, -1)
+	if len(numbers) != len(s) {
+		return s, fmt.Errorf("unexpected nginx status %q", string(b))
+	}
+
+	for i, n := range numbers {
+		v, err := strconv.ParseFloat(string(n), 64)
+		if err != nil {
+			return s, err
+		}
+		s[i] = v
+	}
+
+	return s, nil
+}
+
+// ContainerCollector reports the CPU and memory of the docker containers.
+type ContainerCollector struct {
+	Command Runner
+}
+
+type dockerStats struct {
+	Name     string `json:"Name"`
+	CPUPerc  string `json:"CPUPerc"`
+	MemUsage string `json:"MemUsage"`
+}
+
+// Collect implements the Collector interface.
+func (c *ContainerCollector) Collect() ([]Family, error) {
+	output, err := c.Command.Run("docker", []string{"stats", "--no-stream", "--format", "{{json .}}"})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the container stats: %s", strings.TrimSpace(string(output)))
+	}
+
+	cpu := gauge("nitrod_container_cpu_percent", "Percentage of the machine CPU used by the container.")
+	usage := gauge("nitrod_container_memory_usage_bytes", "Memory used by the container.")
+	limit := gauge("nitrod_container_memory_limit_bytes", "Memory limit of the container.")
+
+	s := bufio.NewScanner(bytes.NewReader(output))
+	for s.Scan() {
+		stats := dockerStats{}
+		if err := json.Unmarshal(s.Bytes(), &stats); err != nil {
+			return nil, fmt.Errorf("unable to read the container stats: %w", err)
+		}
+
+		labels := []Label{{Name: "name", Value: stats.Name}}
+
+		if v, err := strconv.ParseFloat(strings.TrimSuffix(stats.CPUPerc, "%"), 64); err == nil {
+			cpu.Samples = append(cpu.Samples, Sample{Labels: labels, Value: v})
+		}
+
+		sp := strings.Split(stats.MemUsage, "/")
+		if len(sp) != 2 {
+			continue
+		}
+		if v, err := parseSize(sp[0]); err == nil {
+			usage.Samples = append(usage.Samples, Sample{Labels: labels, Value: v})
+		}
+		if v, err := parseSize(sp[1]); err == nil {
+			limit.Samples = append(limit.Samples, Sample{Labels: labels, Value: v})
+		}
+	}
+
+	return []Family{cpu, usage, limit}, s.Err()
+}
+
+var sizeUnits = map[string]float64{
+	"B":   1,
+	"kB":  1000,
+	"KB":  1000,
+	"MB":  1000 * 1000,
+	"GB":  1000 * 1000 * 1000,
+	"TB":  1000 * 1000 * 1000 * 1000,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+}
+
+var sizePattern = regexp.MustCompile(`^([0-9.]+)\s*([A-Za-z]+)$`)
+
+// parseSize parses the human readable sizes from docker stats (e.g. 40.5MiB).
+func parseSize(s string) (float64, error) {
+	m := sizePattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+
+	unit, ok := sizeUnits[m[2]]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit in size %q", s)
+	}
+
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return v * unit, nil
+}
@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func value(t *testing.T, families []Family, name string) float64 {
+	t.Helper()
+
+	for _, f := range families {
+		if f.Name == name {
+			if len(f.Samples) != 1 {
+				t.Fatalf("expected one sample for %s, got %d", name, len(f.Samples))
+			}
+			return f.Samples[0].Value
+		}
+	}
+
+	t.Fatalf("missing metric %s", name)
+	return 0
+}
+
+func TestNginxCollector(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Active connections: 2 \nserver accepts handled requests\n 10 9 20 \nReading: 0 Writing: 1 Waiting: 1 \n"))
+	}))
+	defer srv.Close()
+
+	families, err := (&NginxCollector{URL: srv.URL}).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := value(t, families, "nitrod_nginx_connections_active"); v != 2 {
+		t.Errorf("expected 2 active connections, got %v", v)
+	}
+	if v := value(t, families, "nitrod_nginx_connections_handled_total"); v != 9 {
+		t.Errorf("expected 9 handled connections, got %v", v)
+	}
+	if v := value(t, families, "nitrod_nginx_http_requests_total"); v != 20 {
+		t.Errorf("expected 20 requests, got %v", v)
+	}
+}
+
+func TestFPMCollector(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nitro-fpm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "php7.4-fpm.sock"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"pool":"www","accepted conn":12,"listen queue":0,"idle processes":1,"active processes":3,"total processes":4,"max children reached":0,"slow requests":2}`))
+	}))
+	defer srv.Close()
+
+	families, err := (&FPMCollector{URL: srv.URL + "/fpm-status/", Sockets: filepath.Join(dir, "php*-fpm.sock")}).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if path != "/fpm-status/7.4" {
+		t.Errorf("expected the 7.4 status page to be requested, got %s", path)
+	}
+	if v := value(t, families, "nitrod_phpfpm_active_processes"); v != 3 {
+		t.Errorf("expected 3 active processes, got %v", v)
+	}
+	if v := value(t, families, "nitrod_phpfpm_slow_requests_total"); v != 2 {
+		t.Errorf("expected 2 slow requests, got %v", v)
+	}
+}
+
+type spyRunner struct {
+	Command string
+	Args    []string
+	Output  []byte
+}
+
+func (r *spyRunner) Run(command string, args []string) ([]byte, error) {
+	r.Command = command
+	r.Args = args
+
+	return r.Output, nil
+}
+
+func TestContainerCollector(t *testing.T) {
+	runner := &spyRunner{Output: []byte(`{"CPUPerc":"1.50%","MemUsage":"40MiB / 1.5GiB","Name":"mysql_5.7_3306"}` + "\n")}
+
+	families, err := (&ContainerCollector{Command: runner}).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if runner.Command != "docker" {
+		t.Errorf("expected docker to be run, got %s", runner.Command)
+	}
+	if v := value(t, families, "nitrod_container_cpu_percent"); v != 1.5 {
+		t.Errorf("expected 1.5%% cpu, got %v", v)
+	}
+	if v := value(t, families, "nitrod_container_memory_usage_bytes"); v != 40*1024*1024 {
+		t.Errorf("expected 40MiB memory, got %v", v)
+	}
+	if v := value(t, families, "nitrod_container_memory_limit_bytes"); v != 1.5*1024*1024*1024 {
+		t.Errorf("expected 1.5GiB limit, got %v", v)
+	}
+}
+
+func Test_parseSize(t *testing.T) {
+	tests := []struct {
+		size    string
+		want    float64
+		wantErr bool
+	}{
+		{size: "0B", want: 0},
+		{size: "512KiB", want: 512 * 1024},
+		{size: "1.5kB", want: 1500},
+		{size: " 2GiB ", want: 2 * 1024 * 1024 * 1024},
+		{size: "12XB", wantErr: true},
+		{size: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.size, func(t *testing.T) {
+			got, err := parseSize(tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseSize() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
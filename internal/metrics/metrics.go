@@ -0,0 +1,272 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Family is a group of samples with the same metric name
+// written in the Prometheus text exposition format.
+type Family struct {
+	Name    string
+	Help    string
+	Type    string
+	Samples []Sample
+}
+
+// Sample is a single value for a metric family. The suffix is added to
+// the family name, which is used by histograms for _bucket, _sum, and
+// _count samples.
+type Sample struct {
+	Suffix string
+	Labels []Label
+	Value  float64
+}
+
+// Label is a name and value pair for a sample.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Collector returns the current values of one or more metric families.
+type Collector interface {
+	Collect() ([]Family, error)
+}
+
+// Write writes the families in the Prometheus text exposition format.
+func Write(w io.Writer, families []Family) error {
+	for _, f := range families {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", f.Name, escapeHelp(f.Help), f.Name, f.Type); err != nil {
+			return err
+		}
+
+		for _, s := range f.Samples {
+			if _, err := fmt.Fprintf(w, "%s%s%s %s\n", f.Name, s.Suffix, formatLabels(s.Labels), formatValue(s.Value)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func formatLabels(labels []Label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	var pairs []string
+	for _, l := range labels {
+		v := strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(l.Value)
+		pairs = append(pairs, l.Name+`="`+v+`"`)
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+// labelled stores values by their label values, the
+// label values are joined into a single key.
+type labelled struct {
+	names []string
+	keys  []string
+}
+
+func (l *labelled) key(values []string) string {
+	if len(values) != len(l.names) {
+		panic(fmt.Sprintf("expected %d label values, got %d", len(l.names), len(values)))
+	}
+
+	return strings.Join(values, "\xff")
+}
+
+func (l *labelled) labels(key string) []Label {
+	if len(l.names) == 0 {
+		return nil
+	}
+
+	var labels []Label
+	for i, v := range strings.Split(key, "\xff") {
+		labels = append(labels, Label{Name: l.names[i], Value: v})
+	}
+
+	return labels
+}
+
+func sortedKeys(m map[string]float64) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// CounterVec is a counter partitioned by labels.
+type CounterVec struct {
+	name, help string
+	labelled
+
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// NewCounterVec creates a counter with the label names.
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{
+		name:     name,
+		help:     help,
+		labelled: labelled{names: labels},
+		values:   map[string]float64{},
+	}
+}
+
+// Add adds the value to the counter for the label values.
+func (c *CounterVec) Add(v float64, values ...string) {
+	k := c.key(values)
+
+	c.mu.Lock()
+	c.values[k] += v
+	c.mu.Unlock()
+}
+
+// Inc adds one to the counter for the label values.
+func (c *CounterVec) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// Value returns the current value of the counter for the label values.
+func (c *CounterVec) Value(values ...string) float64 {
+	k := c.key(values)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.values[k]
+}
+
+// Collect implements the Collector interface.
+func (c *CounterVec) Collect() ([]Family, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f := Family{Name: c.name, Help: c.help, Type: "counter"}
+	for _, k := range sortedKeys(c.values) {
+		f.Samples = append(f.Samples, Sample{Labels: c.labels(k), Value: c.values[k]})
+	}
+
+	return []Family{f}, nil
+}
+
+// DefaultBuckets are the histogram buckets, in seconds, used
+// for RPC latency which covers fast calls and long imports.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 300}
+
+// HistogramVec is a histogram partitioned by labels.
+type HistogramVec struct {
+	name, help string
+	buckets    []float64
+	labelled
+
+	mu     sync.Mutex
+	values map[string]*histogram
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// NewHistogramVec creates a histogram with the upper
+// bounds of the buckets and the label names.
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	b := append([]float64(nil), buckets...)
+	sort.Float64s(b)
+
+	return &HistogramVec{
+		name:     name,
+		help:     help,
+		buckets:  b,
+		labelled: labelled{names: labels},
+		values:   map[string]*histogram{},
+	}
+}
+
+// Observe adds the value to the histogram for the label values.
+func (h *HistogramVec) Observe(v float64, values ...string) {
+	k := h.key(values)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hist, ok := h.values[k]
+	if !ok {
+		hist = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.values[k] = hist
+	}
+
+	for i, upper := range h.buckets {
+		if v <= upper {
+			hist.counts[i]++
+		}
+	}
+	hist.sum += v
+	hist.count++
+}
+
+// Collect implements the Collector interface.
+func (h *HistogramVec) Collect() ([]Family, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var keys []string
+	for k := range h.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	f := Family{Name: h.name, Help: h.help, Type: "histogram"}
+	for _, k := range keys {
+		hist := h.values[k]
+		labels := h.labels(k)
+
+		for i, upper := range h.buckets {
+			f.Samples = append(f.Samples, Sample{
+				Suffix: "_bucket",
+				Labels: append(append([]Label(nil), labels...), Label{Name: "le", Value: formatValue(upper)}),
+				Value:  float64(hist.counts[i]),
+			})
+		}
+		f.Samples = append(f.Samples,
+			Sample{Suffix: "_bucket", Labels: append(append([]Label(nil), labels...), Label{Name: "le", Value: "+Inf"}), Value: float64(hist.count)},
+			Sample{Suffix: "_sum", Labels: labels, Value: hist.sum},
+			Sample{Suffix: "_count", Labels: labels, Value: float64(hist.count)},
+		)
+	}
+
+	return []Family{f}, nil
+}
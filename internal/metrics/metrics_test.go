@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	c := NewCounterVec("test_requests_total", "Total requests.", "method", "code")
+	c.Inc("/a", "OK")
+	c.Inc("/a", "OK")
+	c.Add(3, "/b", "Internal")
+
+	h := NewHistogramVec("test_duration_seconds", "Duration.", []float64{1, 0.1}, "method")
+	h.Observe(0.05, "/a")
+	h.Observe(0.5, "/a")
+
+	var families []Family
+	for _, col := range []Collector{c, h} {
+		f, err := col.Collect()
+		if err != nil {
+			t.Fatal(err)
+		}
+		families = append(families, f...)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, families); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP test_requests_total Total requests.
+# TYPE test_requests_total counter
+test_requests_total{method="/a",code="OK"} 2
+test_requests_total{method="/b",code="Internal"} 3
+# HELP test_duration_seconds Duration.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{method="/a",le="0.1"} 1
+test_duration_seconds_bucket{method="/a",le="1"} 2
+test_duration_seconds_bucket{method="/a",le="+Inf"} 2
+test_duration_seconds_sum{method="/a"} 0.55
+test_duration_seconds_count{method="/a"} 2
+`
+	if got := buf.String(); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+type failingCollector struct{}
+
+func (f failingCollector) Collect() ([]Family, error) {
+	return nil, errors.New("unavailable")
+}
+
+func TestRegistry_ServeHTTP(t *testing.T) {
+	c := NewCounterVec("test_total", "Test.")
+	c.Inc()
+
+	r := NewRegistry(nil)
+	r.Register("test", c)
+	r.Register("failing", failingCollector{})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	for _, want := range []string{
+		"test_total 1\n",
+		`nitrod_collector_up{collector="test"} 1`,
+		`nitrod_collector_up{collector="failing"} 0`,
+	} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, w.Body.String())
+		}
+	}
+}
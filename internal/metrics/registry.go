@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"sync"
+)
+
+// Registry holds the collectors that are scraped on each request.
+type Registry struct {
+	mu         sync.Mutex
+	names      []string
+	collectors map[string]Collector
+	logger     *log.Logger
+}
+
+// NewRegistry creates an empty registry, errors from collectors are
+// written to the logger.
+func NewRegistry(logger *log.Logger) *Registry {
+	return &Registry{collectors: map[string]Collector{}, logger: logger}
+}
+
+// Register adds the collector using the name to report if the
+// collector is up. Registering the same name replaces the collector.
+func (r *Registry) Register(name string, c Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.collectors[name]; !ok {
+		r.names = append(r.names, name)
+	}
+	r.collectors[name] = c
+}
+
+// Gather collects every metric family from the collectors. A failing
+// collector does not stop the others, instead nitrod_collector_up is
+// set to 0 for it.
+func (r *Registry) Gather() []Family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	up := Family{Name: "nitrod_collector_up", Help: "Whether the last collection succeeded.", Type: "gauge"}
+
+	var families []Family
+	for _, name := range r.names {
+		f, err := r.collectors[name].Collect()
+
+		value := 1.0
+		if err != nil {
+			if r.logger != nil {
+				r.logger.Printf("error collecting %s metrics, error: %s", name, err)
+			}
+			value = 0
+		}
+
+		families = append(families, f...)
+		up.Samples = append(up.Samples, Sample{Labels: []Label{{Name: "collector", Value: name}}, Value: value})
+	}
+
+	return append(families, up)
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var buf bytes.Buffer
+	if err := Write(&buf, r.Gather()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write(buf.Bytes())
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// RPCRequests counts the gRPC requests by method and status code.
+	RPCRequests = NewCounterVec("nitrod_rpc_requests_total", "Total number of RPCs handled by method and status code.", "method", "code")
+
+	// RPCDuration is the time taken to handle gRPC requests by method.
+	RPCDuration = NewHistogramVec("nitrod_rpc_duration_seconds", "Time taken to handle RPCs by method.", DefaultBuckets, "method")
+
+	// ImportBytes counts the bytes received for database imports by engine.
+	ImportBytes = NewCounterVec("nitrod_import_bytes_total", "Total number of bytes received for database imports.", "engine")
+
+	// ImportDuration is the time taken to import databases by engine and result.
+	ImportDuration = NewHistogramVec("nitrod_import_duration_seconds", "Time taken to import databases.", DefaultBuckets, "engine", "result")
+)
+
+// UnaryServerInterceptor records the count, status, and latency of unary RPCs.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observe(info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records the count, status, and latency of streaming RPCs.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observe(info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+func observe(method string, start time.Time, err error) {
+	RPCRequests.Inc(method, status.Code(err).String())
+	RPCDuration.Observe(time.Since(start).Seconds(), method)
+}
@@ -0,0 +1,162 @@
+// Package nginx renders the nginx configuration of the sites on the host.
+// Sites use the template of their preset, or a template file from the config,
+// that is rendered with the Vars of the site and copied to the machine.
+package nginx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/nitro"
+)
+
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Vars are the variables available to the templates of the sites.
+type Vars struct {
+	// Hostname is the hostname of the site
+	Hostname string
+	// Aliases are the other hostnames of the site
+	Aliases []string
+	// Webroot is the path of the webroot on the machine
+	Webroot string
+	// PHPVersion is the version of PHP, e.g. 7.4
+	PHPVersion string
+	// PHPSocket is the path of the php-fpm socket for the version of PHP
+	PHPSocket string
+	// Env are the environment variables passed to PHP as FastCGI params
+	Env map[string]string
+	// Extra is the configuration added to the server block
+	Extra string
+	// Certificate and CertificateKey are the paths of the
+	// HTTPS certificate of the site on the machine
+	Certificate    string
+	CertificateKey string
+}
+
+// ServerNames returns the hostname and aliases for server_name.
+func (v Vars) ServerNames() string {
+	return strings.Join(append([]string{v.Hostname}, v.Aliases...), " ")
+}
+
+// SiteVars returns the variables of the site for the version of PHP.
+func SiteVars(site config.Site, php string) (Vars, error) {
+	env, err := ParseEnv(site.Env)
+	if err != nil {
+		return Vars{}, fmt.Errorf("the env of %s is not valid, %w", site.Hostname, err)
+	}
+
+	return Vars{
+		Hostname:       site.Hostname,
+		Aliases:        site.Aliases,
+		Webroot:        site.Webroot,
+		PHPVersion:     php,
+		PHPSocket:      "/var/run/php/php" + php + "-fpm.sock",
+		Env:            env,
+		Extra:          strings.TrimSpace(site.Extra),
+		Certificate:    nitro.CertsDir + "/" + site.Hostname + ".crt",
+		CertificateKey: nitro.CertsDir + "/" + site.Hostname + ".key",
+	}, nil
+}
+
+// ParseEnv returns the NAME=value environment variables as a map. Values
+// cannot contain $ since nginx would read it as the start of a variable.
+func ParseEnv(env []string) (map[string]string, error) {
+	vars := make(map[string]string)
+	for _, e := range env {
+		sp := strings.SplitN(e, "=", 2)
+		if len(sp) != 2 || !envName.MatchString(sp[0]) {
+			return nil, fmt.Errorf("%q must be NAME=value", e)
+		}
+
+		// nginx reads $ in a fastcgi_param as a variable and strings
+		// have no way to escape it, so the value would be changed
+		if strings.Contains(sp[1], "$") {
+			return nil, fmt.Errorf("the value of %s cannot contain $", sp[0])
+		}
+
+		vars[sp[0]] = sp[1]
+	}
+
+	return vars, nil
+}
+
+// Parse parses the text of a template, variables that do not exist in
+// Vars are errors when the template is rendered.
+func Parse(name, text string) (*template.Template, error) {
+	t, err := template.New(name).Option("missingkey=error").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the nginx template %s, %w", name, err)
+	}
+
+	return t, nil
+}
+
+// ParseFile parses the template file.
+func ParseFile(file string) (*template.Template, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(filepath.Base(file), string(b))
+}
+
+// Render writes the configuration of the site using the template.
+func Render(w io.Writer, t *template.Template, vars Vars) error {
+	if err := t.Execute(w, vars); err != nil {
+		return fmt.Errorf("unable to render the nginx template for %s, %w", vars.Hostname, err)
+	}
+
+	return nil
+}
+
+// ConfPath returns the path of the rendered configuration of a site.
+func ConfPath(dir, machine, hostname string) string {
+	return filepath.Join(dir, "nginx", machine, hostname+".conf")
+}
+
+// Write renders the configuration of the site to the directory.
+func Write(dir, machine string, t *template.Template, vars Vars) error {
+	var b bytes.Buffer
+	if err := Render(&b, t, vars); err != nil {
+		return err
+	}
+
+	file := ConfPath(dir, machine, vars.Hostname)
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(file, b.Bytes(), 0644)
+}
+
+var funcs = template.FuncMap{
+	"join": func(sep string, s []string) string {
+		return strings.Join(s, sep)
+	},
+	// quote returns the value as a quoted nginx string
+	"quote": func(s string) string {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+	},
+	// indent indents each line of the text, for snippets in blocks
+	"indent": func(spaces int, s string) string {
+		pad := strings.Repeat(" ", spaces)
+		lines := strings.Split(s, "\n")
+		for i, line := range lines {
+			if line != "" {
+				lines[i] = pad + line
+			}
+		}
+
+		return strings.Join(lines, "\n")
+	},
+}
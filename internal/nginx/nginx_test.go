@@ -0,0 +1,167 @@
+package nginx
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/preset"
+)
+
+func TestSiteVars(t *testing.T) {
+	site := config.Site{
+		Hostname: "demo.test",
+		Aliases:  []string{"www.demo.test"},
+		Webroot:  "/home/ubuntu/sites/demo/web",
+		Env:      []string{"APP_ENV=dev", "TOKEN=a=b"},
+	}
+
+	got, err := SiteVars(site, "7.4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Vars{
+		Hostname:       "demo.test",
+		Aliases:        []string{"www.demo.test"},
+		Webroot:        "/home/ubuntu/sites/demo/web",
+		PHPVersion:     "7.4",
+		PHPSocket:      "/var/run/php/php7.4-fpm.sock",
+		Env:            map[string]string{"APP_ENV": "dev", "TOKEN": "a=b"},
+		Certificate:    "/home/ubuntu/.nitro/certs/demo.test.crt",
+		CertificateKey: "/home/ubuntu/.nitro/certs/demo.test.key",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SiteVars() got = %v, want %v", got, want)
+	}
+
+	if got.ServerNames() != "demo.test www.demo.test" {
+		t.Errorf("ServerNames() got = %q", got.ServerNames())
+	}
+
+	site.Env = []string{"APP-ENV=dev"}
+	if _, err := SiteVars(site, "7.4"); err == nil {
+		t.Errorf("expected an invalid env name to return an error")
+	}
+
+	site.Env = []string{"DB_PASSWORD=pa$word"}
+	if _, err := SiteVars(site, "7.4"); err == nil {
+		t.Errorf("expected an env value with $ to return an error")
+	}
+}
+
+func TestWrite_CustomTemplate(t *testing.T) {
+	tmpl, err := ParseFile("testdata/custom.conf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "nitro-nginx-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	vars, err := SiteVars(config.Site{Hostname: "demo.test", Webroot: "/home/ubuntu/sites/demo/public", Env: []string{"APP_ENV=dev"}}, "8.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Write(dir, "nitro-dev", tmpl, vars); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(ConfPath(dir, "nitro-dev", "demo.test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `server {
+    listen 80;
+    server_name demo.test;
+    root /home/ubuntu/sites/demo/public;
+
+    location ~ \.php$ {
+        fastcgi_pass unix:/var/run/php/php8.0-fpm.sock;
+        fastcgi_param APP_ENV "dev";
+    }
+}
+`
+	if string(b) != want {
+		t.Errorf("Write() got = \n%s\nwant \n%s", b, want)
+	}
+}
+
+func TestRender_UnknownVariable(t *testing.T) {
+	tmpl, err := Parse("unknown", "root {{ .Docroot }};")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Render(ioutil.Discard, tmpl, Vars{Hostname: "demo.test"}); err == nil {
+		t.Errorf("expected an unknown variable to return an error")
+	}
+
+	if _, err := Parse("invalid", "root {{ .Webroot ;"); err == nil {
+		t.Errorf("expected an invalid template to return an error")
+	}
+}
+
+func TestRender_Presets(t *testing.T) {
+	for _, name := range preset.Names() {
+		t.Run(name, func(t *testing.T) {
+			p, _ := preset.Lookup(name)
+
+			tmpl, err := Parse(p.Name, p.Template)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			site := config.Site{
+				Hostname: "demo.test",
+				Aliases:  []string{"www.demo.test"},
+				Webroot:  "/home/ubuntu/sites/demo/" + p.Webroot,
+				Env:      p.Environment([]string{`GREETING=say "hi"`}),
+				Extra:    "location /old {\n    return 301 /new;\n}\n",
+			}
+			vars, err := SiteVars(site, "7.4")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var b bytes.Buffer
+			if err := Render(&b, tmpl, vars); err != nil {
+				t.Fatal(err)
+			}
+			conf := b.String()
+
+			lines := []string{
+				"    listen 443 ssl http2;\n",
+				"    ssl_certificate /home/ubuntu/.nitro/certs/demo.test.crt;\n",
+				"    ssl_certificate_key /home/ubuntu/.nitro/certs/demo.test.key;\n",
+				"    server_name demo.test www.demo.test;\n",
+				"    root " + site.Webroot + ";\n",
+				"    location /old {\n        return 301 /new;\n    }\n",
+			}
+			if name != "static" && name != "spa" {
+				lines = append(lines,
+					"        fastcgi_pass unix:/var/run/php/php7.4-fpm.sock;\n",
+					"        fastcgi_param GREETING \"say \\\"hi\\\"\";\n",
+				)
+			}
+			for _, line := range lines {
+				if !strings.Contains(conf, line) {
+					t.Errorf("expected the configuration to contain %q, got:\n%s", line, conf)
+				}
+			}
+
+			if strings.Count(conf, "{") != strings.Count(conf, "}") {
+				t.Errorf("expected the braces to be balanced, got:\n%s", conf)
+			}
+		})
+	}
+}
@@ -24,7 +24,16 @@ func CreateSiteSymllink(name, site string) (*Action, error) {
 	}, nil
 }
 
-func CopyNginxTemplate(name, hostname string) (*Action, error) {
+// NginxConfDir is the directory on the machine the nginx
+// configurations rendered on the host are copied to.
+const NginxConfDir = "/home/ubuntu/.nitro/nginx"
+
+// TransferNginxConfig copies the nginx configuration of the site rendered
+// on the host to the machine, it is not used by nginx until EnableNginxSite.
+func TransferNginxConfig(name, hostname, file string) (*[]Action, error) {
+	if err := validate.MachineName(name); err != nil {
+		return nil, err
+	}
 	if hostname == "" {
 		return nil, errors.New("hostname cannot be empty")
 	}
@@ -32,33 +41,58 @@ func CopyNginxTemplate(name, hostname string) (*Action, error) {
 		return nil, err
 	}
 
-	return &Action{
-		Type:       "exec",
-		UseSyscall: false,
-		Args:       []string{"exec", name, "--", "sudo", "cp", "/opt/nitro/nginx/template.conf", "/etc/nginx/sites-available/" + hostname},
-	}, nil
-}
-
-func ChangeTemplateVariables(name, webroot, hostname, php string, aliases []string) (*[]Action, error) {
-	var actions []Action
-	template := hostname
-	if aliases != nil {
-		hostname = hostname + " " + strings.Join(aliases, " ")
+	dest := NginxConfDir + "/" + hostname + ".conf"
+	actions := []Action{
+		{
+			Type:       "exec",
+			UseSyscall: false,
+			Args:       []string{"exec", name, "--", "mkdir", "-p", NginxConfDir},
+		},
+		{
+			Type:       "transfer",
+			UseSyscall: false,
+			Args:       []string{"transfer", file, name + ":" + dest},
+		},
 	}
 
-	actions = append(actions, *ChangeNginxTemplateVariable(name, template, "CHANGEWEBROOTDIR", webroot))
-	actions = append(actions, *ChangeNginxTemplateVariable(name, template, "CHANGESERVERNAME", hostname))
-	actions = append(actions, *ChangeNginxTemplateVariable(name, template, "CHANGEPHPVERSION", php))
-
 	return &actions, nil
 }
 
-func ChangeNginxTemplateVariable(machine, hostname, variable, actual string) *Action {
-	sedCmd := "s|" + variable + "|" + actual + "|g"
+// EnableNginxSite makes the configuration copied by TransferNginxConfig the
+// available site, links it to the enabled sites and validates the nginx
+// configuration. When the configuration is not valid the previous
+// configuration and link of the site are restored, so nginx is never
+// restarted with an invalid configuration.
+func EnableNginxSite(name, hostname string) (*Action, error) {
+	if err := validate.MachineName(name); err != nil {
+		return nil, err
+	}
+	if hostname == "" {
+		return nil, errors.New("hostname cannot be empty")
+	}
+	if err := validate.Hostname(hostname); err != nil {
+		return nil, err
+	}
+
+	conf := NginxConfDir + "/" + hostname + ".conf"
+	available := "/etc/nginx/sites-available/" + hostname
+	enabled := "/etc/nginx/sites-enabled/" + hostname
+	previous := available + ".nitro-previous"
+
+	script := []string{
+		fmt.Sprintf("rm -f %s", previous),
+		fmt.Sprintf("if [ -e %s ]; then cp -p %s %s; fi", available, available, previous),
+		fmt.Sprintf("if [ -e %s ]; then linked=1; fi", enabled),
+		fmt.Sprintf("cp %s %s && ln -sfn %s %s && nginx -t -q && rm -f %s && exit 0", conf, available, available, enabled, previous),
+		fmt.Sprintf("if [ -e %s ]; then mv %s %s; else rm -f %s; fi", previous, previous, available, available),
+		fmt.Sprintf(`if [ -z "$linked" ]; then rm -f %s; fi`, enabled),
+		fmt.Sprintf("echo 'the nginx configuration of %s is not valid, the changes were undone' >&2", hostname),
+		"exit 1",
+	}
 
 	return &Action{
 		Type:       "exec",
 		UseSyscall: false,
-		Args:       []string{"exec", machine, "--", "sudo", "sed", "-i", sedCmd, fmt.Sprintf("/etc/nginx/sites-available/%v", hostname)},
-	}
+		Args:       []string{"exec", name, "--", "sudo", "bash", "-c", strings.Join(script, "; ")},
+	}, nil
 }
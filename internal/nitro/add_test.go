@@ -0,0 +1,93 @@
+package nitro
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTransferNginxConfig(t *testing.T) {
+	type args struct {
+		name     string
+		hostname string
+		file     string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *[]Action
+		wantErr bool
+	}{
+		{
+			name: "valid args return actions",
+			args: args{name: "somename", hostname: "demo.test", file: "/home/me/.nitro/nginx/somename/demo.test.conf"},
+			want: &[]Action{
+				{
+					Type:       "exec",
+					UseSyscall: false,
+					Args:       []string{"exec", "somename", "--", "mkdir", "-p", "/home/ubuntu/.nitro/nginx"},
+				},
+				{
+					Type:       "transfer",
+					UseSyscall: false,
+					Args:       []string{"transfer", "/home/me/.nitro/nginx/somename/demo.test.conf", "somename:/home/ubuntu/.nitro/nginx/demo.test.conf"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid name returns error",
+			args:    args{name: "", hostname: "demo.test"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty hostname returns error",
+			args:    args{name: "somename", hostname: ""},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TransferNginxConfig(tt.args.name, tt.args.hostname, tt.args.file)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TransferNginxConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TransferNginxConfig() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnableNginxSite(t *testing.T) {
+	got, err := EnableNginxSite("somename", "demo.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	script := "rm -f /etc/nginx/sites-available/demo.test.nitro-previous; " +
+		"if [ -e /etc/nginx/sites-available/demo.test ]; then cp -p /etc/nginx/sites-available/demo.test /etc/nginx/sites-available/demo.test.nitro-previous; fi; " +
+		"if [ -e /etc/nginx/sites-enabled/demo.test ]; then linked=1; fi; " +
+		"cp /home/ubuntu/.nitro/nginx/demo.test.conf /etc/nginx/sites-available/demo.test && ln -sfn /etc/nginx/sites-available/demo.test /etc/nginx/sites-enabled/demo.test && nginx -t -q && rm -f /etc/nginx/sites-available/demo.test.nitro-previous && exit 0; " +
+		"if [ -e /etc/nginx/sites-available/demo.test.nitro-previous ]; then mv /etc/nginx/sites-available/demo.test.nitro-previous /etc/nginx/sites-available/demo.test; else rm -f /etc/nginx/sites-available/demo.test; fi; " +
+		`if [ -z "$linked" ]; then rm -f /etc/nginx/sites-enabled/demo.test; fi; ` +
+		"echo 'the nginx configuration of demo.test is not valid, the changes were undone' >&2; " +
+		"exit 1"
+
+	want := &Action{
+		Type:       "exec",
+		UseSyscall: false,
+		Args:       []string{"exec", "somename", "--", "sudo", "bash", "-c", script},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EnableNginxSite() got = %v, want %v", got, want)
+	}
+
+	for _, hostname := range []string{"", "demo.test; rm -rf /"} {
+		if _, err := EnableNginxSite("somename", hostname); err == nil {
+			t.Errorf("expected an error for the hostname %q", hostname)
+		}
+	}
+}
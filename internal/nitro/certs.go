@@ -0,0 +1,51 @@
+package nitro
+
+import (
+	"errors"
+
+	"github.com/craftcms/nitro/internal/validate"
+)
+
+// CertsDir is the directory on the machine with the certificates of the sites.
+const CertsDir = "/home/ubuntu/.nitro/certs"
+
+// TransferSiteCertificate copies the certificate and private key of the
+// site from the host to the certs directory on the machine, where the
+// nginx template expects them.
+func TransferSiteCertificate(name, hostname, cert, key string) (*[]Action, error) {
+	if err := validate.MachineName(name); err != nil {
+		return nil, err
+	}
+	if hostname == "" {
+		return nil, errors.New("hostname cannot be empty")
+	}
+	if err := validate.Hostname(hostname); err != nil {
+		return nil, err
+	}
+
+	dest := CertsDir + "/" + hostname
+	actions := []Action{
+		{
+			Type:       "exec",
+			UseSyscall: false,
+			Args:       []string{"exec", name, "--", "mkdir", "-p", CertsDir},
+		},
+		{
+			Type:       "transfer",
+			UseSyscall: false,
+			Args:       []string{"transfer", cert, name + ":" + dest + ".crt"},
+		},
+		{
+			Type:       "transfer",
+			UseSyscall: false,
+			Args:       []string{"transfer", key, name + ":" + dest + ".key"},
+		},
+		{
+			Type:       "exec",
+			UseSyscall: false,
+			Args:       []string{"exec", name, "--", "chmod", "600", dest + ".key"},
+		},
+	}
+
+	return &actions, nil
+}
@@ -0,0 +1,73 @@
+package nitro
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTransferSiteCertificate(t *testing.T) {
+	type args struct {
+		name     string
+		hostname string
+		cert     string
+		key      string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *[]Action
+		wantErr bool
+	}{
+		{
+			name: "valid args return actions",
+			args: args{name: "somename", hostname: "demo.test", cert: "/home/me/.nitro/certs/demo.test.crt", key: "/home/me/.nitro/certs/demo.test.key"},
+			want: &[]Action{
+				{
+					Type:       "exec",
+					UseSyscall: false,
+					Args:       []string{"exec", "somename", "--", "mkdir", "-p", "/home/ubuntu/.nitro/certs"},
+				},
+				{
+					Type:       "transfer",
+					UseSyscall: false,
+					Args:       []string{"transfer", "/home/me/.nitro/certs/demo.test.crt", "somename:/home/ubuntu/.nitro/certs/demo.test.crt"},
+				},
+				{
+					Type:       "transfer",
+					UseSyscall: false,
+					Args:       []string{"transfer", "/home/me/.nitro/certs/demo.test.key", "somename:/home/ubuntu/.nitro/certs/demo.test.key"},
+				},
+				{
+					Type:       "exec",
+					UseSyscall: false,
+					Args:       []string{"exec", "somename", "--", "chmod", "600", "/home/ubuntu/.nitro/certs/demo.test.key"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid name returns error",
+			args:    args{name: "", hostname: "demo.test"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty hostname returns error",
+			args:    args{name: "somename", hostname: ""},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TransferSiteCertificate(tt.args.name, tt.args.hostname, tt.args.cert, tt.args.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TransferSiteCertificate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TransferSiteCertificate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package nitro
 
 import (
+	"encoding/base64"
 	"fmt"
+	"path"
 	"strings"
 
+	"github.com/craftcms/nitro/internal/catalog"
 	"github.com/craftcms/nitro/internal/validate"
 )
 
@@ -15,62 +18,26 @@ func CreateDatabaseContainer(machine, engine, version, port string) (*Action, er
 		return nil, err
 	}
 
-	// get the container path and port based on the engine
-	var containerPath string
-	var containerPort string
-	var containerConfPath string
-	var containerInitPath string
-	var hostConfPath string
-	var hostInitPath string
-	var containerEnvVars []string
-
-	switch engine {
-	case "postgres":
-		containerPort = "5432"
-		containerPath = "/var/lib/postgresql/data"
-
-		hostConfPath = "/home/ubuntu/.nitro/databases/postgres/conf.d/"
-		containerConfPath = "/etc/postgresql/"
-
-		hostInitPath = "/home/ubuntu/.nitro/databases/postgres/setup.sql"
-		containerInitPath = "/docker-entrypoint-initdb.d/setup.sql"
-
-		containerEnvVars = []string{"-e", "POSTGRES_PASSWORD=nitro", "-e", "POSTGRES_USER=nitro"}
-	default:
-		containerPort = "3306"
-		containerPath = "/var/lib/mysql"
-
-		v := "5"
-		if strings.Contains(version, "8") {
-			v = "8"
-		}
-
-		hostConfPath = "/home/ubuntu/.nitro/databases/mysql/conf.d/" + v + "/"
-		containerConfPath = "/etc/mysql/conf.d"
-
-		hostInitPath = "/home/ubuntu/.nitro/databases/mysql/setup.sql"
-		containerInitPath = "/docker-entrypoint-initdb.d/setup.sql"
-
-		containerEnvVars = []string{"-e", "MYSQL_ROOT_PASSWORD=nitro", "-e", "MYSQL_DATABASE=nitro", "-e", "MYSQL_USER=nitro", "-e", "MYSQL_PASSWORD=nitro"}
-	}
+	e, _ := catalog.LookupEngine(engine)
+	v, _ := e.Lookup(version)
 
 	// create the volumeMount path using the engine, version, and port
 	volume := containerVolume(engine, version, port)
-	volumeMount := fmt.Sprintf("%s:%s", volume, containerPath)
+	volumeMount := fmt.Sprintf("%s:%s", volume, e.DataPath)
 
 	// build the container machine based on engine, version, and port
 	containerName := containerName(engine, version, port)
 
 	// create the port mapping
-	portMapping := fmt.Sprintf("%v:%v", port, containerPort)
+	portMapping := fmt.Sprintf("%v:%v", port, e.ContainerPort)
 
-	args := []string{"exec", machine, "--", "docker", "run", "-v", hostInitPath + ":" + containerInitPath, "-v", hostConfPath + ":" + containerConfPath, "-v", volumeMount, "--name", containerName, "-d", "--restart=always", "-p", portMapping}
+	args := []string{"exec", machine, "--", "docker", "run", "-v", e.SetupFile + ":" + e.InitPath, "-v", v.Conf + ":" + e.ConfPath, "-v", volumeMount, "--name", containerName, "-d", "--restart=always", "-p", portMapping}
 
 	// append the env vars
-	args = append(args, containerEnvVars...)
+	args = append(args, e.Env...)
 
 	// append the image and tag
-	args = append(args, engine+":"+version)
+	args = append(args, e.Image+":"+v.Tag)
 
 	return &Action{
 		Type:       "exec",
@@ -79,6 +46,47 @@ func CreateDatabaseContainer(machine, engine, version, port string) (*Action, er
 	}, nil
 }
 
+// mariadbFiles are mounted into MariaDB containers, new
+// machines also get the files from the cloud config.
+var mariadbFiles = []struct {
+	path    string
+	content string
+}{
+	{
+		path:    "/home/ubuntu/.nitro/databases/mariadb/conf.d/mariadb.cnf",
+		content: "[mysqld]\nmax_allowed_packet=256M\nwait_timeout=86400\n",
+	},
+	{
+		path: "/home/ubuntu/.nitro/databases/mariadb/setup.sql",
+		content: `CREATE USER IF NOT EXISTS 'nitro'@'localhost' IDENTIFIED BY 'nitro';
+CREATE USER IF NOT EXISTS 'nitro'@'%' IDENTIFIED BY 'nitro';
+GRANT ALL PRIVILEGES ON *.* TO 'nitro'@'localhost' WITH GRANT OPTION;
+GRANT ALL PRIVILEGES ON *.* TO 'nitro'@'%' WITH GRANT OPTION;
+FLUSH PRIVILEGES;
+`,
+	},
+}
+
+// CreateMariaDBConfig writes the files mounted into MariaDB containers on
+// machines created before MariaDB was supported, otherwise docker creates
+// directories in their place. Existing files are not changed.
+func CreateMariaDBConfig(machine string) (*Action, error) {
+	if err := validate.MachineName(machine); err != nil {
+		return nil, err
+	}
+
+	var script []string
+	for _, f := range mariadbFiles {
+		script = append(script, fmt.Sprintf("mkdir -p %s && if [ ! -e %s ]; then echo %s | base64 -d > %s; fi", path.Dir(f.path), f.path, base64.StdEncoding.EncodeToString([]byte(f.content)), f.path))
+	}
+
+	return &Action{
+		Type:       "exec",
+		UseSyscall: false,
+		Args:       []string{"exec", machine, "--", "bash", "-c", strings.Join(script, " && ")},
+	}, nil
+}
+
 // CreateDatabaseVolume will make a database vaolume to ensure that data is persisted during reboots.
 func CreateDatabaseVolume(machine, engine, version, port string) (*Action, error) {
 	if err := validate.DatabaseEngineAndVersion(engine, version); err != nil {
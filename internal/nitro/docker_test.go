@@ -1,7 +1,10 @@
 package nitro
 
 import (
+	"encoding/base64"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -48,6 +51,21 @@ func TestCreateDatabaseContainer(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "create mariadb 10.5",
+			args: args{
+				name:    "mariadbmachine",
+				engine:  "mariadb",
+				version: "10.5",
+				port:    "3307",
+			},
+			want: &Action{
+				Type:       "exec",
+				UseSyscall: false,
+				Args:       []string{"exec", "mariadbmachine", "--", "docker", "run", "-v", "/home/ubuntu/.nitro/databases/mariadb/setup.sql:/docker-entrypoint-initdb.d/setup.sql", "-v", "/home/ubuntu/.nitro/databases/mariadb/conf.d/:/etc/mysql/conf.d", "-v", "mariadb_10.5_3307:/var/lib/mysql", "--name", "mariadb_10.5_3307", "-d", "--restart=always", "-p", "3307:3306", "-e", "MYSQL_ROOT_PASSWORD=nitro", "-e", "MYSQL_DATABASE=nitro", "-e", "MYSQL_USER=nitro", "-e", "MYSQL_PASSWORD=nitro", "mariadb:10.5"},
+			},
+			wantErr: false,
+		},
 		{
 			name: "validation fails",
 			args: args{
@@ -127,3 +145,26 @@ func TestCreateDatabaseVolume(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateMariaDBConfig(t *testing.T) {
+	got, err := CreateMariaDBConfig("mariadbmachine")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got.Args) != 6 || !reflect.DeepEqual(got.Args[:5], []string{"exec", "mariadbmachine", "--", "bash", "-c"}) {
+		t.Fatalf("unexpected args %v", got.Args)
+	}
+
+	script := got.Args[5]
+	for _, f := range mariadbFiles {
+		want := fmt.Sprintf("if [ ! -e %s ]; then echo %s | base64 -d > %s; fi", f.path, base64.StdEncoding.EncodeToString([]byte(f.content)), f.path)
+		if !strings.Contains(script, want) {
+			t.Errorf("expected the script to write %s, got %s", f.path, script)
+		}
+	}
+
+	if _, err := CreateMariaDBConfig("not a machine"); err == nil {
+		t.Error("expected an error for an invalid machine name")
+	}
+}
@@ -40,7 +40,13 @@ func ConfigurePHPExecutionTimeLimit(name, php, limit string) (*Action, error) {
 	}, nil
 }
 
-func ConfigureXdebug(name, php string) (*Action, error) {
+// FPMStatusExpression is the sed expression that enables the status
+// page in the php-fpm pool configuration.
+const FPMStatusExpression = "s|;pm.status_path = /status|pm.status_path = /status|g"
+
+// ConfigurePHPFpmStatus enables the php-fpm status page so nitrod
+// can scrape the pool metrics.
+func ConfigurePHPFpmStatus(name, php string) (*Action, error) {
 	if err := validate.MachineName(name); err != nil {
 		return nil, err
 	}
@@ -51,6 +57,6 @@ func ConfigureXdebug(name, php string) (*Action, error) {
 	return &Action{
 		Type:       "exec",
 		UseSyscall: false,
-		Args:       []string{"exec", name, "--", "sudo", "cp", "/opt/nitro/php-xdebug.ini", "/etc/php/" + php + "/mods-available/xdebug.ini"},
+		Args:       []string{"exec", name, "--", "sudo", "sed", "-i", FPMStatusExpression, "/etc/php/" + php + "/fpm/pool.d/www.conf"},
 	}, nil
 }
@@ -127,7 +127,7 @@ func TestConfigurePHPExecutionTimeLimit(t *testing.T) {
 	}
 }
 
-func TestConfigureXdebug(t *testing.T) {
+func TestConfigurePHPFpmStatus(t *testing.T) {
 	type args struct {
 		name string
 		php  string
@@ -139,7 +139,7 @@ func TestConfigureXdebug(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "returns the printf command to set the config",
+			name: "returns the sed command to enable the status page",
 			args: args{
 				name: "somename",
 				php:  "7.4",
@@ -147,19 +147,10 @@ func TestConfigureXdebug(t *testing.T) {
 			want: &Action{
 				Type:       "exec",
 				UseSyscall: false,
-				Args:       []string{"exec", "somename", "--", "sudo", "cp", "/opt/nitro/php-xdebug.ini", "/etc/php/7.4/mods-available/xdebug.ini"},
+				Args:       []string{"exec", "somename", "--", "sudo", "sed", "-i", "s|;pm.status_path = /status|pm.status_path = /status|g", "/etc/php/7.4/fpm/pool.d/www.conf"},
 			},
 			wantErr: false,
 		},
-		{
-			name: "bad name returns error",
-			args: args{
-				name: "",
-				php:  "7.4",
-			},
-			want:    nil,
-			wantErr: true,
-		},
 		{
 			name: "bad php version returns error",
 			args: args{
@@ -172,13 +163,13 @@ func TestConfigureXdebug(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := ConfigureXdebug(tt.args.name, tt.args.php)
+			got, err := ConfigurePHPFpmStatus(tt.args.name, tt.args.php)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("ConfigureXdebug() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ConfigurePHPFpmStatus() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("ConfigureXdebug() got = \n%v, \nwant \n%v", got, tt.want)
+				t.Errorf("ConfigurePHPFpmStatus() got = \n%v, \nwant \n%v", got, tt.want)
 			}
 		})
 	}
@@ -1,88 +0,0 @@
-package nitro
-
-import (
-	"reflect"
-	"testing"
-)
-
-func TestUpdate(t *testing.T) {
-	type args struct {
-		name string
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    *Action
-		wantErr bool
-	}{
-		{
-			name: "valid args return nitro",
-			args: args{name: "somename"},
-			want: &Action{
-				Type:       "exec",
-				UseSyscall: false,
-				Args:       []string{"exec", "somename", "--", "sudo", "apt", "update"},
-			},
-			wantErr: false,
-		},
-		{
-			name:    "invalid name returns error",
-			args:    args{name: ""},
-			want:    nil,
-			wantErr: true,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := Update(tt.args.name)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("Update() got = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestUpgrade(t *testing.T) {
-	type args struct {
-		name string
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    *Action
-		wantErr bool
-	}{
-		{
-			name: "valid args return nitro",
-			args: args{name: "somename"},
-			want: &Action{
-				Type:       "exec",
-				UseSyscall: false,
-				Args:       []string{"exec", "somename", "--", "sudo", "apt", "upgrade", "-y"},
-			},
-			wantErr: false,
-		},
-		{
-			name:    "invalid name returns error",
-			args:    args{name: ""},
-			want:    nil,
-			wantErr: true,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := Upgrade(tt.args.name)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("Upgrade() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("Upgrade() got = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
@@ -0,0 +1,461 @@
+package nitrod
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// databaseEngine runs the engine specific queries
+// for a database server running in a container.
+type databaseEngine interface {
+	list(container string) ([]*Database, error)
+	create(container, database string) error
+	drop(container, database string) error
+	rename(container, database, name string) error
+	// dump writes a backup of the database to the writer
+	dump(ctx context.Context, container, database string, w io.Writer) error
+	// columns returns the columns of the tables in the database
+	columns(container, database string) ([]tableColumn, error)
+	// update sets the column to the SQL expression and returns the number of rows changed
+	update(container, database string, column tableColumn, expr string) (int64, error)
+	// execute runs the SQL and returns the rows of the last statement
+	execute(ctx context.Context, container, database, sql string) (*QueryDatabaseResponse, error)
+}
+
+// tableColumn is a column of a table, the schema
+// is the database in MySQL.
+type tableColumn struct {
+	schema string
+	table  string
+	column string
+}
+
+// databaseEngine returns the implementation for the engine.
+func (s *NitroService) databaseEngine(engine string) (databaseEngine, error) {
+	switch engine {
+	case "mysql", "mariadb":
+		return &mysqlEngine{command: s.command}, nil
+	case "postgres":
+		return &postgresEngine{command: s.command}, nil
+	}
+
+	return nil, status.Errorf(codes.InvalidArgument, "unknown database engine %q", engine)
+}
+
+// mysqlSystemDatabases are not shown to users.
+var mysqlSystemDatabases = []string{"information_schema", "performance_schema", "sys", "mysql"}
+
+// mysqlEngine is also used for MariaDB, the
+// MariaDB 10 images include the mysql clients.
+type mysqlEngine struct {
+	command Runner
+}
+
+// query runs the SQL and returns the rows as tab separated columns. The password
+// is set in the environment so mysql does not print a warning with the output.
+func (e *mysqlEngine) query(container, sql string) ([][]string, error) {
+	output, err := e.command.Run("docker", []string{"exec", "-i", "-e", "MYSQL_PWD=nitro", container, "mysql", "-unitro", "--batch", "--skip-column-names", "-e", sql})
+	if err != nil {
+		return nil, fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+
+	return rows(output), nil
+}
+
+func (e *mysqlEngine) list(container string) ([]*Database, error) {
+	rows, err := e.query(container, fmt.Sprintf(`SELECT s.SCHEMA_NAME, s.DEFAULT_CHARACTER_SET_NAME, COALESCE(SUM(t.DATA_LENGTH + t.INDEX_LENGTH), 0), COUNT(t.TABLE_NAME)
+FROM information_schema.SCHEMATA s LEFT JOIN information_schema.TABLES t ON t.TABLE_SCHEMA = s.SCHEMA_NAME
+WHERE s.SCHEMA_NAME NOT IN (%s)
+GROUP BY s.SCHEMA_NAME, s.DEFAULT_CHARACTER_SET_NAME ORDER BY s.SCHEMA_NAME`, mysqlList(mysqlSystemDatabases)))
+	if err != nil {
+		return nil, err
+	}
+
+	var dbs []*Database
+	for _, r := range rows {
+		if len(r) != 4 {
+			continue
+		}
+
+		size, _ := strconv.ParseInt(r[2], 10, 64)
+		tables, _ := strconv.ParseInt(r[3], 10, 64)
+		dbs = append(dbs, &Database{Name: r[0], Charset: r[1], Size: size, Tables: tables})
+	}
+
+	return dbs, nil
+}
+
+func (e *mysqlEngine) create(container, database string) error {
+	_, err := e.query(container, "CREATE DATABASE IF NOT EXISTS "+mysqlIdentifier(database))
+	return err
+}
+
+func (e *mysqlEngine) drop(container, database string) error {
+	_, err := e.query(container, "DROP DATABASE IF EXISTS "+mysqlIdentifier(database))
+	return err
+}
+
+// rename moves every table into a new database since MySQL cannot rename
+// databases. Views, stored routines, events and triggers are tied to the
+// database name so they are not moved, and databases with them are not renamed.
+func (e *mysqlEngine) rename(container, database, name string) error {
+	tables, err := e.query(container, fmt.Sprintf("SELECT TABLE_NAME, TABLE_TYPE FROM information_schema.TABLES WHERE TABLE_SCHEMA = %s", mysqlString(database)))
+	if err != nil {
+		return err
+	}
+
+	var renames []string
+	for _, t := range tables {
+		if len(t) != 2 {
+			continue
+		}
+
+		if t[1] == "VIEW" {
+			return status.Errorf(codes.FailedPrecondition, "the database %q has views which cannot be renamed, drop the views and try again", database)
+		}
+
+		renames = append(renames, mysqlIdentifier(database)+"."+mysqlIdentifier(t[0])+" TO "+mysqlIdentifier(name)+"."+mysqlIdentifier(t[0]))
+	}
+
+	// routines and events would be dropped with the old database, and tables
+	// with triggers cannot be moved to another database
+	objects, err := e.query(container, fmt.Sprintf(`SELECT (SELECT COUNT(*) FROM information_schema.ROUTINES WHERE ROUTINE_SCHEMA = %[1]s),
+(SELECT COUNT(*) FROM information_schema.EVENTS WHERE EVENT_SCHEMA = %[1]s),
+(SELECT COUNT(*) FROM information_schema.TRIGGERS WHERE TRIGGER_SCHEMA = %[1]s)`, mysqlString(database)))
+	if err != nil {
+		return err
+	}
+	if len(objects) == 1 && len(objects[0]) == 3 {
+		var found []string
+		for i, kind := range []string{"stored procedures or functions", "events", "triggers"} {
+			if n, _ := strconv.ParseInt(objects[0][i], 10, 64); n > 0 {
+				found = append(found, kind)
+			}
+		}
+
+		if len(found) > 0 {
+			return status.Errorf(codes.FailedPrecondition, "the database %q has %s which cannot be renamed, drop them and try again", database, strings.Join(found, " and "))
+		}
+	}
+
+	charset, err := e.query(container, fmt.Sprintf("SELECT DEFAULT_CHARACTER_SET_NAME FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = %s", mysqlString(database)))
+	if err != nil {
+		return err
+	}
+
+	create := "CREATE DATABASE " + mysqlIdentifier(name)
+	if len(charset) == 1 && len(charset[0]) == 1 {
+		create += " CHARACTER SET " + charset[0][0]
+	}
+	if _, err := e.query(container, create); err != nil {
+		return err
+	}
+
+	// rename all of the tables in a single statement so it is atomic
+	if len(renames) > 0 {
+		if _, err := e.query(container, "RENAME TABLE "+strings.Join(renames, ", ")); err != nil {
+			_ = e.drop(container, name)
+			return err
+		}
+	}
+
+	return e.drop(container, database)
+}
+
+// dump uses a single transaction so InnoDB tables are
+// backed up consistently without locking the database.
+func (e *mysqlEngine) dump(ctx context.Context, container, database string, w io.Writer) error {
+	return runDump(ctx, e.command, []string{"exec", "-e", "MYSQL_PWD=nitro", container, "mysqldump", "-unitro", "--single-transaction", "--routines", "--triggers", database}, w)
+}
+
+func (e *mysqlEngine) columns(container, database string) ([]tableColumn, error) {
+	rows, err := e.query(container, fmt.Sprintf(`SELECT c.TABLE_NAME, c.COLUMN_NAME FROM information_schema.COLUMNS c
+JOIN information_schema.TABLES t ON t.TABLE_SCHEMA = c.TABLE_SCHEMA AND t.TABLE_NAME = c.TABLE_NAME
+WHERE c.TABLE_SCHEMA = %s AND t.TABLE_TYPE = 'BASE TABLE' ORDER BY c.TABLE_NAME, c.ORDINAL_POSITION`, mysqlString(database)))
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []tableColumn
+	for _, r := range rows {
+		if len(r) == 2 {
+			columns = append(columns, tableColumn{schema: database, table: r[0], column: r[1]})
+		}
+	}
+
+	return columns, nil
+}
+
+// update uses ROW_COUNT, which only counts the rows where the value changed.
+func (e *mysqlEngine) update(container, database string, column tableColumn, expr string) (int64, error) {
+	rows, err := e.query(container, fmt.Sprintf("UPDATE %s.%s SET %s = %s; SELECT ROW_COUNT()", mysqlIdentifier(column.schema), mysqlIdentifier(column.table), mysqlIdentifier(column.column), expr))
+	if err != nil {
+		return 0, err
+	}
+
+	return count(rows), nil
+}
+
+// execute uses the XML output of mysql as it can tell NULL apart from the
+// string NULL, each statement has its own resultset element.
+func (e *mysqlEngine) execute(ctx context.Context, container, database, sql string) (*QueryDatabaseResponse, error) {
+	var output bytes.Buffer
+	if err := runDump(ctx, e.command, []string{"exec", "-i", "-e", "MYSQL_PWD=nitro", container, "mysql", "-unitro", "--xml", "--database", database, "-e", sql}, &output); err != nil {
+		return nil, err
+	}
+
+	type field struct {
+		Name  string `xml:"name,attr"`
+		Nil   bool   `xml:"nil,attr"`
+		Value string `xml:",chardata"`
+	}
+	type resultset struct {
+		Rows []struct {
+			Fields []field `xml:"field"`
+		} `xml:"row"`
+	}
+
+	resp := &QueryDatabaseResponse{}
+	d := xml.NewDecoder(&output)
+	for {
+		var rs resultset
+		if err := d.Decode(&rs); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("unable to read the output of mysql: %w", err)
+		}
+
+		resp = &QueryDatabaseResponse{}
+		for i, r := range rs.Rows {
+			row := &QueryRow{}
+			for _, f := range r.Fields {
+				if i == 0 {
+					resp.Columns = append(resp.Columns, f.Name)
+				}
+				row.Values = append(row.Values, &QueryValue{Value: f.Value, Null: f.Nil})
+			}
+			resp.Rows = append(resp.Rows, row)
+		}
+	}
+
+	return resp, nil
+}
+
+type postgresEngine struct {
+	command Runner
+}
+
+// query runs the SQL on the database and returns the rows as tab separated columns.
+func (e *postgresEngine) query(container, database, sql string) ([][]string, error) {
+	output, err := e.command.Run("docker", []string{"exec", "-i", container, "psql", "--username", "nitro", "--dbname", database, "--no-align", "--tuples-only", "--field-separator", "\t", "--command", sql})
+	if err != nil {
+		return nil, fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+
+	return rows(output), nil
+}
+
+func (e *postgresEngine) list(container string) ([]*Database, error) {
+	rows, err := e.query(container, "postgres", `SELECT datname, pg_database_size(datname), pg_encoding_to_char(encoding) FROM pg_database WHERE datistemplate = false AND datname <> 'postgres' ORDER BY datname`)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbs []*Database
+	for _, r := range rows {
+		if len(r) != 3 {
+			continue
+		}
+
+		size, _ := strconv.ParseInt(r[1], 10, 64)
+		db := &Database{Name: r[0], Size: size, Charset: r[2]}
+
+		// tables can only be counted when connected to the database
+		tables, err := e.query(container, db.Name, `SELECT count(*) FROM information_schema.tables WHERE table_schema NOT IN ('pg_catalog', 'information_schema')`)
+		if err == nil && len(tables) == 1 && len(tables[0]) == 1 {
+			db.Tables, _ = strconv.ParseInt(tables[0][0], 10, 64)
+		}
+
+		dbs = append(dbs, db)
+	}
+
+	return dbs, nil
+}
+
+func (e *postgresEngine) create(container, database string) error {
+	_, err := e.query(container, "postgres", "CREATE DATABASE "+postgresIdentifier(database))
+	return err
+}
+
+func (e *postgresEngine) drop(container, database string) error {
+	if err := e.disconnect(container, database); err != nil {
+		return err
+	}
+
+	_, err := e.query(container, "postgres", "DROP DATABASE IF EXISTS "+postgresIdentifier(database))
+	return err
+}
+
+func (e *postgresEngine) rename(container, database, name string) error {
+	if err := e.disconnect(container, database); err != nil {
+		return err
+	}
+
+	_, err := e.query(container, "postgres", "ALTER DATABASE "+postgresIdentifier(database)+" RENAME TO "+postgresIdentifier(name))
+	return err
+}
+
+func (e *postgresEngine) dump(ctx context.Context, container, database string, w io.Writer) error {
+	return runDump(ctx, e.command, []string{"exec", container, "pg_dump", "--username", "nitro", database}, w)
+}
+
+func (e *postgresEngine) columns(container, database string) ([]tableColumn, error) {
+	rows, err := e.query(container, database, `SELECT c.table_schema, c.table_name, c.column_name FROM information_schema.columns c
+JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+WHERE t.table_type = 'BASE TABLE' AND c.table_schema NOT IN ('pg_catalog', 'information_schema')
+ORDER BY c.table_schema, c.table_name, c.ordinal_position`)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []tableColumn
+	for _, r := range rows {
+		if len(r) == 3 {
+			columns = append(columns, tableColumn{schema: r[0], table: r[1], column: r[2]})
+		}
+	}
+
+	return columns, nil
+}
+
+// update only changes the rows with a different value so they are counted the same as MySQL.
+func (e *postgresEngine) update(container, database string, column tableColumn, expr string) (int64, error) {
+	name := postgresIdentifier(column.column)
+	rows, err := e.query(container, database, fmt.Sprintf("WITH changed AS (UPDATE %s.%s SET %s = %s WHERE %s IS DISTINCT FROM %s RETURNING 1) SELECT count(*) FROM changed",
+		postgresIdentifier(column.schema), postgresIdentifier(column.table), name, expr, name, expr))
+	if err != nil {
+		return 0, err
+	}
+
+	return count(rows), nil
+}
+
+// the separators and NULL value of the output of queries, control
+// characters are used as they are not expected in the values
+const (
+	postgresFieldSeparator  = "\x1f"
+	postgresRecordSeparator = "\x1e"
+	postgresNull            = "\x1a"
+)
+
+// execute runs the SQL as a single command so psql only returns the rows of the
+// last statement, the first record of the output has the names of the columns.
+func (e *postgresEngine) execute(ctx context.Context, container, database, sql string) (*QueryDatabaseResponse, error) {
+	var output bytes.Buffer
+	if err := runDump(ctx, e.command, []string{"exec", "-i", container, "psql", "--username", "nitro", "--dbname", database, "--no-psqlrc", "--quiet", "--no-align",
+		"--field-separator", postgresFieldSeparator, "--record-separator", postgresRecordSeparator, "--pset", "null=" + postgresNull, "--pset", "footer=off", "--command", sql}, &output); err != nil {
+		return nil, err
+	}
+
+	resp := &QueryDatabaseResponse{}
+	out := strings.TrimSuffix(strings.TrimRight(output.String(), "\n"), postgresRecordSeparator)
+	if out == "" {
+		return resp, nil
+	}
+
+	records := strings.Split(out, postgresRecordSeparator)
+	resp.Columns = strings.Split(records[0], postgresFieldSeparator)
+	for _, record := range records[1:] {
+		row := &QueryRow{}
+		for _, v := range strings.Split(record, postgresFieldSeparator) {
+			if v == postgresNull {
+				row.Values = append(row.Values, &QueryValue{Null: true})
+				continue
+			}
+			row.Values = append(row.Values, &QueryValue{Value: v})
+		}
+		resp.Rows = append(resp.Rows, row)
+	}
+
+	return resp, nil
+}
+
+// disconnect closes the connections to the database, PostgreSQL
+// will not drop or rename a database with open connections.
+func (e *postgresEngine) disconnect(container, database string) error {
+	_, err := e.query(container, "postgres", fmt.Sprintf("SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = %s AND pid <> pg_backend_pid()", postgresString(database)))
+	return err
+}
+
+// runDump runs docker with the output, such as a dump, written to the
+// writer, the errors are kept separate so they do not end up in the output.
+func runDump(ctx context.Context, command Runner, args []string, w io.Writer) error {
+	var stderr bytes.Buffer
+	if err := command.RunContext(ctx, "docker", args, nil, w, &stderr); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s", msg)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// rows splits the output of a query into rows of tab separated columns.
+func rows(output []byte) [][]string {
+	var r [][]string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line == "" {
+			continue
+		}
+
+		r = append(r, strings.Split(line, "\t"))
+	}
+
+	return r
+}
+
+// count returns the number in the last row of a query, such as SELECT count(*).
+func count(rows [][]string) int64 {
+	if len(rows) == 0 || len(rows[len(rows)-1]) != 1 {
+		return 0
+	}
+
+	n, _ := strconv.ParseInt(rows[len(rows)-1][0], 10, 64)
+
+	return n
+}
+
+func mysqlList(values []string) string {
+	var quoted []string
+	for _, v := range values {
+		quoted = append(quoted, mysqlString(v))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+func mysqlIdentifier(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
+
+func postgresIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func mysqlString(s string) string {
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), "'", "''") + "'"
+}
+
+func postgresString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,163 @@
+// +build linux
+
+package nitrod
+
+import (
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// runAsUser sets the user and groups the command runs as,
+// an empty username runs the command as the current user.
+func runAsUser(cmd *exec.Cmd, username string) error {
+	if username == "" {
+		return nil
+	}
+
+	u, err := user.Lookup(username)
+	if err != nil {
+		return err
+	}
+
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	gid, err := strconv.ParseUint(u.Gid, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	// include the groups so the user can still run docker
+	var groups []uint32
+	if ids, err := u.GroupIds(); err == nil {
+		for _, id := range ids {
+			if g, err := strconv.ParseUint(id, 10, 32); err == nil {
+				groups = append(groups, uint32(g))
+			}
+		}
+	}
+
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Credential = &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid), Groups: groups}
+
+	return nil
+}
+
+// setProcessGroup starts the command in a new process
+// group so any child processes are killed with it.
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// killProcess kills the process group of the command.
+func killProcess(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+
+	_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
+
+func exitSignal(err *exec.ExitError) int {
+	if ws, ok := err.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		return int(ws.Signal())
+	}
+
+	return 0
+}
+
+// startTerminal starts the command in a new session with a
+// pseudo terminal as its stdin, stdout and stderr.
+func startTerminal(cmd *exec.Cmd, size *TerminalSize) (*execProcess, error) {
+	master, slave, err := openTerminal()
+	if err != nil {
+		return nil, err
+	}
+	// the command has its own copy of the terminal
+	defer slave.Close()
+
+	if size != nil {
+		if err := setTerminalSize(master, size); err != nil {
+			master.Close()
+			return nil, err
+		}
+	}
+
+	cmd.Stdin = slave
+	cmd.Stdout = slave
+	cmd.Stderr = slave
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setsid = true
+	cmd.SysProcAttr.Setctty = true
+
+	if err := cmd.Start(); err != nil {
+		master.Close()
+		return nil, err
+	}
+
+	return &execProcess{
+		cmd:    cmd,
+		stdin:  master,
+		stdout: master,
+		// closing the terminal would hang up, so send an end of file (ctrl+d)
+		closeStdin: func() error {
+			_, err := master.Write([]byte{4})
+			return err
+		},
+		resize: func(size *TerminalSize) error {
+			return setTerminalSize(master, size)
+		},
+		close: func() {
+			master.Close()
+		},
+	}, nil
+}
+
+// openTerminal opens a new pseudo terminal and returns both ends.
+func openTerminal() (*os.File, *os.File, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fd := int(master.Fd())
+	if err := unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0); err != nil {
+		master.Close()
+		return nil, nil, err
+	}
+
+	n, err := unix.IoctlGetInt(fd, unix.TIOCGPTN)
+	if err != nil {
+		master.Close()
+		return nil, nil, err
+	}
+
+	slave, err := os.OpenFile("/dev/pts/"+strconv.Itoa(n), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		return nil, nil, err
+	}
+
+	return master, slave, nil
+}
+
+func setTerminalSize(f *os.File, size *TerminalSize) error {
+	return unix.IoctlSetWinsize(int(f.Fd()), unix.TIOCSWINSZ, &unix.Winsize{
+		Row: uint16(size.GetRows()),
+		Col: uint16(size.GetCols()),
+	})
+}
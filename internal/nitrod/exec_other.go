@@ -0,0 +1,35 @@
+// +build !linux
+
+package nitrod
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// runAsUser is only supported on linux, where nitrod runs.
+func runAsUser(cmd *exec.Cmd, username string) error {
+	if username == "" {
+		return nil
+	}
+
+	return errors.New("running commands as another user is only supported on linux")
+}
+
+func setProcessGroup(cmd *exec.Cmd) {}
+
+func killProcess(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+
+	_ = cmd.Process.Kill()
+}
+
+func exitSignal(err *exec.ExitError) int {
+	return 0
+}
+
+func startTerminal(cmd *exec.Cmd, size *TerminalSize) (*execProcess, error) {
+	return nil, errors.New("terminals are only supported on linux")
+}
@@ -1,10 +1,21 @@
 package nitrod
 
-import "os/exec"
+import (
+	"context"
+	"io"
+	"os/exec"
+)
 
 // Runner is an interface to run commands.
 type Runner interface {
 	Run(command string, args []string) ([]byte, error)
+	// RunInput runs the command using the reader as stdin.
+	RunInput(command string, args []string, input io.Reader) ([]byte, error)
+	// RunContext runs the command until it exits or the context is
+	// canceled, the input is optional and the output is written to
+	// stdout and stderr as the command runs. They can be the same
+	// writer to combine the output.
+	RunContext(ctx context.Context, command string, args []string, input io.Reader, stdout, stderr io.Writer) error
 }
 
 // ServiceRunner is an implementation of the Runner interface
@@ -15,3 +26,23 @@ type ServiceRunner struct{}
 func (r ServiceRunner) Run(command string, args []string) ([]byte, error) {
 	return exec.Command(command, args...).CombinedOutput()
 }
+
+// RunInput sends the commands provided to exec.Command
+// and copies the input to the commands stdin.
+func (r ServiceRunner) RunInput(command string, args []string, input io.Reader) ([]byte, error) {
+	cmd := exec.Command(command, args...)
+	cmd.Stdin = input
+
+	return cmd.CombinedOutput()
+}
+
+// RunContext sends the commands provided to exec.CommandContext
+// so the command is killed when the context is canceled.
+func (r ServiceRunner) RunContext(ctx context.Context, command string, args []string, input io.Reader, stdout, stderr io.Writer) error {
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Stdin = input
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	return cmd.Run()
+}
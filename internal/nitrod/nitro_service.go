@@ -3,23 +3,63 @@ package nitrod
 import (
 	"log"
 	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/craftcms/nitro/internal/jobs"
 )
 
-// Version is the nitro version, not using it in the
-// api yet.
+// Version is the nitro version, it is set when building
+// nitrod and is compared with the CLI version.
 var Version string
 
 // NitroService is the struct that runs the gRPC API
 type NitroService struct {
 	command Runner
 	logger  *log.Logger
+	// phpDir is the directory containing the
+	// config for each installed PHP version
+	phpDir string
+	// importDir is where database uploads are stored
+	// until they are imported, so they can be resumed
+	importDir string
+	// homeDir is the only directory Exec runs commands in
+	homeDir string
+	// execUser is the user Exec runs commands as
+	execUser string
+	// jobs runs the long operations in the background
+	jobs *jobs.Manager
+	// backupDir is where the automatic backups are written, the
+	// machine's backups directory on the host is mounted there
+	backupDir string
+	// backupFile has the automatic backup schedule
+	backupFile     string
+	backupMu       sync.Mutex
+	backupsChanged chan struct{}
+	// lookPath finds the commands that are not installed
+	// on every machine, such as age for encrypted backups
+	lookPath func(file string) (string, error)
+	// snapshotMu stops snapshots and rollbacks of
+	// a container from running at the same time
+	snapshotMu sync.Mutex
 }
 
 // NewNitroService will create a new service
 // with the default command and logger
 func NewNitroService() *NitroService {
+	logger := log.New(os.Stdout, "nitrod ", 0)
+
 	return &NitroService{
-		command: &ServiceRunner{},
-		logger:  log.New(os.Stdout, "nitrod ", 0),
+		command:        &ServiceRunner{},
+		logger:         logger,
+		phpDir:         "/etc/php",
+		importDir:      "/home/ubuntu/.nitro/databases/imports",
+		homeDir:        "/home/ubuntu",
+		execUser:       "ubuntu",
+		jobs:           jobs.NewManager("/home/ubuntu/.nitro/jobs", logger),
+		backupDir:      "/home/ubuntu/.nitro/backups",
+		backupFile:     "/home/ubuntu/.nitro/backups.json",
+		backupsChanged: make(chan struct{}, 1),
+		lookPath:       exec.LookPath,
 	}
 }
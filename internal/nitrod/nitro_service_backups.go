@@ -0,0 +1,373 @@
+package nitrod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/craftcms/nitro/internal/backup"
+	"github.com/craftcms/nitro/internal/encrypt"
+	"github.com/craftcms/nitro/internal/validate"
+)
+
+const jobKindBackup = "backup"
+
+// defaultBackupKeep is the number of backups of each
+// database kept when there is no retention.
+const defaultBackupKeep = 7
+
+// backupSchedule is the automatic backup config, it is saved with the
+// last run so the schedule continues when nitrod or the machine restarts.
+type backupSchedule struct {
+	Schedule    string         `json:"schedule"`
+	Targets     []backupTarget `json:"targets"`
+	Keep        int            `json:"keep"`
+	Days        int            `json:"days"`
+	Compression string         `json:"compression"`
+	Recipient   string         `json:"recipient,omitempty"`
+	UTCOffset   int            `json:"utcOffset"`
+	LastRun     time.Time      `json:"lastRun"`
+}
+
+type backupTarget struct {
+	Engine    string   `json:"engine"`
+	Container string   `json:"container"`
+	Databases []string `json:"databases,omitempty"`
+}
+
+// ConfigureBackups saves the automatic backup schedule and wakes up the
+// scheduler. The time of the last backup is kept, so applying the same
+// config again does not start another backup.
+func (s *NitroService) ConfigureBackups(ctx context.Context, req *ConfigureBackupsRequest) (*ServiceResponse, error) {
+	s.backupMu.Lock()
+	defer s.backupMu.Unlock()
+
+	if req.GetSchedule() == "" {
+		if err := os.Remove(s.backupFile); err != nil && !os.IsNotExist(err) {
+			s.logger.Println("error removing the backup schedule, error:", err)
+			return nil, status.Errorf(codes.Internal, "unable to stop the automatic backups")
+		}
+		s.backupsUpdated()
+
+		return &ServiceResponse{Message: "Automatic backups are disabled"}, nil
+	}
+
+	if _, err := backup.ParseSchedule(req.GetSchedule()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	if _, err := backup.CompressionFormat(req.GetCompression()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	// the recipient is a key and not the ID of a key since the keys of the host are not on the machine
+	if r := req.GetRecipient(); r != "" && !encrypt.IsAgeRecipient(r) && !strings.HasPrefix(r, "-----BEGIN PGP PUBLIC KEY BLOCK-----") {
+		return nil, status.Errorf(codes.InvalidArgument, "the recipient must be an age recipient or an armored GPG public key")
+	}
+
+	// age is not installed on the machines, the backups would fail when they run
+	if r := req.GetRecipient(); encrypt.IsAgeRecipient(r) {
+		if _, err := s.lookPath("age"); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "age is not installed on the machine, use a GPG public key to encrypt the automatic backups")
+		}
+	}
+
+	if req.GetKeep() < 0 || req.GetDays() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "the number of backups and days to keep cannot be negative")
+	}
+
+	if len(req.GetTargets()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "there are no database engines to backup")
+	}
+
+	schedule := backupSchedule{
+		Schedule:    req.GetSchedule(),
+		Keep:        int(req.GetKeep()),
+		Days:        int(req.GetDays()),
+		Compression: req.GetCompression(),
+		Recipient:   req.GetRecipient(),
+		UTCOffset:   int(req.GetUtcOffset()),
+	}
+
+	for _, t := range req.GetTargets() {
+		if _, err := s.databaseEngine(t.GetEngine()); err != nil {
+			return nil, err
+		}
+		if t.GetContainer() == "" || strings.ContainsAny(t.GetContainer(), `/\`) {
+			return nil, status.Errorf(codes.InvalidArgument, "the container %q is not valid", t.GetContainer())
+		}
+		for _, db := range t.GetDatabases() {
+			if err := validate.DatabaseName(db); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, err.Error())
+			}
+			// the name is used for the backup file
+			if strings.ContainsAny(db, `/\`) {
+				return nil, status.Errorf(codes.InvalidArgument, "the database %q is not valid", db)
+			}
+		}
+
+		schedule.Targets = append(schedule.Targets, backupTarget{Engine: t.GetEngine(), Container: t.GetContainer(), Databases: t.GetDatabases()})
+	}
+
+	if existing, err := s.loadBackupSchedule(); err == nil && existing != nil {
+		schedule.LastRun = existing.LastRun
+	}
+
+	if err := s.saveBackupSchedule(schedule); err != nil {
+		s.logger.Println("error saving the backup schedule, error:", err)
+		return nil, status.Errorf(codes.Internal, "unable to save the backup schedule")
+	}
+	s.backupsUpdated()
+
+	return &ServiceResponse{Message: "Scheduled the automatic backups to run " + schedule.Schedule}, nil
+}
+
+// ScheduleBackups runs the automatic backups as jobs until the context is
+// canceled. The next backup is based on the last run, so a backup that was
+// missed while the machine was stopped runs when nitrod starts.
+func (s *NitroService) ScheduleBackups(ctx context.Context) {
+	for {
+		var next <-chan time.Time
+		var timer *time.Timer
+
+		schedule, err := s.loadBackupSchedule()
+		if err != nil {
+			s.logger.Println("error loading the backup schedule, error:", err)
+		}
+		if schedule != nil {
+			interval, err := backup.ParseSchedule(schedule.Schedule)
+			if err == nil {
+				timer = time.NewTimer(time.Until(schedule.LastRun.Add(interval)))
+				next = timer.C
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-s.backupsChanged:
+		case <-next:
+			s.startBackups()
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// startBackups records the run and starts the backup job, unless
+// the previous backup is still running.
+func (s *NitroService) startBackups() {
+	s.backupMu.Lock()
+	defer s.backupMu.Unlock()
+
+	schedule, err := s.loadBackupSchedule()
+	if err != nil || schedule == nil {
+		return
+	}
+
+	schedule.LastRun = time.Now()
+	if err := s.saveBackupSchedule(*schedule); err != nil {
+		s.logger.Println("error saving the backup schedule, error:", err)
+		return
+	}
+
+	for _, j := range s.jobs.List() {
+		if !j.Done() && j.Kind == jobKindBackup {
+			s.logger.Printf("Skipping the automatic backup, job %s is still running", j.ID)
+			return
+		}
+	}
+
+	run := *schedule
+	if _, err := s.jobs.Start(jobKindBackup, "Back up the databases", func(ctx context.Context, log io.Writer) error {
+		return s.backupDatabases(ctx, run, log)
+	}); err != nil {
+		s.logger.Println("error starting the automatic backup, error:", err)
+	}
+}
+
+// backupDatabases backs up each database in the targets and then removes
+// the expired backups. A database that fails does not stop the others.
+func (s *NitroService) backupDatabases(ctx context.Context, schedule backupSchedule, log io.Writer) error {
+	loc := time.FixedZone("host", schedule.UTCOffset)
+
+	var failed []string
+	for _, t := range schedule.Targets {
+		engine, err := s.databaseEngine(t.Engine)
+		if err != nil {
+			return err
+		}
+
+		databases := t.Databases
+		if len(databases) == 0 {
+			dbs, err := engine.list(t.Container)
+			if err != nil {
+				fmt.Fprintf(log, "Unable to list the databases in %s: %s\n", t.Container, err)
+				failed = append(failed, t.Container)
+				continue
+			}
+
+			for _, db := range dbs {
+				databases = append(databases, db.GetName())
+			}
+		}
+
+		for _, database := range databases {
+			opts := backup.Options{Compression: schedule.Compression, Encryption: encrypt.Options{Recipient: schedule.Recipient}}
+			name, err := s.backupDatabase(ctx, engine, t.Container, database, opts, time.Now().In(loc))
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != nil {
+				fmt.Fprintf(log, "Unable to back up %s in %s: %s\n", database, t.Container, err)
+				failed = append(failed, t.Container+"/"+database)
+				continue
+			}
+
+			fmt.Fprintf(log, "Backed up %s in %s to %s\n", database, t.Container, name)
+		}
+	}
+
+	s.removeExpiredBackups(schedule, log)
+
+	if len(failed) > 0 {
+		return fmt.Errorf("unable to back up %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// backupDatabase dumps the database into the container's backup directory,
+// compressed and encrypted using the options, and writes its manifest. The
+// dump is written to a hidden file and renamed once it is complete.
+func (s *NitroService) backupDatabase(ctx context.Context, engine databaseEngine, container, database string, opts backup.Options, t time.Time) (string, error) {
+	dir := filepath.Join(s.backupDir, container)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	ext, err := opts.Ext()
+	if err != nil {
+		return "", err
+	}
+	name := backup.Filename(database, t, ext)
+	tmp := filepath.Join(dir, "."+name)
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return "", err
+	}
+
+	m, err := backup.Write(f, opts, func(w io.Writer) error {
+		return engine.dump(ctx, container, database, w)
+	})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, filepath.Join(dir, name))
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+
+	m.Container = container
+	m.Databases = []string{database}
+	m.Automatic = true
+	if err := backup.WriteManifest(filepath.Join(dir, name), m); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// removeExpiredBackups removes the automatic backups in the target containers
+// that are over the number to keep or older than the number of days. Backups
+// taken by hand are in the same directories and are not removed.
+func (s *NitroService) removeExpiredBackups(schedule backupSchedule, log io.Writer) {
+	keep, days := schedule.Keep, schedule.Days
+	if keep == 0 && days == 0 {
+		keep = defaultBackupKeep
+	}
+
+	backups, err := backup.List(s.backupDir)
+	if err != nil {
+		fmt.Fprintf(log, "Unable to list the backups: %s\n", err)
+		return
+	}
+
+	targets := make(map[string]bool)
+	for _, t := range schedule.Targets {
+		targets[t.Container] = true
+	}
+
+	var scheduled []backup.Backup
+	for _, b := range backups {
+		if targets[b.Container] {
+			scheduled = append(scheduled, b)
+		}
+	}
+
+	for _, b := range backup.Expired(backup.Automatic(scheduled), keep, days, time.Now()) {
+		if err := backup.Remove(b.Path); err != nil {
+			fmt.Fprintf(log, "Unable to remove the expired backup %s: %s\n", filepath.Base(b.Path), err)
+			continue
+		}
+
+		fmt.Fprintf(log, "Removed the expired backup %s from %s\n", filepath.Base(b.Path), b.Container)
+	}
+}
+
+// backupsUpdated wakes up the scheduler without blocking.
+func (s *NitroService) backupsUpdated() {
+	select {
+	case s.backupsChanged <- struct{}{}:
+	default:
+	}
+}
+
+// loadBackupSchedule returns nil when there is no schedule.
+func (s *NitroService) loadBackupSchedule() (*backupSchedule, error) {
+	b, err := ioutil.ReadFile(s.backupFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var schedule backupSchedule
+	if err := json.Unmarshal(b, &schedule); err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+func (s *NitroService) saveBackupSchedule(schedule backupSchedule) error {
+	b, err := json.MarshalIndent(schedule, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.backupFile), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(s.backupFile, b, 0644)
+}
@@ -0,0 +1,297 @@
+package nitrod
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/craftcms/nitro/internal/backup"
+)
+
+func testBackupsService(t *testing.T, runner Runner) (*NitroService, func()) {
+	s, cleanup := testJobsService(t, runner)
+	s.backupDir = filepath.Join(s.importDir, "backups")
+	s.backupFile = filepath.Join(s.importDir, "backups.json")
+	s.backupsChanged = make(chan struct{}, 1)
+	s.lookPath = func(file string) (string, error) {
+		return "/usr/bin/" + file, nil
+	}
+
+	return s, cleanup
+}
+
+func TestNitroService_ConfigureBackups(t *testing.T) {
+	target := []*BackupTarget{{Engine: "mysql", Container: "mysql_5.7_3306"}}
+
+	tests := []struct {
+		name       string
+		request    *ConfigureBackupsRequest
+		withoutAge bool
+		wantCode   codes.Code
+	}{
+		{
+			name:    "saves the schedule",
+			request: &ConfigureBackupsRequest{Schedule: "daily", Targets: target, Keep: 5, Compression: "gzip"},
+		},
+		{
+			name:    "saves the schedule with an encryption key",
+			request: &ConfigureBackupsRequest{Schedule: "daily", Targets: target, Compression: "zstd", Recipient: "age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"},
+		},
+		{
+			name:       "age recipients are rejected when age is not installed",
+			request:    &ConfigureBackupsRequest{Schedule: "daily", Targets: target, Recipient: "age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"},
+			withoutAge: true,
+			wantCode:   codes.FailedPrecondition,
+		},
+		{
+			name:     "recipients must be keys",
+			request:  &ConfigureBackupsRequest{Schedule: "daily", Targets: target, Recipient: "me@example.test"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:    "an empty schedule disables the backups",
+			request: &ConfigureBackupsRequest{},
+		},
+		{
+			name:     "invalid schedules are rejected",
+			request:  &ConfigureBackupsRequest{Schedule: "5m", Targets: target},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "unknown compression is rejected",
+			request:  &ConfigureBackupsRequest{Schedule: "daily", Targets: target, Compression: "rar"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "unknown engines are rejected",
+			request:  &ConfigureBackupsRequest{Schedule: "daily", Targets: []*BackupTarget{{Engine: "oracle", Container: "oracle"}}},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "invalid database names are rejected",
+			request:  &ConfigureBackupsRequest{Schedule: "daily", Targets: []*BackupTarget{{Engine: "mysql", Container: "mysql_5.7_3306", Databases: []string{"my craft"}}}},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "containers cannot be paths",
+			request:  &ConfigureBackupsRequest{Schedule: "daily", Targets: []*BackupTarget{{Engine: "mysql", Container: "../mysql"}}},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, cleanup := testBackupsService(t, &spyChainRunner{})
+			defer cleanup()
+
+			if tt.withoutAge {
+				s.lookPath = func(file string) (string, error) {
+					return "", exec.ErrNotFound
+				}
+			}
+
+			_, err := s.ConfigureBackups(context.Background(), tt.request)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("ConfigureBackups() error = %v, want code %v", err, tt.wantCode)
+			}
+
+			schedule, err := s.loadBackupSchedule()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantCode != codes.OK || tt.request.GetSchedule() == "" {
+				if schedule != nil {
+					t.Errorf("expected no schedule, got %+v", schedule)
+				}
+				return
+			}
+
+			if schedule == nil || schedule.Schedule != tt.request.GetSchedule() || schedule.Keep != int(tt.request.GetKeep()) || len(schedule.Targets) != len(tt.request.GetTargets()) {
+				t.Errorf("unexpected schedule %+v", schedule)
+			}
+
+			select {
+			case <-s.backupsChanged:
+			default:
+				t.Error("expected the scheduler to be notified")
+			}
+		})
+	}
+}
+
+func TestNitroService_ConfigureBackupsKeepsTheLastRun(t *testing.T) {
+	s, cleanup := testBackupsService(t, &spyChainRunner{})
+	defer cleanup()
+
+	lastRun := time.Date(2020, 11, 18, 2, 0, 0, 0, time.UTC)
+	if err := s.saveBackupSchedule(backupSchedule{Schedule: "daily", LastRun: lastRun}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := &ConfigureBackupsRequest{Schedule: "weekly", Targets: []*BackupTarget{{Engine: "postgres", Container: "postgres_12_5432"}}}
+	if _, err := s.ConfigureBackups(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+
+	schedule, err := s.loadBackupSchedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if schedule.Schedule != "weekly" || !schedule.LastRun.Equal(lastRun) {
+		t.Errorf("unexpected schedule %+v", schedule)
+	}
+}
+
+func TestNitroService_backupDatabases(t *testing.T) {
+	runner := &spyChainRunner{
+		Outputs: []string{
+			// the databases in the container
+			"craft\tutf8mb4\t100\t1\nblog\tutf8mb4\t100\t1\n",
+			"craft dump",
+			"blog dump",
+		},
+	}
+	s, cleanup := testBackupsService(t, runner)
+	defer cleanup()
+
+	// an old automatic backup that is over the number to keep, and a
+	// backup taken by hand that is older but is never removed
+	old := filepath.Join(s.backupDir, "mysql_5.7_3306", "craft-201101_020000.sql.gz")
+	kept := filepath.Join(s.backupDir, "mysql_5.7_3306", "craft-201102_020000.sql.gz")
+	manual := filepath.Join(s.backupDir, "mysql_5.7_3306", "craft-201001_020000.sql.gz")
+	for _, file := range []string{old, kept, manual} {
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(file, []byte("old"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := backup.WriteManifest(file, &backup.Manifest{Engine: "mysql", Automatic: file != manual}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	schedule := backupSchedule{
+		Schedule:    "daily",
+		Targets:     []backupTarget{{Engine: "mysql", Container: "mysql_5.7_3306"}},
+		Keep:        2,
+		Compression: "gzip",
+	}
+
+	var log bytes.Buffer
+	if err := s.backupDatabases(context.Background(), schedule, &log); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(s.backupDir, "mysql_5.7_3306", "*.sql.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(files)
+
+	// the new blog and craft backups, the newest of the existing automatic
+	// backups and the backup taken by hand
+	if len(files) != 4 || files[1] != manual || files[2] != kept {
+		t.Fatalf("unexpected backups %v\n%s", files, log.String())
+	}
+
+	got := map[string]string{}
+	for _, file := range files {
+		if file == kept || file == manual {
+			continue
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatalf("expected %s to be compressed: %v", file, err)
+		}
+		b, err := ioutil.ReadAll(gz)
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got[filepath.Base(file)[:4]] = string(b)
+
+		m, err := backup.ReadManifest(file)
+		if err != nil {
+			t.Fatalf("expected a manifest for %s: %v", file, err)
+		}
+		if m.Container != "mysql_5.7_3306" || len(m.Databases) != 1 || m.Compression != "gzip" || !m.Automatic || m.DumpSize != int64(len(b)) || m.Checksum == "" {
+			t.Errorf("unexpected manifest %+v", m)
+		}
+	}
+
+	want := map[string]string{"blog": "blog dump", "craf": "craft dump"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("backups = %v, want %v", got, want)
+	}
+
+	wantArgs := []string{"exec", "-e", "MYSQL_PWD=nitro", "mysql_5.7_3306", "mysqldump", "-unitro", "--single-transaction", "--routines", "--triggers", "craft"}
+	if !reflect.DeepEqual(runner.Args[1]["docker"], wantArgs) {
+		t.Errorf("dump args = %v, want %v", runner.Args[1]["docker"], wantArgs)
+	}
+}
+
+func TestNitroService_ScheduleBackups(t *testing.T) {
+	s, cleanup := testBackupsService(t, &spyChainRunner{Output: "dump"})
+	defer cleanup()
+
+	// the backup is overdue so it runs right away
+	schedule := backupSchedule{
+		Schedule: "daily",
+		Targets:  []backupTarget{{Engine: "postgres", Container: "postgres_12_5432", Databases: []string{"craft"}}},
+		LastRun:  time.Now().Add(-48 * time.Hour),
+	}
+	if err := s.saveBackupSchedule(schedule); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.ScheduleBackups(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(s.jobs.List()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	list := s.jobs.List()
+	if len(list) != 1 || list[0].Kind != jobKindBackup {
+		t.Fatalf("expected a backup job, got %+v", list)
+	}
+
+	stream := watchJob(t, s, list[0].ID)
+	if stream.job.GetState() != JobState_SUCCEEDED {
+		t.Errorf("expected the job to succeed, got %v: %s", stream.job.GetState(), stream.log)
+	}
+
+	saved, err := s.loadBackupSchedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.LastRun.After(schedule.LastRun) {
+		t.Errorf("expected the last run to be updated, got %v", saved.LastRun)
+	}
+}
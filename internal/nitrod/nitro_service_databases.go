@@ -0,0 +1,164 @@
+package nitrod
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/craftcms/nitro/internal/validate"
+)
+
+// ListDatabases returns the user databases in the container with
+// their size, character set and number of tables.
+func (s *NitroService) ListDatabases(ctx context.Context, request *ListDatabasesRequest) (*ListDatabasesResponse, error) {
+	engine, err := s.databaseEngine(request.GetEngine())
+	if err != nil {
+		return nil, err
+	}
+
+	if request.GetContainer() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "a container is required to list the databases")
+	}
+
+	dbs, err := s.listDatabases(engine, request.GetContainer())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListDatabasesResponse{Databases: dbs}, nil
+}
+
+// CreateDatabase creates an empty database in the container.
+func (s *NitroService) CreateDatabase(ctx context.Context, request *CreateDatabaseRequest) (*ServiceResponse, error) {
+	engine, container, database, err := s.databaseRequest(request.GetEngine(), request.GetContainer(), request.GetDatabase())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validate.DatabaseName(database); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	if db, err := s.findDatabase(engine, container, database); err != nil {
+		return nil, err
+	} else if db != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "the database %q already exists", database)
+	}
+
+	if err := engine.create(container, database); err != nil {
+		return nil, s.databaseError(err, "unable to create the database "+database)
+	}
+
+	return &ServiceResponse{Message: "Successfully created the database " + database}, nil
+}
+
+// DropDatabase removes a database and all of its data from the container.
+func (s *NitroService) DropDatabase(ctx context.Context, request *DropDatabaseRequest) (*ServiceResponse, error) {
+	engine, container, database, err := s.databaseRequest(request.GetEngine(), request.GetContainer(), request.GetDatabase())
+	if err != nil {
+		return nil, err
+	}
+
+	if db, err := s.findDatabase(engine, container, database); err != nil {
+		return nil, err
+	} else if db == nil {
+		return nil, status.Errorf(codes.NotFound, "the database %q does not exist", database)
+	}
+
+	if err := engine.drop(container, database); err != nil {
+		return nil, s.databaseError(err, "unable to drop the database "+database)
+	}
+
+	return &ServiceResponse{Message: "Successfully dropped the database " + database}, nil
+}
+
+// RenameDatabase renames a database in the container.
+func (s *NitroService) RenameDatabase(ctx context.Context, request *RenameDatabaseRequest) (*ServiceResponse, error) {
+	engine, container, database, err := s.databaseRequest(request.GetEngine(), request.GetContainer(), request.GetDatabase())
+	if err != nil {
+		return nil, err
+	}
+
+	name := request.GetName()
+	if err := validate.DatabaseName(name); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	dbs, err := s.listDatabases(engine, container)
+	if err != nil {
+		return nil, err
+	}
+
+	if databaseNamed(dbs, database) == nil {
+		return nil, status.Errorf(codes.NotFound, "the database %q does not exist", database)
+	}
+	if databaseNamed(dbs, name) != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "the database %q already exists", name)
+	}
+
+	if err := engine.rename(container, database, name); err != nil {
+		return nil, s.databaseError(err, "unable to rename the database "+database)
+	}
+
+	return &ServiceResponse{Message: "Successfully renamed the database " + database + " to " + name}, nil
+}
+
+// databaseRequest validates the fields shared by the requests for a single database.
+func (s *NitroService) databaseRequest(engine, container, database string) (databaseEngine, string, string, error) {
+	e, err := s.databaseEngine(engine)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	if container == "" {
+		return nil, "", "", status.Errorf(codes.InvalidArgument, "a container is required")
+	}
+
+	if database == "" {
+		return nil, "", "", status.Errorf(codes.InvalidArgument, "a database name is required")
+	}
+
+	return e, container, database, nil
+}
+
+func (s *NitroService) listDatabases(engine databaseEngine, container string) ([]*Database, error) {
+	dbs, err := engine.list(container)
+	if err != nil {
+		return nil, s.databaseError(err, "unable to list the databases in "+container)
+	}
+
+	return dbs, nil
+}
+
+// findDatabase returns the database with the name, or nil when it does not exist.
+func (s *NitroService) findDatabase(engine databaseEngine, container, database string) (*Database, error) {
+	dbs, err := s.listDatabases(engine, container)
+	if err != nil {
+		return nil, err
+	}
+
+	return databaseNamed(dbs, database), nil
+}
+
+// databaseError logs the output of a failed command and returns it to the
+// client, errors that already have a status are returned as they are.
+func (s *NitroService) databaseError(err error, message string) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	s.logger.Println(message+", error:", err)
+
+	return status.Errorf(codes.Unknown, "%s: %s", message, err.Error())
+}
+
+func databaseNamed(dbs []*Database, name string) *Database {
+	for _, db := range dbs {
+		if db.GetName() == name {
+			return db
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package nitrod
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNitroService_ListDatabases(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *ListDatabasesRequest
+		outputs  []string
+		want     []*Database
+		wantCode codes.Code
+	}{
+		{
+			name:    "mysql databases include the size, charset and tables",
+			request: &ListDatabasesRequest{Engine: "mysql", Container: "mysql_5.7_3306"},
+			outputs: []string{"craft\tutf8mb4\t1048576\t42\nempty\tutf8\t0\t0\n"},
+			want: []*Database{
+				{Name: "craft", Charset: "utf8mb4", Size: 1048576, Tables: 42},
+				{Name: "empty", Charset: "utf8", Size: 0, Tables: 0},
+			},
+		},
+		{
+			name:    "postgres tables are counted in each database",
+			request: &ListDatabasesRequest{Engine: "postgres", Container: "postgres_12_5432"},
+			outputs: []string{"craft\t8012345\tUTF8\nnitro\t7890123\tUTF8\n", "12\n", "0\n"},
+			want: []*Database{
+				{Name: "craft", Charset: "UTF8", Size: 8012345, Tables: 12},
+				{Name: "nitro", Charset: "UTF8", Size: 7890123, Tables: 0},
+			},
+		},
+		{
+			name:     "unknown engines return an error",
+			request:  &ListDatabasesRequest{Engine: "sqlite", Container: "sqlite"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "a container is required",
+			request:  &ListDatabasesRequest{Engine: "mysql"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spy := &spyChainRunner{Outputs: tt.outputs}
+			s := &NitroService{
+				command: spy,
+				logger:  log.New(ioutil.Discard, "testing", 0),
+			}
+
+			got, err := s.ListDatabases(context.TODO(), tt.request)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("ListDatabases() code = %v, want %v, err = %v", code, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(got.GetDatabases(), tt.want) {
+				t.Errorf("ListDatabases() got = \n%v, \nwant \n%v", got.GetDatabases(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNitroService_ManageDatabases(t *testing.T) {
+	tests := []struct {
+		name        string
+		call        func(s *NitroService) (*ServiceResponse, error)
+		outputs     []string
+		want        string
+		wantCode    codes.Code
+		wantQueries []string
+	}{
+		{
+			name: "creates a mysql database",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.CreateDatabase(context.TODO(), &CreateDatabaseRequest{Engine: "mysql", Container: "mysql_5.7_3306", Database: "craft"})
+			},
+			outputs: []string{"nitro\tutf8\t0\t0\n", ""},
+			want:    "Successfully created the database craft",
+			wantQueries: []string{
+				"CREATE DATABASE IF NOT EXISTS `craft`",
+			},
+		},
+		{
+			name: "creating an existing database returns an error",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.CreateDatabase(context.TODO(), &CreateDatabaseRequest{Engine: "mysql", Container: "mysql_5.7_3306", Database: "craft"})
+			},
+			outputs:  []string{"craft\tutf8\t0\t0\n"},
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name: "invalid database names return an error",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.CreateDatabase(context.TODO(), &CreateDatabaseRequest{Engine: "postgres", Container: "postgres_12_5432", Database: "pg_craft"})
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "drops a postgres database after closing the connections",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.DropDatabase(context.TODO(), &DropDatabaseRequest{Engine: "postgres", Container: "postgres_12_5432", Database: "craft"})
+			},
+			outputs: []string{"craft\t8012345\tUTF8\n", "12\n", "", ""},
+			want:    "Successfully dropped the database craft",
+			wantQueries: []string{
+				"SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = 'craft' AND pid <> pg_backend_pid()",
+				`DROP DATABASE IF EXISTS "craft"`,
+			},
+		},
+		{
+			name: "dropping a missing database returns an error",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.DropDatabase(context.TODO(), &DropDatabaseRequest{Engine: "mysql", Container: "mysql_5.7_3306", Database: "craft"})
+			},
+			outputs:  []string{""},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "renames a mysql database by moving the tables",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.RenameDatabase(context.TODO(), &RenameDatabaseRequest{Engine: "mysql", Container: "mysql_5.7_3306", Database: "craft", Name: "craft_old"})
+			},
+			outputs: []string{"craft\tutf8mb4\t1024\t2\n", "users\tBASE TABLE\nentries\tBASE TABLE\n", "0\t0\t0\n", "utf8mb4\n", "", "", ""},
+			want:    "Successfully renamed the database craft to craft_old",
+			wantQueries: []string{
+				"SELECT TABLE_NAME, TABLE_TYPE FROM information_schema.TABLES WHERE TABLE_SCHEMA = 'craft'",
+				"SELECT (SELECT COUNT(*) FROM information_schema.ROUTINES WHERE ROUTINE_SCHEMA = 'craft'),\n(SELECT COUNT(*) FROM information_schema.EVENTS WHERE EVENT_SCHEMA = 'craft'),\n(SELECT COUNT(*) FROM information_schema.TRIGGERS WHERE TRIGGER_SCHEMA = 'craft')",
+				"SELECT DEFAULT_CHARACTER_SET_NAME FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = 'craft'",
+				"CREATE DATABASE `craft_old` CHARACTER SET utf8mb4",
+				"RENAME TABLE `craft`.`users` TO `craft_old`.`users`, `craft`.`entries` TO `craft_old`.`entries`",
+				"DROP DATABASE IF EXISTS `craft`",
+			},
+		},
+		{
+			name: "mysql databases with views cannot be renamed",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.RenameDatabase(context.TODO(), &RenameDatabaseRequest{Engine: "mysql", Container: "mysql_5.7_3306", Database: "craft", Name: "craft_old"})
+			},
+			outputs:  []string{"craft\tutf8mb4\t1024\t2\n", "users\tBASE TABLE\nrecent_users\tVIEW\n"},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "mysql databases with stored routines cannot be renamed",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.RenameDatabase(context.TODO(), &RenameDatabaseRequest{Engine: "mysql", Container: "mysql_5.7_3306", Database: "craft", Name: "craft_old"})
+			},
+			outputs:  []string{"craft\tutf8mb4\t1024\t2\n", "users\tBASE TABLE\n", "2\t0\t0\n"},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "mysql databases with triggers cannot be renamed",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.RenameDatabase(context.TODO(), &RenameDatabaseRequest{Engine: "mysql", Container: "mysql_5.7_3306", Database: "craft", Name: "craft_old"})
+			},
+			outputs:  []string{"craft\tutf8mb4\t1024\t2\n", "users\tBASE TABLE\n", "0\t0\t1\n"},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "renames a postgres database",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.RenameDatabase(context.TODO(), &RenameDatabaseRequest{Engine: "postgres", Container: "postgres_12_5432", Database: "craft", Name: "craft_old"})
+			},
+			outputs: []string{"craft\t8012345\tUTF8\n", "12\n", "", ""},
+			want:    "Successfully renamed the database craft to craft_old",
+			wantQueries: []string{
+				"SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = 'craft' AND pid <> pg_backend_pid()",
+				`ALTER DATABASE "craft" RENAME TO "craft_old"`,
+			},
+		},
+		{
+			name: "renaming to an existing database returns an error",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.RenameDatabase(context.TODO(), &RenameDatabaseRequest{Engine: "postgres", Container: "postgres_12_5432", Database: "craft", Name: "nitro"})
+			},
+			outputs:  []string{"craft\t8012345\tUTF8\nnitro\t7890123\tUTF8\n", "12\n", "0\n"},
+			wantCode: codes.AlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spy := &spyChainRunner{Outputs: tt.outputs}
+			s := &NitroService{
+				command: spy,
+				logger:  log.New(ioutil.Discard, "testing", 0),
+			}
+
+			got, err := tt.call(s)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v, err = %v", code, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.GetMessage() != tt.want {
+				t.Errorf("got message %q, want %q", got.GetMessage(), tt.want)
+			}
+
+			// the queries after listing the databases
+			var queries []string
+			for _, a := range spy.Args {
+				args := a["docker"]
+				queries = append(queries, args[len(args)-1])
+			}
+			if len(queries) < len(tt.wantQueries) {
+				t.Fatalf("expected %d queries, got %v", len(tt.wantQueries), queries)
+			}
+			queries = queries[len(queries)-len(tt.wantQueries):]
+			if !reflect.DeepEqual(queries, tt.wantQueries) {
+				t.Errorf("got queries \n%v, \nwant \n%v", queries, tt.wantQueries)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package nitrod
+
+import (
+	"errors"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/craftcms/nitro/internal/validate"
+)
+
+// execBufferSize is the largest chunk of output sent in a single message.
+const execBufferSize = 32 * 1024
+
+// composerPath is where the install composer command puts composer.
+const composerPath = "/usr/local/bin/composer"
+
+// execProcess is a command started by Exec, with a terminal
+// or with separate pipes for stdin, stdout and stderr.
+type execProcess struct {
+	cmd    *exec.Cmd
+	stdin  io.Writer
+	stdout io.Reader
+	// stderr is nil when using a terminal
+	stderr     io.Reader
+	closeStdin func() error
+	resize     func(size *TerminalSize) error
+	close      func()
+}
+
+// Exec runs a command in a directory on the machine as the ubuntu user. The
+// first message starts the command and the following messages are sent to
+// its input. The output is streamed back and the last message has the exit
+// code. If the client goes away, the command is killed.
+func (s *NitroService) Exec(stream NitroService_ExecServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to create the stream: %s", err.Error())
+	}
+
+	start := req.GetStart()
+	if start == nil {
+		return status.Errorf(codes.InvalidArgument, "the first message must start the command")
+	}
+
+	cmd, err := s.execCommand(start)
+	if err != nil {
+		return err
+	}
+
+	var p *execProcess
+	switch start.GetTty() {
+	case true:
+		p, err = startTerminal(cmd, start.GetSize())
+	default:
+		p, err = startPipes(cmd)
+	}
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return status.Errorf(codes.NotFound, "the command %q was not found", start.GetCommand())
+		}
+
+		s.logger.Println("error starting command, error:", err)
+		return status.Errorf(codes.Internal, "unable to start %q: %s", start.GetCommand(), err.Error())
+	}
+	defer p.close()
+
+	// the output is sent from more than one goroutine
+	var mu sync.Mutex
+	send := func(resp *ExecResponse) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return stream.Send(resp)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.sendOutput(p.stdout, func(b []byte) error {
+			return send(&ExecResponse{Response: &ExecResponse_Stdout{Stdout: b}})
+		})
+	}()
+	if p.stderr != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.sendOutput(p.stderr, func(b []byte) error {
+				return send(&ExecResponse{Response: &ExecResponse_Stderr{Stderr: b}})
+			})
+		}()
+	}
+
+	exited := make(chan struct{})
+	go s.receiveInput(stream, p, exited)
+
+	// the output has to be read before waiting for the command
+	wg.Wait()
+
+	code := 0
+	err = p.cmd.Wait()
+	close(exited)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			s.logger.Println("error waiting for command, error:", err)
+			return status.Errorf(codes.Internal, "unable to run %q: %s", start.GetCommand(), err.Error())
+		}
+
+		code = exitCode(exitErr)
+	}
+
+	return send(&ExecResponse{Response: &ExecResponse_ExitCode{ExitCode: int32(code)}})
+}
+
+// execCommand creates the command for the request, using the
+// PHP version for php, composer and craft.
+func (s *NitroService) execCommand(start *ExecStart) (*exec.Cmd, error) {
+	command := start.GetCommand()
+	args := start.GetArgs()
+	if command == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "a command is required")
+	}
+
+	if php := start.GetPhp(); php != "" {
+		if err := validate.PHPVersion(php); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+
+		switch command {
+		case "php":
+			command = "php" + php
+		case "composer":
+			command, args = "php"+php, append([]string{composerPath}, args...)
+		case "craft":
+			command, args = "php"+php, append([]string{"craft"}, args...)
+		}
+	}
+
+	// only allow running commands in the home directory
+	dir := filepath.Clean(start.GetDir())
+	if dir == "." {
+		dir = s.homeDir
+	}
+	if rel, err := filepath.Rel(s.homeDir, dir); err != nil || !filepath.IsAbs(dir) || strings.HasPrefix(rel, "..") {
+		return nil, status.Errorf(codes.PermissionDenied, "commands can only run in %s", s.homeDir)
+	}
+
+	env := []string{
+		"HOME=" + s.homeDir,
+		"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
+		"CRAFT_NITRO=1",
+		"DB_USER=nitro",
+		"DB_PASSWORD=nitro",
+	}
+	if s.execUser != "" {
+		env = append(env, "USER="+s.execUser, "LOGNAME="+s.execUser)
+	}
+	if start.GetTty() {
+		env = append(env, "TERM=xterm-256color")
+	}
+	for _, e := range start.GetEnv() {
+		if !strings.Contains(e, "=") {
+			return nil, status.Errorf(codes.InvalidArgument, "the environment variable %q must be KEY=value", e)
+		}
+
+		env = append(env, e)
+	}
+
+	cmd := exec.Command(command, args...)
+	cmd.Dir = dir
+	cmd.Env = env
+
+	if err := runAsUser(cmd, s.execUser); err != nil {
+		s.logger.Println("error setting the user for the command, error:", err)
+		return nil, status.Errorf(codes.Internal, "unable to run the command as %s", s.execUser)
+	}
+
+	return cmd, nil
+}
+
+// sendOutput reads from the output of the command until it is closed.
+func (s *NitroService) sendOutput(r io.Reader, send func(b []byte) error) {
+	buf := make([]byte, execBufferSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			b := make([]byte, n)
+			copy(b, buf[:n])
+			if err := send(b); err != nil {
+				s.logger.Println("error sending output, error:", err)
+			}
+		}
+
+		// a terminal returns an error instead of EOF when the command exits
+		if err != nil {
+			return
+		}
+	}
+}
+
+// receiveInput writes the input from the client to the command. When the
+// stream is canceled, the client has gone away so the command is killed.
+func (s *NitroService) receiveInput(stream NitroService_ExecServer, p *execProcess, exited <-chan struct{}) {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			_ = p.closeStdin()
+			return
+		}
+		if err != nil {
+			select {
+			case <-exited:
+			default:
+				killProcess(p.cmd)
+			}
+			return
+		}
+
+		switch r := req.GetRequest().(type) {
+		case *ExecRequest_Stdin:
+			if _, err := p.stdin.Write(r.Stdin); err != nil {
+				s.logger.Println("error writing input, error:", err)
+			}
+		case *ExecRequest_Resize:
+			if err := p.resize(r.Resize); err != nil {
+				s.logger.Println("error resizing the terminal, error:", err)
+			}
+		case *ExecRequest_CloseStdin:
+			_ = p.closeStdin()
+		}
+	}
+}
+
+// startPipes starts the command with separate pipes for stdin, stdout and stderr.
+func startPipes(cmd *exec.Cmd) (*execProcess, error) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	setProcessGroup(cmd)
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	return &execProcess{
+		cmd:        cmd,
+		stdin:      stdin,
+		stdout:     stdout,
+		stderr:     stderr,
+		closeStdin: stdin.Close,
+		resize: func(size *TerminalSize) error {
+			return nil
+		},
+		close: func() {},
+	}, nil
+}
+
+// exitCode returns the exit code, or 128 plus the
+// signal number when the command was killed.
+func exitCode(err *exec.ExitError) int {
+	if code := err.ExitCode(); code >= 0 {
+		return code
+	}
+
+	if sig := exitSignal(err); sig > 0 {
+		return 128 + sig
+	}
+
+	return 1
+}
@@ -0,0 +1,229 @@
+package nitrod
+
+import (
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// spyExecServer sends the requests to the service and
+// records the output and exit code of the command.
+type spyExecServer struct {
+	grpc.ServerStream
+	mu        sync.Mutex
+	requests  []*ExecRequest
+	stdout    string
+	stderr    string
+	exitCodes []int32
+}
+
+func (s *spyExecServer) Recv() (*ExecRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+
+	return req, nil
+}
+
+func (s *spyExecServer) Send(resp *ExecResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r := resp.GetResponse().(type) {
+	case *ExecResponse_Stdout:
+		s.stdout += string(r.Stdout)
+	case *ExecResponse_Stderr:
+		s.stderr += string(r.Stderr)
+	case *ExecResponse_ExitCode:
+		s.exitCodes = append(s.exitCodes, r.ExitCode)
+	}
+
+	return nil
+}
+
+func execStart(start *ExecStart, stdin ...string) []*ExecRequest {
+	requests := []*ExecRequest{{Request: &ExecRequest_Start{Start: start}}}
+	for _, in := range stdin {
+		requests = append(requests, &ExecRequest{Request: &ExecRequest_Stdin{Stdin: []byte(in)}})
+	}
+
+	return requests
+}
+
+func TestNitroService_Exec(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nitrod-exec-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name         string
+		requests     []*ExecRequest
+		wantStdout   string
+		wantStderr   string
+		wantExitCode int32
+		wantCode     codes.Code
+	}{
+		{
+			name:       "runs the command in the directory",
+			requests:   execStart(&ExecStart{Command: "pwd", Dir: dir}),
+			wantStdout: dir + "\n",
+		},
+		{
+			name:       "sends the input and separates the output",
+			requests:   execStart(&ExecStart{Command: "sh", Args: []string{"-c", "cat; echo oops >&2"}, Dir: dir}, "hello ", "world\n"),
+			wantStdout: "hello world\n",
+			wantStderr: "oops\n",
+		},
+		{
+			name:         "returns the exit code",
+			requests:     execStart(&ExecStart{Command: "sh", Args: []string{"-c", "exit 3"}, Dir: dir}),
+			wantExitCode: 3,
+		},
+		{
+			name:       "sets the environment",
+			requests:   execStart(&ExecStart{Command: "sh", Args: []string{"-c", "echo $CRAFT_NITRO $ENVIRONMENT"}, Dir: dir, Env: []string{"ENVIRONMENT=dev"}}),
+			wantStdout: "1 dev\n",
+		},
+		{
+			name:     "commands cannot run outside of the home directory",
+			requests: execStart(&ExecStart{Command: "pwd", Dir: "/etc"}),
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "parent directories are not allowed",
+			requests: execStart(&ExecStart{Command: "pwd", Dir: dir + "/../"}),
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "missing commands return an error",
+			requests: execStart(&ExecStart{Command: "not-a-real-command", Dir: dir}),
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "the first message must start the command",
+			requests: []*ExecRequest{{Request: &ExecRequest_Stdin{Stdin: []byte("ls")}}},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &NitroService{
+				logger:  log.New(ioutil.Discard, "testing", 0),
+				homeDir: dir,
+			}
+			stream := &spyExecServer{requests: tt.requests}
+
+			err := s.Exec(stream)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("Exec() code = %v, want %v, err = %v", code, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if stream.stdout != tt.wantStdout {
+				t.Errorf("expected stdout to be %q, got %q", tt.wantStdout, stream.stdout)
+			}
+			if stream.stderr != tt.wantStderr {
+				t.Errorf("expected stderr to be %q, got %q", tt.wantStderr, stream.stderr)
+			}
+			if len(stream.exitCodes) != 1 || stream.exitCodes[0] != tt.wantExitCode {
+				t.Errorf("expected the exit code to be %d, got %v", tt.wantExitCode, stream.exitCodes)
+			}
+		})
+	}
+}
+
+func TestNitroService_ExecTerminal(t *testing.T) {
+	if _, err := os.Stat("/dev/ptmx"); err != nil {
+		t.Skip("terminals are not available")
+	}
+
+	dir, err := ioutil.TempDir("", "nitrod-exec-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := &NitroService{
+		logger:  log.New(ioutil.Discard, "testing", 0),
+		homeDir: dir,
+	}
+	stream := &spyExecServer{requests: execStart(&ExecStart{
+		Command: "sh",
+		Args:    []string{"-c", "test -t 0 && stty size"},
+		Dir:     dir,
+		Tty:     true,
+		Size:    &TerminalSize{Rows: 40, Cols: 120},
+	})}
+
+	if err := s.Exec(stream); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.TrimSpace(stream.stdout); got != "40 120" {
+		t.Errorf("expected the terminal size to be %q, got %q", "40 120", got)
+	}
+	if len(stream.exitCodes) != 1 || stream.exitCodes[0] != 0 {
+		t.Errorf("expected the exit code to be 0, got %v", stream.exitCodes)
+	}
+}
+
+func TestNitroService_execCommand(t *testing.T) {
+	s := &NitroService{homeDir: "/home/ubuntu"}
+
+	tests := []struct {
+		name     string
+		start    *ExecStart
+		wantArgs []string
+	}{
+		{
+			name:     "php uses the version",
+			start:    &ExecStart{Command: "php", Args: []string{"-v"}, Php: "7.3"},
+			wantArgs: []string{"php7.3", "-v"},
+		},
+		{
+			name:     "composer runs with the php version",
+			start:    &ExecStart{Command: "composer", Args: []string{"install"}, Php: "8.0"},
+			wantArgs: []string{"php8.0", "/usr/local/bin/composer", "install"},
+		},
+		{
+			name:     "craft runs from the directory with the php version",
+			start:    &ExecStart{Command: "craft", Args: []string{"migrate/all"}, Php: "7.4", Dir: "/home/ubuntu/sites/demo"},
+			wantArgs: []string{"php7.4", "craft", "migrate/all"},
+		},
+		{
+			name:     "other commands are not changed",
+			start:    &ExecStart{Command: "ls", Args: []string{"-la"}, Php: "7.4"},
+			wantArgs: []string{"ls", "-la"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := s.execCommand(tt.start)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if strings.Join(cmd.Args, " ") != strings.Join(tt.wantArgs, " ") {
+				t.Errorf("expected the args to be %v, got %v", tt.wantArgs, cmd.Args)
+			}
+		})
+	}
+}
@@ -1,237 +1,511 @@
 package nitrod
 
 import (
-	"archive/zip"
-	"compress/gzip"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"os"
-	"strings"
+	"path/filepath"
+	"regexp"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/craftcms/nitro/internal/compress"
+	"github.com/craftcms/nitro/internal/metrics"
 	"github.com/craftcms/nitro/internal/scripts"
 )
 
+const (
+	// uploadProgressInterval is how often, in bytes,
+	// the upload progress is sent to the client.
+	uploadProgressInterval = 1024 * 1024
+
+	// importProgressInterval is how often, in statements,
+	// the import progress is sent to the client.
+	importProgressInterval = 1000
+
+	// uploadExpiry is how long an upload that was not
+	// finished is kept so the import can be resumed.
+	uploadExpiry = 24 * time.Hour
+)
+
+var checksumPattern = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
 type DatabaseImportOptions struct {
-	Engine          string
-	Database        string
-	Container       string
-	File            string
-	Compressed      bool
-	CompressionType string
-	CreateDatabase  bool
+	Engine         string
+	Database       string
+	Container      string
+	File           string
+	CreateDatabase bool
 }
 
-func (s *NitroService) ImportDatabase(stream NitroService_ImportDatabaseServer) error {
+// ImportDatabase receives a header followed by the chunks of a database backup. The
+// upload is stored using the checksum so if the connection drops, the client can
+// start a new stream and resume from the offset in the first progress message.
+// Once the upload is complete the checksum is verified and the backup is imported,
+// or for background imports, a job is started to import the backup.
+func (s *NitroService) ImportDatabase(stream NitroService_ImportDatabaseServer) (err error) {
 	options := DatabaseImportOptions{}
 
-	// create a temp file
-	file, err := s.createFile(os.TempDir(), "nitro-db-upload-")
+	// record the size and duration of the import
+	start := time.Now()
+	var received int64
+	defer func() {
+		result := "success"
+		if err != nil {
+			result = "error"
+		}
+		metrics.ImportBytes.Add(float64(received), options.Engine)
+		metrics.ImportDuration.Observe(time.Since(start).Seconds(), options.Engine, result)
+	}()
+
+	req, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to create the stream: %s", err.Error())
+	}
+
+	header := req.GetHeader()
+	if header == nil {
+		return status.Errorf(codes.InvalidArgument, "the first message must be the import header")
+	}
+	if !checksumPattern.MatchString(header.GetChecksum()) {
+		return status.Errorf(codes.InvalidArgument, "a sha256 checksum is required to import a database")
+	}
+
+	options = DatabaseImportOptions{
+		Engine:         header.GetEngine(),
+		Database:       header.GetDatabase(),
+		Container:      header.GetContainer(),
+		CreateDatabase: header.GetCreateDatabase(),
+	}
+
+	// the directory is not created on machines where nitrod was upgraded
+	if err := os.MkdirAll(s.importDir, 0755); err != nil {
+		s.logger.Println("error creating the imports directory:", err)
+		return status.Errorf(codes.Internal, "unable to create the directory for the upload")
+	}
+
+	// open the upload, if it exists this is a resumed upload
+	file := filepath.Join(s.importDir, header.GetChecksum()+".part")
+	s.removeStaleUploads(file)
+
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
-		s.logger.Println("Error creating a temp file for the upload:", err.Error())
-		return status.Errorf(codes.Internal, "Unable creating a temp file for the upload")
+		s.logger.Println("error opening the upload:", err)
+		return status.Errorf(codes.Internal, "unable to create the file for the upload")
+	}
+	defer f.Close()
+
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to read the upload")
+	}
+
+	// the upload cannot be larger than the file, start over
+	if offset > header.GetSize() {
+		if err := f.Truncate(0); err != nil {
+			return status.Errorf(codes.Internal, "unable to reset the upload")
+		}
+		if offset, err = f.Seek(0, io.SeekStart); err != nil {
+			return status.Errorf(codes.Internal, "unable to reset the upload")
+		}
+	}
+
+	if offset > 0 {
+		s.logger.Printf("Resuming upload %s at %d of %d bytes", header.GetChecksum(), offset, header.GetSize())
 	}
-	defer file.Close()
 
-	options.File = file.Name()
+	// tell the client where to start sending from
+	if err := stream.Send(&ImportDatabaseProgress{Stage: ImportStage_UPLOADING, Offset: offset, Size: header.GetSize()}); err != nil {
+		return status.Errorf(codes.Internal, "unable to send the progress %v", err)
+	}
 
-	// handle the file streaming requests
-	for {
+	sent := offset
+	for offset < header.GetSize() {
 		req, err := stream.Recv()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return status.Errorf(codes.Internal, "unable to create the stream: %s", err.Error())
+			// keep the upload so it can be resumed
+			s.logger.Printf("Upload %s stopped at %d of %d bytes: %s", header.GetChecksum(), offset, header.GetSize(), err)
+			return status.Errorf(codes.Aborted, "the upload stopped at %d of %d bytes: %s", offset, header.GetSize(), err)
 		}
 
-		// set the variables for later use only if they are not empty
-		if options.Engine == "" {
-			options.Engine = req.GetEngine()
-		}
-		if options.Container == "" {
-			options.Container = req.GetContainer()
-		}
-		if options.Database == "" {
-			options.Database = req.GetDatabase()
-		}
-		if (options.Compressed == false) && (req.GetCompressed()) {
-			options.Compressed = req.GetCompressed()
-		}
-		if options.CompressionType == "" && req.GetCompressionType() != "" {
-			options.CompressionType = req.GetCompressionType()
-		}
-		if (options.CreateDatabase == false) && (req.GetCreateDatabase()) {
-			options.CreateDatabase = req.GetCreateDatabase()
+		data := req.GetData()
+		if offset+int64(len(data)) > header.GetSize() {
+			return status.Errorf(codes.InvalidArgument, "the upload is larger than the expected size of %d bytes", header.GetSize())
 		}
 
-		// write the backup content into the temp file
-		_, err = file.Write(req.GetData())
+		n, err := f.Write(data)
+		offset += int64(n)
+		received += int64(n)
 		if err != nil {
-			return status.Errorf(codes.Internal, "unable to write the backup to the temp file")
+			return status.Errorf(codes.Internal, "unable to write the backup to the upload file")
+		}
+
+		if offset-sent >= uploadProgressInterval || offset == header.GetSize() {
+			if err := stream.Send(&ImportDatabaseProgress{Stage: ImportStage_UPLOADING, Offset: offset, Size: header.GetSize()}); err != nil {
+				return status.Errorf(codes.Aborted, "unable to send the progress %v", err)
+			}
+			sent = offset
 		}
 	}
 
-	// if the file is compressed, extract it and we are done
-	if options.Compressed {
-		s.logger.Println("The file is compressed, extracting now")
+	if err := f.Close(); err != nil {
+		return status.Errorf(codes.Internal, "unable to write the backup to the upload file")
+	}
 
-		uncompressedFile, err := s.createFile(os.TempDir(), "nitro-compressed-db-")
-		if err != nil {
-			s.logger.Println("error creating the compressed db file:", err.Error())
-			return err
-		}
-		defer uncompressedFile.Close()
+	if offset != header.GetSize() {
+		return status.Errorf(codes.Aborted, "the upload stopped at %d of %d bytes", offset, header.GetSize())
+	}
 
-		// create the gzip reader
-		switch options.CompressionType {
-		case "gz":
-			s.logger.Println("Using gzip to open to file", file.Name())
-			f, err := os.Open(file.Name())
-			if err != nil {
-				s.logger.Println("error reopening the file for the gzip reader")
-				return status.Errorf(codes.Unknown, "error reopening the file for the gzip reader. %s", err.Error())
-			}
-			reader, err := gzip.NewReader(f)
-			if err != nil {
-				s.logger.Println("error creating the gzip reader", err.Error())
-				return status.Errorf(codes.Unknown, "error reading the compressed file. %s", err.Error())
-			}
+	// verify the complete upload, not just the bytes sent on this stream
+	if err := stream.Send(&ImportDatabaseProgress{Stage: ImportStage_VERIFYING, Offset: offset, Size: header.GetSize()}); err != nil {
+		return status.Errorf(codes.Internal, "unable to send the progress %v", err)
+	}
 
-			for {
-				reader.Multistream(false)
-				// copy contents to the new file
-				if _, err := io.Copy(uncompressedFile, reader); err != nil {
-					return err
-				}
-
-				err = reader.Reset(f)
-				if err == io.EOF {
-					break
-				}
-				if err != nil {
-					return err
-				}
-			}
+	checksum, err := fileChecksum(file)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to verify the upload")
+	}
+	if checksum != header.GetChecksum() {
+		os.Remove(file)
+		s.logger.Printf("The checksum %s does not match the expected checksum %s", checksum, header.GetChecksum())
+		return status.Errorf(codes.DataLoss, "the checksum of the upload does not match, the backup was not imported")
+	}
 
-			if err := reader.Close(); err != nil {
-				s.logger.Println("error closing the gzip reader, err:", err.Error())
-				return err
-			}
+	// the upload is no longer needed once it is imported, background
+	// imports remove the upload when the job is done
+	queued := false
+	defer func() {
+		if !queued {
+			s.removeUpload(file)
+		}
+	}()
 
-			options.File = uncompressedFile.Name()
-		default:
-			s.logger.Println("Using zip to open to file", file.Name())
-			reader, err := zip.OpenReader(file.Name())
-			if err != nil {
-				return err
-			}
+	options.File = file
 
-			for _, z := range reader.File {
+	// pick the dump to import from the archive
+	dumps, err := compress.Dumps(file)
+	if err != nil {
+		s.logger.Println("error reading the archive:", err)
+		return status.Errorf(codes.InvalidArgument, "unable to read the backup: %s", err)
+	}
 
-				// only accept sql files, ignoring MACOS specific files
-				if strings.Contains(z.Name, ".sql") && strings.Contains(z.Name, "MACOSX") == false {
-					b, err := z.Open()
-					if err != nil {
-						return err
-					}
+	var member string
+	switch len(dumps) {
+	case 0:
+		return status.Errorf(codes.InvalidArgument, "the archive does not contain a database dump (.sql or .dump)")
+	case 1:
+		member = dumps[0]
+	default:
+		if member, err = s.selectDump(stream, dumps); err != nil {
+			return err
+		}
+	}
 
-					// copy the contents into the uncompressed temp file
-					if _, err := io.Copy(uncompressedFile, b); err != nil {
-						return err
-					}
-				}
-			}
+	if header.GetBackground() {
+		job, err := s.jobs.Start(jobKindImport, importDescription(options, member), func(ctx context.Context, log io.Writer) error {
+			defer s.removeUpload(file)
+			return s.importJob(ctx, options, member, log)
+		})
+		if err != nil {
+			s.logger.Println("error starting the import job:", err)
+			return status.Errorf(codes.Internal, "unable to start the import")
+		}
+		queued = true
+
+		return stream.Send(&ImportDatabaseProgress{
+			Stage:   ImportStage_QUEUED,
+			Offset:  offset,
+			Size:    header.GetSize(),
+			Job:     job.ID,
+			Message: "Importing the database in the background",
+		})
+	}
 
-			if err := reader.Close(); err != nil {
-				s.logger.Println("error closing the zip reader, err:", err.Error())
-				return err
-			}
+	r, err := compress.OpenDump(file, member)
+	if err != nil {
+		s.logger.Println("error opening the dump:", err)
+		return status.Errorf(codes.InvalidArgument, "unable to read the backup: %s", err)
+	}
+	defer r.Close()
 
-			options.File = uncompressedFile.Name()
+	if member != "" {
+		s.logger.Printf("Importing %q from the archive", member)
+		if err := stream.Send(&ImportDatabaseProgress{Stage: ImportStage_DECOMPRESSING, Size: header.GetSize(), Message: member}); err != nil {
+			return status.Errorf(codes.Internal, "unable to send the progress %v", err)
 		}
 	}
 
 	// import the database
-	if _, err := s.importDatabase(options); err != nil {
+	if err := stream.Send(&ImportDatabaseProgress{Stage: ImportStage_IMPORTING, Size: header.GetSize()}); err != nil {
+		return status.Errorf(codes.Internal, "unable to send the progress %v", err)
+	}
+
+	// the import is stopped when the client cancels or disconnects
+	statements, err := s.importDatabase(stream.Context(), options, r, nil, func(statements int64) {
+		_ = stream.Send(&ImportDatabaseProgress{Stage: ImportStage_IMPORTING, Statements: statements, Size: header.GetSize()})
+	})
+	if err != nil {
 		s.logger.Printf("Error importing database: %s\n", err)
 		return err
 	}
 
-	if err := stream.SendAndClose(&ServiceResponse{Message: "Successfully imported the database"}); err != nil {
+	if err := stream.Send(&ImportDatabaseProgress{
+		Stage:      ImportStage_COMPLETE,
+		Offset:     offset,
+		Size:       header.GetSize(),
+		Statements: statements,
+		Message:    "Successfully imported the database",
+	}); err != nil {
 		return status.Errorf(codes.Internal, "unable to send the response %v", err)
 	}
 
-	// remove the temp file to save space
-	if err := os.Remove(options.File); err != nil {
-		s.logger.Println("error removing temp file:", options.File)
+	return nil
+}
+
+// selectDump asks the client which of the dumps in the archive to import.
+func (s *NitroService) selectDump(stream NitroService_ImportDatabaseServer, dumps []string) (string, error) {
+	if err := stream.Send(&ImportDatabaseProgress{Stage: ImportStage_SELECTING, Members: dumps}); err != nil {
+		return "", status.Errorf(codes.Internal, "unable to send the progress %v", err)
+	}
+
+	req, err := stream.Recv()
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "the archive contains %d dumps, one must be selected to import", len(dumps))
+	}
+
+	for _, d := range dumps {
+		if d == req.GetMember() {
+			return d, nil
+		}
+	}
+
+	return "", status.Errorf(codes.InvalidArgument, "the archive does not contain %q", req.GetMember())
+}
+
+// importJob imports the dump as a background job, the progress is written to the log.
+func (s *NitroService) importJob(ctx context.Context, opts DatabaseImportOptions, member string, log io.Writer) error {
+	r, err := compress.OpenDump(opts.File, member)
+	if err != nil {
+		return fmt.Errorf("unable to read the backup: %w", err)
+	}
+	defer r.Close()
+
+	if member != "" {
+		fmt.Fprintf(log, "Extracting %q from the archive\n", member)
+	}
+	fmt.Fprintln(log, "Importing the database")
+
+	statements, err := s.importDatabase(ctx, opts, r, log, func(statements int64) {
+		fmt.Fprintf(log, "Imported %d statements\n", statements)
+	})
+	if err != nil {
+		s.logger.Printf("Error importing database: %s\n", err)
+		return errors.New(status.Convert(err).Message())
 	}
-	s.logger.Println("removed temp file:", options.File)
+
+	fmt.Fprintf(log, "Successfully imported the database, %d statements\n", statements)
 
 	return nil
 }
 
-func (s *NitroService) importDatabase(opts DatabaseImportOptions) (string, error) {
+// importDescription describes the import for the list of jobs.
+func importDescription(opts DatabaseImportOptions, member string) string {
+	name := opts.Database
+	if name == "" {
+		name = "the backup"
+	}
+	if member != "" {
+		name = member
+	}
+
+	return fmt.Sprintf("Import %s into %s", name, opts.Container)
+}
+
+// removeUpload removes an upload once it has been imported.
+func (s *NitroService) removeUpload(file string) {
+	if err := os.Remove(file); err != nil {
+		s.logger.Println("error removing the upload:", file)
+	}
+}
+
+// removeStaleUploads removes the uploads, other than the file, that have not
+// been written to for longer than the uploadExpiry, as they were abandoned.
+func (s *NitroService) removeStaleUploads(file string) {
+	uploads, err := filepath.Glob(filepath.Join(s.importDir, "*.part"))
+	if err != nil {
+		return
+	}
+
+	for _, upload := range uploads {
+		if upload == file {
+			continue
+		}
+
+		info, err := os.Stat(upload)
+		if err != nil || time.Since(info.ModTime()) < uploadExpiry {
+			continue
+		}
+
+		if err := os.Remove(upload); err != nil {
+			s.logger.Println("error removing the stale upload:", upload)
+			continue
+		}
+		s.logger.Println("Removed the stale upload", filepath.Base(upload))
+	}
+}
+
+// importDatabase creates the database, if needed, and pipes the dump into the
+// database container until it is done or the context is canceled. The output
+// of the import is also written to the log when it is not nil. The progress
+// func is called with the number of statements sent to the database every
+// importProgressInterval statements.
+func (s *NitroService) importDatabase(ctx context.Context, opts DatabaseImportOptions, dump io.Reader, log io.Writer, progress func(int64)) (int64, error) {
+	br := bufio.NewReader(dump)
+	b, _ := br.Peek(5)
+	custom := compress.IsPostgresCustom(b)
+
+	counter := &statementCounter{reader: br, interval: importProgressInterval, progress: progress}
+
 	switch opts.Engine {
-	case "mysql":
+	case "mysql", "mariadb":
+		if custom {
+			return 0, status.Errorf(codes.InvalidArgument, "PostgreSQL custom format backups cannot be imported into MySQL")
+		}
+
 		// should we skip creating the database?
 		if opts.CreateDatabase == false {
 			if output, err := s.command.Run("/bin/bash", []string{"-c", fmt.Sprintf(scripts.FmtDockerMysqlCreateDatabaseIfNotExists, opts.Container, opts.Database)}); err != nil {
 				s.logger.Println(string(output))
-				return string(output), err
+				return 0, status.Errorf(codes.Unknown, string(output))
 			}
 			s.logger.Printf("Created the MySQL database %q\n", opts.Database)
 		}
 
 		s.logger.Printf("Beginning MySQL import of file %q", opts.File)
 
-		// if we are skipping create, it has the use statement and no database name
-		if opts.CreateDatabase {
-			opts.Database = "emptydatabase"
+		args := []string{"exec", "-i", opts.Container, "mysql", "-unitro", "-pnitro"}
+
+		// if the file creates the database, it has the use statement and no database name
+		if !opts.CreateDatabase {
+			args = append(args, opts.Database)
 		}
 
 		// import the database
-		output, err := s.command.Run("/bin/bash", []string{"-c", fmt.Sprintf(`docker exec -i %q mysql -unitro -pnitro %s < %s`, opts.Container, opts.Database, opts.File)})
+		output, err := s.runImport(ctx, args, counter, log)
 		if err != nil {
 			s.logger.Println("Error importing the MySQL database:", string(output))
-			return "", err
+			return counter.statements, status.Errorf(codes.Unknown, string(output))
 		}
 	default:
 		output, err := s.command.Run("/bin/bash", []string{"-c", fmt.Sprintf(scripts.FmtDockerPostgresCreateDatabase, opts.Container, opts.Database)})
 		if err != nil {
 			s.logger.Println("Error creating the PostgreSQL database:", string(output))
-			return "", err
+			return 0, status.Errorf(codes.Unknown, string(output))
 		}
 		s.logger.Printf("created PostgreSQL database %q for engine %q", opts.Database, opts.Container)
 
-		output, err = s.command.Run("/bin/bash", []string{"-c", fmt.Sprintf(scripts.FmtDockerPostgresImportDatabase, opts.Container, opts.Database, opts.File)})
+		// custom format backups are binary so there are no statements to count
+		if custom {
+			output, err = s.runImport(ctx, []string{"exec", "-i", opts.Container, "pg_restore", "-U", "nitro", "-h", "127.0.0.1", "--no-owner", "-d", opts.Database}, br, log)
+			if err != nil {
+				s.logger.Println("Error restoring PostgreSQL database:", string(output))
+				return 0, status.Errorf(codes.Unknown, string(output))
+			}
+			break
+		}
+
+		output, err = s.runImport(ctx, []string{"exec", "-i", opts.Container, "psql", "-U", "nitro", "-h", "127.0.0.1", opts.Database}, counter, log)
 		if err != nil {
 			s.logger.Println("Error importing PostgreSQL database:", string(output))
-			return "", err
+			return counter.statements, status.Errorf(codes.Unknown, string(output))
 		}
 	}
 
 	s.logger.Printf("Imported %s database %q into %q", opts.Engine, opts.Database, opts.Container)
 
-	return fmt.Sprintf("Imported %s database %q into %q", opts.Engine, opts.Database, opts.Container), nil
+	return counter.statements, nil
 }
 
-func (s *NitroService) createFile(dir string, pattern string) (*os.File, error) {
-	f, err := ioutil.TempFile(dir, pattern)
-	if err != nil {
-		return nil, err
+// runImport runs docker with the dump as the input and returns the output.
+func (s *NitroService) runImport(ctx context.Context, args []string, input io.Reader, log io.Writer) ([]byte, error) {
+	var buf bytes.Buffer
+	var output io.Writer = &buf
+	if log != nil {
+		output = io.MultiWriter(&buf, log)
+	}
+
+	err := s.command.RunContext(ctx, "docker", args, input, output, output)
+
+	return buf.Bytes(), err
+}
+
+// statementCounter counts the SQL statements read from a dump by
+// looking for lines that end with a semicolon.
+type statementCounter struct {
+	reader     io.Reader
+	interval   int64
+	progress   func(int64)
+	statements int64
+	// last is the last non-whitespace byte of the current line
+	last byte
+}
+
+func (c *statementCounter) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+
+	for _, b := range p[:n] {
+		switch b {
+		case '\n':
+			c.endLine()
+		case ' ', '\t', '\r':
+		default:
+			c.last = b
+		}
+	}
+
+	if err == io.EOF {
+		c.endLine()
 	}
 
-	// TODO remove this
-	// set the owner of the file to the ubuntu user for importing
-	if err := os.Chown(f.Name(), 1000, 1000); err != nil {
-		return nil, err
+	return n, err
+}
+
+func (c *statementCounter) endLine() {
+	if c.last != ';' {
+		c.last = 0
+		return
 	}
+	c.last = 0
+
+	c.statements++
+	if c.progress != nil && c.interval > 0 && c.statements%c.interval == 0 {
+		c.progress(c.statements)
+	}
+}
 
-	s.logger.Println("Created file", f.Name())
+// fileChecksum returns the hex encoded sha256 of the file.
+func fileChecksum(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, bufio.NewReader(f)); err != nil {
+		return "", err
+	}
 
-	return f, nil
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
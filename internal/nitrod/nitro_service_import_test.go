@@ -1,34 +1,408 @@
 package nitrod
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// spyImportDatabaseServer is used to send a database
+// backup to the service in chunks.
+type spyImportDatabaseServer struct {
+	grpc.ServerStream
+	ctx       context.Context
+	requests  []*ImportDatabaseRequest
+	responses []*ImportDatabaseProgress
+	err       error
+}
+
+func (s *spyImportDatabaseServer) Context() context.Context {
+	if s.ctx != nil {
+		return s.ctx
+	}
+
+	return context.Background()
+}
+
+func (s *spyImportDatabaseServer) Recv() (*ImportDatabaseRequest, error) {
+	if len(s.requests) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+
+	return req, nil
+}
+
+func (s *spyImportDatabaseServer) Send(p *ImportDatabaseProgress) error {
+	s.responses = append(s.responses, p)
+	return nil
+}
+
+func importHeader(backup []byte) *ImportDatabaseRequest {
+	sum := sha256.Sum256(backup)
+
+	return &ImportDatabaseRequest{Request: &ImportDatabaseRequest_Header{Header: &ImportDatabaseHeader{
+		Engine:    "mysql",
+		Database:  "craft",
+		Container: "mysql_5.7_3306",
+		Checksum:  hex.EncodeToString(sum[:]),
+		Size:      int64(len(backup)),
+	}}}
+}
+
+func importData(b []byte) *ImportDatabaseRequest {
+	return &ImportDatabaseRequest{Request: &ImportDatabaseRequest_Data{Data: b}}
+}
+
 func TestNitroService_ImportDatabase(t *testing.T) {
-	type fields struct {
-		command Runner
-		logger  *log.Logger
+	backup := []byte("CREATE TABLE a (id int);\nINSERT INTO a VALUES (1),\n(2);\n")
+	checksum := importHeader(backup).GetHeader().GetChecksum()
+
+	tests := []struct {
+		name         string
+		requests     []*ImportDatabaseRequest
+		recvErr      error
+		partial      []byte
+		wantErr      codes.Code
+		wantStages   []ImportStage
+		wantOffset   int64
+		wantInput    []string
+		wantUpload   bool
+		wantCommands []string
+	}{
+		{
+			name:         "uploads and imports the backup",
+			requests:     []*ImportDatabaseRequest{importHeader(backup), importData(backup[:10]), importData(backup[10:])},
+			wantStages:   []ImportStage{ImportStage_UPLOADING, ImportStage_UPLOADING, ImportStage_VERIFYING, ImportStage_IMPORTING, ImportStage_COMPLETE},
+			wantInput:    []string{string(backup)},
+			wantCommands: []string{"/bin/bash", "docker"},
+		},
+		{
+			name:         "resumes the upload from the existing file",
+			requests:     []*ImportDatabaseRequest{importHeader(backup), importData(backup[10:])},
+			partial:      backup[:10],
+			wantStages:   []ImportStage{ImportStage_UPLOADING, ImportStage_UPLOADING, ImportStage_VERIFYING, ImportStage_IMPORTING, ImportStage_COMPLETE},
+			wantOffset:   10,
+			wantInput:    []string{string(backup)},
+			wantCommands: []string{"/bin/bash", "docker"},
+		},
+		{
+			name:       "keeps the upload when the stream stops",
+			requests:   []*ImportDatabaseRequest{importHeader(backup), importData(backup[:10])},
+			recvErr:    status.Error(codes.Unavailable, "connection lost"),
+			wantErr:    codes.Aborted,
+			wantStages: []ImportStage{ImportStage_UPLOADING},
+			wantUpload: true,
+		},
+		{
+			name:       "does not import when the checksum does not match",
+			requests:   []*ImportDatabaseRequest{importHeader(backup), importData([]byte(strings.ToUpper(string(backup))))},
+			wantErr:    codes.DataLoss,
+			wantStages: []ImportStage{ImportStage_UPLOADING, ImportStage_UPLOADING, ImportStage_VERIFYING},
+		},
+		{
+			name:     "requires the header first",
+			requests: []*ImportDatabaseRequest{importData(backup)},
+			wantErr:  codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "nitro-import")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			upload := filepath.Join(dir, checksum+".part")
+			if tt.partial != nil {
+				if err := ioutil.WriteFile(upload, tt.partial, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			runner := &spyChainRunner{}
+			s := &NitroService{
+				command:   runner,
+				logger:    log.New(ioutil.Discard, "testing", 0),
+				importDir: dir,
+			}
+			stream := &spyImportDatabaseServer{requests: tt.requests, err: tt.recvErr}
+
+			err = s.ImportDatabase(stream)
+			if status.Code(err) != tt.wantErr {
+				t.Fatalf("ImportDatabase() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var stages []ImportStage
+			for _, p := range stream.responses {
+				stages = append(stages, p.GetStage())
+			}
+			if !reflect.DeepEqual(stages, tt.wantStages) {
+				t.Errorf("expected the stages %v, got %v", tt.wantStages, stages)
+			}
+
+			if len(stream.responses) > 0 && stream.responses[0].GetOffset() != tt.wantOffset {
+				t.Errorf("expected the upload to start at %d, got %d", tt.wantOffset, stream.responses[0].GetOffset())
+			}
+
+			if !reflect.DeepEqual(runner.Input, tt.wantInput) {
+				t.Errorf("expected the input %q, got %q", tt.wantInput, runner.Input)
+			}
+
+			if !reflect.DeepEqual(runner.Commands, tt.wantCommands) {
+				t.Errorf("expected the commands %v, got %v", tt.wantCommands, runner.Commands)
+			}
+
+			if _, err := os.Stat(upload); os.IsNotExist(err) == tt.wantUpload {
+				t.Errorf("expected the upload to exist %v", tt.wantUpload)
+			}
+
+			if tt.wantErr == codes.OK {
+				last := stream.responses[len(stream.responses)-1]
+				if last.GetStatements() != 2 {
+					t.Errorf("expected 2 statements to be imported, got %d", last.GetStatements())
+				}
+			}
+		})
 	}
-	type args struct {
-		stream NitroService_ImportDatabaseServer
+}
+
+func TestNitroService_ImportDatabase_CreatesTheDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nitro-import")
+	if err != nil {
+		t.Fatal(err)
 	}
+	defer os.RemoveAll(dir)
+
+	backup := []byte("CREATE TABLE a (id int);\n")
+	s := &NitroService{
+		command:   &spyChainRunner{},
+		logger:    log.New(ioutil.Discard, "testing", 0),
+		importDir: filepath.Join(dir, "databases", "imports"),
+	}
+	stream := &spyImportDatabaseServer{requests: []*ImportDatabaseRequest{importHeader(backup), importData(backup)}}
+
+	if err := s.ImportDatabase(stream); err != nil {
+		t.Fatalf("ImportDatabase() error = %v", err)
+	}
+}
+
+func TestNitroService_ImportDatabase_RemovesStaleUploads(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nitro-import")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	stale := filepath.Join(dir, strings.Repeat("a", 64)+".part")
+	recent := filepath.Join(dir, strings.Repeat("b", 64)+".part")
+	for _, file := range []string{stale, recent} {
+		if err := ioutil.WriteFile(file, []byte("CREATE"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-uploadExpiry - time.Hour)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	backup := []byte("CREATE TABLE a (id int);\n")
+	s := &NitroService{
+		command:   &spyChainRunner{},
+		logger:    log.New(ioutil.Discard, "testing", 0),
+		importDir: dir,
+	}
+	stream := &spyImportDatabaseServer{requests: []*ImportDatabaseRequest{importHeader(backup), importData(backup)}}
+
+	if err := s.ImportDatabase(stream); err != nil {
+		t.Fatalf("ImportDatabase() error = %v", err)
+	}
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("expected the stale upload to be removed")
+	}
+	if _, err := os.Stat(recent); err != nil {
+		t.Errorf("expected the recent upload to be kept so it can be resumed")
+	}
+}
+
+func TestNitroService_ImportDatabase_StopsWhenCanceled(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nitro-import")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	backup := []byte("CREATE TABLE a (id int);\n")
+	runner := &spyChainRunner{}
+	s := &NitroService{
+		command:   runner,
+		logger:    log.New(ioutil.Discard, "testing", 0),
+		importDir: dir,
+	}
+	stream := &spyImportDatabaseServer{ctx: ctx, requests: []*ImportDatabaseRequest{importHeader(backup), importData(backup)}}
+
+	if err := s.ImportDatabase(stream); err == nil {
+		t.Fatal("expected the import to stop when the client cancels")
+	}
+
+	if len(runner.Input) != 0 {
+		t.Errorf("expected the dump to not be imported, got %q", runner.Input)
+	}
+}
+
+func Test_statementCounter(t *testing.T) {
+	dump := "-- comment\nCREATE TABLE a (id int);\nINSERT INTO a VALUES\n(1);  \n\nSELECT 1;"
+
+	var reported []int64
+	c := &statementCounter{reader: strings.NewReader(dump), interval: 2, progress: func(n int64) {
+		reported = append(reported, n)
+	}}
+
+	// read in small chunks so lines are split between reads
+	buf := make([]byte, 3)
+	for {
+		_, err := c.Read(buf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if c.statements != 3 {
+		t.Errorf("expected 3 statements, got %d", c.statements)
+	}
+	if !reflect.DeepEqual(reported, []int64{2}) {
+		t.Errorf("expected progress at 2, got %v", reported)
+	}
+}
+
+func TestNitroService_ImportDatabase_Archives(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, f := range []struct{ name, content string }{
+		{"craft.sql", "SELECT 1;\n"},
+		{"other.sql", "SELECT 2;\nSELECT 3;\n"},
+	} {
+		if err := tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(f.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gz.Close()
+	archive := buf.Bytes()
+
+	custom := []byte("PGDMP\x01\x0e\x00binary")
+	customHeader := importHeader(custom)
+	customHeader.GetHeader().Engine = "postgres"
+	customHeader.GetHeader().Container = "postgres_12_5432"
+
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
+		name       string
+		requests   []*ImportDatabaseRequest
+		wantErr    codes.Code
+		wantStages []ImportStage
+		wantInput  []string
+		wantArgs   []string
 	}{
-		// TODO add tests
+		{
+			name: "asks which dump to import from the archive",
+			requests: []*ImportDatabaseRequest{
+				importHeader(archive),
+				importData(archive),
+				{Request: &ImportDatabaseRequest_Member{Member: "other.sql"}},
+			},
+			wantStages: []ImportStage{ImportStage_UPLOADING, ImportStage_UPLOADING, ImportStage_VERIFYING, ImportStage_SELECTING, ImportStage_DECOMPRESSING, ImportStage_IMPORTING, ImportStage_COMPLETE},
+			wantInput:  []string{"SELECT 2;\nSELECT 3;\n"},
+			wantArgs:   []string{"exec", "-i", "mysql_5.7_3306", "mysql", "-unitro", "-pnitro", "craft"},
+		},
+		{
+			name: "requires a dump to be selected",
+			requests: []*ImportDatabaseRequest{
+				importHeader(archive),
+				importData(archive),
+				{Request: &ImportDatabaseRequest_Member{Member: "missing.sql"}},
+			},
+			wantErr:    codes.InvalidArgument,
+			wantStages: []ImportStage{ImportStage_UPLOADING, ImportStage_UPLOADING, ImportStage_VERIFYING, ImportStage_SELECTING},
+		},
+		{
+			name:       "restores postgres custom format backups with pg_restore",
+			requests:   []*ImportDatabaseRequest{customHeader, importData(custom)},
+			wantStages: []ImportStage{ImportStage_UPLOADING, ImportStage_UPLOADING, ImportStage_VERIFYING, ImportStage_IMPORTING, ImportStage_COMPLETE},
+			wantInput:  []string{string(custom)},
+			wantArgs:   []string{"exec", "-i", "postgres_12_5432", "pg_restore", "-U", "nitro", "-h", "127.0.0.1", "--no-owner", "-d", "craft"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "nitro-import")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			runner := &spyChainRunner{}
 			s := &NitroService{
-				command: tt.fields.command,
-				logger:  tt.fields.logger,
+				command:   runner,
+				logger:    log.New(ioutil.Discard, "testing", 0),
+				importDir: dir,
+			}
+			stream := &spyImportDatabaseServer{requests: tt.requests}
+
+			err = s.ImportDatabase(stream)
+			if status.Code(err) != tt.wantErr {
+				t.Fatalf("ImportDatabase() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			if err := s.ImportDatabase(tt.args.stream); (err != nil) != tt.wantErr {
-				t.Errorf("ImportDatabase() error = %v, wantErr %v", err, tt.wantErr)
+
+			var stages []ImportStage
+			for _, p := range stream.responses {
+				stages = append(stages, p.GetStage())
+			}
+			if !reflect.DeepEqual(stages, tt.wantStages) {
+				t.Errorf("expected the stages %v, got %v", tt.wantStages, stages)
+			}
+
+			if !reflect.DeepEqual(runner.Input, tt.wantInput) {
+				t.Errorf("expected the input %q, got %q", tt.wantInput, runner.Input)
+			}
+
+			if tt.wantArgs != nil {
+				last := runner.Args[len(runner.Args)-1]["docker"]
+				if !reflect.DeepEqual(last, tt.wantArgs) {
+					t.Errorf("expected the args %v, got %v", tt.wantArgs, last)
+				}
 			}
 		})
 	}
@@ -3,38 +3,81 @@ package nitrod
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/craftcms/nitro/internal/phpini"
 	"github.com/craftcms/nitro/internal/validate"
 )
 
-func (s *NitroService) GetPhpIniSetting(ctx context.Context, req *GetPhpIniSettingRequest) (*ServiceResponse, error) {
+// GetPhpIniSetting returns the typed value of a PHP ini setting for both the fpm
+// and cli SAPIs and whether the value was set by the nitro override file.
+func (s *NitroService) GetPhpIniSetting(ctx context.Context, req *GetPhpIniSettingRequest) (*PhpIniSettingResponse, error) {
 	// validate php version
 	if err := validate.PHPVersion(req.GetVersion()); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
 
-	// get the setting from the php ini_get function
-	output, err := s.command.Run("bash", []string{"-c", fmt.Sprintf("php-fpm%s -i | grep '%s'", req.GetVersion(), req.GetSetting())})
+	resp := &PhpIniSettingResponse{Version: req.GetVersion(), Setting: req.GetSetting()}
+	for _, sapi := range sapis(PhpSapi_ALL) {
+		value, err := s.iniValue(req.GetVersion(), sapi, req.GetSetting())
+		if err != nil {
+			return nil, err
+		}
+
+		switch sapi {
+		case "fpm":
+			resp.Fpm = value
+		default:
+			resp.Cli = value
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *NitroService) iniValue(version, sapi, setting string) (*PhpIniValue, error) {
+	settings, err := s.iniGetAll(version, sapi)
 	if err != nil {
 		s.logger.Println("error getting ini setting:", err)
-		s.logger.Println("output:", string(output))
-		return nil, status.Errorf(codes.Unknown, string(output))
+		return nil, status.Errorf(codes.Unknown, err.Error())
 	}
 
-	// get the output in a normal format
-	var settingValue string
-	sp := strings.Split(string(output), " ")
-	if len(sp) == 5 {
-		settingValue = sp[len(sp)-1]
+	current, ok := settings[setting]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, fmt.Sprintf("Unable to find the PHP setting %q", setting))
 	}
 
-	if settingValue == "" {
-		return &ServiceResponse{Message: fmt.Sprintf("Unable to find the PHP setting %q", req.GetSetting())}, nil
+	overrides, err := s.iniOverrides(version, sapi)
+	if err != nil {
+		s.logger.Println("error reading the ini overrides:", err)
+		return nil, status.Errorf(codes.Internal, "unable to read the ini overrides for PHP %s", version)
 	}
+	_, overridden := overrides[setting]
+
+	// the value from ini_get_all is what PHP is using,
+	// so return the raw value if the type is unexpected
+	v, _ := phpini.ParseValue(setting, current.Global)
 
-	return &ServiceResponse{Message: fmt.Sprintf("The setting %q is currently set to %v", req.GetSetting(), settingValue)}, nil
+	return &PhpIniValue{
+		Type:       phpIniValueType(v.Kind),
+		Raw:        v.Raw,
+		Bool:       v.Bool,
+		Int:        v.Int,
+		Overridden: overridden,
+	}, nil
+}
+
+func phpIniValueType(k phpini.Kind) PhpIniValueType {
+	switch k {
+	case phpini.Boolean:
+		return PhpIniValueType_BOOLEAN
+	case phpini.Integer:
+		return PhpIniValueType_INTEGER
+	case phpini.Bytes:
+		return PhpIniValueType_BYTES
+	default:
+		return PhpIniValueType_STRING
+	}
 }
@@ -0,0 +1,39 @@
+package nitrod
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/craftcms/nitro/internal/validate"
+)
+
+// ResetPhpIniSetting removes a setting from the nitro override file so PHP
+// uses the value from the php.ini file for the version and SAPI.
+func (s *NitroService) ResetPhpIniSetting(ctx context.Context, request *ResetPhpIniSettingRequest) (*ServiceResponse, error) {
+	setting := request.GetSetting()
+	version := request.GetVersion()
+
+	// validate the php version
+	if err := validate.PHPVersion(version); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	if _, err := s.validateIniSetting(version, setting); err != nil {
+		return nil, err
+	}
+
+	for _, sapi := range sapis(request.GetSapi()) {
+		if err := s.setIniOverride(version, sapi, setting, nil); err != nil {
+			s.logger.Println("error resetting ini setting, error:", err)
+			return nil, status.Errorf(codes.Internal, "unable to reset the ini setting %q", setting)
+		}
+	}
+
+	if err := s.restartPhpFpm(version, request.GetSapi()); err != nil {
+		return nil, err
+	}
+
+	return &ServiceResponse{Message: "Successfully reset the ini setting for " + setting + " to the default"}, nil
+}
@@ -0,0 +1,99 @@
+package nitrod
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNitrodService_ResetPhpIniSetting(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		request *ResetPhpIniSettingRequest
+	}
+	tests := []struct {
+		name          string
+		args          args
+		want          *ServiceResponse
+		wantErr       bool
+		wantCommands  []string
+		wantOverrides map[string]string
+	}{
+		{
+			name: "removes the setting for every sapi",
+			args: args{
+				ctx:     context.TODO(),
+				request: &ResetPhpIniSettingRequest{Version: "7.4", Setting: "memory_limit"},
+			},
+			want:         &ServiceResponse{Message: "Successfully reset the ini setting for memory_limit to the default"},
+			wantCommands: []string{"env", "service"},
+			wantOverrides: map[string]string{
+				"fpm": "max_execution_time = 300\n",
+				"cli": "max_execution_time = 300\n",
+			},
+		},
+		{
+			name: "can reset only the cli sapi",
+			args: args{
+				ctx:     context.TODO(),
+				request: &ResetPhpIniSettingRequest{Version: "7.4", Setting: "memory_limit", Sapi: PhpSapi_CLI},
+			},
+			want:         &ServiceResponse{Message: "Successfully reset the ini setting for memory_limit to the default"},
+			wantCommands: []string{"env"},
+			wantOverrides: map[string]string{
+				"fpm": "memory_limit = 512M\nmax_execution_time = 300\n",
+				"cli": "max_execution_time = 300\n",
+			},
+		},
+		{
+			name: "unknown settings return an error",
+			args: args{
+				ctx:     context.TODO(),
+				request: &ResetPhpIniSettingRequest{Version: "7.4", Setting: "not_a_setting"},
+			},
+			wantErr:      true,
+			wantCommands: []string{"env"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testPhpDir(t, "7.4")
+			defer os.RemoveAll(dir)
+
+			for _, sapi := range []string{"fpm", "cli"} {
+				if err := ioutil.WriteFile(filepath.Join(dir, "7.4", sapi, "conf.d", "99-nitro.ini"), []byte("memory_limit = 512M\nmax_execution_time = 300\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			spy := &spyChainRunner{Output: iniGetAllOutput}
+			s := &NitroService{
+				command: spy,
+				logger:  log.New(ioutil.Discard, "testing", 0),
+				phpDir:  dir,
+			}
+			got, err := s.ResetPhpIniSetting(tt.args.ctx, tt.args.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResetPhpIniSetting() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResetPhpIniSetting() got = %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(spy.Commands, tt.wantCommands) {
+				t.Errorf("expected the commands to be:\n%v\n, got:\n%v", tt.wantCommands, spy.Commands)
+			}
+
+			for sapi, want := range tt.wantOverrides {
+				if got := testOverrides(t, dir, "7.4", sapi); got != want {
+					t.Errorf("expected the %s overrides to be:\n%v\ngot:\n%v", sapi, want, got)
+				}
+			}
+		})
+	}
+}
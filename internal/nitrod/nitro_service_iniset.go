@@ -2,18 +2,31 @@ package nitrod
 
 import (
 	"context"
-	"errors"
-	"strconv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/craftcms/nitro/internal/phpini"
 	"github.com/craftcms/nitro/internal/validate"
 )
 
-// PhpIniSettings enables changing specific values for the php ini
+// iniValidators are additional checks for settings
+// where PHP accepts values that break Craft.
+var iniValidators = map[string]func(string) error{
+	"max_execution_time": validate.MaxExecutionTime,
+	"max_input_vars":     validate.MaxInputVars,
+	"max_file_uploads":   validate.PhpMaxFileUploads,
+}
+
+// PhpIniSettings changes the value of any PHP ini setting by writing it to the nitro
+// override file for the PHP version and SAPI. The php.ini files are never modified.
 func (s *NitroService) PhpIniSettings(ctx context.Context, request *ChangePhpIniSettingRequest) (*ServiceResponse, error) {
-	var setting string
+	setting := request.GetSetting()
 	value := request.GetValue()
 	version := request.GetVersion()
 
@@ -22,78 +35,165 @@ func (s *NitroService) PhpIniSettings(ctx context.Context, request *ChangePhpIni
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
 
-	// validate the setting and value
-	switch request.GetSetting() {
-	case PhpIniSetting_MAX_EXECUTION_TIME:
-		if err := validate.MaxExecutionTime(value); err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, err.Error())
-		}
+	// make sure the setting exists for the php version
+	current, err := s.validateIniSetting(version, setting)
+	if err != nil {
+		return nil, err
+	}
 
-		setting = "max_execution_time"
-	case PhpIniSetting_UPLOAD_MAX_FILESIZE:
-		if err := validate.IsMegabytes(value); err != nil {
+	// validate the value using the type of the current value
+	if err := phpini.ValidateValue(setting, current.Global, value); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if validator, ok := iniValidators[setting]; ok {
+		if err := validator(value); err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, err.Error())
 		}
+	}
 
-		setting = "upload_max_filesize"
-	case PhpIniSetting_MAX_INPUT_TIME:
-		if _, err := strconv.Atoi(value); err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	for _, sapi := range sapis(request.GetSapi()) {
+		if err := s.setIniOverride(version, sapi, setting, &value); err != nil {
+			s.logger.Println("error changing ini setting, error:", err)
+			return nil, status.Errorf(codes.Internal, "unable to change the ini setting %q", setting)
 		}
+	}
 
-		setting = "max_input_time"
-	case PhpIniSetting_MAX_INPUT_VARS:
-		if err := validate.MaxInputVars(value); err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, err.Error())
-		}
+	if err := s.restartPhpFpm(version, request.GetSapi()); err != nil {
+		return nil, err
+	}
 
-		setting = "max_input_vars"
-	case PhpIniSetting_MAX_FILE_UPLOADS:
-		if err := validate.PhpMaxFileUploads(value); err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, err.Error())
-		}
+	return &ServiceResponse{Message: "Successfully changed the ini setting for " + setting + " to " + value}, nil
+}
 
-		setting = "max_file_uploads"
-	case PhpIniSetting_MEMORY_LIMIT:
-		if err := validate.IsMegabytes(value); err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, err.Error())
-		}
+// validateIniSetting checks that the setting is known to the PHP
+// version using the output of ini_get_all and returns the setting.
+func (s *NitroService) validateIniSetting(version, setting string) (phpini.Setting, error) {
+	if setting == "" {
+		return phpini.Setting{}, status.Errorf(codes.InvalidArgument, "a PHP setting is required")
+	}
+
+	settings, err := s.iniGetAll(version, "cli")
+	if err != nil {
+		s.logger.Println("error listing the ini settings, error:", err)
+		return phpini.Setting{}, status.Errorf(codes.Unknown, err.Error())
+	}
 
-		setting = "memory_limit"
-	case PhpIniSetting_DISPLAY_ERRORS:
-		switch value {
-		case "On":
-			break
-		case "Off":
-			break
-		default:
-			err := errors.New("only the value On or Off is authorized for display_errors")
+	current, ok := settings[setting]
+	if !ok {
+		msg := fmt.Sprintf("the PHP setting %q does not exist for PHP %s", setting, version)
+		s.logger.Println(msg)
+		return phpini.Setting{}, status.Errorf(codes.InvalidArgument, msg)
+	}
 
-			s.logger.Println(err.Error())
+	return current, nil
+}
 
-			return nil, status.Errorf(codes.InvalidArgument, err.Error())
-		}
+// iniGetAll runs ini_get_all using the php.ini and conf.d directory of the
+// SAPI, this returns the same values the SAPI sees without running it.
+func (s *NitroService) iniGetAll(version, sapi string) (phpini.Settings, error) {
+	dir := filepath.Join(s.phpDir, version, sapi)
+
+	output, err := s.command.Run("env", []string{
+		"PHP_INI_SCAN_DIR=" + filepath.Join(dir, "conf.d"),
+		"php" + version,
+		"-c", filepath.Join(dir, "php.ini"),
+		"-r", phpini.IniGetAllScript,
+	})
+	if err != nil {
+		s.logger.Println("output:", string(output))
+		return nil, fmt.Errorf("unable to get the ini settings for PHP %s %s: %w", version, sapi, err)
+	}
 
-		setting = "display_errors"
+	settings := phpini.Settings{}
+	if err := json.Unmarshal(output, &settings); err != nil {
+		return nil, fmt.Errorf("unable to read the ini settings for PHP %s %s: %w", version, sapi, err)
+	}
+
+	return settings, nil
+}
+
+// iniOverridePath returns the path to the nitro override
+// file for the PHP version and SAPI.
+func (s *NitroService) iniOverridePath(version, sapi string) string {
+	return filepath.Join(s.phpDir, version, sapi, "conf.d", phpini.OverrideFile)
+}
+
+// iniOverrides returns the settings in the override file, if the
+// file does not exist it returns an empty set of overrides.
+func (s *NitroService) iniOverrides(version, sapi string) (phpini.Overrides, error) {
+	f, err := os.Open(s.iniOverridePath(version, sapi))
+	if os.IsNotExist(err) {
+		return phpini.Overrides{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return phpini.Parse(f)
+}
+
+// setIniOverride sets the setting in the override file, if the value
+// is nil the setting is removed. The file is replaced atomically so
+// php-fpm never reads a partially written file.
+func (s *NitroService) setIniOverride(version, sapi, setting string, value *string) error {
+	overrides, err := s.iniOverrides(version, sapi)
+	if err != nil {
+		return err
+	}
+
+	switch value {
+	case nil:
+		delete(overrides, setting)
 	default:
-		msg := "changing " + PhpIniSetting_name[int32(request.GetSetting())] + " is not authorized"
-		s.logger.Println(msg)
-		return nil, status.Errorf(codes.InvalidArgument, msg)
+		overrides[setting] = *value
 	}
 
-	// change setting using sed
-	if output, err := s.command.Run("sed", []string{"-i", "/" + setting + `/c\` + setting + " = " + value, "/etc/php/" + version + "/fpm/php.ini"}); err != nil {
-		s.logger.Println("error changing ini setting, error:", err)
-		s.logger.Println("output:", string(output))
-		return nil, status.Errorf(codes.Unknown, string(output))
+	file := s.iniOverridePath(version, sapi)
+	tmp, err := ioutil.TempFile(filepath.Dir(file), "."+phpini.OverrideFile)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(overrides.Render()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), file)
+}
+
+// restartPhpFpm restarts php-fpm when the change affects the fpm SAPI,
+// the cli SAPI reads the ini files on every run.
+func (s *NitroService) restartPhpFpm(version string, sapi PhpSapi) error {
+	if sapi == PhpSapi_CLI {
+		return nil
 	}
 
-	// restart php-fpm using service
 	if output, err := s.command.Run("service", []string{"php" + version + "-fpm", "restart"}); err != nil {
 		s.logger.Println("error restarting php-fpm, error:", err)
 		s.logger.Println("output:", string(output))
-		return nil, status.Errorf(codes.Unknown, string(output))
+		return status.Errorf(codes.Unknown, string(output))
 	}
 
-	return &ServiceResponse{Message: "Successfully changed the ini setting for " + setting + " to " + value}, nil
+	return nil
+}
+
+// sapis returns the names of the SAPI directories for a request.
+func sapis(sapi PhpSapi) []string {
+	switch sapi {
+	case PhpSapi_FPM:
+		return []string{"fpm"}
+	case PhpSapi_CLI:
+		return []string{"cli"}
+	default:
+		return []string{"fpm", "cli"}
+	}
 }
@@ -4,71 +4,108 @@ import (
 	"context"
 	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
 
 func TestNitrodService_GetPhpIniSetting(t *testing.T) {
-	type fields struct {
-		command Runner
-		logger  *log.Logger
-	}
 	type args struct {
 		ctx     context.Context
 		request *GetPhpIniSettingRequest
 	}
 	tests := []struct {
-		name         string
-		fields       fields
-		args         args
-		want         *ServiceResponse
-		wantErr      bool
-		wantCommands []string
-		wantArgs     []map[string][]string
+		name      string
+		args      args
+		overrides string
+		want      *PhpIniSettingResponse
+		wantErr   bool
 	}{
 		{
-			name: "can get the php ini setting for max_file_uploads",
-			fields: fields{
-				logger: log.New(ioutil.Discard, "testing", 0),
+			name: "can get the php ini setting for memory_limit",
+			args: args{
+				ctx:     context.TODO(),
+				request: &GetPhpIniSettingRequest{Version: "7.4", Setting: "memory_limit"},
 			},
+			want: &PhpIniSettingResponse{
+				Version: "7.4",
+				Setting: "memory_limit",
+				Fpm:     &PhpIniValue{Type: PhpIniValueType_BYTES, Raw: "128M", Int: 134217728},
+				Cli:     &PhpIniValue{Type: PhpIniValueType_BYTES, Raw: "128M", Int: 134217728},
+			},
+		},
+		{
+			name: "returns booleans for boolean settings",
+			args: args{
+				ctx:     context.TODO(),
+				request: &GetPhpIniSettingRequest{Version: "7.4", Setting: "opcache.enable"},
+			},
+			want: &PhpIniSettingResponse{
+				Version: "7.4",
+				Setting: "opcache.enable",
+				Fpm:     &PhpIniValue{Type: PhpIniValueType_BOOLEAN, Raw: "1", Bool: true},
+				Cli:     &PhpIniValue{Type: PhpIniValueType_BOOLEAN, Raw: "1", Bool: true},
+			},
+		},
+		{
+			name: "reports if the setting is overridden by nitro",
 			args: args{
 				ctx:     context.TODO(),
-				request: &GetPhpIniSettingRequest{Version: "7.4", Setting: "max_file_uploads"},
+				request: &GetPhpIniSettingRequest{Version: "7.4", Setting: "max_execution_time"},
+			},
+			overrides: "max_execution_time = 30\n",
+			want: &PhpIniSettingResponse{
+				Version: "7.4",
+				Setting: "max_execution_time",
+				Fpm:     &PhpIniValue{Type: PhpIniValueType_INTEGER, Raw: "30", Int: 30, Overridden: true},
+				Cli:     &PhpIniValue{Type: PhpIniValueType_INTEGER, Raw: "30", Int: 30},
 			},
-			want:         &ServiceResponse{Message: "The setting \"max_file_uploads\" is currently set to 512M"},
-			wantErr:      false,
-			wantCommands: []string{"bash"},
-			wantArgs: []map[string][]string{
-				{
-					"bash": {"-c", "php-fpm7.4 -i | grep 'max_file_uploads'"},
-				},
+		},
+		{
+			name: "unknown settings return an error",
+			args: args{
+				ctx:     context.TODO(),
+				request: &GetPhpIniSettingRequest{Version: "7.4", Setting: "not_a_setting"},
 			},
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			spy := &spyChainRunner{
-				Output: "memory_limit => 512M => 512M",
+			dir := testPhpDir(t, "7.4")
+			defer os.RemoveAll(dir)
+
+			if tt.overrides != "" {
+				if err := ioutil.WriteFile(filepath.Join(dir, "7.4", "fpm", "conf.d", "99-nitro.ini"), []byte(tt.overrides), 0644); err != nil {
+					t.Fatal(err)
+				}
 			}
+
+			spy := &spyChainRunner{Output: iniGetAllOutput}
 			s := &NitroService{
 				command: spy,
-				logger:  tt.fields.logger,
+				logger:  log.New(ioutil.Discard, "testing", 0),
+				phpDir:  dir,
 			}
 			got, err := s.GetPhpIniSetting(tt.args.ctx, tt.args.request)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetPhpIniSetting() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.String(), tt.want.String()) {
 				t.Errorf("GetPhpIniSetting() got = %v, want %v", got, tt.want)
 			}
 
-			if !reflect.DeepEqual(spy.Commands, tt.wantCommands) {
-				t.Errorf("expected the commands to be:\n%v\n, got:\n%v", tt.wantCommands, spy.Commands)
+			wantArgs := []map[string][]string{
+				{"env": {"PHP_INI_SCAN_DIR=" + filepath.Join(dir, "7.4", "fpm", "conf.d"), "php7.4", "-c", filepath.Join(dir, "7.4", "fpm", "php.ini"), "-r", "echo json_encode(ini_get_all(null, true));"}},
+				{"env": {"PHP_INI_SCAN_DIR=" + filepath.Join(dir, "7.4", "cli", "conf.d"), "php7.4", "-c", filepath.Join(dir, "7.4", "cli", "php.ini"), "-r", "echo json_encode(ini_get_all(null, true));"}},
 			}
-
-			if !reflect.DeepEqual(spy.Args, tt.wantArgs) {
-				t.Errorf("expected the args to be:\n%v\ngot:\n%v", tt.wantArgs, spy.Args)
+			if !reflect.DeepEqual(spy.Args, wantArgs) {
+				t.Errorf("expected the args to be:\n%v\ngot:\n%v", wantArgs, spy.Args)
 			}
 		})
 	}
@@ -4,239 +4,179 @@ import (
 	"context"
 	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
+const iniGetAllOutput = `{"display_errors":{"global_value":"1","local_value":"1","access":7},"error_reporting":{"global_value":"32767","local_value":"32767","access":7},"max_execution_time":{"global_value":"30","local_value":"30","access":7},"max_input_time":{"global_value":"-1","local_value":"-1","access":6},"max_input_vars":{"global_value":"1000","local_value":"1000","access":6},"memory_limit":{"global_value":"128M","local_value":"128M","access":7},"opcache.enable":{"global_value":"1","local_value":"1","access":7}}`
+
 func TestNitrodService_PhpIniSettings(t *testing.T) {
-	type fields struct {
-		command Runner
-		logger  *log.Logger
-	}
 	type args struct {
 		ctx     context.Context
 		request *ChangePhpIniSettingRequest
 	}
 	tests := []struct {
-		name         string
-		fields       fields
-		args         args
-		want         *ServiceResponse
-		wantErr      bool
-		wantCommands []string
-		wantArgs     []map[string][]string
+		name          string
+		args          args
+		want          *ServiceResponse
+		wantErr       bool
+		wantCommands  []string
+		wantOverrides map[string]string
 	}{
 		{
-			name: "can modify the php ini setting for display_errors",
-			fields: fields{
-				logger: log.New(ioutil.Discard, "testing", 0),
-			},
+			name: "can modify the php ini setting for memory_limit",
 			args: args{
 				ctx:     context.TODO(),
-				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: PhpIniSetting_DISPLAY_ERRORS, Value: "On"},
+				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: "memory_limit", Value: "512M"},
 			},
-			want:         &ServiceResponse{Message: "Successfully changed the ini setting for display_errors to On"},
-			wantErr:      false,
-			wantCommands: []string{"sed", "service"},
-			wantArgs: []map[string][]string{
-				{
-					"sed": {"-i", `/display_errors/c\display_errors = On`, "/etc/php/7.4/fpm/php.ini"},
-				},
-				{
-					"service": {"php7.4-fpm", "restart"},
-				},
+			want:         &ServiceResponse{Message: "Successfully changed the ini setting for memory_limit to 512M"},
+			wantCommands: []string{"env", "service"},
+			wantOverrides: map[string]string{
+				"fpm": "memory_limit = 512M\n",
+				"cli": "memory_limit = 512M\n",
 			},
 		},
 		{
-			name: "can modify the php ini setting for memory_limit",
-			fields: fields{
-				logger: log.New(ioutil.Discard, "testing", 0),
-			},
+			name: "can set error_reporting to an expression of constants",
 			args: args{
 				ctx:     context.TODO(),
-				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: PhpIniSetting_MEMORY_LIMIT, Value: "512M"},
+				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: "error_reporting", Value: "E_ALL & ~E_DEPRECATED"},
 			},
-			want:         &ServiceResponse{Message: "Successfully changed the ini setting for memory_limit to 512M"},
-			wantErr:      false,
-			wantCommands: []string{"sed", "service"},
-			wantArgs: []map[string][]string{
-				{
-					"sed": {"-i", `/memory_limit/c\memory_limit = 512M`, "/etc/php/7.4/fpm/php.ini"},
-				},
-				{
-					"service": {"php7.4-fpm", "restart"},
-				},
+			want:         &ServiceResponse{Message: "Successfully changed the ini setting for error_reporting to E_ALL & ~E_DEPRECATED"},
+			wantCommands: []string{"env", "service"},
+			wantOverrides: map[string]string{
+				"fpm": "error_reporting = E_ALL & ~E_DEPRECATED\n",
+				"cli": "error_reporting = E_ALL & ~E_DEPRECATED\n",
 			},
 		},
 		{
-			name: "can modify the php ini setting for max_file_uploads",
-			fields: fields{
-				logger: log.New(ioutil.Discard, "testing", 0),
-			},
+			name: "can modify settings that are not in php.ini",
 			args: args{
 				ctx:     context.TODO(),
-				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: PhpIniSetting_MAX_FILE_UPLOADS, Value: "400"},
+				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: "opcache.enable", Value: "Off"},
 			},
-			want:         &ServiceResponse{Message: "Successfully changed the ini setting for max_file_uploads to 400"},
-			wantErr:      false,
-			wantCommands: []string{"sed", "service"},
-			wantArgs: []map[string][]string{
-				{
-					"sed": {"-i", `/max_file_uploads/c\max_file_uploads = 400`, "/etc/php/7.4/fpm/php.ini"},
-				},
-				{
-					"service": {"php7.4-fpm", "restart"},
-				},
+			want:         &ServiceResponse{Message: "Successfully changed the ini setting for opcache.enable to Off"},
+			wantCommands: []string{"env", "service"},
+			wantOverrides: map[string]string{
+				"fpm": "opcache.enable = Off\n",
+				"cli": "opcache.enable = Off\n",
 			},
 		},
 		{
-			name: "can modify the php ini setting for max_input_time",
-			fields: fields{
-				logger: log.New(ioutil.Discard, "testing", 0),
-			},
+			name: "changing only the cli sapi does not restart php-fpm",
 			args: args{
 				ctx:     context.TODO(),
-				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: PhpIniSetting_MAX_INPUT_TIME, Value: "4000"},
+				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: "max_execution_time", Value: "0", Sapi: PhpSapi_CLI},
 			},
-			want:         &ServiceResponse{Message: "Successfully changed the ini setting for max_input_time to 4000"},
-			wantErr:      false,
-			wantCommands: []string{"sed", "service"},
-			wantArgs: []map[string][]string{
-				{
-					"sed": {"-i", `/max_input_time/c\max_input_time = 4000`, "/etc/php/7.4/fpm/php.ini"},
-				},
-				{
-					"service": {"php7.4-fpm", "restart"},
-				},
+			want:         &ServiceResponse{Message: "Successfully changed the ini setting for max_execution_time to 0"},
+			wantCommands: []string{"env"},
+			wantOverrides: map[string]string{
+				"fpm": "",
+				"cli": "max_execution_time = 0\n",
 			},
 		},
 		{
-			name: "can modify the php ini setting for upload_max_filesize",
-			fields: fields{
-				logger: log.New(ioutil.Discard, "testing", 0),
-			},
+			name: "can modify only the fpm sapi",
 			args: args{
 				ctx:     context.TODO(),
-				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: PhpIniSetting_UPLOAD_MAX_FILESIZE, Value: "10M"},
+				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: "display_errors", Value: "stderr", Sapi: PhpSapi_FPM},
 			},
-			want:         &ServiceResponse{Message: "Successfully changed the ini setting for upload_max_filesize to 10M"},
-			wantErr:      false,
-			wantCommands: []string{"sed", "service"},
-			wantArgs: []map[string][]string{
-				{
-					"sed": {"-i", `/upload_max_filesize/c\upload_max_filesize = 10M`, "/etc/php/7.4/fpm/php.ini"},
-				},
-				{
-					"service": {"php7.4-fpm", "restart"},
-				},
+			want:         &ServiceResponse{Message: "Successfully changed the ini setting for display_errors to stderr"},
+			wantCommands: []string{"env", "service"},
+			wantOverrides: map[string]string{
+				"fpm": "display_errors = stderr\n",
+				"cli": "",
 			},
 		},
 		{
-			name: "can modify the php ini setting for upload_max_filesize",
-			fields: fields{
-				logger: log.New(ioutil.Discard, "testing", 0),
-			},
+			name: "unknown settings return an error",
 			args: args{
 				ctx:     context.TODO(),
-				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: PhpIniSetting_UPLOAD_MAX_FILESIZE, Value: "10M"},
-			},
-			want:         &ServiceResponse{Message: "Successfully changed the ini setting for upload_max_filesize to 10M"},
-			wantErr:      false,
-			wantCommands: []string{"sed", "service"},
-			wantArgs: []map[string][]string{
-				{
-					"sed": {"-i", `/upload_max_filesize/c\upload_max_filesize = 10M`, "/etc/php/7.4/fpm/php.ini"},
-				},
-				{
-					"service": {"php7.4-fpm", "restart"},
-				},
+				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: "not_a_setting", Value: "1"},
 			},
+			wantErr:      true,
+			wantCommands: []string{"env"},
 		},
 		{
-			name: "can modify the php ini setting for max_input_vars",
-			fields: fields{
-				logger: log.New(ioutil.Discard, "testing", 0),
-			},
+			name: "can modify settings using the type of the current value",
 			args: args{
 				ctx:     context.TODO(),
-				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: PhpIniSetting_MAX_INPUT_VARS, Value: "1000"},
+				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: "max_input_time", Value: "60"},
 			},
-			want:         &ServiceResponse{Message: "Successfully changed the ini setting for max_input_vars to 1000"},
-			wantErr:      false,
-			wantCommands: []string{"sed", "service"},
-			wantArgs: []map[string][]string{
-				{
-					"sed": {"-i", `/max_input_vars/c\max_input_vars = 1000`, "/etc/php/7.4/fpm/php.ini"},
-				},
-				{
-					"service": {"php7.4-fpm", "restart"},
-				},
+			want:         &ServiceResponse{Message: "Successfully changed the ini setting for max_input_time to 60"},
+			wantCommands: []string{"env", "service"},
+			wantOverrides: map[string]string{
+				"fpm": "max_input_time = 60\n",
+				"cli": "max_input_time = 60\n",
 			},
 		},
 		{
-			name: "setting max_input_vars to a non-integer returns an error",
-			fields: fields{
-				logger: log.New(ioutil.Discard, "testing", 0),
-			},
+			name: "values that do not match the type of the current value return an error",
 			args: args{
 				ctx:     context.TODO(),
-				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: PhpIniSetting_MAX_INPUT_VARS, Value: "300b"},
+				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: "max_input_time", Value: "abc"},
 			},
-			want:    nil,
-			wantErr: true,
+			wantErr:      true,
+			wantCommands: []string{"env"},
 		},
 		{
-			name: "setting max_input_vars must be less than 10000",
-			fields: fields{
-				logger: log.New(ioutil.Discard, "testing", 0),
-			},
+			name: "invalid display_errors values return an error",
 			args: args{
 				ctx:     context.TODO(),
-				request: &ChangePhpIniSettingRequest{Version: "7.3", Setting: PhpIniSetting_MAX_INPUT_VARS, Value: "10000"},
+				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: "display_errors", Value: "banana"},
 			},
-			want:    nil,
-			wantErr: true,
+			wantErr:      true,
+			wantCommands: []string{"env"},
 		},
 		{
-			name: "can modify the php ini setting for max_execution_time",
-			fields: fields{
-				logger: log.New(ioutil.Discard, "testing", 0),
-			},
+			name: "invalid sizes return an error",
 			args: args{
 				ctx:     context.TODO(),
-				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: PhpIniSetting_MAX_EXECUTION_TIME, Value: "300"},
+				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: "memory_limit", Value: "lots"},
 			},
-			want:         &ServiceResponse{Message: "Successfully changed the ini setting for max_execution_time to 300"},
-			wantErr:      false,
-			wantCommands: []string{"sed", "service"},
-			wantArgs: []map[string][]string{
-				{
-					"sed": {"-i", `/max_execution_time/c\max_execution_time = 300`, "/etc/php/7.4/fpm/php.ini"},
-				},
-				{
-					"service": {"php7.4-fpm", "restart"},
-				},
+			wantErr:      true,
+			wantCommands: []string{"env"},
+		},
+		{
+			name: "setting max_input_vars to a non-integer returns an error",
+			args: args{
+				ctx:     context.TODO(),
+				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: "max_input_vars", Value: "300b"},
 			},
+			wantErr:      true,
+			wantCommands: []string{"env"},
 		},
 		{
-			name: "setting max_execution_time to a non-integer returns an error",
-			fields: fields{
-				logger: log.New(ioutil.Discard, "testing", 0),
+			name: "setting max_input_vars must be less than 10000",
+			args: args{
+				ctx:     context.TODO(),
+				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: "max_input_vars", Value: "10000"},
 			},
+			wantErr:      true,
+			wantCommands: []string{"env"},
+		},
+		{
+			name: "invalid php versions return an error",
 			args: args{
 				ctx:     context.TODO(),
-				request: &ChangePhpIniSettingRequest{Version: "7.4", Setting: PhpIniSetting_MAX_EXECUTION_TIME, Value: "300b"},
+				request: &ChangePhpIniSettingRequest{Version: "5.6", Setting: "memory_limit", Value: "512M"},
 			},
-			want:    nil,
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			spy := &spyChainRunner{}
+			dir := testPhpDir(t, "7.4")
+			defer os.RemoveAll(dir)
+
+			spy := &spyChainRunner{Output: iniGetAllOutput}
 			s := &NitroService{
 				command: spy,
-				logger:  tt.fields.logger,
+				logger:  log.New(ioutil.Discard, "testing", 0),
+				phpDir:  dir,
 			}
 			got, err := s.PhpIniSettings(tt.args.ctx, tt.args.request)
 			if (err != nil) != tt.wantErr {
@@ -251,9 +191,78 @@ func TestNitrodService_PhpIniSettings(t *testing.T) {
 				t.Errorf("expected the commands to be:\n%v\n, got:\n%v", tt.wantCommands, spy.Commands)
 			}
 
-			if !reflect.DeepEqual(spy.Args, tt.wantArgs) {
-				t.Errorf("expected the args to be:\n%v\ngot:\n%v", tt.wantArgs, spy.Args)
+			for sapi, want := range tt.wantOverrides {
+				if got := testOverrides(t, dir, "7.4", sapi); got != want {
+					t.Errorf("expected the %s overrides to be:\n%v\ngot:\n%v", sapi, want, got)
+				}
 			}
 		})
 	}
 }
+
+func TestNitrodService_PhpIniSettingsKeepsExistingOverrides(t *testing.T) {
+	dir := testPhpDir(t, "7.4")
+	defer os.RemoveAll(dir)
+
+	s := &NitroService{
+		command: &spyChainRunner{Output: iniGetAllOutput},
+		logger:  log.New(ioutil.Discard, "testing", 0),
+		phpDir:  dir,
+	}
+
+	requests := []*ChangePhpIniSettingRequest{
+		{Version: "7.4", Setting: "memory_limit", Value: "512M"},
+		{Version: "7.4", Setting: "max_execution_time", Value: "300"},
+		{Version: "7.4", Setting: "memory_limit", Value: "1G"},
+	}
+	for _, r := range requests {
+		if _, err := s.PhpIniSettings(context.TODO(), r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := "max_execution_time = 300\nmemory_limit = 1G\n"
+	if got := testOverrides(t, dir, "7.4", "fpm"); got != want {
+		t.Errorf("expected the overrides to be:\n%v\ngot:\n%v", want, got)
+	}
+}
+
+// testPhpDir creates a temp directory with the conf.d
+// directories for the fpm and cli SAPIs of each version.
+func testPhpDir(t *testing.T, versions ...string) string {
+	dir, err := ioutil.TempDir("", "nitrod-php-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range versions {
+		for _, sapi := range []string{"fpm", "cli"} {
+			if err := os.MkdirAll(filepath.Join(dir, v, sapi, "conf.d"), 0755); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	return dir
+}
+
+// testOverrides returns the settings from an override file
+// without the comments nitro adds to the top of the file.
+func testOverrides(t *testing.T, dir, version, sapi string) string {
+	b, err := ioutil.ReadFile(filepath.Join(dir, version, sapi, "conf.d", "99-nitro.ini"))
+	if os.IsNotExist(err) {
+		return ""
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var settings string
+	for _, line := range strings.Split(string(b), "\n") {
+		if len(line) > 0 && line[0] != ';' {
+			settings += line + "\n"
+		}
+	}
+
+	return settings
+}
@@ -0,0 +1,167 @@
+package nitrod
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/craftcms/nitro/internal/jobs"
+	"github.com/craftcms/nitro/internal/nitro"
+	"github.com/craftcms/nitro/internal/validate"
+)
+
+const (
+	jobKindImport  = "import"
+	jobKindInstall = "install"
+	jobKindUpgrade = "upgrade"
+)
+
+// StartJob runs the package installs and upgrades in the background, the
+// job is returned right away and the log can be followed with WatchJob.
+func (s *NitroService) StartJob(ctx context.Context, req *StartJobRequest) (*Job, error) {
+	var kind, description string
+	var fn jobs.Func
+
+	switch j := req.GetJob().(type) {
+	case *StartJobRequest_InstallPackages:
+		version := j.InstallPackages.GetVersion()
+		if err := validate.PHPVersion(version); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+
+		kind, description = jobKindInstall, "Install the packages for PHP "+version
+		fn = func(ctx context.Context, log io.Writer) error {
+			if err := s.aptGet(ctx, log, append([]string{"install", "-y"}, nitro.PHPPackages(version)...)...); err != nil {
+				return err
+			}
+
+			return s.enableFpmStatus(ctx, log, version)
+		}
+	case *StartJobRequest_UpgradePackages:
+		kind, description = jobKindUpgrade, "Upgrade the packages"
+		fn = func(ctx context.Context, log io.Writer) error {
+			if err := s.aptGet(ctx, log, "update"); err != nil {
+				return err
+			}
+
+			return s.aptGet(ctx, log, "upgrade", "-y")
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "a job is required")
+	}
+
+	// apt only allows one install at a time
+	job, err := s.jobs.StartExclusive(kind, description, fn, jobKindInstall, jobKindUpgrade)
+	switch {
+	case errors.Is(err, jobs.ErrConflict):
+		return nil, status.Errorf(codes.FailedPrecondition, "the job %s is already changing the packages", job.ID)
+	case err != nil:
+		s.logger.Println("error starting the job, error:", err)
+		return nil, status.Errorf(codes.Internal, "unable to start the job")
+	}
+
+	return jobMessage(job), nil
+}
+
+// GetJob returns the state of a job.
+func (s *NitroService) GetJob(ctx context.Context, req *GetJobRequest) (*Job, error) {
+	job, err := s.jobs.Get(req.GetId())
+	if err != nil {
+		return nil, jobError(err, req.GetId())
+	}
+
+	return jobMessage(job), nil
+}
+
+// ListJobs returns the running jobs and the recently finished jobs.
+func (s *NitroService) ListJobs(ctx context.Context, req *ListJobsRequest) (*ListJobsResponse, error) {
+	resp := &ListJobsResponse{}
+	for _, job := range s.jobs.List() {
+		resp.Jobs = append(resp.Jobs, jobMessage(job))
+	}
+
+	return resp, nil
+}
+
+// WatchJob streams the log of the job from the offset as it is written. The
+// stream ends when the job is done, if the client goes away the job continues.
+func (s *NitroService) WatchJob(req *WatchJobRequest, stream NitroService_WatchJobServer) error {
+	err := s.jobs.Watch(stream.Context(), req.GetId(), req.GetOffset(), func(job jobs.Job, log []byte, offset int64) error {
+		return stream.Send(&WatchJobResponse{Job: jobMessage(job), Log: log, Offset: offset})
+	})
+	if err != nil {
+		return jobError(err, req.GetId())
+	}
+
+	return nil
+}
+
+// CancelJob stops a running job.
+func (s *NitroService) CancelJob(ctx context.Context, req *CancelJobRequest) (*Job, error) {
+	job, err := s.jobs.Cancel(req.GetId())
+	if err != nil {
+		return nil, jobError(err, req.GetId())
+	}
+
+	return jobMessage(job), nil
+}
+
+// aptGet runs apt-get without prompting for input.
+func (s *NitroService) aptGet(ctx context.Context, log io.Writer, args ...string) error {
+	return s.command.RunContext(ctx, "env", append([]string{"DEBIAN_FRONTEND=noninteractive", "apt-get"}, args...), nil, log, log)
+}
+
+// enableFpmStatus enables the php-fpm status page of the PHP version, which
+// the metrics of nitrod scrape, and restarts php-fpm.
+func (s *NitroService) enableFpmStatus(ctx context.Context, log io.Writer, version string) error {
+	pool := filepath.Join(s.phpDir, version, "fpm", "pool.d", "www.conf")
+	if err := s.command.RunContext(ctx, "sed", []string{"-i", nitro.FPMStatusExpression, pool}, nil, log, log); err != nil {
+		return err
+	}
+
+	return s.command.RunContext(ctx, "service", []string{"php" + version + "-fpm", "restart"}, nil, log, log)
+}
+
+func jobError(err error, id string) error {
+	switch {
+	case errors.Is(err, jobs.ErrNotFound):
+		return status.Errorf(codes.NotFound, "the job %q does not exist", id)
+	case errors.Is(err, jobs.ErrFinished):
+		return status.Errorf(codes.FailedPrecondition, "the job %s has already finished", id)
+	case errors.Is(err, context.Canceled):
+		return status.Errorf(codes.Canceled, err.Error())
+	}
+
+	return status.Errorf(codes.Internal, "unable to read the job %s: %s", id, err)
+}
+
+func jobMessage(job jobs.Job) *Job {
+	j := &Job{
+		Id:          job.ID,
+		Kind:        job.Kind,
+		Description: job.Description,
+		Message:     job.Message,
+		Created:     job.Created.Unix(),
+	}
+
+	switch job.State {
+	case jobs.Succeeded:
+		j.State = JobState_SUCCEEDED
+	case jobs.Failed:
+		j.State = JobState_FAILED
+	case jobs.Canceled:
+		j.State = JobState_CANCELED
+	default:
+		j.State = JobState_RUNNING
+	}
+
+	if !job.Finished.IsZero() {
+		j.Finished = job.Finished.Unix()
+	}
+
+	return j
+}
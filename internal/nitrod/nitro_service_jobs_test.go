@@ -0,0 +1,294 @@
+package nitrod
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/craftcms/nitro/internal/jobs"
+)
+
+// spyWatchJobServer records the log and the last state of the job.
+type spyWatchJobServer struct {
+	grpc.ServerStream
+	ctx context.Context
+	log string
+	job *Job
+}
+
+func (s *spyWatchJobServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *spyWatchJobServer) Send(resp *WatchJobResponse) error {
+	s.log += string(resp.GetLog())
+	s.job = resp.GetJob()
+	return nil
+}
+
+func testJobsService(t *testing.T, runner Runner) (*NitroService, func()) {
+	dir, err := ioutil.TempDir("", "nitrod-jobs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger := log.New(ioutil.Discard, "testing", 0)
+
+	return &NitroService{
+		command:   runner,
+		logger:    logger,
+		importDir: dir,
+		jobs:      jobs.NewManager(filepath.Join(dir, "jobs"), logger),
+	}, func() { os.RemoveAll(dir) }
+}
+
+// watchJob waits for the job to finish and returns the stream.
+func watchJob(t *testing.T, s *NitroService, id string) *spyWatchJobServer {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream := &spyWatchJobServer{ctx: ctx}
+	if err := s.WatchJob(&WatchJobRequest{Id: id}, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	return stream
+}
+
+func TestNitroService_StartJob(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *StartJobRequest
+		wantKind string
+		wantArgs []string
+		wantCode codes.Code
+	}{
+		{
+			name:     "installs the packages for the php version",
+			request:  &StartJobRequest{Job: &StartJobRequest_InstallPackages{InstallPackages: &InstallPackagesJob{Version: "7.3"}}},
+			wantKind: "install",
+			wantArgs: []string{
+				"env DEBIAN_FRONTEND=noninteractive apt-get install -y php7.3 php7.3-mbstring",
+				"sed -i s|;pm.status_path = /status|pm.status_path = /status|g 7.3/fpm/pool.d/www.conf",
+				"service php7.3-fpm restart",
+			},
+		},
+		{
+			name:     "upgrades the packages",
+			request:  &StartJobRequest{Job: &StartJobRequest_UpgradePackages{UpgradePackages: &UpgradePackagesJob{}}},
+			wantKind: "upgrade",
+			wantArgs: []string{
+				"env DEBIAN_FRONTEND=noninteractive apt-get update",
+				"env DEBIAN_FRONTEND=noninteractive apt-get upgrade -y",
+			},
+		},
+		{
+			name:     "invalid php versions return an error",
+			request:  &StartJobRequest{Job: &StartJobRequest_InstallPackages{InstallPackages: &InstallPackagesJob{Version: "5.6"}}},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "a job is required",
+			request:  &StartJobRequest{},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &spyChainRunner{Output: "Reading package lists... Done\n"}
+			s, cleanup := testJobsService(t, runner)
+			defer cleanup()
+
+			job, err := s.StartJob(context.TODO(), tt.request)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("StartJob() code = %v, want %v, err = %v", code, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if job.GetKind() != tt.wantKind {
+				t.Errorf("expected the kind %q, got %q", tt.wantKind, job.GetKind())
+			}
+
+			stream := watchJob(t, s, job.GetId())
+			if stream.job.GetState() != JobState_SUCCEEDED {
+				t.Errorf("expected the job to succeed, got %v: %s", stream.job.GetState(), stream.job.GetMessage())
+			}
+			if want := strings.Repeat("Reading package lists... Done\n", len(tt.wantArgs)); stream.log != want {
+				t.Errorf("expected the log %q, got %q", want, stream.log)
+			}
+
+			var args []string
+			for _, a := range runner.Args {
+				for command, arg := range a {
+					args = append(args, strings.Join(append([]string{command}, arg...), " "))
+				}
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Fatalf("expected the commands %v, got %v", tt.wantArgs, args)
+			}
+			for i := range args {
+				if !strings.HasPrefix(args[i], tt.wantArgs[i]) {
+					t.Errorf("expected the command %q, got %q", tt.wantArgs[i], args[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNitroService_StartJob_OneAptJob(t *testing.T) {
+	s, cleanup := testJobsService(t, &spyChainRunner{})
+	defer cleanup()
+
+	// a job that has the apt lock
+	release := make(chan struct{})
+	defer close(release)
+	running, err := s.jobs.Start("install", "Install the packages for PHP 7.4", func(ctx context.Context, log io.Writer) error {
+		<-release
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.StartJob(context.TODO(), &StartJobRequest{Job: &StartJobRequest_UpgradePackages{UpgradePackages: &UpgradePackagesJob{}}})
+	if code := status.Code(err); code != codes.FailedPrecondition {
+		t.Errorf("expected the code %v, got %v", codes.FailedPrecondition, code)
+	}
+	if !strings.Contains(err.Error(), running.ID) {
+		t.Errorf("expected the error to have the running job, got %v", err)
+	}
+}
+
+func TestNitroService_Jobs(t *testing.T) {
+	s, cleanup := testJobsService(t, &spyChainRunner{})
+	defer cleanup()
+
+	started := make(chan struct{})
+	job, err := s.jobs.Start("import", "Import craft into mysql_5.7_3306", func(ctx context.Context, log io.Writer) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	got, err := s.GetJob(context.TODO(), &GetJobRequest{Id: job.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetState() != JobState_RUNNING || got.GetDescription() != "Import craft into mysql_5.7_3306" {
+		t.Errorf("unexpected job %v", got)
+	}
+
+	list, err := s.ListJobs(context.TODO(), &ListJobsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.GetJobs()) != 1 || list.GetJobs()[0].GetId() != job.ID {
+		t.Errorf("expected the list to have the job, got %v", list.GetJobs())
+	}
+
+	if _, err := s.CancelJob(context.TODO(), &CancelJobRequest{Id: job.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	stream := watchJob(t, s, job.ID)
+	if stream.job.GetState() != JobState_CANCELED || stream.job.GetFinished() == 0 {
+		t.Errorf("expected the job to be canceled, got %v", stream.job)
+	}
+
+	codeTests := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{
+			name: "canceling a finished job",
+			call: func() error {
+				_, err := s.CancelJob(context.TODO(), &CancelJobRequest{Id: job.ID})
+				return err
+			},
+			want: codes.FailedPrecondition,
+		},
+		{
+			name: "getting a missing job",
+			call: func() error {
+				_, err := s.GetJob(context.TODO(), &GetJobRequest{Id: "missing"})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "watching a missing job",
+			call: func() error {
+				return s.WatchJob(&WatchJobRequest{Id: "missing"}, &spyWatchJobServer{ctx: context.TODO()})
+			},
+			want: codes.NotFound,
+		},
+	}
+	for _, tt := range codeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := status.Code(tt.call()); code != tt.want {
+				t.Errorf("expected the code %v, got %v", tt.want, code)
+			}
+		})
+	}
+}
+
+func TestNitroService_ImportDatabase_Background(t *testing.T) {
+	backup := []byte("CREATE TABLE a (id int);\nINSERT INTO a VALUES (1),\n(2);\n")
+
+	runner := &spyChainRunner{}
+	s, cleanup := testJobsService(t, runner)
+	defer cleanup()
+
+	header := importHeader(backup)
+	header.GetHeader().Background = true
+	stream := &spyImportDatabaseServer{requests: []*ImportDatabaseRequest{header, importData(backup)}}
+
+	if err := s.ImportDatabase(stream); err != nil {
+		t.Fatal(err)
+	}
+
+	var stages []ImportStage
+	for _, p := range stream.responses {
+		stages = append(stages, p.GetStage())
+	}
+	want := []ImportStage{ImportStage_UPLOADING, ImportStage_UPLOADING, ImportStage_VERIFYING, ImportStage_QUEUED}
+	if !reflect.DeepEqual(stages, want) {
+		t.Fatalf("expected the stages %v, got %v", want, stages)
+	}
+
+	id := stream.responses[len(stream.responses)-1].GetJob()
+	watch := watchJob(t, s, id)
+	if watch.job.GetState() != JobState_SUCCEEDED || watch.job.GetKind() != "import" {
+		t.Errorf("expected the import job to succeed, got %v", watch.job)
+	}
+	if !strings.Contains(watch.log, "Successfully imported the database, 2 statements") {
+		t.Errorf("expected the log to have the result, got %q", watch.log)
+	}
+
+	if !reflect.DeepEqual(runner.Input, []string{string(backup)}) {
+		t.Errorf("expected the input %q, got %q", backup, runner.Input)
+	}
+
+	upload := filepath.Join(s.importDir, header.GetHeader().GetChecksum()+".part")
+	if _, err := os.Stat(upload); !os.IsNotExist(err) {
+		t.Error("expected the job to remove the upload")
+	}
+}
@@ -0,0 +1,36 @@
+package nitrod
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// QueryDatabase runs the query on the database and returns the rows of the
+// last statement. The values are returned as strings, with NULL values
+// flagged, so the client can format them as a table, JSON or CSV.
+func (s *NitroService) QueryDatabase(ctx context.Context, request *QueryDatabaseRequest) (*QueryDatabaseResponse, error) {
+	engine, container, name, err := s.databaseRequest(request.GetEngine(), request.GetContainer(), request.GetDatabase())
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(request.GetQuery()) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "a query is required")
+	}
+
+	if db, err := s.findDatabase(engine, container, name); err != nil {
+		return nil, err
+	} else if db == nil {
+		return nil, status.Errorf(codes.NotFound, "the database %q does not exist", name)
+	}
+
+	resp, err := engine.execute(ctx, container, name, request.GetQuery())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to run the query: %s", err.Error())
+	}
+
+	return resp, nil
+}
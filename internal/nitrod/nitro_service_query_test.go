@@ -0,0 +1,119 @@
+package nitrod
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNitroService_QueryDatabase(t *testing.T) {
+	mysqlOutput := `<?xml version="1.0"?>
+
+<resultset statement="UPDATE users SET admin = 1" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+</resultset>
+<resultset statement="SELECT id, email, firstName FROM users" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <row>
+	<field name="id">1</field>
+	<field name="email">admin@example.com</field>
+	<field name="firstName" xsi:nil="true" />
+  </row>
+  <row>
+	<field name="id">2</field>
+	<field name="email">&lt;tom&gt;@example.com</field>
+	<field name="firstName">NULL</field>
+  </row>
+</resultset>
+`
+
+	tests := []struct {
+		name      string
+		request   *QueryDatabaseRequest
+		outputs   []string
+		columns   []string
+		rows      [][]string
+		wantCode  codes.Code
+		wantQuery string
+	}{
+		{
+			name:      "mysql returns the rows of the last statement",
+			request:   &QueryDatabaseRequest{Engine: "mysql", Container: "mysql_5.7_3306", Database: "craft", Query: "UPDATE users SET admin = 1; SELECT id, email, firstName FROM users"},
+			outputs:   []string{"craft\tutf8mb4\t1024\t2\n", mysqlOutput},
+			columns:   []string{"id", "email", "firstName"},
+			rows:      [][]string{{"1", "admin@example.com", "<null>"}, {"2", "<tom>@example.com", "NULL"}},
+			wantQuery: "UPDATE users SET admin = 1; SELECT id, email, firstName FROM users",
+		},
+		{
+			name:      "postgres returns the header and rows",
+			request:   &QueryDatabaseRequest{Engine: "postgres", Container: "postgres_12_5432", Database: "craft", Query: "SELECT id, email, \"firstName\" FROM users"},
+			outputs:   []string{"craft\t8012345\tUTF8\n", "12\n", "id\x1femail\x1ffirstName\x1e1\x1fadmin@example.com\x1f\x1a\x1e2\x1fa\tb\nc\x1f\n"},
+			columns:   []string{"id", "email", "firstName"},
+			rows:      [][]string{{"1", "admin@example.com", "<null>"}, {"2", "a\tb\nc", ""}},
+			wantQuery: "SELECT id, email, \"firstName\" FROM users",
+		},
+		{
+			name:    "statements without rows return no columns",
+			request: &QueryDatabaseRequest{Engine: "postgres", Container: "postgres_12_5432", Database: "craft", Query: "DELETE FROM sessions"},
+			outputs: []string{"craft\t8012345\tUTF8\n", "12\n", "\n"},
+		},
+		{
+			name:     "queries are required",
+			request:  &QueryDatabaseRequest{Engine: "mysql", Container: "mysql_5.7_3306", Database: "craft", Query: " "},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "missing databases return an error",
+			request:  &QueryDatabaseRequest{Engine: "mysql", Container: "mysql_5.7_3306", Database: "craft", Query: "SELECT 1"},
+			outputs:  []string{""},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spy := &spyChainRunner{Outputs: tt.outputs}
+			s := &NitroService{
+				command: spy,
+				logger:  log.New(ioutil.Discard, "testing", 0),
+			}
+
+			got, err := s.QueryDatabase(context.TODO(), tt.request)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v, err = %v", code, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(got.GetColumns(), tt.columns) {
+				t.Errorf("expected the columns %q, got %q", tt.columns, got.GetColumns())
+			}
+
+			var rows [][]string
+			for _, r := range got.GetRows() {
+				var row []string
+				for _, v := range r.GetValues() {
+					if v.GetNull() {
+						row = append(row, "<null>")
+						continue
+					}
+					row = append(row, v.GetValue())
+				}
+				rows = append(rows, row)
+			}
+			if !reflect.DeepEqual(rows, tt.rows) {
+				t.Errorf("expected the rows %q, got %q", tt.rows, rows)
+			}
+
+			if tt.wantQuery != "" {
+				args := spy.Args[len(spy.Args)-1]["docker"]
+				if query := args[len(args)-1]; query != tt.wantQuery {
+					t.Errorf("expected the query %q, got %q", tt.wantQuery, query)
+				}
+			}
+		})
+	}
+}
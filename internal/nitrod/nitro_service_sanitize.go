@@ -0,0 +1,71 @@
+package nitrod
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/craftcms/nitro/internal/database"
+)
+
+// SanitizeDatabase anonymises the columns of the database that match the rules,
+// the first rule that matches a column is used. The values are changed the same
+// way as sanitizing a dump so imports and databases sanitized in place match.
+func (s *NitroService) SanitizeDatabase(ctx context.Context, request *SanitizeDatabaseRequest) (*SanitizeDatabaseResponse, error) {
+	engine, container, name, err := s.databaseRequest(request.GetEngine(), request.GetContainer(), request.GetDatabase())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(request.GetRules()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "there are no rules to sanitize the database")
+	}
+
+	var rules []database.SanitizeRule
+	for _, r := range request.GetRules() {
+		rule := database.SanitizeRule{Table: r.GetTable(), Column: r.GetColumn(), Action: r.GetAction(), Value: r.GetValue()}
+		if err := rule.Validate(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		rules = append(rules, rule)
+	}
+
+	if db, err := s.findDatabase(engine, container, name); err != nil {
+		return nil, err
+	} else if db == nil {
+		return nil, status.Errorf(codes.NotFound, "the database %q does not exist", name)
+	}
+
+	columns, err := engine.columns(container, name)
+	if err != nil {
+		return nil, s.databaseError(err, "unable to list the columns of "+name)
+	}
+
+	resp := &SanitizeDatabaseResponse{}
+	for _, c := range columns {
+		for _, rule := range rules {
+			if !rule.Matches(c.table, c.column) {
+				continue
+			}
+
+			rows, err := engine.update(container, name, c, rule.Expression(request.GetEngine(), quoteIdentifier(request.GetEngine(), c.column)))
+			if err != nil {
+				return nil, s.databaseError(err, "unable to sanitize "+c.table+"."+c.column)
+			}
+
+			resp.Columns = append(resp.Columns, &SanitizedColumn{Table: c.table, Column: c.column, Rows: rows})
+			break
+		}
+	}
+
+	return resp, nil
+}
+
+func quoteIdentifier(engine, name string) string {
+	if engine == "postgres" {
+		return postgresIdentifier(name)
+	}
+
+	return mysqlIdentifier(name)
+}
@@ -0,0 +1,106 @@
+package nitrod
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNitroService_SanitizeDatabase(t *testing.T) {
+	rules := []*SanitizeRule{
+		{Table: "users", Column: "email", Action: "email"},
+		{Table: "users", Column: "*", Action: "constant", Value: "x"},
+		{Table: "fmc_*", Column: "field_*", Action: "null"},
+	}
+
+	tests := []struct {
+		name        string
+		request     *SanitizeDatabaseRequest
+		outputs     []string
+		want        []*SanitizedColumn
+		wantCode    codes.Code
+		wantQueries []string
+	}{
+		{
+			name:    "mysql columns are updated with the first rule that matches",
+			request: &SanitizeDatabaseRequest{Engine: "mysql", Container: "mysql_5.7_3306", Database: "craft", Rules: rules},
+			outputs: []string{"craft\tutf8mb4\t1024\t2\n", "entries\tid\nfmc_contact\tfield_name\nusers\temail\nusers\tfirstName\n", "3\n", "2\n", "1\n"},
+			want: []*SanitizedColumn{
+				{Table: "fmc_contact", Column: "field_name", Rows: 3},
+				{Table: "users", Column: "email", Rows: 2},
+				{Table: "users", Column: "firstName", Rows: 1},
+			},
+			wantQueries: []string{
+				"UPDATE `craft`.`fmc_contact` SET `field_name` = NULL; SELECT ROW_COUNT()",
+				"UPDATE `craft`.`users` SET `email` = CONCAT('user-', LEFT(MD5(LOWER(`email`)), 12), '@example.test'); SELECT ROW_COUNT()",
+				"UPDATE `craft`.`users` SET `firstName` = 'x'; SELECT ROW_COUNT()",
+			},
+		},
+		{
+			name:    "postgres columns are updated in their schema",
+			request: &SanitizeDatabaseRequest{Engine: "postgres", Container: "postgres_12_5432", Database: "craft", Rules: rules[:1]},
+			outputs: []string{"craft\t8012345\tUTF8\n", "12\n", "public\tusers\temail\n", "4\n"},
+			want: []*SanitizedColumn{
+				{Table: "users", Column: "email", Rows: 4},
+			},
+			wantQueries: []string{
+				`WITH changed AS (UPDATE "public"."users" SET "email" = 'user-' || left(md5(lower("email"::text)), 12) || '@example.test' WHERE "email" IS DISTINCT FROM 'user-' || left(md5(lower("email"::text)), 12) || '@example.test' RETURNING 1) SELECT count(*) FROM changed`,
+			},
+		},
+		{
+			name:     "invalid rules return an error",
+			request:  &SanitizeDatabaseRequest{Engine: "mysql", Container: "mysql_5.7_3306", Database: "craft", Rules: []*SanitizeRule{{Table: "users", Column: "email", Action: "shuffle"}}},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "rules are required",
+			request:  &SanitizeDatabaseRequest{Engine: "mysql", Container: "mysql_5.7_3306", Database: "craft"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "missing databases return an error",
+			request:  &SanitizeDatabaseRequest{Engine: "mysql", Container: "mysql_5.7_3306", Database: "craft", Rules: rules},
+			outputs:  []string{""},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spy := &spyChainRunner{Outputs: tt.outputs}
+			s := &NitroService{
+				command: spy,
+				logger:  log.New(ioutil.Discard, "testing", 0),
+			}
+
+			got, err := s.SanitizeDatabase(context.TODO(), tt.request)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v, err = %v", code, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(got.GetColumns(), tt.want) {
+				t.Errorf("SanitizeDatabase() got = \n%v, \nwant \n%v", got.GetColumns(), tt.want)
+			}
+
+			// the updates are the last queries
+			var queries []string
+			for _, a := range spy.Args {
+				args := a["docker"]
+				queries = append(queries, args[len(args)-1])
+			}
+			if len(queries) < len(tt.wantQueries) {
+				t.Fatalf("expected %d queries, got %v", len(tt.wantQueries), queries)
+			}
+			if got := queries[len(queries)-len(tt.wantQueries):]; !reflect.DeepEqual(got, tt.wantQueries) {
+				t.Errorf("got queries \n%q, \nwant \n%q", got, tt.wantQueries)
+			}
+		})
+	}
+}
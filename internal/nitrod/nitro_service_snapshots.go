@@ -0,0 +1,299 @@
+package nitrod
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/craftcms/nitro/internal/validate"
+)
+
+// the labels on snapshot volumes
+const (
+	snapshotLabel          = "nitro.snapshot"
+	snapshotContainerLabel = "nitro.container"
+	snapshotCreatedLabel   = "nitro.created"
+)
+
+// CreateSnapshot stops the container while its data volume is copied
+// to a new volume, so the snapshot can be restored with RollbackSnapshot.
+func (s *NitroService) CreateSnapshot(ctx context.Context, req *CreateSnapshotRequest) (*ServiceResponse, error) {
+	container, name, err := snapshotRequest(req.GetContainer(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+
+	if snapshot, err := s.findSnapshot(container, name); err != nil {
+		return nil, err
+	} else if snapshot != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "the snapshot %q of %s already exists", name, container)
+	}
+
+	image, volume, err := s.dataVolume(container)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := snapshotVolume(volume, name)
+	if output, err := s.command.Run("docker", []string{
+		"volume", "create",
+		"--label", snapshotLabel + "=" + name,
+		"--label", snapshotContainerLabel + "=" + container,
+		"--label", snapshotCreatedLabel + "=" + strconv.FormatInt(time.Now().Unix(), 10),
+		snapshot,
+	}); err != nil {
+		return nil, s.snapshotError(output, "unable to create the snapshot volume "+snapshot)
+	}
+
+	if err := s.withStoppedContainer(container, func() error {
+		return s.copyVolume(image, volume, snapshot)
+	}); err != nil {
+		s.removeVolume(snapshot)
+
+		return nil, s.databaseError(err, "unable to create the snapshot "+name)
+	}
+
+	return &ServiceResponse{Message: fmt.Sprintf("Created the snapshot %q of %s", name, container)}, nil
+}
+
+// ListSnapshots returns the snapshots sorted by container and the
+// time they were created, the newest first.
+func (s *NitroService) ListSnapshots(ctx context.Context, req *ListSnapshotsRequest) (*ListSnapshotsResponse, error) {
+	snapshots, err := s.listSnapshots(req.GetContainer())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListSnapshotsResponse{Snapshots: snapshots}, nil
+}
+
+// RollbackSnapshot stops the container while the data volume is
+// replaced with the snapshot. The snapshot is kept so it can be
+// rolled back to again. The data volume is copied before it is
+// replaced, so it is restored when the rollback fails.
+func (s *NitroService) RollbackSnapshot(ctx context.Context, req *RollbackSnapshotRequest) (*ServiceResponse, error) {
+	container, name, err := snapshotRequest(req.GetContainer(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+
+	snapshot, err := s.findSnapshot(container, name)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, status.Errorf(codes.NotFound, "the snapshot %q of %s does not exist", name, container)
+	}
+
+	image, volume, err := s.dataVolume(container)
+	if err != nil {
+		return nil, err
+	}
+
+	// a copy of the data in case the rollback fails part way through, the copy
+	// is only left behind when it could not be restored so it is never replaced
+	backup := volume + "_rollback"
+	if _, err := s.command.Run("docker", []string{"volume", "inspect", backup}); err == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "the volume %s has the data from before a rollback that failed, copy the data you need and remove it with `docker volume rm %s` to roll back again", backup, backup)
+	}
+
+	restored := true
+	if output, err := s.command.Run("docker", []string{"volume", "create", backup}); err != nil {
+		return nil, s.snapshotError(output, "unable to create the volume "+backup)
+	}
+
+	if err := s.withStoppedContainer(container, func() error {
+		if err := s.copyVolume(image, volume, backup); err != nil {
+			return err
+		}
+
+		if err := s.copyVolume(image, snapshot.GetVolume(), volume); err != nil {
+			if restoreErr := s.copyVolume(image, backup, volume); restoreErr != nil {
+				s.logger.Println("error restoring the data volume, error:", restoreErr)
+				restored = false
+				return fmt.Errorf("%w, the data from before the rollback is in the volume %s", err, backup)
+			}
+
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		if restored {
+			s.removeVolume(backup)
+		}
+		return nil, s.databaseError(err, "unable to rollback to the snapshot "+name)
+	}
+
+	s.removeVolume(backup)
+
+	return &ServiceResponse{Message: fmt.Sprintf("Rolled back %s to the snapshot %q", container, name)}, nil
+}
+
+// RemoveSnapshot removes the snapshot volume.
+func (s *NitroService) RemoveSnapshot(ctx context.Context, req *RemoveSnapshotRequest) (*ServiceResponse, error) {
+	container, name, err := snapshotRequest(req.GetContainer(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+
+	snapshot, err := s.findSnapshot(container, name)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, status.Errorf(codes.NotFound, "the snapshot %q of %s does not exist", name, container)
+	}
+
+	if output, err := s.command.Run("docker", []string{"volume", "rm", snapshot.GetVolume()}); err != nil {
+		return nil, s.snapshotError(output, "unable to remove the snapshot "+name)
+	}
+
+	return &ServiceResponse{Message: fmt.Sprintf("Removed the snapshot %q of %s", name, container)}, nil
+}
+
+// snapshotRequest validates the fields shared by the requests for a single snapshot.
+func snapshotRequest(container, name string) (string, string, error) {
+	if container == "" || strings.ContainsAny(container, `/\ `) {
+		return "", "", status.Errorf(codes.InvalidArgument, "the container %q is not valid", container)
+	}
+
+	if err := validate.SnapshotName(name); err != nil {
+		return "", "", status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	return container, name, nil
+}
+
+// snapshotVolume returns the name of the volume for a snapshot of the volume.
+func snapshotVolume(volume, name string) string {
+	return volume + "_snapshot_" + name
+}
+
+func (s *NitroService) listSnapshots(container string) ([]*Snapshot, error) {
+	args := []string{"volume", "ls", "--filter", "label=" + snapshotLabel}
+	if container != "" {
+		args = append(args, "--filter", "label="+snapshotContainerLabel+"="+container)
+	}
+	args = append(args, "--format", fmt.Sprintf(`{{.Name}}\t{{.Label %q}}\t{{.Label %q}}\t{{.Label %q}}`, snapshotLabel, snapshotContainerLabel, snapshotCreatedLabel))
+
+	output, err := s.command.Run("docker", args)
+	if err != nil {
+		return nil, s.snapshotError(output, "unable to list the snapshots")
+	}
+
+	var snapshots []*Snapshot
+	for _, r := range rows(output) {
+		if len(r) != 4 {
+			continue
+		}
+
+		created, _ := strconv.ParseInt(r[3], 10, 64)
+		snapshots = append(snapshots, &Snapshot{Volume: r[0], Name: r[1], Container: r[2], Created: created})
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		if snapshots[i].GetContainer() != snapshots[j].GetContainer() {
+			return snapshots[i].GetContainer() < snapshots[j].GetContainer()
+		}
+
+		return snapshots[i].GetCreated() > snapshots[j].GetCreated()
+	})
+
+	return snapshots, nil
+}
+
+// findSnapshot returns the snapshot of the container, or nil when it does not exist.
+func (s *NitroService) findSnapshot(container, name string) (*Snapshot, error) {
+	snapshots, err := s.listSnapshots(container)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snapshot := range snapshots {
+		if snapshot.GetName() == name {
+			return snapshot, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// dataVolume returns the image of the container and the volume with its data,
+// the database containers only have one volume the other mounts are binds.
+func (s *NitroService) dataVolume(container string) (string, string, error) {
+	output, err := s.command.Run("docker", []string{"inspect", "--type", "container", "--format", `{{.Config.Image}}{{range .Mounts}}{{if eq .Type "volume"}} {{.Name}}{{end}}{{end}}`, container})
+	if err != nil {
+		return "", "", status.Errorf(codes.NotFound, "the container %q does not exist", container)
+	}
+
+	fields := strings.Fields(string(output))
+	if len(fields) != 2 {
+		return "", "", status.Errorf(codes.FailedPrecondition, "the container %q does not have a data volume", container)
+	}
+
+	return fields[0], fields[1], nil
+}
+
+// withStoppedContainer stops the container while the func runs so the data
+// is not changed, the container is always started again afterwards.
+func (s *NitroService) withStoppedContainer(container string, fn func() error) error {
+	if output, err := s.command.Run("docker", []string{"stop", container}); err != nil {
+		return fmt.Errorf("unable to stop %s: %s", container, strings.TrimSpace(string(output)))
+	}
+
+	err := fn()
+
+	if output, startErr := s.command.Run("docker", []string{"start", container}); startErr != nil && err == nil {
+		err = fmt.Errorf("unable to start %s: %s", container, strings.TrimSpace(string(output)))
+	}
+
+	return err
+}
+
+// copyVolume replaces the contents of a volume with another volume. The copy
+// runs in the database image, so it has the same users and there is nothing
+// to pull.
+func (s *NitroService) copyVolume(image, from, to string) error {
+	output, err := s.command.Run("docker", []string{
+		"run", "--rm",
+		"-v", from + ":/from",
+		"-v", to + ":/to",
+		"--entrypoint", "sh",
+		image,
+		"-c", "rm -rf /to/* /to/.[!.]* /to/..?* && cp -a /from/. /to/",
+	})
+	if err != nil {
+		return fmt.Errorf("unable to copy %s to %s: %s", from, to, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// removeVolume removes a volume, errors are only logged since the
+// volume is left behind and does not change the result.
+func (s *NitroService) removeVolume(volume string) {
+	if output, err := s.command.Run("docker", []string{"volume", "rm", volume}); err != nil {
+		s.logger.Println("error removing the volume", volume+", error:", strings.TrimSpace(string(output)))
+	}
+}
+
+// snapshotError logs the output of a failed docker command and returns it to the client.
+func (s *NitroService) snapshotError(output []byte, message string) error {
+	return s.databaseError(fmt.Errorf("%s", strings.TrimSpace(string(output))), message)
+}
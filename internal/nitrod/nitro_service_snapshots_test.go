@@ -0,0 +1,211 @@
+package nitrod
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNitroService_Snapshots(t *testing.T) {
+	tests := []struct {
+		name         string
+		call         func(s *NitroService) (*ServiceResponse, error)
+		outputs      []string
+		errors       map[int]error
+		want         string
+		wantCode     codes.Code
+		wantCommands []string
+		// wantCopies are the volume mounts of each copy
+		wantCopies []string
+	}{
+		{
+			name: "snapshots copy the data volume while the container is stopped",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.CreateSnapshot(context.TODO(), &CreateSnapshotRequest{Container: "mysql_5.7_3306", Name: "before-migration"})
+			},
+			outputs:      []string{"", "mysql:5.7 mysql_5.7_3306\n", "mysql_5.7_3306_snapshot_before-migration\n", "", "", ""},
+			want:         `Created the snapshot "before-migration" of mysql_5.7_3306`,
+			wantCommands: []string{"volume ls", "inspect", "volume create", "stop", "run", "start"},
+			wantCopies:   []string{"-v mysql_5.7_3306:/from -v mysql_5.7_3306_snapshot_before-migration:/to"},
+		},
+		{
+			name: "existing snapshots are not replaced",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.CreateSnapshot(context.TODO(), &CreateSnapshotRequest{Container: "mysql_5.7_3306", Name: "before-migration"})
+			},
+			outputs:      []string{"mysql_5.7_3306_snapshot_before-migration\tbefore-migration\tmysql_5.7_3306\t1600000000\n"},
+			wantCode:     codes.AlreadyExists,
+			wantCommands: []string{"volume ls"},
+		},
+		{
+			name: "invalid snapshot names return an error",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.CreateSnapshot(context.TODO(), &CreateSnapshotRequest{Container: "mysql_5.7_3306", Name: "../data"})
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "containers without a data volume return an error",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.CreateSnapshot(context.TODO(), &CreateSnapshotRequest{Container: "mysql_5.7_3306", Name: "before-migration"})
+			},
+			outputs:      []string{"", "mysql:5.7\n"},
+			wantCode:     codes.FailedPrecondition,
+			wantCommands: []string{"volume ls", "inspect"},
+		},
+		{
+			name: "rollbacks copy the snapshot to the data volume while the container is stopped",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.RollbackSnapshot(context.TODO(), &RollbackSnapshotRequest{Container: "postgres_12_5432", Name: "before-migration"})
+			},
+			outputs:      []string{"postgres_12_5432_snapshot_before-migration\tbefore-migration\tpostgres_12_5432\t1600000000\n", "postgres:12 postgres_12_5432\n", "Error: No such volume: postgres_12_5432_rollback", "", "", "", "", "", ""},
+			errors:       map[int]error{2: errors.New("exit status 1")},
+			want:         `Rolled back postgres_12_5432 to the snapshot "before-migration"`,
+			wantCommands: []string{"volume ls", "inspect", "volume inspect", "volume create", "stop", "run", "run", "start", "volume rm"},
+			wantCopies: []string{
+				"-v postgres_12_5432:/from -v postgres_12_5432_rollback:/to",
+				"-v postgres_12_5432_snapshot_before-migration:/from -v postgres_12_5432:/to",
+			},
+		},
+		{
+			name: "failed rollbacks restore the data volume",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.RollbackSnapshot(context.TODO(), &RollbackSnapshotRequest{Container: "postgres_12_5432", Name: "before-migration"})
+			},
+			outputs:      []string{"postgres_12_5432_snapshot_before-migration\tbefore-migration\tpostgres_12_5432\t1600000000\n", "postgres:12 postgres_12_5432\n", "Error: No such volume: postgres_12_5432_rollback", "", "", "", "no space left on device", "", "", ""},
+			errors:       map[int]error{2: errors.New("exit status 1"), 6: errors.New("exit status 1")},
+			wantCode:     codes.Unknown,
+			wantCommands: []string{"volume ls", "inspect", "volume inspect", "volume create", "stop", "run", "run", "run", "start", "volume rm"},
+			wantCopies: []string{
+				"-v postgres_12_5432:/from -v postgres_12_5432_rollback:/to",
+				"-v postgres_12_5432_snapshot_before-migration:/from -v postgres_12_5432:/to",
+				"-v postgres_12_5432_rollback:/from -v postgres_12_5432:/to",
+			},
+		},
+		{
+			name: "the copy of the data volume is kept when it cannot be restored",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.RollbackSnapshot(context.TODO(), &RollbackSnapshotRequest{Container: "postgres_12_5432", Name: "before-migration"})
+			},
+			outputs:      []string{"postgres_12_5432_snapshot_before-migration\tbefore-migration\tpostgres_12_5432\t1600000000\n", "postgres:12 postgres_12_5432\n", "Error: No such volume: postgres_12_5432_rollback", "", "", "", "no space left on device", "no space left on device", ""},
+			errors:       map[int]error{2: errors.New("exit status 1"), 6: errors.New("exit status 1"), 7: errors.New("exit status 1")},
+			wantCode:     codes.Unknown,
+			wantCommands: []string{"volume ls", "inspect", "volume inspect", "volume create", "stop", "run", "run", "run", "start"},
+			wantCopies: []string{
+				"-v postgres_12_5432:/from -v postgres_12_5432_rollback:/to",
+				"-v postgres_12_5432_snapshot_before-migration:/from -v postgres_12_5432:/to",
+				"-v postgres_12_5432_rollback:/from -v postgres_12_5432:/to",
+			},
+		},
+		{
+			name: "the data volume is not changed when it cannot be copied",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.RollbackSnapshot(context.TODO(), &RollbackSnapshotRequest{Container: "postgres_12_5432", Name: "before-migration"})
+			},
+			outputs:      []string{"postgres_12_5432_snapshot_before-migration\tbefore-migration\tpostgres_12_5432\t1600000000\n", "postgres:12 postgres_12_5432\n", "Error: No such volume: postgres_12_5432_rollback", "", "", "no space left on device", "", ""},
+			errors:       map[int]error{2: errors.New("exit status 1"), 5: errors.New("exit status 1")},
+			wantCode:     codes.Unknown,
+			wantCommands: []string{"volume ls", "inspect", "volume inspect", "volume create", "stop", "run", "start", "volume rm"},
+			wantCopies:   []string{"-v postgres_12_5432:/from -v postgres_12_5432_rollback:/to"},
+		},
+		{
+			name: "rollbacks are refused while the copy of a failed rollback exists",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.RollbackSnapshot(context.TODO(), &RollbackSnapshotRequest{Container: "postgres_12_5432", Name: "before-migration"})
+			},
+			outputs:      []string{"postgres_12_5432_snapshot_before-migration\tbefore-migration\tpostgres_12_5432\t1600000000\n", "postgres:12 postgres_12_5432\n", "[{\"Name\": \"postgres_12_5432_rollback\"}]"},
+			wantCode:     codes.FailedPrecondition,
+			wantCommands: []string{"volume ls", "inspect", "volume inspect"},
+		},
+		{
+			name: "rolling back to a missing snapshot returns an error",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.RollbackSnapshot(context.TODO(), &RollbackSnapshotRequest{Container: "postgres_12_5432", Name: "before-migration"})
+			},
+			outputs:      []string{""},
+			wantCode:     codes.NotFound,
+			wantCommands: []string{"volume ls"},
+		},
+		{
+			name: "removes the snapshot volume",
+			call: func(s *NitroService) (*ServiceResponse, error) {
+				return s.RemoveSnapshot(context.TODO(), &RemoveSnapshotRequest{Container: "mysql_5.7_3306", Name: "before-migration"})
+			},
+			outputs:      []string{"mysql_5.7_3306_snapshot_before-migration\tbefore-migration\tmysql_5.7_3306\t1600000000\n", ""},
+			want:         `Removed the snapshot "before-migration" of mysql_5.7_3306`,
+			wantCommands: []string{"volume ls", "volume rm"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spy := &spyChainRunner{Outputs: tt.outputs, Errors: tt.errors}
+			s := &NitroService{
+				command: spy,
+				logger:  log.New(ioutil.Discard, "testing", 0),
+			}
+
+			got, err := tt.call(s)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v, err = %v", code, tt.wantCode, err)
+			}
+			if err == nil && got.GetMessage() != tt.want {
+				t.Errorf("got message %q, want %q", got.GetMessage(), tt.want)
+			}
+
+			var commands []string
+			copies := 0
+			for _, a := range spy.Args {
+				args := a["docker"]
+				command := args[0]
+				if command == "volume" {
+					command += " " + args[1]
+				}
+				commands = append(commands, command)
+
+				if command == "run" {
+					if copies >= len(tt.wantCopies) || !strings.Contains(strings.Join(args, " "), tt.wantCopies[copies]) {
+						t.Errorf("unexpected copy %v, want the copies %v", args, tt.wantCopies)
+					}
+					copies++
+				}
+			}
+
+			if !reflect.DeepEqual(commands, tt.wantCommands) {
+				t.Errorf("got commands %v, want %v", commands, tt.wantCommands)
+			}
+		})
+	}
+}
+
+func TestNitroService_ListSnapshots(t *testing.T) {
+	spy := &spyChainRunner{Outputs: []string{
+		"postgres_12_5432_snapshot_a\ta\tpostgres_12_5432\t1600000000\n" +
+			"mysql_5.7_3306_snapshot_old\told\tmysql_5.7_3306\t1500000000\n" +
+			"mysql_5.7_3306_snapshot_new\tnew\tmysql_5.7_3306\t1600000000\n",
+	}}
+	s := &NitroService{
+		command: spy,
+		logger:  log.New(ioutil.Discard, "testing", 0),
+	}
+
+	got, err := s.ListSnapshots(context.TODO(), &ListSnapshotsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*Snapshot{
+		{Name: "new", Container: "mysql_5.7_3306", Volume: "mysql_5.7_3306_snapshot_new", Created: 1600000000},
+		{Name: "old", Container: "mysql_5.7_3306", Volume: "mysql_5.7_3306_snapshot_old", Created: 1500000000},
+		{Name: "a", Container: "postgres_12_5432", Volume: "postgres_12_5432_snapshot_a", Created: 1600000000},
+	}
+	if !reflect.DeepEqual(got.GetSnapshots(), want) {
+		t.Errorf("ListSnapshots() got = \n%v, \nwant \n%v", got.GetSnapshots(), want)
+	}
+}
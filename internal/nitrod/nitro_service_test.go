@@ -1,5 +1,11 @@
 package nitrod
 
+import (
+	"context"
+	"io"
+	"io/ioutil"
+)
+
 // spyChainRunner is used for services that run multiple
 // Run commands such as editing an ini file and then
 // restarting the php-fpm service after completion
@@ -7,6 +13,13 @@ type spyChainRunner struct {
 	Commands []string
 	Args     []map[string][]string
 	Output   string
+	Input    []string
+	// Outputs are returned in order for each
+	// command before falling back to Output
+	Outputs []string
+	// Errors are returned by Run for the
+	// command at the index
+	Errors map[int]error
 }
 
 func (r *spyChainRunner) Run(command string, args []string) ([]byte, error) {
@@ -17,8 +30,43 @@ func (r *spyChainRunner) Run(command string, args []string) ([]byte, error) {
 	if r.Output != "" {
 		output = r.Output
 	}
+	if len(r.Outputs) > 0 {
+		output, r.Outputs = r.Outputs[0], r.Outputs[1:]
+	}
+
+	return []byte(output), r.Errors[len(r.Commands)-1]
+}
+
+func (r *spyChainRunner) RunInput(command string, args []string, input io.Reader) ([]byte, error) {
+	b, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	r.Input = append(r.Input, string(b))
+
+	return r.Run(command, args)
+}
+
+func (r *spyChainRunner) RunContext(ctx context.Context, command string, args []string, input io.Reader, stdout, stderr io.Writer) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if input != nil {
+		b, err := ioutil.ReadAll(input)
+		if err != nil {
+			return err
+		}
+		r.Input = append(r.Input, string(b))
+	}
 
-	return []byte(output), nil
+	b, err := r.Run(command, args)
+	if err != nil {
+		return err
+	}
+
+	_, err = stdout.Write(b)
+	return err
 }
 
 type spyServiceRunner struct {
@@ -33,3 +81,27 @@ func (r *spyServiceRunner) Run(command string, args []string) ([]byte, error) {
 
 	return []byte(command), nil
 }
+
+func (r *spyServiceRunner) RunInput(command string, args []string, input io.Reader) ([]byte, error) {
+	if _, err := io.Copy(ioutil.Discard, input); err != nil {
+		return nil, err
+	}
+
+	return r.Run(command, args)
+}
+
+func (r *spyServiceRunner) RunContext(ctx context.Context, command string, args []string, input io.Reader, stdout, stderr io.Writer) error {
+	if input != nil {
+		if _, err := io.Copy(ioutil.Discard, input); err != nil {
+			return err
+		}
+	}
+
+	b, err := r.Run(command, args)
+	if err != nil {
+		return err
+	}
+
+	_, err = stdout.Write(b)
+	return err
+}
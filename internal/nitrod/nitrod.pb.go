@@ -29,67 +29,266 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
-type PhpIniSetting int32
+type ImportStage int32
 
 const (
-	PhpIniSetting_MAX_EXECUTION_TIME  PhpIniSetting = 0
-	PhpIniSetting_UPLOAD_MAX_FILESIZE PhpIniSetting = 1
-	PhpIniSetting_MAX_INPUT_TIME      PhpIniSetting = 2
-	PhpIniSetting_MAX_INPUT_VARS      PhpIniSetting = 3
-	PhpIniSetting_MAX_FILE_UPLOADS    PhpIniSetting = 4
-	PhpIniSetting_MEMORY_LIMIT        PhpIniSetting = 5
-	PhpIniSetting_DISPLAY_ERRORS      PhpIniSetting = 6
+	ImportStage_UPLOADING     ImportStage = 0
+	ImportStage_VERIFYING     ImportStage = 1
+	ImportStage_DECOMPRESSING ImportStage = 2
+	ImportStage_IMPORTING     ImportStage = 3
+	ImportStage_COMPLETE      ImportStage = 4
+	ImportStage_SELECTING     ImportStage = 5
+	ImportStage_QUEUED        ImportStage = 6
 )
 
-// Enum value maps for PhpIniSetting.
+// Enum value maps for ImportStage.
 var (
-	PhpIniSetting_name = map[int32]string{
-		0: "MAX_EXECUTION_TIME",
-		1: "UPLOAD_MAX_FILESIZE",
-		2: "MAX_INPUT_TIME",
-		3: "MAX_INPUT_VARS",
-		4: "MAX_FILE_UPLOADS",
-		5: "MEMORY_LIMIT",
-		6: "DISPLAY_ERRORS",
-	}
-	PhpIniSetting_value = map[string]int32{
-		"MAX_EXECUTION_TIME":  0,
-		"UPLOAD_MAX_FILESIZE": 1,
-		"MAX_INPUT_TIME":      2,
-		"MAX_INPUT_VARS":      3,
-		"MAX_FILE_UPLOADS":    4,
-		"MEMORY_LIMIT":        5,
-		"DISPLAY_ERRORS":      6,
+	ImportStage_name = map[int32]string{
+		0: "UPLOADING",
+		1: "VERIFYING",
+		2: "DECOMPRESSING",
+		3: "IMPORTING",
+		4: "COMPLETE",
+		5: "SELECTING",
+		6: "QUEUED",
+	}
+	ImportStage_value = map[string]int32{
+		"UPLOADING":     0,
+		"VERIFYING":     1,
+		"DECOMPRESSING": 2,
+		"IMPORTING":     3,
+		"COMPLETE":      4,
+		"SELECTING":     5,
+		"QUEUED":        6,
 	}
 )
 
-func (x PhpIniSetting) Enum() *PhpIniSetting {
-	p := new(PhpIniSetting)
+func (x ImportStage) Enum() *ImportStage {
+	p := new(ImportStage)
 	*p = x
 	return p
 }
 
-func (x PhpIniSetting) String() string {
+func (x ImportStage) String() string {
 	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (PhpIniSetting) Descriptor() protoreflect.EnumDescriptor {
+func (ImportStage) Descriptor() protoreflect.EnumDescriptor {
 	return file_internal_nitrod_nitrod_proto_enumTypes[0].Descriptor()
 }
 
-func (PhpIniSetting) Type() protoreflect.EnumType {
+func (ImportStage) Type() protoreflect.EnumType {
 	return &file_internal_nitrod_nitrod_proto_enumTypes[0]
 }
 
-func (x PhpIniSetting) Number() protoreflect.EnumNumber {
+func (x ImportStage) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use PhpIniSetting.Descriptor instead.
-func (PhpIniSetting) EnumDescriptor() ([]byte, []int) {
+// Deprecated: Use ImportStage.Descriptor instead.
+func (ImportStage) EnumDescriptor() ([]byte, []int) {
 	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{0}
 }
 
+type PhpSapi int32
+
+const (
+	PhpSapi_ALL PhpSapi = 0
+	PhpSapi_FPM PhpSapi = 1
+	PhpSapi_CLI PhpSapi = 2
+)
+
+// Enum value maps for PhpSapi.
+var (
+	PhpSapi_name = map[int32]string{
+		0: "ALL",
+		1: "FPM",
+		2: "CLI",
+	}
+	PhpSapi_value = map[string]int32{
+		"ALL": 0,
+		"FPM": 1,
+		"CLI": 2,
+	}
+)
+
+func (x PhpSapi) Enum() *PhpSapi {
+	p := new(PhpSapi)
+	*p = x
+	return p
+}
+
+func (x PhpSapi) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PhpSapi) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_nitrod_nitrod_proto_enumTypes[1].Descriptor()
+}
+
+func (PhpSapi) Type() protoreflect.EnumType {
+	return &file_internal_nitrod_nitrod_proto_enumTypes[1]
+}
+
+func (x PhpSapi) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PhpSapi.Descriptor instead.
+func (PhpSapi) EnumDescriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{1}
+}
+
+type PhpIniValueType int32
+
+const (
+	PhpIniValueType_STRING  PhpIniValueType = 0
+	PhpIniValueType_BOOLEAN PhpIniValueType = 1
+	PhpIniValueType_INTEGER PhpIniValueType = 2
+	PhpIniValueType_BYTES   PhpIniValueType = 3
+)
+
+// Enum value maps for PhpIniValueType.
+var (
+	PhpIniValueType_name = map[int32]string{
+		0: "STRING",
+		1: "BOOLEAN",
+		2: "INTEGER",
+		3: "BYTES",
+	}
+	PhpIniValueType_value = map[string]int32{
+		"STRING":  0,
+		"BOOLEAN": 1,
+		"INTEGER": 2,
+		"BYTES":   3,
+	}
+)
+
+func (x PhpIniValueType) Enum() *PhpIniValueType {
+	p := new(PhpIniValueType)
+	*p = x
+	return p
+}
+
+func (x PhpIniValueType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PhpIniValueType) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_nitrod_nitrod_proto_enumTypes[2].Descriptor()
+}
+
+func (PhpIniValueType) Type() protoreflect.EnumType {
+	return &file_internal_nitrod_nitrod_proto_enumTypes[2]
+}
+
+func (x PhpIniValueType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PhpIniValueType.Descriptor instead.
+func (PhpIniValueType) EnumDescriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{2}
+}
+
+type XdebugStart int32
+
+const (
+	XdebugStart_AUTOSTART XdebugStart = 0
+	XdebugStart_TRIGGER   XdebugStart = 1
+)
+
+// Enum value maps for XdebugStart.
+var (
+	XdebugStart_name = map[int32]string{
+		0: "AUTOSTART",
+		1: "TRIGGER",
+	}
+	XdebugStart_value = map[string]int32{
+		"AUTOSTART": 0,
+		"TRIGGER":   1,
+	}
+)
+
+func (x XdebugStart) Enum() *XdebugStart {
+	p := new(XdebugStart)
+	*p = x
+	return p
+}
+
+func (x XdebugStart) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (XdebugStart) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_nitrod_nitrod_proto_enumTypes[3].Descriptor()
+}
+
+func (XdebugStart) Type() protoreflect.EnumType {
+	return &file_internal_nitrod_nitrod_proto_enumTypes[3]
+}
+
+func (x XdebugStart) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use XdebugStart.Descriptor instead.
+func (XdebugStart) EnumDescriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{3}
+}
+
+type JobState int32
+
+const (
+	JobState_RUNNING   JobState = 0
+	JobState_SUCCEEDED JobState = 1
+	JobState_FAILED    JobState = 2
+	JobState_CANCELED  JobState = 3
+)
+
+// Enum value maps for JobState.
+var (
+	JobState_name = map[int32]string{
+		0: "RUNNING",
+		1: "SUCCEEDED",
+		2: "FAILED",
+		3: "CANCELED",
+	}
+	JobState_value = map[string]int32{
+		"RUNNING":   0,
+		"SUCCEEDED": 1,
+		"FAILED":    2,
+		"CANCELED":  3,
+	}
+)
+
+func (x JobState) Enum() *JobState {
+	p := new(JobState)
+	*p = x
+	return p
+}
+
+func (x JobState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobState) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_nitrod_nitrod_proto_enumTypes[4].Descriptor()
+}
+
+func (JobState) Type() protoreflect.EnumType {
+	return &file_internal_nitrod_nitrod_proto_enumTypes[4]
+}
+
+func (x JobState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobState.Descriptor instead.
+func (JobState) EnumDescriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{4}
+}
+
 type ServiceAction int32
 
 const (
@@ -123,11 +322,11 @@ func (x ServiceAction) String() string {
 }
 
 func (ServiceAction) Descriptor() protoreflect.EnumDescriptor {
-	return file_internal_nitrod_nitrod_proto_enumTypes[1].Descriptor()
+	return file_internal_nitrod_nitrod_proto_enumTypes[5].Descriptor()
 }
 
 func (ServiceAction) Type() protoreflect.EnumType {
-	return &file_internal_nitrod_nitrod_proto_enumTypes[1]
+	return &file_internal_nitrod_nitrod_proto_enumTypes[5]
 }
 
 func (x ServiceAction) Number() protoreflect.EnumNumber {
@@ -136,7 +335,7 @@ func (x ServiceAction) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ServiceAction.Descriptor instead.
 func (ServiceAction) EnumDescriptor() ([]byte, []int) {
-	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{1}
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{5}
 }
 
 type ChangePhpIniSettingRequest struct {
@@ -144,9 +343,10 @@ type ChangePhpIniSettingRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Version string        `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	Setting PhpIniSetting `protobuf:"varint,2,opt,name=setting,proto3,enum=nitrod.PhpIniSetting" json:"setting,omitempty"`
-	Value   string        `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Version string  `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Value   string  `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Setting string  `protobuf:"bytes,4,opt,name=setting,proto3" json:"setting,omitempty"`
+	Sapi    PhpSapi `protobuf:"varint,5,opt,name=sapi,proto3,enum=nitrod.PhpSapi" json:"sapi,omitempty"`
 }
 
 func (x *ChangePhpIniSettingRequest) Reset() {
@@ -188,30 +388,39 @@ func (x *ChangePhpIniSettingRequest) GetVersion() string {
 	return ""
 }
 
-func (x *ChangePhpIniSettingRequest) GetSetting() PhpIniSetting {
+func (x *ChangePhpIniSettingRequest) GetValue() string {
 	if x != nil {
-		return x.Setting
+		return x.Value
 	}
-	return PhpIniSetting_MAX_EXECUTION_TIME
+	return ""
 }
 
-func (x *ChangePhpIniSettingRequest) GetValue() string {
+func (x *ChangePhpIniSettingRequest) GetSetting() string {
 	if x != nil {
-		return x.Value
+		return x.Setting
 	}
 	return ""
 }
 
-type DisableXdebugRequest struct {
+func (x *ChangePhpIniSettingRequest) GetSapi() PhpSapi {
+	if x != nil {
+		return x.Sapi
+	}
+	return PhpSapi_ALL
+}
+
+type ResetPhpIniSettingRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Version string  `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Setting string  `protobuf:"bytes,2,opt,name=setting,proto3" json:"setting,omitempty"`
+	Sapi    PhpSapi `protobuf:"varint,3,opt,name=sapi,proto3,enum=nitrod.PhpSapi" json:"sapi,omitempty"`
 }
 
-func (x *DisableXdebugRequest) Reset() {
-	*x = DisableXdebugRequest{}
+func (x *ResetPhpIniSettingRequest) Reset() {
+	*x = ResetPhpIniSettingRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_nitrod_nitrod_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -219,13 +428,13 @@ func (x *DisableXdebugRequest) Reset() {
 	}
 }
 
-func (x *DisableXdebugRequest) String() string {
+func (x *ResetPhpIniSettingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DisableXdebugRequest) ProtoMessage() {}
+func (*ResetPhpIniSettingRequest) ProtoMessage() {}
 
-func (x *DisableXdebugRequest) ProtoReflect() protoreflect.Message {
+func (x *ResetPhpIniSettingRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_nitrod_nitrod_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -237,19 +446,33 @@ func (x *DisableXdebugRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DisableXdebugRequest.ProtoReflect.Descriptor instead.
-func (*DisableXdebugRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResetPhpIniSettingRequest.ProtoReflect.Descriptor instead.
+func (*ResetPhpIniSettingRequest) Descriptor() ([]byte, []int) {
 	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *DisableXdebugRequest) GetVersion() string {
+func (x *ResetPhpIniSettingRequest) GetVersion() string {
 	if x != nil {
 		return x.Version
 	}
 	return ""
 }
 
-type EnableXdebugRequest struct {
+func (x *ResetPhpIniSettingRequest) GetSetting() string {
+	if x != nil {
+		return x.Setting
+	}
+	return ""
+}
+
+func (x *ResetPhpIniSettingRequest) GetSapi() PhpSapi {
+	if x != nil {
+		return x.Sapi
+	}
+	return PhpSapi_ALL
+}
+
+type DisableXdebugRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -257,8 +480,8 @@ type EnableXdebugRequest struct {
 	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (x *EnableXdebugRequest) Reset() {
-	*x = EnableXdebugRequest{}
+func (x *DisableXdebugRequest) Reset() {
+	*x = DisableXdebugRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_nitrod_nitrod_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -266,13 +489,13 @@ func (x *EnableXdebugRequest) Reset() {
 	}
 }
 
-func (x *EnableXdebugRequest) String() string {
+func (x *DisableXdebugRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EnableXdebugRequest) ProtoMessage() {}
+func (*DisableXdebugRequest) ProtoMessage() {}
 
-func (x *EnableXdebugRequest) ProtoReflect() protoreflect.Message {
+func (x *DisableXdebugRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_nitrod_nitrod_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -284,29 +507,28 @@ func (x *EnableXdebugRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EnableXdebugRequest.ProtoReflect.Descriptor instead.
-func (*EnableXdebugRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DisableXdebugRequest.ProtoReflect.Descriptor instead.
+func (*DisableXdebugRequest) Descriptor() ([]byte, []int) {
 	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *EnableXdebugRequest) GetVersion() string {
+func (x *DisableXdebugRequest) GetVersion() string {
 	if x != nil {
 		return x.Version
 	}
 	return ""
 }
 
-type GetPhpIniSettingRequest struct {
+type EnableXdebugRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	Setting string `protobuf:"bytes,2,opt,name=setting,proto3" json:"setting,omitempty"`
 }
 
-func (x *GetPhpIniSettingRequest) Reset() {
-	*x = GetPhpIniSettingRequest{}
+func (x *EnableXdebugRequest) Reset() {
+	*x = EnableXdebugRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_nitrod_nitrod_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -314,13 +536,13 @@ func (x *GetPhpIniSettingRequest) Reset() {
 	}
 }
 
-func (x *GetPhpIniSettingRequest) String() string {
+func (x *EnableXdebugRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPhpIniSettingRequest) ProtoMessage() {}
+func (*EnableXdebugRequest) ProtoMessage() {}
 
-func (x *GetPhpIniSettingRequest) ProtoReflect() protoreflect.Message {
+func (x *EnableXdebugRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_nitrod_nitrod_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -332,36 +554,37 @@ func (x *GetPhpIniSettingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPhpIniSettingRequest.ProtoReflect.Descriptor instead.
-func (*GetPhpIniSettingRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use EnableXdebugRequest.ProtoReflect.Descriptor instead.
+func (*EnableXdebugRequest) Descriptor() ([]byte, []int) {
 	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetPhpIniSettingRequest) GetVersion() string {
+func (x *EnableXdebugRequest) GetVersion() string {
 	if x != nil {
 		return x.Version
 	}
 	return ""
 }
 
-func (x *GetPhpIniSettingRequest) GetSetting() string {
-	if x != nil {
-		return x.Setting
-	}
-	return ""
-}
-
-type PhpFpmServiceRequest struct {
+// ConfigureXdebugRequest has the settings using the Xdebug 3 names,
+// nitrod converts them when PHP has Xdebug 2 installed.
+type ConfigureXdebugRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Version string        `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	Action  ServiceAction `protobuf:"varint,2,opt,name=action,proto3,enum=nitrod.ServiceAction" json:"action,omitempty"`
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// mode is a comma separated list of modes, e.g. debug,develop
+	Mode string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	// clientHost is detected from the machine's route to the host when empty
+	ClientHost string      `protobuf:"bytes,3,opt,name=clientHost,proto3" json:"clientHost,omitempty"`
+	ClientPort int32       `protobuf:"varint,4,opt,name=clientPort,proto3" json:"clientPort,omitempty"`
+	Idekey     string      `protobuf:"bytes,5,opt,name=idekey,proto3" json:"idekey,omitempty"`
+	Start      XdebugStart `protobuf:"varint,6,opt,name=start,proto3,enum=nitrod.XdebugStart" json:"start,omitempty"`
 }
 
-func (x *PhpFpmServiceRequest) Reset() {
-	*x = PhpFpmServiceRequest{}
+func (x *ConfigureXdebugRequest) Reset() {
+	*x = ConfigureXdebugRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_nitrod_nitrod_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -369,13 +592,13 @@ func (x *PhpFpmServiceRequest) Reset() {
 	}
 }
 
-func (x *PhpFpmServiceRequest) String() string {
+func (x *ConfigureXdebugRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PhpFpmServiceRequest) ProtoMessage() {}
+func (*ConfigureXdebugRequest) ProtoMessage() {}
 
-func (x *PhpFpmServiceRequest) ProtoReflect() protoreflect.Message {
+func (x *ConfigureXdebugRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_nitrod_nitrod_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -387,35 +610,66 @@ func (x *PhpFpmServiceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PhpFpmServiceRequest.ProtoReflect.Descriptor instead.
-func (*PhpFpmServiceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfigureXdebugRequest.ProtoReflect.Descriptor instead.
+func (*ConfigureXdebugRequest) Descriptor() ([]byte, []int) {
 	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *PhpFpmServiceRequest) GetVersion() string {
+func (x *ConfigureXdebugRequest) GetVersion() string {
 	if x != nil {
 		return x.Version
 	}
 	return ""
 }
 
-func (x *PhpFpmServiceRequest) GetAction() ServiceAction {
+func (x *ConfigureXdebugRequest) GetMode() string {
 	if x != nil {
-		return x.Action
+		return x.Mode
 	}
-	return ServiceAction_RESTART
+	return ""
 }
 
-type NginxServiceRequest struct {
+func (x *ConfigureXdebugRequest) GetClientHost() string {
+	if x != nil {
+		return x.ClientHost
+	}
+	return ""
+}
+
+func (x *ConfigureXdebugRequest) GetClientPort() int32 {
+	if x != nil {
+		return x.ClientPort
+	}
+	return 0
+}
+
+func (x *ConfigureXdebugRequest) GetIdekey() string {
+	if x != nil {
+		return x.Idekey
+	}
+	return ""
+}
+
+func (x *ConfigureXdebugRequest) GetStart() XdebugStart {
+	if x != nil {
+		return x.Start
+	}
+	return XdebugStart_AUTOSTART
+}
+
+type XdebugSettingsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Action ServiceAction `protobuf:"varint,1,opt,name=action,proto3,enum=nitrod.ServiceAction" json:"action,omitempty"`
+	Version       string           `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	XdebugVersion string           `protobuf:"bytes,2,opt,name=xdebugVersion,proto3" json:"xdebugVersion,omitempty"`
+	Enabled       bool             `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Settings      []*XdebugSetting `protobuf:"bytes,4,rep,name=settings,proto3" json:"settings,omitempty"`
 }
 
-func (x *NginxServiceRequest) Reset() {
-	*x = NginxServiceRequest{}
+func (x *XdebugSettingsResponse) Reset() {
+	*x = XdebugSettingsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_nitrod_nitrod_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -423,13 +677,13 @@ func (x *NginxServiceRequest) Reset() {
 	}
 }
 
-func (x *NginxServiceRequest) String() string {
+func (x *XdebugSettingsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NginxServiceRequest) ProtoMessage() {}
+func (*XdebugSettingsResponse) ProtoMessage() {}
 
-func (x *NginxServiceRequest) ProtoReflect() protoreflect.Message {
+func (x *XdebugSettingsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_nitrod_nitrod_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -441,34 +695,50 @@ func (x *NginxServiceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NginxServiceRequest.ProtoReflect.Descriptor instead.
-func (*NginxServiceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use XdebugSettingsResponse.ProtoReflect.Descriptor instead.
+func (*XdebugSettingsResponse) Descriptor() ([]byte, []int) {
 	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *NginxServiceRequest) GetAction() ServiceAction {
+func (x *XdebugSettingsResponse) GetVersion() string {
 	if x != nil {
-		return x.Action
+		return x.Version
 	}
-	return ServiceAction_RESTART
+	return ""
 }
 
-type ImportDatabaseRequest struct {
+func (x *XdebugSettingsResponse) GetXdebugVersion() string {
+	if x != nil {
+		return x.XdebugVersion
+	}
+	return ""
+}
+
+func (x *XdebugSettingsResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *XdebugSettingsResponse) GetSettings() []*XdebugSetting {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type XdebugSetting struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Engine          string `protobuf:"bytes,1,opt,name=engine,proto3" json:"engine,omitempty"`
-	Database        string `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`
-	Container       string `protobuf:"bytes,3,opt,name=container,proto3" json:"container,omitempty"`
-	Data            []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
-	Compressed      bool   `protobuf:"varint,5,opt,name=compressed,proto3" json:"compressed,omitempty"`
-	CompressionType string `protobuf:"bytes,6,opt,name=compressionType,proto3" json:"compressionType,omitempty"`
-	CreateDatabase  bool   `protobuf:"varint,7,opt,name=createDatabase,proto3" json:"createDatabase,omitempty"`
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (x *ImportDatabaseRequest) Reset() {
-	*x = ImportDatabaseRequest{}
+func (x *XdebugSetting) Reset() {
+	*x = XdebugSetting{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_nitrod_nitrod_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -476,13 +746,13 @@ func (x *ImportDatabaseRequest) Reset() {
 	}
 }
 
-func (x *ImportDatabaseRequest) String() string {
+func (x *XdebugSetting) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ImportDatabaseRequest) ProtoMessage() {}
+func (*XdebugSetting) ProtoMessage() {}
 
-func (x *ImportDatabaseRequest) ProtoReflect() protoreflect.Message {
+func (x *XdebugSetting) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_nitrod_nitrod_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -494,85 +764,160 @@ func (x *ImportDatabaseRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ImportDatabaseRequest.ProtoReflect.Descriptor instead.
-func (*ImportDatabaseRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use XdebugSetting.ProtoReflect.Descriptor instead.
+func (*XdebugSetting) Descriptor() ([]byte, []int) {
 	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ImportDatabaseRequest) GetEngine() string {
+func (x *XdebugSetting) GetName() string {
 	if x != nil {
-		return x.Engine
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ImportDatabaseRequest) GetDatabase() string {
+func (x *XdebugSetting) GetValue() string {
 	if x != nil {
-		return x.Database
+		return x.Value
 	}
 	return ""
 }
 
-func (x *ImportDatabaseRequest) GetContainer() string {
+type GetPhpIniSettingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Setting string `protobuf:"bytes,2,opt,name=setting,proto3" json:"setting,omitempty"`
+}
+
+func (x *GetPhpIniSettingRequest) Reset() {
+	*x = GetPhpIniSettingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPhpIniSettingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPhpIniSettingRequest) ProtoMessage() {}
+
+func (x *GetPhpIniSettingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPhpIniSettingRequest.ProtoReflect.Descriptor instead.
+func (*GetPhpIniSettingRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetPhpIniSettingRequest) GetVersion() string {
 	if x != nil {
-		return x.Container
+		return x.Version
 	}
 	return ""
 }
 
-func (x *ImportDatabaseRequest) GetData() []byte {
+func (x *GetPhpIniSettingRequest) GetSetting() string {
 	if x != nil {
-		return x.Data
+		return x.Setting
 	}
-	return nil
+	return ""
 }
 
-func (x *ImportDatabaseRequest) GetCompressed() bool {
-	if x != nil {
-		return x.Compressed
+type PhpFpmServiceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version string        `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Action  ServiceAction `protobuf:"varint,2,opt,name=action,proto3,enum=nitrod.ServiceAction" json:"action,omitempty"`
+}
+
+func (x *PhpFpmServiceRequest) Reset() {
+	*x = PhpFpmServiceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return false
 }
 
-func (x *ImportDatabaseRequest) GetCompressionType() string {
+func (x *PhpFpmServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhpFpmServiceRequest) ProtoMessage() {}
+
+func (x *PhpFpmServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhpFpmServiceRequest.ProtoReflect.Descriptor instead.
+func (*PhpFpmServiceRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PhpFpmServiceRequest) GetVersion() string {
 	if x != nil {
-		return x.CompressionType
+		return x.Version
 	}
 	return ""
 }
 
-func (x *ImportDatabaseRequest) GetCreateDatabase() bool {
+func (x *PhpFpmServiceRequest) GetAction() ServiceAction {
 	if x != nil {
-		return x.CreateDatabase
+		return x.Action
 	}
-	return false
+	return ServiceAction_RESTART
 }
 
-type ServiceResponse struct {
+type NginxServiceRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Action ServiceAction `protobuf:"varint,1,opt,name=action,proto3,enum=nitrod.ServiceAction" json:"action,omitempty"`
 }
 
-func (x *ServiceResponse) Reset() {
-	*x = ServiceResponse{}
+func (x *NginxServiceRequest) Reset() {
+	*x = NginxServiceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_nitrod_nitrod_proto_msgTypes[7]
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ServiceResponse) String() string {
+func (x *NginxServiceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServiceResponse) ProtoMessage() {}
+func (*NginxServiceRequest) ProtoMessage() {}
 
-func (x *ServiceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_nitrod_nitrod_proto_msgTypes[7]
+func (x *NginxServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -583,524 +928,5371 @@ func (x *ServiceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServiceResponse.ProtoReflect.Descriptor instead.
-func (*ServiceResponse) Descriptor() ([]byte, []int) {
-	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use NginxServiceRequest.ProtoReflect.Descriptor instead.
+func (*NginxServiceRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ServiceResponse) GetMessage() string {
+func (x *NginxServiceRequest) GetAction() ServiceAction {
 	if x != nil {
-		return x.Message
+		return x.Action
 	}
-	return ""
+	return ServiceAction_RESTART
 }
 
-var File_internal_nitrod_nitrod_proto protoreflect.FileDescriptor
+// ImportDatabaseRequest is sent as a single header followed by the
+// chunks of the file, starting at the offset nitrod responds with.
+type ImportDatabaseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_internal_nitrod_nitrod_proto_rawDesc = []byte{
-	0x0a, 0x1c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x6f,
-	0x64, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
-	0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x22, 0x7d, 0x0a, 0x1a, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x50, 0x68, 0x70, 0x49, 0x6e, 0x69, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2f,
-	0x0a, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x15, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x50, 0x68, 0x70, 0x49, 0x6e, 0x69, 0x53,
-	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x30, 0x0a, 0x14, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65,
-	0x58, 0x64, 0x65, 0x62, 0x75, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a,
-	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x2f, 0x0a, 0x13, 0x45, 0x6e, 0x61, 0x62, 0x6c,
-	0x65, 0x58, 0x64, 0x65, 0x62, 0x75, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18,
-	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4d, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x50,
-	0x68, 0x70, 0x49, 0x6e, 0x69, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a,
-	0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x22, 0x5f, 0x0a, 0x14, 0x50, 0x68, 0x70, 0x46, 0x70,
-	0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x06, 0x61, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6e, 0x69, 0x74, 0x72,
-	0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x44, 0x0a, 0x13, 0x4e, 0x67, 0x69, 0x6e,
-	0x78, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x2d, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x15, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xef,
-	0x01, 0x0a, 0x15, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x67, 0x69,
-	0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
-	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09,
-	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1e,
-	0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x12, 0x28,
-	0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70,
-	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x0e, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
-	0x22, 0x2b, 0x0a, 0x0f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2a, 0xa4, 0x01,
-	0x0a, 0x0d, 0x50, 0x68, 0x70, 0x49, 0x6e, 0x69, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12,
-	0x16, 0x0a, 0x12, 0x4d, 0x41, 0x58, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x49, 0x4f, 0x4e,
-	0x5f, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x55, 0x50, 0x4c, 0x4f, 0x41,
-	0x44, 0x5f, 0x4d, 0x41, 0x58, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x53, 0x49, 0x5a, 0x45, 0x10, 0x01,
-	0x12, 0x12, 0x0a, 0x0e, 0x4d, 0x41, 0x58, 0x5f, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f, 0x54, 0x49,
-	0x4d, 0x45, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x4d, 0x41, 0x58, 0x5f, 0x49, 0x4e, 0x50, 0x55,
-	0x54, 0x5f, 0x56, 0x41, 0x52, 0x53, 0x10, 0x03, 0x12, 0x14, 0x0a, 0x10, 0x4d, 0x41, 0x58, 0x5f,
-	0x46, 0x49, 0x4c, 0x45, 0x5f, 0x55, 0x50, 0x4c, 0x4f, 0x41, 0x44, 0x53, 0x10, 0x04, 0x12, 0x10,
-	0x0a, 0x0c, 0x4d, 0x45, 0x4d, 0x4f, 0x52, 0x59, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x10, 0x05,
-	0x12, 0x12, 0x0a, 0x0e, 0x44, 0x49, 0x53, 0x50, 0x4c, 0x41, 0x59, 0x5f, 0x45, 0x52, 0x52, 0x4f,
-	0x52, 0x53, 0x10, 0x06, 0x2a, 0x31, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x53, 0x54, 0x41, 0x52, 0x54,
-	0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x54, 0x4f, 0x50, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05,
-	0x53, 0x54, 0x41, 0x52, 0x54, 0x10, 0x02, 0x32, 0x8f, 0x03, 0x0a, 0x0c, 0x4e, 0x69, 0x74, 0x72,
-	0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x0e, 0x50, 0x68, 0x70, 0x49,
-	0x6e, 0x69, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x22, 0x2e, 0x6e, 0x69, 0x74,
-	0x72, 0x6f, 0x64, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x68, 0x70, 0x49, 0x6e, 0x69,
-	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
-	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x10, 0x47, 0x65, 0x74,
-	0x50, 0x68, 0x70, 0x49, 0x6e, 0x69, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x1f, 0x2e,
-	0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x68, 0x70, 0x49, 0x6e, 0x69,
-	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
-	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0d, 0x44, 0x69, 0x73,
-	0x61, 0x62, 0x6c, 0x65, 0x58, 0x64, 0x65, 0x62, 0x75, 0x67, 0x12, 0x1c, 0x2e, 0x6e, 0x69, 0x74,
-	0x72, 0x6f, 0x64, 0x2e, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x58, 0x64, 0x65, 0x62, 0x75,
-	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f,
-	0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0c, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x58, 0x64, 0x65,
-	0x62, 0x75, 0x67, 0x12, 0x1b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x45, 0x6e, 0x61,
-	0x62, 0x6c, 0x65, 0x58, 0x64, 0x65, 0x62, 0x75, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0e, 0x49,
-	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1d, 0x2e,
-	0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e,
-	0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x32, 0x93, 0x01, 0x0a, 0x0d, 0x53, 0x79,
-	0x73, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x05, 0x4e,
-	0x67, 0x69, 0x6e, 0x78, 0x12, 0x1b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x4e, 0x67,
-	0x69, 0x6e, 0x78, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x06,
-	0x50, 0x68, 0x70, 0x46, 0x70, 0x6d, 0x12, 0x1c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e,
-	0x50, 0x68, 0x70, 0x46, 0x70, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42,
-	0x11, 0x5a, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6e, 0x69, 0x74, 0x72,
-	0x6f, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	// Types that are assignable to Request:
+	//	*ImportDatabaseRequest_Header
+	//	*ImportDatabaseRequest_Data
+	//	*ImportDatabaseRequest_Member
+	Request isImportDatabaseRequest_Request `protobuf_oneof:"request"`
 }
 
-var (
-	file_internal_nitrod_nitrod_proto_rawDescOnce sync.Once
-	file_internal_nitrod_nitrod_proto_rawDescData = file_internal_nitrod_nitrod_proto_rawDesc
-)
+func (x *ImportDatabaseRequest) Reset() {
+	*x = ImportDatabaseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_internal_nitrod_nitrod_proto_rawDescGZIP() []byte {
-	file_internal_nitrod_nitrod_proto_rawDescOnce.Do(func() {
-		file_internal_nitrod_nitrod_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_nitrod_nitrod_proto_rawDescData)
-	})
-	return file_internal_nitrod_nitrod_proto_rawDescData
+func (x *ImportDatabaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_internal_nitrod_nitrod_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_internal_nitrod_nitrod_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_internal_nitrod_nitrod_proto_goTypes = []interface{}{
-	(PhpIniSetting)(0),                 // 0: nitrod.PhpIniSetting
-	(ServiceAction)(0),                 // 1: nitrod.ServiceAction
-	(*ChangePhpIniSettingRequest)(nil), // 2: nitrod.ChangePhpIniSettingRequest
-	(*DisableXdebugRequest)(nil),       // 3: nitrod.DisableXdebugRequest
-	(*EnableXdebugRequest)(nil),        // 4: nitrod.EnableXdebugRequest
-	(*GetPhpIniSettingRequest)(nil),    // 5: nitrod.GetPhpIniSettingRequest
-	(*PhpFpmServiceRequest)(nil),       // 6: nitrod.PhpFpmServiceRequest
-	(*NginxServiceRequest)(nil),        // 7: nitrod.NginxServiceRequest
-	(*ImportDatabaseRequest)(nil),      // 8: nitrod.ImportDatabaseRequest
-	(*ServiceResponse)(nil),            // 9: nitrod.ServiceResponse
+func (*ImportDatabaseRequest) ProtoMessage() {}
+
+func (x *ImportDatabaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_internal_nitrod_nitrod_proto_depIdxs = []int32{
-	0,  // 0: nitrod.ChangePhpIniSettingRequest.setting:type_name -> nitrod.PhpIniSetting
-	1,  // 1: nitrod.PhpFpmServiceRequest.action:type_name -> nitrod.ServiceAction
-	1,  // 2: nitrod.NginxServiceRequest.action:type_name -> nitrod.ServiceAction
-	2,  // 3: nitrod.NitroService.PhpIniSettings:input_type -> nitrod.ChangePhpIniSettingRequest
-	5,  // 4: nitrod.NitroService.GetPhpIniSetting:input_type -> nitrod.GetPhpIniSettingRequest
-	3,  // 5: nitrod.NitroService.DisableXdebug:input_type -> nitrod.DisableXdebugRequest
-	4,  // 6: nitrod.NitroService.EnableXdebug:input_type -> nitrod.EnableXdebugRequest
-	8,  // 7: nitrod.NitroService.ImportDatabase:input_type -> nitrod.ImportDatabaseRequest
-	7,  // 8: nitrod.SystemService.Nginx:input_type -> nitrod.NginxServiceRequest
-	6,  // 9: nitrod.SystemService.PhpFpm:input_type -> nitrod.PhpFpmServiceRequest
-	9,  // 10: nitrod.NitroService.PhpIniSettings:output_type -> nitrod.ServiceResponse
-	9,  // 11: nitrod.NitroService.GetPhpIniSetting:output_type -> nitrod.ServiceResponse
-	9,  // 12: nitrod.NitroService.DisableXdebug:output_type -> nitrod.ServiceResponse
-	9,  // 13: nitrod.NitroService.EnableXdebug:output_type -> nitrod.ServiceResponse
-	9,  // 14: nitrod.NitroService.ImportDatabase:output_type -> nitrod.ServiceResponse
-	9,  // 15: nitrod.SystemService.Nginx:output_type -> nitrod.ServiceResponse
-	9,  // 16: nitrod.SystemService.PhpFpm:output_type -> nitrod.ServiceResponse
-	10, // [10:17] is the sub-list for method output_type
-	3,  // [3:10] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+
+// Deprecated: Use ImportDatabaseRequest.ProtoReflect.Descriptor instead.
+func (*ImportDatabaseRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{10}
 }
 
-func init() { file_internal_nitrod_nitrod_proto_init() }
-func file_internal_nitrod_nitrod_proto_init() {
-	if File_internal_nitrod_nitrod_proto != nil {
-		return
+func (m *ImportDatabaseRequest) GetRequest() isImportDatabaseRequest_Request {
+	if m != nil {
+		return m.Request
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_internal_nitrod_nitrod_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChangePhpIniSettingRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return nil
+}
+
+func (x *ImportDatabaseRequest) GetHeader() *ImportDatabaseHeader {
+	if x, ok := x.GetRequest().(*ImportDatabaseRequest_Header); ok {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *ImportDatabaseRequest) GetData() []byte {
+	if x, ok := x.GetRequest().(*ImportDatabaseRequest_Data); ok {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportDatabaseRequest) GetMember() string {
+	if x, ok := x.GetRequest().(*ImportDatabaseRequest_Member); ok {
+		return x.Member
+	}
+	return ""
+}
+
+type isImportDatabaseRequest_Request interface {
+	isImportDatabaseRequest_Request()
+}
+
+type ImportDatabaseRequest_Header struct {
+	Header *ImportDatabaseHeader `protobuf:"bytes,8,opt,name=header,proto3,oneof"`
+}
+
+type ImportDatabaseRequest_Data struct {
+	Data []byte `protobuf:"bytes,9,opt,name=data,proto3,oneof"`
+}
+
+type ImportDatabaseRequest_Member struct {
+	// member is the dump to import when nitrod asks
+	// to select one of the dumps in an archive
+	Member string `protobuf:"bytes,10,opt,name=member,proto3,oneof"`
+}
+
+func (*ImportDatabaseRequest_Header) isImportDatabaseRequest_Request() {}
+
+func (*ImportDatabaseRequest_Data) isImportDatabaseRequest_Request() {}
+
+func (*ImportDatabaseRequest_Member) isImportDatabaseRequest_Request() {}
+
+type ImportDatabaseHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Engine    string `protobuf:"bytes,1,opt,name=engine,proto3" json:"engine,omitempty"`
+	Database  string `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`
+	Container string `protobuf:"bytes,3,opt,name=container,proto3" json:"container,omitempty"`
+	// compressed and compressionType are informational,
+	// nitrod detects the format from the upload
+	Compressed      bool   `protobuf:"varint,4,opt,name=compressed,proto3" json:"compressed,omitempty"`
+	CompressionType string `protobuf:"bytes,5,opt,name=compressionType,proto3" json:"compressionType,omitempty"`
+	CreateDatabase  bool   `protobuf:"varint,6,opt,name=createDatabase,proto3" json:"createDatabase,omitempty"`
+	// checksum is the hex encoded sha256 of the file, it
+	// identifies the upload when resuming
+	Checksum string `protobuf:"bytes,7,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Size     int64  `protobuf:"varint,8,opt,name=size,proto3" json:"size,omitempty"`
+	// background imports run as a job once the upload is
+	// verified, so the import continues if the client goes away
+	Background bool `protobuf:"varint,9,opt,name=background,proto3" json:"background,omitempty"`
+}
+
+func (x *ImportDatabaseHeader) Reset() {
+	*x = ImportDatabaseHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportDatabaseHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportDatabaseHeader) ProtoMessage() {}
+
+func (x *ImportDatabaseHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_internal_nitrod_nitrod_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DisableXdebugRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_internal_nitrod_nitrod_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EnableXdebugRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportDatabaseHeader.ProtoReflect.Descriptor instead.
+func (*ImportDatabaseHeader) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ImportDatabaseHeader) GetEngine() string {
+	if x != nil {
+		return x.Engine
+	}
+	return ""
+}
+
+func (x *ImportDatabaseHeader) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *ImportDatabaseHeader) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *ImportDatabaseHeader) GetCompressed() bool {
+	if x != nil {
+		return x.Compressed
+	}
+	return false
+}
+
+func (x *ImportDatabaseHeader) GetCompressionType() string {
+	if x != nil {
+		return x.CompressionType
+	}
+	return ""
+}
+
+func (x *ImportDatabaseHeader) GetCreateDatabase() bool {
+	if x != nil {
+		return x.CreateDatabase
+	}
+	return false
+}
+
+func (x *ImportDatabaseHeader) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *ImportDatabaseHeader) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ImportDatabaseHeader) GetBackground() bool {
+	if x != nil {
+		return x.Background
+	}
+	return false
+}
+
+// ImportDatabaseProgress is sent by nitrod as the import moves through
+// each stage. The first message has the offset the upload resumes from.
+// When an archive has several dumps, nitrod sends the SELECTING stage
+// and waits for the client to send the member to import. Background
+// imports end with the QUEUED stage and the ID of the job.
+type ImportDatabaseProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stage      ImportStage `protobuf:"varint,1,opt,name=stage,proto3,enum=nitrod.ImportStage" json:"stage,omitempty"`
+	Offset     int64       `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Size       int64       `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Statements int64       `protobuf:"varint,4,opt,name=statements,proto3" json:"statements,omitempty"`
+	Message    string      `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// members are the dumps in the archive to select from
+	Members []string `protobuf:"bytes,6,rep,name=members,proto3" json:"members,omitempty"`
+	Job     string   `protobuf:"bytes,7,opt,name=job,proto3" json:"job,omitempty"`
+}
+
+func (x *ImportDatabaseProgress) Reset() {
+	*x = ImportDatabaseProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportDatabaseProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportDatabaseProgress) ProtoMessage() {}
+
+func (x *ImportDatabaseProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_internal_nitrod_nitrod_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetPhpIniSettingRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportDatabaseProgress.ProtoReflect.Descriptor instead.
+func (*ImportDatabaseProgress) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ImportDatabaseProgress) GetStage() ImportStage {
+	if x != nil {
+		return x.Stage
+	}
+	return ImportStage_UPLOADING
+}
+
+func (x *ImportDatabaseProgress) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ImportDatabaseProgress) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ImportDatabaseProgress) GetStatements() int64 {
+	if x != nil {
+		return x.Statements
+	}
+	return 0
+}
+
+func (x *ImportDatabaseProgress) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ImportDatabaseProgress) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *ImportDatabaseProgress) GetJob() string {
+	if x != nil {
+		return x.Job
+	}
+	return ""
+}
+
+type ListDatabasesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Engine    string `protobuf:"bytes,1,opt,name=engine,proto3" json:"engine,omitempty"`
+	Container string `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+}
+
+func (x *ListDatabasesRequest) Reset() {
+	*x = ListDatabasesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDatabasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDatabasesRequest) ProtoMessage() {}
+
+func (x *ListDatabasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_internal_nitrod_nitrod_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PhpFpmServiceRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDatabasesRequest.ProtoReflect.Descriptor instead.
+func (*ListDatabasesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListDatabasesRequest) GetEngine() string {
+	if x != nil {
+		return x.Engine
+	}
+	return ""
+}
+
+func (x *ListDatabasesRequest) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+type ListDatabasesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Databases []*Database `protobuf:"bytes,1,rep,name=databases,proto3" json:"databases,omitempty"`
+}
+
+func (x *ListDatabasesResponse) Reset() {
+	*x = ListDatabasesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDatabasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDatabasesResponse) ProtoMessage() {}
+
+func (x *ListDatabasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_internal_nitrod_nitrod_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NginxServiceRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDatabasesResponse.ProtoReflect.Descriptor instead.
+func (*ListDatabasesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListDatabasesResponse) GetDatabases() []*Database {
+	if x != nil {
+		return x.Databases
+	}
+	return nil
+}
+
+type Database struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// size is the size of the data and indexes in bytes
+	Size    int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Charset string `protobuf:"bytes,3,opt,name=charset,proto3" json:"charset,omitempty"`
+	Tables  int64  `protobuf:"varint,4,opt,name=tables,proto3" json:"tables,omitempty"`
+}
+
+func (x *Database) Reset() {
+	*x = Database{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Database) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Database) ProtoMessage() {}
+
+func (x *Database) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_internal_nitrod_nitrod_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ImportDatabaseRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Database.ProtoReflect.Descriptor instead.
+func (*Database) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Database) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Database) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Database) GetCharset() string {
+	if x != nil {
+		return x.Charset
+	}
+	return ""
+}
+
+func (x *Database) GetTables() int64 {
+	if x != nil {
+		return x.Tables
+	}
+	return 0
+}
+
+type CreateDatabaseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Engine    string `protobuf:"bytes,1,opt,name=engine,proto3" json:"engine,omitempty"`
+	Container string `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+	Database  string `protobuf:"bytes,3,opt,name=database,proto3" json:"database,omitempty"`
+}
+
+func (x *CreateDatabaseRequest) Reset() {
+	*x = CreateDatabaseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateDatabaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDatabaseRequest) ProtoMessage() {}
+
+func (x *CreateDatabaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_internal_nitrod_nitrod_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServiceResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDatabaseRequest.ProtoReflect.Descriptor instead.
+func (*CreateDatabaseRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateDatabaseRequest) GetEngine() string {
+	if x != nil {
+		return x.Engine
+	}
+	return ""
+}
+
+func (x *CreateDatabaseRequest) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *CreateDatabaseRequest) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+type DropDatabaseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Engine    string `protobuf:"bytes,1,opt,name=engine,proto3" json:"engine,omitempty"`
+	Container string `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+	Database  string `protobuf:"bytes,3,opt,name=database,proto3" json:"database,omitempty"`
+}
+
+func (x *DropDatabaseRequest) Reset() {
+	*x = DropDatabaseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DropDatabaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DropDatabaseRequest) ProtoMessage() {}
+
+func (x *DropDatabaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DropDatabaseRequest.ProtoReflect.Descriptor instead.
+func (*DropDatabaseRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DropDatabaseRequest) GetEngine() string {
+	if x != nil {
+		return x.Engine
+	}
+	return ""
+}
+
+func (x *DropDatabaseRequest) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *DropDatabaseRequest) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+type RenameDatabaseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Engine    string `protobuf:"bytes,1,opt,name=engine,proto3" json:"engine,omitempty"`
+	Container string `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+	Database  string `protobuf:"bytes,3,opt,name=database,proto3" json:"database,omitempty"`
+	Name      string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *RenameDatabaseRequest) Reset() {
+	*x = RenameDatabaseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenameDatabaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameDatabaseRequest) ProtoMessage() {}
+
+func (x *RenameDatabaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameDatabaseRequest.ProtoReflect.Descriptor instead.
+func (*RenameDatabaseRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RenameDatabaseRequest) GetEngine() string {
+	if x != nil {
+		return x.Engine
+	}
+	return ""
+}
+
+func (x *RenameDatabaseRequest) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *RenameDatabaseRequest) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *RenameDatabaseRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SanitizeDatabaseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Engine    string          `protobuf:"bytes,1,opt,name=engine,proto3" json:"engine,omitempty"`
+	Container string          `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+	Database  string          `protobuf:"bytes,3,opt,name=database,proto3" json:"database,omitempty"`
+	Rules     []*SanitizeRule `protobuf:"bytes,4,rep,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *SanitizeDatabaseRequest) Reset() {
+	*x = SanitizeDatabaseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SanitizeDatabaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SanitizeDatabaseRequest) ProtoMessage() {}
+
+func (x *SanitizeDatabaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SanitizeDatabaseRequest.ProtoReflect.Descriptor instead.
+func (*SanitizeDatabaseRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SanitizeDatabaseRequest) GetEngine() string {
+	if x != nil {
+		return x.Engine
+	}
+	return ""
+}
+
+func (x *SanitizeDatabaseRequest) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *SanitizeDatabaseRequest) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *SanitizeDatabaseRequest) GetRules() []*SanitizeRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// SanitizeRule anonymises the columns that match the table and column
+// patterns, the action is email, hash, null or constant.
+type SanitizeRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Table  string `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	Column string `protobuf:"bytes,2,opt,name=column,proto3" json:"column,omitempty"`
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Value  string `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *SanitizeRule) Reset() {
+	*x = SanitizeRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SanitizeRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SanitizeRule) ProtoMessage() {}
+
+func (x *SanitizeRule) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SanitizeRule.ProtoReflect.Descriptor instead.
+func (*SanitizeRule) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SanitizeRule) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *SanitizeRule) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *SanitizeRule) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *SanitizeRule) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type SanitizeDatabaseResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Columns []*SanitizedColumn `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+}
+
+func (x *SanitizeDatabaseResponse) Reset() {
+	*x = SanitizeDatabaseResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SanitizeDatabaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SanitizeDatabaseResponse) ProtoMessage() {}
+
+func (x *SanitizeDatabaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SanitizeDatabaseResponse.ProtoReflect.Descriptor instead.
+func (*SanitizeDatabaseResponse) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SanitizeDatabaseResponse) GetColumns() []*SanitizedColumn {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+type SanitizedColumn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Table  string `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	Column string `protobuf:"bytes,2,opt,name=column,proto3" json:"column,omitempty"`
+	// rows is the number of rows that were changed
+	Rows int64 `protobuf:"varint,3,opt,name=rows,proto3" json:"rows,omitempty"`
+}
+
+func (x *SanitizedColumn) Reset() {
+	*x = SanitizedColumn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SanitizedColumn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SanitizedColumn) ProtoMessage() {}
+
+func (x *SanitizedColumn) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SanitizedColumn.ProtoReflect.Descriptor instead.
+func (*SanitizedColumn) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SanitizedColumn) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *SanitizedColumn) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *SanitizedColumn) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+type QueryDatabaseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Engine    string `protobuf:"bytes,1,opt,name=engine,proto3" json:"engine,omitempty"`
+	Container string `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+	Database  string `protobuf:"bytes,3,opt,name=database,proto3" json:"database,omitempty"`
+	Query     string `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *QueryDatabaseRequest) Reset() {
+	*x = QueryDatabaseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryDatabaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryDatabaseRequest) ProtoMessage() {}
+
+func (x *QueryDatabaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryDatabaseRequest.ProtoReflect.Descriptor instead.
+func (*QueryDatabaseRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *QueryDatabaseRequest) GetEngine() string {
+	if x != nil {
+		return x.Engine
+	}
+	return ""
+}
+
+func (x *QueryDatabaseRequest) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *QueryDatabaseRequest) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *QueryDatabaseRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+// QueryDatabaseResponse has the rows returned by the last
+// statement of the query, which are empty for statements
+// that do not return rows.
+type QueryDatabaseResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Columns []string    `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	Rows    []*QueryRow `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+}
+
+func (x *QueryDatabaseResponse) Reset() {
+	*x = QueryDatabaseResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryDatabaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryDatabaseResponse) ProtoMessage() {}
+
+func (x *QueryDatabaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryDatabaseResponse.ProtoReflect.Descriptor instead.
+func (*QueryDatabaseResponse) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *QueryDatabaseResponse) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *QueryDatabaseResponse) GetRows() []*QueryRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type QueryRow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []*QueryValue `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *QueryRow) Reset() {
+	*x = QueryRow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRow) ProtoMessage() {}
+
+func (x *QueryRow) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRow.ProtoReflect.Descriptor instead.
+func (*QueryRow) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *QueryRow) GetValues() []*QueryValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type QueryValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Null  bool   `protobuf:"varint,2,opt,name=null,proto3" json:"null,omitempty"`
+}
+
+func (x *QueryValue) Reset() {
+	*x = QueryValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryValue) ProtoMessage() {}
+
+func (x *QueryValue) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryValue.ProtoReflect.Descriptor instead.
+func (*QueryValue) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *QueryValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *QueryValue) GetNull() bool {
+	if x != nil {
+		return x.Null
+	}
+	return false
+}
+
+// ExecRequest is sent as a single start message followed by
+// the input for the command and changes to the terminal size.
+type ExecRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Request:
+	//	*ExecRequest_Start
+	//	*ExecRequest_Stdin
+	//	*ExecRequest_Resize
+	//	*ExecRequest_CloseStdin
+	Request isExecRequest_Request `protobuf_oneof:"request"`
+}
+
+func (x *ExecRequest) Reset() {
+	*x = ExecRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecRequest) ProtoMessage() {}
+
+func (x *ExecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecRequest.ProtoReflect.Descriptor instead.
+func (*ExecRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{27}
+}
+
+func (m *ExecRequest) GetRequest() isExecRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *ExecRequest) GetStart() *ExecStart {
+	if x, ok := x.GetRequest().(*ExecRequest_Start); ok {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ExecRequest) GetStdin() []byte {
+	if x, ok := x.GetRequest().(*ExecRequest_Stdin); ok {
+		return x.Stdin
+	}
+	return nil
+}
+
+func (x *ExecRequest) GetResize() *TerminalSize {
+	if x, ok := x.GetRequest().(*ExecRequest_Resize); ok {
+		return x.Resize
+	}
+	return nil
+}
+
+func (x *ExecRequest) GetCloseStdin() bool {
+	if x, ok := x.GetRequest().(*ExecRequest_CloseStdin); ok {
+		return x.CloseStdin
+	}
+	return false
+}
+
+type isExecRequest_Request interface {
+	isExecRequest_Request()
+}
+
+type ExecRequest_Start struct {
+	Start *ExecStart `protobuf:"bytes,1,opt,name=start,proto3,oneof"`
+}
+
+type ExecRequest_Stdin struct {
+	Stdin []byte `protobuf:"bytes,2,opt,name=stdin,proto3,oneof"`
+}
+
+type ExecRequest_Resize struct {
+	Resize *TerminalSize `protobuf:"bytes,3,opt,name=resize,proto3,oneof"`
+}
+
+type ExecRequest_CloseStdin struct {
+	// closeStdin is sent when there is no more input
+	CloseStdin bool `protobuf:"varint,4,opt,name=closeStdin,proto3,oneof"`
+}
+
+func (*ExecRequest_Start) isExecRequest_Request() {}
+
+func (*ExecRequest_Stdin) isExecRequest_Request() {}
+
+func (*ExecRequest_Resize) isExecRequest_Request() {}
+
+func (*ExecRequest_CloseStdin) isExecRequest_Request() {}
+
+type ExecStart struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Command string   `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Args    []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	// dir is the directory on the machine to run the command in
+	Dir string `protobuf:"bytes,3,opt,name=dir,proto3" json:"dir,omitempty"`
+	// php is the version of PHP used for php, composer and craft
+	Php string `protobuf:"bytes,4,opt,name=php,proto3" json:"php,omitempty"`
+	// env is a list of KEY=value variables for the command
+	Env  []string      `protobuf:"bytes,5,rep,name=env,proto3" json:"env,omitempty"`
+	Tty  bool          `protobuf:"varint,6,opt,name=tty,proto3" json:"tty,omitempty"`
+	Size *TerminalSize `protobuf:"bytes,7,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *ExecStart) Reset() {
+	*x = ExecStart{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecStart) ProtoMessage() {}
+
+func (x *ExecStart) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecStart.ProtoReflect.Descriptor instead.
+func (*ExecStart) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ExecStart) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *ExecStart) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *ExecStart) GetDir() string {
+	if x != nil {
+		return x.Dir
+	}
+	return ""
+}
+
+func (x *ExecStart) GetPhp() string {
+	if x != nil {
+		return x.Php
+	}
+	return ""
+}
+
+func (x *ExecStart) GetEnv() []string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *ExecStart) GetTty() bool {
+	if x != nil {
+		return x.Tty
+	}
+	return false
+}
+
+func (x *ExecStart) GetSize() *TerminalSize {
+	if x != nil {
+		return x.Size
+	}
+	return nil
+}
+
+type TerminalSize struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rows uint32 `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Cols uint32 `protobuf:"varint,2,opt,name=cols,proto3" json:"cols,omitempty"`
+}
+
+func (x *TerminalSize) Reset() {
+	*x = TerminalSize{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TerminalSize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminalSize) ProtoMessage() {}
+
+func (x *TerminalSize) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminalSize.ProtoReflect.Descriptor instead.
+func (*TerminalSize) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TerminalSize) GetRows() uint32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *TerminalSize) GetCols() uint32 {
+	if x != nil {
+		return x.Cols
+	}
+	return 0
+}
+
+// ExecResponse streams the output of the command, the
+// last message has the exit code of the command.
+type ExecResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Response:
+	//	*ExecResponse_Stdout
+	//	*ExecResponse_Stderr
+	//	*ExecResponse_ExitCode
+	Response isExecResponse_Response `protobuf_oneof:"response"`
+}
+
+func (x *ExecResponse) Reset() {
+	*x = ExecResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecResponse) ProtoMessage() {}
+
+func (x *ExecResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecResponse.ProtoReflect.Descriptor instead.
+func (*ExecResponse) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{30}
+}
+
+func (m *ExecResponse) GetResponse() isExecResponse_Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (x *ExecResponse) GetStdout() []byte {
+	if x, ok := x.GetResponse().(*ExecResponse_Stdout); ok {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *ExecResponse) GetStderr() []byte {
+	if x, ok := x.GetResponse().(*ExecResponse_Stderr); ok {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *ExecResponse) GetExitCode() int32 {
+	if x, ok := x.GetResponse().(*ExecResponse_ExitCode); ok {
+		return x.ExitCode
+	}
+	return 0
+}
+
+type isExecResponse_Response interface {
+	isExecResponse_Response()
+}
+
+type ExecResponse_Stdout struct {
+	Stdout []byte `protobuf:"bytes,1,opt,name=stdout,proto3,oneof"`
+}
+
+type ExecResponse_Stderr struct {
+	Stderr []byte `protobuf:"bytes,2,opt,name=stderr,proto3,oneof"`
+}
+
+type ExecResponse_ExitCode struct {
+	ExitCode int32 `protobuf:"varint,3,opt,name=exitCode,proto3,oneof"`
+}
+
+func (*ExecResponse_Stdout) isExecResponse_Response() {}
+
+func (*ExecResponse_Stderr) isExecResponse_Response() {}
+
+func (*ExecResponse_ExitCode) isExecResponse_Response() {}
+
+type Job struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind        string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Description string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	State       JobState `protobuf:"varint,4,opt,name=state,proto3,enum=nitrod.JobState" json:"state,omitempty"`
+	// message is the error when the job fails
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// created and finished are unix timestamps
+	Created  int64 `protobuf:"varint,6,opt,name=created,proto3" json:"created,omitempty"`
+	Finished int64 `protobuf:"varint,7,opt,name=finished,proto3" json:"finished,omitempty"`
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Job) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Job) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Job) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Job) GetState() JobState {
+	if x != nil {
+		return x.State
+	}
+	return JobState_RUNNING
+}
+
+func (x *Job) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Job) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *Job) GetFinished() int64 {
+	if x != nil {
+		return x.Finished
+	}
+	return 0
+}
+
+type StartJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Job:
+	//	*StartJobRequest_InstallPackages
+	//	*StartJobRequest_UpgradePackages
+	Job isStartJobRequest_Job `protobuf_oneof:"job"`
+}
+
+func (x *StartJobRequest) Reset() {
+	*x = StartJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartJobRequest) ProtoMessage() {}
+
+func (x *StartJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartJobRequest.ProtoReflect.Descriptor instead.
+func (*StartJobRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{32}
+}
+
+func (m *StartJobRequest) GetJob() isStartJobRequest_Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (x *StartJobRequest) GetInstallPackages() *InstallPackagesJob {
+	if x, ok := x.GetJob().(*StartJobRequest_InstallPackages); ok {
+		return x.InstallPackages
+	}
+	return nil
+}
+
+func (x *StartJobRequest) GetUpgradePackages() *UpgradePackagesJob {
+	if x, ok := x.GetJob().(*StartJobRequest_UpgradePackages); ok {
+		return x.UpgradePackages
+	}
+	return nil
+}
+
+type isStartJobRequest_Job interface {
+	isStartJobRequest_Job()
+}
+
+type StartJobRequest_InstallPackages struct {
+	InstallPackages *InstallPackagesJob `protobuf:"bytes,1,opt,name=installPackages,proto3,oneof"`
+}
+
+type StartJobRequest_UpgradePackages struct {
+	UpgradePackages *UpgradePackagesJob `protobuf:"bytes,2,opt,name=upgradePackages,proto3,oneof"`
+}
+
+func (*StartJobRequest_InstallPackages) isStartJobRequest_Job() {}
+
+func (*StartJobRequest_UpgradePackages) isStartJobRequest_Job() {}
+
+// InstallPackagesJob installs the packages for a version of PHP.
+type InstallPackagesJob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *InstallPackagesJob) Reset() {
+	*x = InstallPackagesJob{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstallPackagesJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallPackagesJob) ProtoMessage() {}
+
+func (x *InstallPackagesJob) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallPackagesJob.ProtoReflect.Descriptor instead.
+func (*InstallPackagesJob) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *InstallPackagesJob) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// UpgradePackagesJob updates the package lists and upgrades the packages.
+type UpgradePackagesJob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpgradePackagesJob) Reset() {
+	*x = UpgradePackagesJob{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpgradePackagesJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradePackagesJob) ProtoMessage() {}
+
+func (x *UpgradePackagesJob) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradePackagesJob.ProtoReflect.Descriptor instead.
+func (*UpgradePackagesJob) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{34}
+}
+
+type GetJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListJobsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{36}
+}
+
+type ListJobsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Jobs []*Job `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+// WatchJobRequest starts sending the log from the offset,
+// so a client can resume watching where it stopped.
+type WatchJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Offset int64  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (x *WatchJobRequest) Reset() {
+	*x = WatchJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchJobRequest) ProtoMessage() {}
+
+func (x *WatchJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchJobRequest.ProtoReflect.Descriptor instead.
+func (*WatchJobRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *WatchJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WatchJobRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// WatchJobResponse is sent as the job writes to its log, the
+// offset is where the next part of the log starts. The stream
+// ends once the job is done and the whole log is sent.
+type WatchJobResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Job    *Job   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	Log    []byte `protobuf:"bytes,2,opt,name=log,proto3" json:"log,omitempty"`
+	Offset int64  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (x *WatchJobResponse) Reset() {
+	*x = WatchJobResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchJobResponse) ProtoMessage() {}
+
+func (x *WatchJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchJobResponse.ProtoReflect.Descriptor instead.
+func (*WatchJobResponse) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *WatchJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *WatchJobResponse) GetLog() []byte {
+	if x != nil {
+		return x.Log
+	}
+	return nil
+}
+
+func (x *WatchJobResponse) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type CancelJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CancelJobRequest) Reset() {
+	*x = CancelJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobRequest) ProtoMessage() {}
+
+func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CancelJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ConfigureBackupsRequest replaces the automatic backup schedule,
+// an empty schedule stops the automatic backups.
+type ConfigureBackupsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// schedule is hourly, daily, weekly or a duration such as 12h
+	Schedule string `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	// targets are the containers to backup, each database is
+	// backed up when a target does not list the databases
+	Targets []*BackupTarget `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	// keep is the number of backups of each database to keep
+	Keep int32 `protobuf:"varint,3,opt,name=keep,proto3" json:"keep,omitempty"`
+	// days is the number of days to keep backups for
+	Days int32 `protobuf:"varint,4,opt,name=days,proto3" json:"days,omitempty"`
+	// compression is gzip, zstd or none
+	Compression string `protobuf:"bytes,5,opt,name=compression,proto3" json:"compression,omitempty"`
+	// utcOffset is the host's offset from UTC in seconds, the backups
+	// are named using the host's time like the backups made by the CLI
+	UtcOffset int32 `protobuf:"varint,6,opt,name=utcOffset,proto3" json:"utcOffset,omitempty"`
+	// recipient is the public key the backups are encrypted with,
+	// an age recipient or an armored GPG public key
+	Recipient string `protobuf:"bytes,7,opt,name=recipient,proto3" json:"recipient,omitempty"`
+}
+
+func (x *ConfigureBackupsRequest) Reset() {
+	*x = ConfigureBackupsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigureBackupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigureBackupsRequest) ProtoMessage() {}
+
+func (x *ConfigureBackupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigureBackupsRequest.ProtoReflect.Descriptor instead.
+func (*ConfigureBackupsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ConfigureBackupsRequest) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *ConfigureBackupsRequest) GetTargets() []*BackupTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *ConfigureBackupsRequest) GetKeep() int32 {
+	if x != nil {
+		return x.Keep
+	}
+	return 0
+}
+
+func (x *ConfigureBackupsRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *ConfigureBackupsRequest) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *ConfigureBackupsRequest) GetUtcOffset() int32 {
+	if x != nil {
+		return x.UtcOffset
+	}
+	return 0
+}
+
+func (x *ConfigureBackupsRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+type BackupTarget struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Engine    string   `protobuf:"bytes,1,opt,name=engine,proto3" json:"engine,omitempty"`
+	Container string   `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+	Databases []string `protobuf:"bytes,3,rep,name=databases,proto3" json:"databases,omitempty"`
+}
+
+func (x *BackupTarget) Reset() {
+	*x = BackupTarget{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackupTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupTarget) ProtoMessage() {}
+
+func (x *BackupTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupTarget.ProtoReflect.Descriptor instead.
+func (*BackupTarget) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *BackupTarget) GetEngine() string {
+	if x != nil {
+		return x.Engine
+	}
+	return ""
+}
+
+func (x *BackupTarget) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *BackupTarget) GetDatabases() []string {
+	if x != nil {
+		return x.Databases
+	}
+	return nil
+}
+
+// CreateSnapshotRequest copies the data volume of the
+// container to a volume named after the snapshot.
+type CreateSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Container string `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *CreateSnapshotRequest) Reset() {
+	*x = CreateSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSnapshotRequest) ProtoMessage() {}
+
+func (x *CreateSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateSnapshotRequest) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *CreateSnapshotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListSnapshotsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// container is optional and only lists the container's snapshots
+	Container string `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+}
+
+func (x *ListSnapshotsRequest) Reset() {
+	*x = ListSnapshotsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsRequest) ProtoMessage() {}
+
+func (x *ListSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListSnapshotsRequest) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+type ListSnapshotsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snapshots []*Snapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+}
+
+func (x *ListSnapshotsResponse) Reset() {
+	*x = ListSnapshotsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsResponse) ProtoMessage() {}
+
+func (x *ListSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListSnapshotsResponse) GetSnapshots() []*Snapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+type Snapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Container string `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+	Volume    string `protobuf:"bytes,3,opt,name=volume,proto3" json:"volume,omitempty"`
+	// created is a unix timestamp
+	Created int64 `protobuf:"varint,4,opt,name=created,proto3" json:"created,omitempty"`
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Snapshot) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Snapshot) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *Snapshot) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *Snapshot) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+// RollbackSnapshotRequest replaces the data volume
+// of the container with the snapshot.
+type RollbackSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Container string `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *RollbackSnapshotRequest) Reset() {
+	*x = RollbackSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RollbackSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackSnapshotRequest) ProtoMessage() {}
+
+func (x *RollbackSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*RollbackSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RollbackSnapshotRequest) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *RollbackSnapshotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RemoveSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Container string `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *RemoveSnapshotRequest) Reset() {
+	*x = RemoveSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveSnapshotRequest) ProtoMessage() {}
+
+func (x *RemoveSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*RemoveSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *RemoveSnapshotRequest) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *RemoveSnapshotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type PhpIniValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type       PhpIniValueType `protobuf:"varint,1,opt,name=type,proto3,enum=nitrod.PhpIniValueType" json:"type,omitempty"`
+	Raw        string          `protobuf:"bytes,2,opt,name=raw,proto3" json:"raw,omitempty"`
+	Bool       bool            `protobuf:"varint,3,opt,name=bool,proto3" json:"bool,omitempty"`
+	Int        int64           `protobuf:"varint,4,opt,name=int,proto3" json:"int,omitempty"`
+	Overridden bool            `protobuf:"varint,5,opt,name=overridden,proto3" json:"overridden,omitempty"`
+}
+
+func (x *PhpIniValue) Reset() {
+	*x = PhpIniValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PhpIniValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhpIniValue) ProtoMessage() {}
+
+func (x *PhpIniValue) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhpIniValue.ProtoReflect.Descriptor instead.
+func (*PhpIniValue) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *PhpIniValue) GetType() PhpIniValueType {
+	if x != nil {
+		return x.Type
+	}
+	return PhpIniValueType_STRING
+}
+
+func (x *PhpIniValue) GetRaw() string {
+	if x != nil {
+		return x.Raw
+	}
+	return ""
+}
+
+func (x *PhpIniValue) GetBool() bool {
+	if x != nil {
+		return x.Bool
+	}
+	return false
+}
+
+func (x *PhpIniValue) GetInt() int64 {
+	if x != nil {
+		return x.Int
+	}
+	return 0
+}
+
+func (x *PhpIniValue) GetOverridden() bool {
+	if x != nil {
+		return x.Overridden
+	}
+	return false
+}
+
+type PhpIniSettingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version string       `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Setting string       `protobuf:"bytes,2,opt,name=setting,proto3" json:"setting,omitempty"`
+	Fpm     *PhpIniValue `protobuf:"bytes,3,opt,name=fpm,proto3" json:"fpm,omitempty"`
+	Cli     *PhpIniValue `protobuf:"bytes,4,opt,name=cli,proto3" json:"cli,omitempty"`
+}
+
+func (x *PhpIniSettingResponse) Reset() {
+	*x = PhpIniSettingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PhpIniSettingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhpIniSettingResponse) ProtoMessage() {}
+
+func (x *PhpIniSettingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhpIniSettingResponse.ProtoReflect.Descriptor instead.
+func (*PhpIniSettingResponse) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *PhpIniSettingResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PhpIniSettingResponse) GetSetting() string {
+	if x != nil {
+		return x.Setting
+	}
+	return ""
+}
+
+func (x *PhpIniSettingResponse) GetFpm() *PhpIniValue {
+	if x != nil {
+		return x.Fpm
+	}
+	return nil
+}
+
+func (x *PhpIniSettingResponse) GetCli() *PhpIniValue {
+	if x != nil {
+		return x.Cli
+	}
+	return nil
+}
+
+type VersionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VersionRequest) Reset() {
+	*x = VersionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionRequest) ProtoMessage() {}
+
+func (x *VersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionRequest.ProtoReflect.Descriptor instead.
+func (*VersionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{51}
+}
+
+type VersionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Os      string `protobuf:"bytes,2,opt,name=os,proto3" json:"os,omitempty"`
+	Arch    string `protobuf:"bytes,3,opt,name=arch,proto3" json:"arch,omitempty"`
+}
+
+func (x *VersionResponse) Reset() {
+	*x = VersionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionResponse) ProtoMessage() {}
+
+func (x *VersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionResponse.ProtoReflect.Descriptor instead.
+func (*VersionResponse) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *VersionResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+// UpgradeDaemonRequest is sent as a single header
+// followed by the chunks of the nitrod binary.
+type UpgradeDaemonRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Request:
+	//	*UpgradeDaemonRequest_Header
+	//	*UpgradeDaemonRequest_Data
+	Request isUpgradeDaemonRequest_Request `protobuf_oneof:"request"`
+}
+
+func (x *UpgradeDaemonRequest) Reset() {
+	*x = UpgradeDaemonRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpgradeDaemonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeDaemonRequest) ProtoMessage() {}
+
+func (x *UpgradeDaemonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeDaemonRequest.ProtoReflect.Descriptor instead.
+func (*UpgradeDaemonRequest) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{53}
+}
+
+func (m *UpgradeDaemonRequest) GetRequest() isUpgradeDaemonRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *UpgradeDaemonRequest) GetHeader() *UpgradeDaemonHeader {
+	if x, ok := x.GetRequest().(*UpgradeDaemonRequest_Header); ok {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *UpgradeDaemonRequest) GetData() []byte {
+	if x, ok := x.GetRequest().(*UpgradeDaemonRequest_Data); ok {
+		return x.Data
+	}
+	return nil
+}
+
+type isUpgradeDaemonRequest_Request interface {
+	isUpgradeDaemonRequest_Request()
+}
+
+type UpgradeDaemonRequest_Header struct {
+	Header *UpgradeDaemonHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type UpgradeDaemonRequest_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*UpgradeDaemonRequest_Header) isUpgradeDaemonRequest_Request() {}
+
+func (*UpgradeDaemonRequest_Data) isUpgradeDaemonRequest_Request() {}
+
+type UpgradeDaemonHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// checksum is the hex encoded sha256 of the binary
+	Checksum string `protobuf:"bytes,2,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Size     int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *UpgradeDaemonHeader) Reset() {
+	*x = UpgradeDaemonHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpgradeDaemonHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeDaemonHeader) ProtoMessage() {}
+
+func (x *UpgradeDaemonHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeDaemonHeader.ProtoReflect.Descriptor instead.
+func (*UpgradeDaemonHeader) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *UpgradeDaemonHeader) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *UpgradeDaemonHeader) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *UpgradeDaemonHeader) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ServiceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ServiceResponse) Reset() {
+	*x = ServiceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_nitrod_nitrod_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceResponse) ProtoMessage() {}
+
+func (x *ServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_nitrod_nitrod_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceResponse.ProtoReflect.Descriptor instead.
+func (*ServiceResponse) Descriptor() ([]byte, []int) {
+	return file_internal_nitrod_nitrod_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ServiceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_internal_nitrod_nitrod_proto protoreflect.FileDescriptor
+
+var file_internal_nitrod_nitrod_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x6f,
+	0x64, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x22, 0x91, 0x01, 0x0a, 0x1a, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x50, 0x68, 0x70, 0x49, 0x6e, 0x69, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12,
+	0x23, 0x0a, 0x04, 0x73, 0x61, 0x70, 0x69, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x50, 0x68, 0x70, 0x53, 0x61, 0x70, 0x69, 0x52, 0x04,
+	0x73, 0x61, 0x70, 0x69, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x74, 0x0a, 0x19, 0x52, 0x65,
+	0x73, 0x65, 0x74, 0x50, 0x68, 0x70, 0x49, 0x6e, 0x69, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x23, 0x0a, 0x04, 0x73,
+	0x61, 0x70, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x6f, 0x64, 0x2e, 0x50, 0x68, 0x70, 0x53, 0x61, 0x70, 0x69, 0x52, 0x04, 0x73, 0x61, 0x70, 0x69,
+	0x22, 0x30, 0x0a, 0x14, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x58, 0x64, 0x65, 0x62, 0x75,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x2f, 0x0a, 0x13, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x58, 0x64, 0x65, 0x62,
+	0x75, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0xc9, 0x01, 0x0a, 0x16, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x65, 0x58, 0x64, 0x65, 0x62, 0x75, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x69, 0x64, 0x65, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x64,
+	0x65, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x58, 0x64, 0x65,
+	0x62, 0x75, 0x67, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x22,
+	0xa5, 0x01, 0x0a, 0x16, 0x58, 0x64, 0x65, 0x62, 0x75, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x78, 0x64, 0x65, 0x62, 0x75, 0x67, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x78, 0x64, 0x65,
+	0x62, 0x75, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x12, 0x31, 0x0a, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e,
+	0x58, 0x64, 0x65, 0x62, 0x75, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x73,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x39, 0x0a, 0x0d, 0x58, 0x64, 0x65, 0x62, 0x75,
+	0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x22, 0x4d, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x50, 0x68, 0x70, 0x49, 0x6e, 0x69, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x22, 0x5f, 0x0a, 0x14, 0x50, 0x68, 0x70, 0x46, 0x70, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x44, 0x0a, 0x13, 0x4e, 0x67, 0x69, 0x6e, 0x78, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x06, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x90, 0x01, 0x0a, 0x15, 0x49, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x36, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x49, 0x6d, 0x70, 0x6f,
+	0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x48, 0x00, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x18, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x08, 0x22, 0xaa, 0x02, 0x0a, 0x14,
+	0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x26, 0x0a, 0x0e, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x75, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x75, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x61, 0x63, 0x6b,
+	0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x62, 0x61,
+	0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0xd5, 0x01, 0x0a, 0x16, 0x49, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x29, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x49, 0x6d, 0x70, 0x6f,
+	0x72, 0x74, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6a, 0x6f, 0x62,
+	0x22, 0x4c, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x22, 0x47,
+	0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x62,
+	0x61, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x6f, 0x64, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x09, 0x64, 0x61,
+	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x73, 0x22, 0x64, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x62,
+	0x61, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x72, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68,
+	0x61, 0x72, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x22, 0x69, 0x0a,
+	0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x22, 0x67, 0x0a, 0x13, 0x44, 0x72, 0x6f, 0x70,
+	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x22, 0x7d, 0x0a, 0x15, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62,
+	0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e,
+	0x67, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x22, 0x97, 0x01, 0x0a, 0x17, 0x53, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x6e,
+	0x67, 0x69, 0x6e, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x2a,
+	0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65, 0x52,
+	0x75, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x6a, 0x0a, 0x0c, 0x53, 0x61,
+	0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x4d, 0x0a, 0x18, 0x53, 0x61, 0x6e, 0x69, 0x74, 0x69,
+	0x7a, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x31, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x61, 0x6e,
+	0x69, 0x74, 0x69, 0x7a, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x22, 0x53, 0x0a, 0x0f, 0x53, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a,
+	0x65, 0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x22, 0x7e, 0x0a, 0x14, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0x57, 0x0a, 0x15, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x24, 0x0a,
+	0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x6f, 0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72,
+	0x6f, 0x77, 0x73, 0x22, 0x36, 0x0a, 0x08, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x6f, 0x77, 0x12,
+	0x2a, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x36, 0x0a, 0x0a, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x75, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6e,
+	0x75, 0x6c, 0x6c, 0x22, 0xad, 0x01, 0x0a, 0x0b, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x45, 0x78, 0x65, 0x63,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x00, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x16,
+	0x0a, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52,
+	0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x2e, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e,
+	0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x48, 0x00, 0x52, 0x06,
+	0x72, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x20, 0x0a, 0x0a, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x53,
+	0x74, 0x64, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x6c,
+	0x6f, 0x73, 0x65, 0x53, 0x74, 0x64, 0x69, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0xab, 0x01, 0x0a, 0x09, 0x45, 0x78, 0x65, 0x63, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61,
+	0x72, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12,
+	0x10, 0x0a, 0x03, 0x64, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x69,
+	0x72, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x68, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x70, 0x68, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x03, 0x74, 0x74, 0x79, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x54,
+	0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x22, 0x36, 0x0a, 0x0c, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x22, 0x6c, 0x0a, 0x0c, 0x45, 0x78, 0x65,
+	0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x74, 0x64,
+	0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x64,
+	0x6f, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x1c, 0x0a,
+	0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48,
+	0x00, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xc3, 0x01, 0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x4a, 0x6f,
+	0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x22, 0xa8, 0x01,
+	0x0a, 0x0f, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x46, 0x0a, 0x0f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x6f, 0x64, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x73, 0x4a, 0x6f, 0x62, 0x48, 0x00, 0x52, 0x0f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x0f, 0x75, 0x70, 0x67,
+	0x72, 0x61, 0x64, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x55, 0x70, 0x67, 0x72,
+	0x61, 0x64, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x4a, 0x6f, 0x62, 0x48, 0x00,
+	0x52, 0x0f, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x73, 0x42, 0x05, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x22, 0x2e, 0x0a, 0x12, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x4a, 0x6f, 0x62, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x14, 0x0a, 0x12, 0x55, 0x70, 0x67, 0x72,
+	0x61, 0x64, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x4a, 0x6f, 0x62, 0x22, 0x1f,
+	0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x11, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x33, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x4a, 0x6f,
+	0x62, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x22, 0x39, 0x0a, 0x0f, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x22, 0x5b, 0x0a, 0x10, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x4a, 0x6f, 0x62,
+	0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22,
+	0x22, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0xeb, 0x01, 0x0a, 0x17, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x54, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6b,
+	0x65, 0x65, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6b, 0x65, 0x65, 0x70, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x61, 0x79, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x64,
+	0x61, 0x79, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x74, 0x63, 0x4f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x75, 0x74, 0x63, 0x4f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e,
+	0x74, 0x22, 0x62, 0x0a, 0x0c, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x62,
+	0x61, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x73, 0x22, 0x49, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x22, 0x34, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x22, 0x47, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2e, 0x0a, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x52, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x22,
+	0x6e, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x22,
+	0x4b, 0x0a, 0x17, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x49, 0x0a, 0x15,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x92, 0x01, 0x0a, 0x0b, 0x50, 0x68, 0x70, 0x49,
+	0x6e, 0x69, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x50,
+	0x68, 0x70, 0x49, 0x6e, 0x69, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x6f, 0x6c, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x62, 0x6f, 0x6f, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x69, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x22, 0x99, 0x01, 0x0a,
+	0x15, 0x50, 0x68, 0x70, 0x49, 0x6e, 0x69, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x25, 0x0a, 0x03, 0x66, 0x70,
+	0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64,
+	0x2e, 0x50, 0x68, 0x70, 0x49, 0x6e, 0x69, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x66, 0x70,
+	0x6d, 0x12, 0x25, 0x0a, 0x03, 0x63, 0x6c, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x50, 0x68, 0x70, 0x49, 0x6e, 0x69, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x03, 0x63, 0x6c, 0x69, 0x22, 0x10, 0x0a, 0x0e, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4f, 0x0a, 0x0f, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x63, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x63, 0x68, 0x22, 0x6e, 0x0a, 0x14, 0x55,
+	0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x55, 0x70, 0x67,
+	0x72, 0x61, 0x64, 0x65, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x48, 0x00, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5f, 0x0a, 0x13, 0x55,
+	0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x2b, 0x0a, 0x0f,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2a, 0x76, 0x0a, 0x0b, 0x49, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x50, 0x4c, 0x4f,
+	0x41, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x56, 0x45, 0x52, 0x49, 0x46,
+	0x59, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x45, 0x43, 0x4f, 0x4d, 0x50,
+	0x52, 0x45, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x49, 0x4d, 0x50,
+	0x4f, 0x52, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4d, 0x50,
+	0x4c, 0x45, 0x54, 0x45, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x45, 0x4c, 0x45, 0x43, 0x54,
+	0x49, 0x4e, 0x47, 0x10, 0x05, 0x12, 0x0a, 0x0a, 0x06, 0x51, 0x55, 0x45, 0x55, 0x45, 0x44, 0x10,
+	0x06, 0x2a, 0x24, 0x0a, 0x07, 0x50, 0x68, 0x70, 0x53, 0x61, 0x70, 0x69, 0x12, 0x07, 0x0a, 0x03,
+	0x41, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x46, 0x50, 0x4d, 0x10, 0x01, 0x12, 0x07,
+	0x0a, 0x03, 0x43, 0x4c, 0x49, 0x10, 0x02, 0x2a, 0x42, 0x0a, 0x0f, 0x50, 0x68, 0x70, 0x49, 0x6e,
+	0x69, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54,
+	0x52, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x42, 0x4f, 0x4f, 0x4c, 0x45, 0x41,
+	0x4e, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x54, 0x45, 0x47, 0x45, 0x52, 0x10, 0x02,
+	0x12, 0x09, 0x0a, 0x05, 0x42, 0x59, 0x54, 0x45, 0x53, 0x10, 0x03, 0x2a, 0x29, 0x0a, 0x0b, 0x58,
+	0x64, 0x65, 0x62, 0x75, 0x67, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x55,
+	0x54, 0x4f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x54, 0x52, 0x49,
+	0x47, 0x47, 0x45, 0x52, 0x10, 0x01, 0x2a, 0x40, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12,
+	0x0d, 0x0a, 0x09, 0x53, 0x55, 0x43, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0a,
+	0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x41,
+	0x4e, 0x43, 0x45, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x31, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x53,
+	0x54, 0x41, 0x52, 0x54, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x54, 0x4f, 0x50, 0x10, 0x01,
+	0x12, 0x09, 0x0a, 0x05, 0x53, 0x54, 0x41, 0x52, 0x54, 0x10, 0x02, 0x32, 0xff, 0x0d, 0x0a, 0x0c,
+	0x4e, 0x69, 0x74, 0x72, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x0e,
+	0x50, 0x68, 0x70, 0x49, 0x6e, 0x69, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x22,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x68,
+	0x70, 0x49, 0x6e, 0x69, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a,
+	0x10, 0x47, 0x65, 0x74, 0x50, 0x68, 0x70, 0x49, 0x6e, 0x69, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x12, 0x1f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x68,
+	0x70, 0x49, 0x6e, 0x69, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x50, 0x68, 0x70, 0x49,
+	0x6e, 0x69, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x68, 0x70, 0x49,
+	0x6e, 0x69, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x21, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x6f, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x68, 0x70, 0x49, 0x6e, 0x69, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0d, 0x44, 0x69, 0x73, 0x61, 0x62,
+	0x6c, 0x65, 0x58, 0x64, 0x65, 0x62, 0x75, 0x67, 0x12, 0x1c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f,
+	0x64, 0x2e, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x58, 0x64, 0x65, 0x62, 0x75, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x46, 0x0a, 0x0c, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x58, 0x64, 0x65, 0x62, 0x75,
+	0x67, 0x12, 0x1b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x45, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x58, 0x64, 0x65, 0x62, 0x75, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0f, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x58, 0x64, 0x65, 0x62, 0x75, 0x67, 0x12, 0x1e, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x58,
+	0x64, 0x65, 0x62, 0x75, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x58, 0x64, 0x65, 0x62, 0x75, 0x67, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x55,
+	0x0a, 0x0e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x12, 0x1d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74,
+	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x44,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22,
+	0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x4e, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x46, 0x0a, 0x0c, 0x44, 0x72, 0x6f, 0x70, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x12, 0x1b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x44, 0x72, 0x6f, 0x70, 0x44,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0e, 0x52, 0x65, 0x6e,
+	0x61, 0x6d, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1d, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x6f, 0x64, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62,
+	0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x10, 0x53, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1f, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x6f, 0x64, 0x2e, 0x53, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62,
+	0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x6f, 0x64, 0x2e, 0x53, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e,
+	0x0a, 0x0d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12,
+	0x1c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x61,
+	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x37,
+	0x0a, 0x04, 0x45, 0x78, 0x65, 0x63, 0x12, 0x13, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e,
+	0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x6f, 0x64, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x32, 0x0a, 0x08, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x4a, 0x6f, 0x62, 0x12, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x4a, 0x6f, 0x62, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x06, 0x47,
+	0x65, 0x74, 0x4a, 0x6f, 0x62, 0x12, 0x15, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x47,
+	0x65, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x4a, 0x6f, 0x62, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x08, 0x4c,
+	0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x18, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f,
+	0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x08,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x12, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f,
+	0x64, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x18, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12,
+	0x34, 0x0a, 0x09, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x12, 0x18, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e,
+	0x4a, 0x6f, 0x62, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x12, 0x1f, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x6f, 0x64, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x42, 0x61, 0x63, 0x6b,
+	0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x4e, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x73, 0x12, 0x1c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x4e, 0x0a, 0x10, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x52,
+	0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x4a, 0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x12, 0x1d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x9d, 0x02,
+	0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x3f, 0x0a, 0x05, 0x4e, 0x67, 0x69, 0x6e, 0x78, 0x12, 0x1b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f,
+	0x64, 0x2e, 0x4e, 0x67, 0x69, 0x6e, 0x78, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x41, 0x0a, 0x06, 0x50, 0x68, 0x70, 0x46, 0x70, 0x6d, 0x12, 0x1c, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x6f, 0x64, 0x2e, 0x50, 0x68, 0x70, 0x46, 0x70, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f,
+	0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x4a, 0x0a, 0x0d, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x44, 0x61, 0x65, 0x6d,
+	0x6f, 0x6e, 0x12, 0x1c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x55, 0x70, 0x67, 0x72,
+	0x61, 0x64, 0x65, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x42, 0x11, 0x5a,
+	0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x6f, 0x64,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_internal_nitrod_nitrod_proto_rawDescOnce sync.Once
+	file_internal_nitrod_nitrod_proto_rawDescData = file_internal_nitrod_nitrod_proto_rawDesc
+)
+
+func file_internal_nitrod_nitrod_proto_rawDescGZIP() []byte {
+	file_internal_nitrod_nitrod_proto_rawDescOnce.Do(func() {
+		file_internal_nitrod_nitrod_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_nitrod_nitrod_proto_rawDescData)
+	})
+	return file_internal_nitrod_nitrod_proto_rawDescData
+}
+
+var file_internal_nitrod_nitrod_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_internal_nitrod_nitrod_proto_msgTypes = make([]protoimpl.MessageInfo, 56)
+var file_internal_nitrod_nitrod_proto_goTypes = []interface{}{
+	(ImportStage)(0),                   // 0: nitrod.ImportStage
+	(PhpSapi)(0),                       // 1: nitrod.PhpSapi
+	(PhpIniValueType)(0),               // 2: nitrod.PhpIniValueType
+	(XdebugStart)(0),                   // 3: nitrod.XdebugStart
+	(JobState)(0),                      // 4: nitrod.JobState
+	(ServiceAction)(0),                 // 5: nitrod.ServiceAction
+	(*ChangePhpIniSettingRequest)(nil), // 6: nitrod.ChangePhpIniSettingRequest
+	(*ResetPhpIniSettingRequest)(nil),  // 7: nitrod.ResetPhpIniSettingRequest
+	(*DisableXdebugRequest)(nil),       // 8: nitrod.DisableXdebugRequest
+	(*EnableXdebugRequest)(nil),        // 9: nitrod.EnableXdebugRequest
+	(*ConfigureXdebugRequest)(nil),     // 10: nitrod.ConfigureXdebugRequest
+	(*XdebugSettingsResponse)(nil),     // 11: nitrod.XdebugSettingsResponse
+	(*XdebugSetting)(nil),              // 12: nitrod.XdebugSetting
+	(*GetPhpIniSettingRequest)(nil),    // 13: nitrod.GetPhpIniSettingRequest
+	(*PhpFpmServiceRequest)(nil),       // 14: nitrod.PhpFpmServiceRequest
+	(*NginxServiceRequest)(nil),        // 15: nitrod.NginxServiceRequest
+	(*ImportDatabaseRequest)(nil),      // 16: nitrod.ImportDatabaseRequest
+	(*ImportDatabaseHeader)(nil),       // 17: nitrod.ImportDatabaseHeader
+	(*ImportDatabaseProgress)(nil),     // 18: nitrod.ImportDatabaseProgress
+	(*ListDatabasesRequest)(nil),       // 19: nitrod.ListDatabasesRequest
+	(*ListDatabasesResponse)(nil),      // 20: nitrod.ListDatabasesResponse
+	(*Database)(nil),                   // 21: nitrod.Database
+	(*CreateDatabaseRequest)(nil),      // 22: nitrod.CreateDatabaseRequest
+	(*DropDatabaseRequest)(nil),        // 23: nitrod.DropDatabaseRequest
+	(*RenameDatabaseRequest)(nil),      // 24: nitrod.RenameDatabaseRequest
+	(*SanitizeDatabaseRequest)(nil),    // 25: nitrod.SanitizeDatabaseRequest
+	(*SanitizeRule)(nil),               // 26: nitrod.SanitizeRule
+	(*SanitizeDatabaseResponse)(nil),   // 27: nitrod.SanitizeDatabaseResponse
+	(*SanitizedColumn)(nil),            // 28: nitrod.SanitizedColumn
+	(*QueryDatabaseRequest)(nil),       // 29: nitrod.QueryDatabaseRequest
+	(*QueryDatabaseResponse)(nil),      // 30: nitrod.QueryDatabaseResponse
+	(*QueryRow)(nil),                   // 31: nitrod.QueryRow
+	(*QueryValue)(nil),                 // 32: nitrod.QueryValue
+	(*ExecRequest)(nil),                // 33: nitrod.ExecRequest
+	(*ExecStart)(nil),                  // 34: nitrod.ExecStart
+	(*TerminalSize)(nil),               // 35: nitrod.TerminalSize
+	(*ExecResponse)(nil),               // 36: nitrod.ExecResponse
+	(*Job)(nil),                        // 37: nitrod.Job
+	(*StartJobRequest)(nil),            // 38: nitrod.StartJobRequest
+	(*InstallPackagesJob)(nil),         // 39: nitrod.InstallPackagesJob
+	(*UpgradePackagesJob)(nil),         // 40: nitrod.UpgradePackagesJob
+	(*GetJobRequest)(nil),              // 41: nitrod.GetJobRequest
+	(*ListJobsRequest)(nil),            // 42: nitrod.ListJobsRequest
+	(*ListJobsResponse)(nil),           // 43: nitrod.ListJobsResponse
+	(*WatchJobRequest)(nil),            // 44: nitrod.WatchJobRequest
+	(*WatchJobResponse)(nil),           // 45: nitrod.WatchJobResponse
+	(*CancelJobRequest)(nil),           // 46: nitrod.CancelJobRequest
+	(*ConfigureBackupsRequest)(nil),    // 47: nitrod.ConfigureBackupsRequest
+	(*BackupTarget)(nil),               // 48: nitrod.BackupTarget
+	(*CreateSnapshotRequest)(nil),      // 49: nitrod.CreateSnapshotRequest
+	(*ListSnapshotsRequest)(nil),       // 50: nitrod.ListSnapshotsRequest
+	(*ListSnapshotsResponse)(nil),      // 51: nitrod.ListSnapshotsResponse
+	(*Snapshot)(nil),                   // 52: nitrod.Snapshot
+	(*RollbackSnapshotRequest)(nil),    // 53: nitrod.RollbackSnapshotRequest
+	(*RemoveSnapshotRequest)(nil),      // 54: nitrod.RemoveSnapshotRequest
+	(*PhpIniValue)(nil),                // 55: nitrod.PhpIniValue
+	(*PhpIniSettingResponse)(nil),      // 56: nitrod.PhpIniSettingResponse
+	(*VersionRequest)(nil),             // 57: nitrod.VersionRequest
+	(*VersionResponse)(nil),            // 58: nitrod.VersionResponse
+	(*UpgradeDaemonRequest)(nil),       // 59: nitrod.UpgradeDaemonRequest
+	(*UpgradeDaemonHeader)(nil),        // 60: nitrod.UpgradeDaemonHeader
+	(*ServiceResponse)(nil),            // 61: nitrod.ServiceResponse
+}
+var file_internal_nitrod_nitrod_proto_depIdxs = []int32{
+	1,  // 0: nitrod.ChangePhpIniSettingRequest.sapi:type_name -> nitrod.PhpSapi
+	1,  // 1: nitrod.ResetPhpIniSettingRequest.sapi:type_name -> nitrod.PhpSapi
+	3,  // 2: nitrod.ConfigureXdebugRequest.start:type_name -> nitrod.XdebugStart
+	12, // 3: nitrod.XdebugSettingsResponse.settings:type_name -> nitrod.XdebugSetting
+	5,  // 4: nitrod.PhpFpmServiceRequest.action:type_name -> nitrod.ServiceAction
+	5,  // 5: nitrod.NginxServiceRequest.action:type_name -> nitrod.ServiceAction
+	17, // 6: nitrod.ImportDatabaseRequest.header:type_name -> nitrod.ImportDatabaseHeader
+	0,  // 7: nitrod.ImportDatabaseProgress.stage:type_name -> nitrod.ImportStage
+	21, // 8: nitrod.ListDatabasesResponse.databases:type_name -> nitrod.Database
+	26, // 9: nitrod.SanitizeDatabaseRequest.rules:type_name -> nitrod.SanitizeRule
+	28, // 10: nitrod.SanitizeDatabaseResponse.columns:type_name -> nitrod.SanitizedColumn
+	31, // 11: nitrod.QueryDatabaseResponse.rows:type_name -> nitrod.QueryRow
+	32, // 12: nitrod.QueryRow.values:type_name -> nitrod.QueryValue
+	34, // 13: nitrod.ExecRequest.start:type_name -> nitrod.ExecStart
+	35, // 14: nitrod.ExecRequest.resize:type_name -> nitrod.TerminalSize
+	35, // 15: nitrod.ExecStart.size:type_name -> nitrod.TerminalSize
+	4,  // 16: nitrod.Job.state:type_name -> nitrod.JobState
+	39, // 17: nitrod.StartJobRequest.installPackages:type_name -> nitrod.InstallPackagesJob
+	40, // 18: nitrod.StartJobRequest.upgradePackages:type_name -> nitrod.UpgradePackagesJob
+	37, // 19: nitrod.ListJobsResponse.jobs:type_name -> nitrod.Job
+	37, // 20: nitrod.WatchJobResponse.job:type_name -> nitrod.Job
+	48, // 21: nitrod.ConfigureBackupsRequest.targets:type_name -> nitrod.BackupTarget
+	52, // 22: nitrod.ListSnapshotsResponse.snapshots:type_name -> nitrod.Snapshot
+	2,  // 23: nitrod.PhpIniValue.type:type_name -> nitrod.PhpIniValueType
+	55, // 24: nitrod.PhpIniSettingResponse.fpm:type_name -> nitrod.PhpIniValue
+	55, // 25: nitrod.PhpIniSettingResponse.cli:type_name -> nitrod.PhpIniValue
+	60, // 26: nitrod.UpgradeDaemonRequest.header:type_name -> nitrod.UpgradeDaemonHeader
+	6,  // 27: nitrod.NitroService.PhpIniSettings:input_type -> nitrod.ChangePhpIniSettingRequest
+	13, // 28: nitrod.NitroService.GetPhpIniSetting:input_type -> nitrod.GetPhpIniSettingRequest
+	7,  // 29: nitrod.NitroService.ResetPhpIniSetting:input_type -> nitrod.ResetPhpIniSettingRequest
+	8,  // 30: nitrod.NitroService.DisableXdebug:input_type -> nitrod.DisableXdebugRequest
+	9,  // 31: nitrod.NitroService.EnableXdebug:input_type -> nitrod.EnableXdebugRequest
+	10, // 32: nitrod.NitroService.ConfigureXdebug:input_type -> nitrod.ConfigureXdebugRequest
+	16, // 33: nitrod.NitroService.ImportDatabase:input_type -> nitrod.ImportDatabaseRequest
+	19, // 34: nitrod.NitroService.ListDatabases:input_type -> nitrod.ListDatabasesRequest
+	22, // 35: nitrod.NitroService.CreateDatabase:input_type -> nitrod.CreateDatabaseRequest
+	23, // 36: nitrod.NitroService.DropDatabase:input_type -> nitrod.DropDatabaseRequest
+	24, // 37: nitrod.NitroService.RenameDatabase:input_type -> nitrod.RenameDatabaseRequest
+	25, // 38: nitrod.NitroService.SanitizeDatabase:input_type -> nitrod.SanitizeDatabaseRequest
+	29, // 39: nitrod.NitroService.QueryDatabase:input_type -> nitrod.QueryDatabaseRequest
+	33, // 40: nitrod.NitroService.Exec:input_type -> nitrod.ExecRequest
+	38, // 41: nitrod.NitroService.StartJob:input_type -> nitrod.StartJobRequest
+	41, // 42: nitrod.NitroService.GetJob:input_type -> nitrod.GetJobRequest
+	42, // 43: nitrod.NitroService.ListJobs:input_type -> nitrod.ListJobsRequest
+	44, // 44: nitrod.NitroService.WatchJob:input_type -> nitrod.WatchJobRequest
+	46, // 45: nitrod.NitroService.CancelJob:input_type -> nitrod.CancelJobRequest
+	47, // 46: nitrod.NitroService.ConfigureBackups:input_type -> nitrod.ConfigureBackupsRequest
+	49, // 47: nitrod.NitroService.CreateSnapshot:input_type -> nitrod.CreateSnapshotRequest
+	50, // 48: nitrod.NitroService.ListSnapshots:input_type -> nitrod.ListSnapshotsRequest
+	53, // 49: nitrod.NitroService.RollbackSnapshot:input_type -> nitrod.RollbackSnapshotRequest
+	54, // 50: nitrod.NitroService.RemoveSnapshot:input_type -> nitrod.RemoveSnapshotRequest
+	15, // 51: nitrod.SystemService.Nginx:input_type -> nitrod.NginxServiceRequest
+	14, // 52: nitrod.SystemService.PhpFpm:input_type -> nitrod.PhpFpmServiceRequest
+	57, // 53: nitrod.SystemService.Version:input_type -> nitrod.VersionRequest
+	59, // 54: nitrod.SystemService.UpgradeDaemon:input_type -> nitrod.UpgradeDaemonRequest
+	61, // 55: nitrod.NitroService.PhpIniSettings:output_type -> nitrod.ServiceResponse
+	56, // 56: nitrod.NitroService.GetPhpIniSetting:output_type -> nitrod.PhpIniSettingResponse
+	61, // 57: nitrod.NitroService.ResetPhpIniSetting:output_type -> nitrod.ServiceResponse
+	61, // 58: nitrod.NitroService.DisableXdebug:output_type -> nitrod.ServiceResponse
+	61, // 59: nitrod.NitroService.EnableXdebug:output_type -> nitrod.ServiceResponse
+	11, // 60: nitrod.NitroService.ConfigureXdebug:output_type -> nitrod.XdebugSettingsResponse
+	18, // 61: nitrod.NitroService.ImportDatabase:output_type -> nitrod.ImportDatabaseProgress
+	20, // 62: nitrod.NitroService.ListDatabases:output_type -> nitrod.ListDatabasesResponse
+	61, // 63: nitrod.NitroService.CreateDatabase:output_type -> nitrod.ServiceResponse
+	61, // 64: nitrod.NitroService.DropDatabase:output_type -> nitrod.ServiceResponse
+	61, // 65: nitrod.NitroService.RenameDatabase:output_type -> nitrod.ServiceResponse
+	27, // 66: nitrod.NitroService.SanitizeDatabase:output_type -> nitrod.SanitizeDatabaseResponse
+	30, // 67: nitrod.NitroService.QueryDatabase:output_type -> nitrod.QueryDatabaseResponse
+	36, // 68: nitrod.NitroService.Exec:output_type -> nitrod.ExecResponse
+	37, // 69: nitrod.NitroService.StartJob:output_type -> nitrod.Job
+	37, // 70: nitrod.NitroService.GetJob:output_type -> nitrod.Job
+	43, // 71: nitrod.NitroService.ListJobs:output_type -> nitrod.ListJobsResponse
+	45, // 72: nitrod.NitroService.WatchJob:output_type -> nitrod.WatchJobResponse
+	37, // 73: nitrod.NitroService.CancelJob:output_type -> nitrod.Job
+	61, // 74: nitrod.NitroService.ConfigureBackups:output_type -> nitrod.ServiceResponse
+	61, // 75: nitrod.NitroService.CreateSnapshot:output_type -> nitrod.ServiceResponse
+	51, // 76: nitrod.NitroService.ListSnapshots:output_type -> nitrod.ListSnapshotsResponse
+	61, // 77: nitrod.NitroService.RollbackSnapshot:output_type -> nitrod.ServiceResponse
+	61, // 78: nitrod.NitroService.RemoveSnapshot:output_type -> nitrod.ServiceResponse
+	61, // 79: nitrod.SystemService.Nginx:output_type -> nitrod.ServiceResponse
+	61, // 80: nitrod.SystemService.PhpFpm:output_type -> nitrod.ServiceResponse
+	58, // 81: nitrod.SystemService.Version:output_type -> nitrod.VersionResponse
+	61, // 82: nitrod.SystemService.UpgradeDaemon:output_type -> nitrod.ServiceResponse
+	55, // [55:83] is the sub-list for method output_type
+	27, // [27:55] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_internal_nitrod_nitrod_proto_init() }
+func file_internal_nitrod_nitrod_proto_init() {
+	if File_internal_nitrod_nitrod_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internal_nitrod_nitrod_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangePhpIniSettingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResetPhpIniSettingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisableXdebugRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnableXdebugRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigureXdebugRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*XdebugSettingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*XdebugSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPhpIniSettingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PhpFpmServiceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NginxServiceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportDatabaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportDatabaseHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportDatabaseProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDatabasesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDatabasesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Database); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateDatabaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DropDatabaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenameDatabaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SanitizeDatabaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SanitizeRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SanitizeDatabaseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SanitizedColumn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryDatabaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryDatabaseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryRow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecStart); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TerminalSize); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Job); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstallPackagesJob); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpgradePackagesJob); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListJobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListJobsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchJobResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigureBackupsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupTarget); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSnapshotsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSnapshotsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RollbackSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PhpIniValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PhpIniSettingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpgradeDaemonRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpgradeDaemonHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_nitrod_nitrod_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServiceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_internal_nitrod_nitrod_proto_msgTypes[10].OneofWrappers = []interface{}{
+		(*ImportDatabaseRequest_Header)(nil),
+		(*ImportDatabaseRequest_Data)(nil),
+		(*ImportDatabaseRequest_Member)(nil),
+	}
+	file_internal_nitrod_nitrod_proto_msgTypes[27].OneofWrappers = []interface{}{
+		(*ExecRequest_Start)(nil),
+		(*ExecRequest_Stdin)(nil),
+		(*ExecRequest_Resize)(nil),
+		(*ExecRequest_CloseStdin)(nil),
+	}
+	file_internal_nitrod_nitrod_proto_msgTypes[30].OneofWrappers = []interface{}{
+		(*ExecResponse_Stdout)(nil),
+		(*ExecResponse_Stderr)(nil),
+		(*ExecResponse_ExitCode)(nil),
+	}
+	file_internal_nitrod_nitrod_proto_msgTypes[32].OneofWrappers = []interface{}{
+		(*StartJobRequest_InstallPackages)(nil),
+		(*StartJobRequest_UpgradePackages)(nil),
+	}
+	file_internal_nitrod_nitrod_proto_msgTypes[53].OneofWrappers = []interface{}{
+		(*UpgradeDaemonRequest_Header)(nil),
+		(*UpgradeDaemonRequest_Data)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_nitrod_nitrod_proto_rawDesc,
+			NumEnums:      6,
+			NumMessages:   56,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_internal_nitrod_nitrod_proto_goTypes,
+		DependencyIndexes: file_internal_nitrod_nitrod_proto_depIdxs,
+		EnumInfos:         file_internal_nitrod_nitrod_proto_enumTypes,
+		MessageInfos:      file_internal_nitrod_nitrod_proto_msgTypes,
+	}.Build()
+	File_internal_nitrod_nitrod_proto = out.File
+	file_internal_nitrod_nitrod_proto_rawDesc = nil
+	file_internal_nitrod_nitrod_proto_goTypes = nil
+	file_internal_nitrod_nitrod_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// NitroServiceClient is the client API for NitroService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NitroServiceClient interface {
+	PhpIniSettings(ctx context.Context, in *ChangePhpIniSettingRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+	GetPhpIniSetting(ctx context.Context, in *GetPhpIniSettingRequest, opts ...grpc.CallOption) (*PhpIniSettingResponse, error)
+	ResetPhpIniSetting(ctx context.Context, in *ResetPhpIniSettingRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+	DisableXdebug(ctx context.Context, in *DisableXdebugRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+	EnableXdebug(ctx context.Context, in *EnableXdebugRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+	ConfigureXdebug(ctx context.Context, in *ConfigureXdebugRequest, opts ...grpc.CallOption) (*XdebugSettingsResponse, error)
+	ImportDatabase(ctx context.Context, opts ...grpc.CallOption) (NitroService_ImportDatabaseClient, error)
+	ListDatabases(ctx context.Context, in *ListDatabasesRequest, opts ...grpc.CallOption) (*ListDatabasesResponse, error)
+	CreateDatabase(ctx context.Context, in *CreateDatabaseRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+	DropDatabase(ctx context.Context, in *DropDatabaseRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+	RenameDatabase(ctx context.Context, in *RenameDatabaseRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+	SanitizeDatabase(ctx context.Context, in *SanitizeDatabaseRequest, opts ...grpc.CallOption) (*SanitizeDatabaseResponse, error)
+	QueryDatabase(ctx context.Context, in *QueryDatabaseRequest, opts ...grpc.CallOption) (*QueryDatabaseResponse, error)
+	Exec(ctx context.Context, opts ...grpc.CallOption) (NitroService_ExecClient, error)
+	StartJob(ctx context.Context, in *StartJobRequest, opts ...grpc.CallOption) (*Job, error)
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error)
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	WatchJob(ctx context.Context, in *WatchJobRequest, opts ...grpc.CallOption) (NitroService_WatchJobClient, error)
+	CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Job, error)
+	ConfigureBackups(ctx context.Context, in *ConfigureBackupsRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+	CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+	ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error)
+	RollbackSnapshot(ctx context.Context, in *RollbackSnapshotRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+	RemoveSnapshot(ctx context.Context, in *RemoveSnapshotRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+}
+
+type nitroServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNitroServiceClient(cc grpc.ClientConnInterface) NitroServiceClient {
+	return &nitroServiceClient{cc}
+}
+
+func (c *nitroServiceClient) PhpIniSettings(ctx context.Context, in *ChangePhpIniSettingRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/PhpIniSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) GetPhpIniSetting(ctx context.Context, in *GetPhpIniSettingRequest, opts ...grpc.CallOption) (*PhpIniSettingResponse, error) {
+	out := new(PhpIniSettingResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/GetPhpIniSetting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) ResetPhpIniSetting(ctx context.Context, in *ResetPhpIniSettingRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/ResetPhpIniSetting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) DisableXdebug(ctx context.Context, in *DisableXdebugRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/DisableXdebug", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) EnableXdebug(ctx context.Context, in *EnableXdebugRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/EnableXdebug", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) ConfigureXdebug(ctx context.Context, in *ConfigureXdebugRequest, opts ...grpc.CallOption) (*XdebugSettingsResponse, error) {
+	out := new(XdebugSettingsResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/ConfigureXdebug", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) ImportDatabase(ctx context.Context, opts ...grpc.CallOption) (NitroService_ImportDatabaseClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NitroService_serviceDesc.Streams[0], "/nitrod.NitroService/ImportDatabase", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nitroServiceImportDatabaseClient{stream}
+	return x, nil
+}
+
+type NitroService_ImportDatabaseClient interface {
+	Send(*ImportDatabaseRequest) error
+	Recv() (*ImportDatabaseProgress, error)
+	grpc.ClientStream
+}
+
+type nitroServiceImportDatabaseClient struct {
+	grpc.ClientStream
+}
+
+func (x *nitroServiceImportDatabaseClient) Send(m *ImportDatabaseRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *nitroServiceImportDatabaseClient) Recv() (*ImportDatabaseProgress, error) {
+	m := new(ImportDatabaseProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *nitroServiceClient) ListDatabases(ctx context.Context, in *ListDatabasesRequest, opts ...grpc.CallOption) (*ListDatabasesResponse, error) {
+	out := new(ListDatabasesResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/ListDatabases", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) CreateDatabase(ctx context.Context, in *CreateDatabaseRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/CreateDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) DropDatabase(ctx context.Context, in *DropDatabaseRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/DropDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) RenameDatabase(ctx context.Context, in *RenameDatabaseRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/RenameDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) SanitizeDatabase(ctx context.Context, in *SanitizeDatabaseRequest, opts ...grpc.CallOption) (*SanitizeDatabaseResponse, error) {
+	out := new(SanitizeDatabaseResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/SanitizeDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) QueryDatabase(ctx context.Context, in *QueryDatabaseRequest, opts ...grpc.CallOption) (*QueryDatabaseResponse, error) {
+	out := new(QueryDatabaseResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/QueryDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) Exec(ctx context.Context, opts ...grpc.CallOption) (NitroService_ExecClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NitroService_serviceDesc.Streams[1], "/nitrod.NitroService/Exec", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nitroServiceExecClient{stream}
+	return x, nil
+}
+
+type NitroService_ExecClient interface {
+	Send(*ExecRequest) error
+	Recv() (*ExecResponse, error)
+	grpc.ClientStream
+}
+
+type nitroServiceExecClient struct {
+	grpc.ClientStream
+}
+
+func (x *nitroServiceExecClient) Send(m *ExecRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *nitroServiceExecClient) Recv() (*ExecResponse, error) {
+	m := new(ExecResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *nitroServiceClient) StartJob(ctx context.Context, in *StartJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/StartJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/GetJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/ListJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) WatchJob(ctx context.Context, in *WatchJobRequest, opts ...grpc.CallOption) (NitroService_WatchJobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NitroService_serviceDesc.Streams[2], "/nitrod.NitroService/WatchJob", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nitroServiceWatchJobClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NitroService_WatchJobClient interface {
+	Recv() (*WatchJobResponse, error)
+	grpc.ClientStream
+}
+
+type nitroServiceWatchJobClient struct {
+	grpc.ClientStream
+}
+
+func (x *nitroServiceWatchJobClient) Recv() (*WatchJobResponse, error) {
+	m := new(WatchJobResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *nitroServiceClient) CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/CancelJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) ConfigureBackups(ctx context.Context, in *ConfigureBackupsRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/ConfigureBackups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/CreateSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error) {
+	out := new(ListSnapshotsResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/ListSnapshots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) RollbackSnapshot(ctx context.Context, in *RollbackSnapshotRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/RollbackSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nitroServiceClient) RemoveSnapshot(ctx context.Context, in *RemoveSnapshotRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
+	out := new(ServiceResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.NitroService/RemoveSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NitroServiceServer is the server API for NitroService service.
+type NitroServiceServer interface {
+	PhpIniSettings(context.Context, *ChangePhpIniSettingRequest) (*ServiceResponse, error)
+	GetPhpIniSetting(context.Context, *GetPhpIniSettingRequest) (*PhpIniSettingResponse, error)
+	ResetPhpIniSetting(context.Context, *ResetPhpIniSettingRequest) (*ServiceResponse, error)
+	DisableXdebug(context.Context, *DisableXdebugRequest) (*ServiceResponse, error)
+	EnableXdebug(context.Context, *EnableXdebugRequest) (*ServiceResponse, error)
+	ConfigureXdebug(context.Context, *ConfigureXdebugRequest) (*XdebugSettingsResponse, error)
+	ImportDatabase(NitroService_ImportDatabaseServer) error
+	ListDatabases(context.Context, *ListDatabasesRequest) (*ListDatabasesResponse, error)
+	CreateDatabase(context.Context, *CreateDatabaseRequest) (*ServiceResponse, error)
+	DropDatabase(context.Context, *DropDatabaseRequest) (*ServiceResponse, error)
+	RenameDatabase(context.Context, *RenameDatabaseRequest) (*ServiceResponse, error)
+	SanitizeDatabase(context.Context, *SanitizeDatabaseRequest) (*SanitizeDatabaseResponse, error)
+	QueryDatabase(context.Context, *QueryDatabaseRequest) (*QueryDatabaseResponse, error)
+	Exec(NitroService_ExecServer) error
+	StartJob(context.Context, *StartJobRequest) (*Job, error)
+	GetJob(context.Context, *GetJobRequest) (*Job, error)
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	WatchJob(*WatchJobRequest, NitroService_WatchJobServer) error
+	CancelJob(context.Context, *CancelJobRequest) (*Job, error)
+	ConfigureBackups(context.Context, *ConfigureBackupsRequest) (*ServiceResponse, error)
+	CreateSnapshot(context.Context, *CreateSnapshotRequest) (*ServiceResponse, error)
+	ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsResponse, error)
+	RollbackSnapshot(context.Context, *RollbackSnapshotRequest) (*ServiceResponse, error)
+	RemoveSnapshot(context.Context, *RemoveSnapshotRequest) (*ServiceResponse, error)
+}
+
+// UnimplementedNitroServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedNitroServiceServer struct {
+}
+
+func (*UnimplementedNitroServiceServer) PhpIniSettings(context.Context, *ChangePhpIniSettingRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PhpIniSettings not implemented")
+}
+func (*UnimplementedNitroServiceServer) GetPhpIniSetting(context.Context, *GetPhpIniSettingRequest) (*PhpIniSettingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPhpIniSetting not implemented")
+}
+func (*UnimplementedNitroServiceServer) ResetPhpIniSetting(context.Context, *ResetPhpIniSettingRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPhpIniSetting not implemented")
+}
+func (*UnimplementedNitroServiceServer) DisableXdebug(context.Context, *DisableXdebugRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableXdebug not implemented")
+}
+func (*UnimplementedNitroServiceServer) EnableXdebug(context.Context, *EnableXdebugRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableXdebug not implemented")
+}
+func (*UnimplementedNitroServiceServer) ConfigureXdebug(context.Context, *ConfigureXdebugRequest) (*XdebugSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigureXdebug not implemented")
+}
+func (*UnimplementedNitroServiceServer) ImportDatabase(NitroService_ImportDatabaseServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportDatabase not implemented")
+}
+func (*UnimplementedNitroServiceServer) ListDatabases(context.Context, *ListDatabasesRequest) (*ListDatabasesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDatabases not implemented")
+}
+func (*UnimplementedNitroServiceServer) CreateDatabase(context.Context, *CreateDatabaseRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDatabase not implemented")
+}
+func (*UnimplementedNitroServiceServer) DropDatabase(context.Context, *DropDatabaseRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropDatabase not implemented")
+}
+func (*UnimplementedNitroServiceServer) RenameDatabase(context.Context, *RenameDatabaseRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameDatabase not implemented")
+}
+func (*UnimplementedNitroServiceServer) SanitizeDatabase(context.Context, *SanitizeDatabaseRequest) (*SanitizeDatabaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SanitizeDatabase not implemented")
+}
+func (*UnimplementedNitroServiceServer) QueryDatabase(context.Context, *QueryDatabaseRequest) (*QueryDatabaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryDatabase not implemented")
+}
+func (*UnimplementedNitroServiceServer) Exec(NitroService_ExecServer) error {
+	return status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (*UnimplementedNitroServiceServer) StartJob(context.Context, *StartJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartJob not implemented")
+}
+func (*UnimplementedNitroServiceServer) GetJob(context.Context, *GetJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (*UnimplementedNitroServiceServer) ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (*UnimplementedNitroServiceServer) WatchJob(*WatchJobRequest, NitroService_WatchJobServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchJob not implemented")
+}
+func (*UnimplementedNitroServiceServer) CancelJob(context.Context, *CancelJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelJob not implemented")
+}
+func (*UnimplementedNitroServiceServer) ConfigureBackups(context.Context, *ConfigureBackupsRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigureBackups not implemented")
+}
+func (*UnimplementedNitroServiceServer) CreateSnapshot(context.Context, *CreateSnapshotRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSnapshot not implemented")
+}
+func (*UnimplementedNitroServiceServer) ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSnapshots not implemented")
+}
+func (*UnimplementedNitroServiceServer) RollbackSnapshot(context.Context, *RollbackSnapshotRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackSnapshot not implemented")
+}
+func (*UnimplementedNitroServiceServer) RemoveSnapshot(context.Context, *RemoveSnapshotRequest) (*ServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSnapshot not implemented")
+}
+
+func RegisterNitroServiceServer(s *grpc.Server, srv NitroServiceServer) {
+	s.RegisterService(&_NitroService_serviceDesc, srv)
+}
+
+func _NitroService_PhpIniSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePhpIniSettingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_internal_nitrod_nitrod_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   8,
-			NumExtensions: 0,
-			NumServices:   2,
-		},
-		GoTypes:           file_internal_nitrod_nitrod_proto_goTypes,
-		DependencyIndexes: file_internal_nitrod_nitrod_proto_depIdxs,
-		EnumInfos:         file_internal_nitrod_nitrod_proto_enumTypes,
-		MessageInfos:      file_internal_nitrod_nitrod_proto_msgTypes,
-	}.Build()
-	File_internal_nitrod_nitrod_proto = out.File
-	file_internal_nitrod_nitrod_proto_rawDesc = nil
-	file_internal_nitrod_nitrod_proto_goTypes = nil
-	file_internal_nitrod_nitrod_proto_depIdxs = nil
+	if interceptor == nil {
+		return srv.(NitroServiceServer).PhpIniSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/PhpIniSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).PhpIniSettings(ctx, req.(*ChangePhpIniSettingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+func _NitroService_GetPhpIniSetting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPhpIniSettingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NitroServiceServer).GetPhpIniSetting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/GetPhpIniSetting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).GetPhpIniSetting(ctx, req.(*GetPhpIniSettingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func _NitroService_ResetPhpIniSetting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPhpIniSettingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NitroServiceServer).ResetPhpIniSetting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/ResetPhpIniSetting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).ResetPhpIniSetting(ctx, req.(*ResetPhpIniSettingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// NitroServiceClient is the client API for NitroService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type NitroServiceClient interface {
-	PhpIniSettings(ctx context.Context, in *ChangePhpIniSettingRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
-	GetPhpIniSetting(ctx context.Context, in *GetPhpIniSettingRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
-	DisableXdebug(ctx context.Context, in *DisableXdebugRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
-	EnableXdebug(ctx context.Context, in *EnableXdebugRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
-	ImportDatabase(ctx context.Context, opts ...grpc.CallOption) (NitroService_ImportDatabaseClient, error)
+func _NitroService_DisableXdebug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableXdebugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NitroServiceServer).DisableXdebug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/DisableXdebug",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).DisableXdebug(ctx, req.(*DisableXdebugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type nitroServiceClient struct {
-	cc grpc.ClientConnInterface
+func _NitroService_EnableXdebug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableXdebugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NitroServiceServer).EnableXdebug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/EnableXdebug",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).EnableXdebug(ctx, req.(*EnableXdebugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewNitroServiceClient(cc grpc.ClientConnInterface) NitroServiceClient {
-	return &nitroServiceClient{cc}
+func _NitroService_ConfigureXdebug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigureXdebugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NitroServiceServer).ConfigureXdebug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/ConfigureXdebug",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).ConfigureXdebug(ctx, req.(*ConfigureXdebugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *nitroServiceClient) PhpIniSettings(ctx context.Context, in *ChangePhpIniSettingRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
-	out := new(ServiceResponse)
-	err := c.cc.Invoke(ctx, "/nitrod.NitroService/PhpIniSettings", in, out, opts...)
-	if err != nil {
+func _NitroService_ImportDatabase_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NitroServiceServer).ImportDatabase(&nitroServiceImportDatabaseServer{stream})
+}
+
+type NitroService_ImportDatabaseServer interface {
+	Send(*ImportDatabaseProgress) error
+	Recv() (*ImportDatabaseRequest, error)
+	grpc.ServerStream
+}
+
+type nitroServiceImportDatabaseServer struct {
+	grpc.ServerStream
+}
+
+func (x *nitroServiceImportDatabaseServer) Send(m *ImportDatabaseProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *nitroServiceImportDatabaseServer) Recv() (*ImportDatabaseRequest, error) {
+	m := new(ImportDatabaseRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *nitroServiceClient) GetPhpIniSetting(ctx context.Context, in *GetPhpIniSettingRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
-	out := new(ServiceResponse)
-	err := c.cc.Invoke(ctx, "/nitrod.NitroService/GetPhpIniSetting", in, out, opts...)
-	if err != nil {
+func _NitroService_ListDatabases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDatabasesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NitroServiceServer).ListDatabases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/ListDatabases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).ListDatabases(ctx, req.(*ListDatabasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *nitroServiceClient) DisableXdebug(ctx context.Context, in *DisableXdebugRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
-	out := new(ServiceResponse)
-	err := c.cc.Invoke(ctx, "/nitrod.NitroService/DisableXdebug", in, out, opts...)
-	if err != nil {
+func _NitroService_CreateDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDatabaseRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NitroServiceServer).CreateDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/CreateDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).CreateDatabase(ctx, req.(*CreateDatabaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *nitroServiceClient) EnableXdebug(ctx context.Context, in *EnableXdebugRequest, opts ...grpc.CallOption) (*ServiceResponse, error) {
-	out := new(ServiceResponse)
-	err := c.cc.Invoke(ctx, "/nitrod.NitroService/EnableXdebug", in, out, opts...)
-	if err != nil {
+func _NitroService_DropDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DropDatabaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NitroServiceServer).DropDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/DropDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).DropDatabase(ctx, req.(*DropDatabaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NitroService_RenameDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameDatabaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NitroServiceServer).RenameDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/RenameDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).RenameDatabase(ctx, req.(*RenameDatabaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NitroService_SanitizeDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SanitizeDatabaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NitroServiceServer).SanitizeDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/SanitizeDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).SanitizeDatabase(ctx, req.(*SanitizeDatabaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NitroService_QueryDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDatabaseRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NitroServiceServer).QueryDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/QueryDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).QueryDatabase(ctx, req.(*QueryDatabaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *nitroServiceClient) ImportDatabase(ctx context.Context, opts ...grpc.CallOption) (NitroService_ImportDatabaseClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_NitroService_serviceDesc.Streams[0], "/nitrod.NitroService/ImportDatabase", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &nitroServiceImportDatabaseClient{stream}
-	return x, nil
+func _NitroService_Exec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NitroServiceServer).Exec(&nitroServiceExecServer{stream})
 }
 
-type NitroService_ImportDatabaseClient interface {
-	Send(*ImportDatabaseRequest) error
-	CloseAndRecv() (*ServiceResponse, error)
-	grpc.ClientStream
+type NitroService_ExecServer interface {
+	Send(*ExecResponse) error
+	Recv() (*ExecRequest, error)
+	grpc.ServerStream
 }
 
-type nitroServiceImportDatabaseClient struct {
-	grpc.ClientStream
+type nitroServiceExecServer struct {
+	grpc.ServerStream
 }
 
-func (x *nitroServiceImportDatabaseClient) Send(m *ImportDatabaseRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (x *nitroServiceExecServer) Send(m *ExecResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (x *nitroServiceImportDatabaseClient) CloseAndRecv() (*ServiceResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(ServiceResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func (x *nitroServiceExecServer) Recv() (*ExecRequest, error) {
+	m := new(ExecRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-// NitroServiceServer is the server API for NitroService service.
-type NitroServiceServer interface {
-	PhpIniSettings(context.Context, *ChangePhpIniSettingRequest) (*ServiceResponse, error)
-	GetPhpIniSetting(context.Context, *GetPhpIniSettingRequest) (*ServiceResponse, error)
-	DisableXdebug(context.Context, *DisableXdebugRequest) (*ServiceResponse, error)
-	EnableXdebug(context.Context, *EnableXdebugRequest) (*ServiceResponse, error)
-	ImportDatabase(NitroService_ImportDatabaseServer) error
+func _NitroService_StartJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NitroServiceServer).StartJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/StartJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).StartJob(ctx, req.(*StartJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedNitroServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedNitroServiceServer struct {
+func _NitroService_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NitroServiceServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/GetJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (*UnimplementedNitroServiceServer) PhpIniSettings(context.Context, *ChangePhpIniSettingRequest) (*ServiceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PhpIniSettings not implemented")
-}
-func (*UnimplementedNitroServiceServer) GetPhpIniSetting(context.Context, *GetPhpIniSettingRequest) (*ServiceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPhpIniSetting not implemented")
+func _NitroService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NitroServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/ListJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedNitroServiceServer) DisableXdebug(context.Context, *DisableXdebugRequest) (*ServiceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DisableXdebug not implemented")
+
+func _NitroService_WatchJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchJobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NitroServiceServer).WatchJob(m, &nitroServiceWatchJobServer{stream})
 }
-func (*UnimplementedNitroServiceServer) EnableXdebug(context.Context, *EnableXdebugRequest) (*ServiceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EnableXdebug not implemented")
+
+type NitroService_WatchJobServer interface {
+	Send(*WatchJobResponse) error
+	grpc.ServerStream
 }
-func (*UnimplementedNitroServiceServer) ImportDatabase(NitroService_ImportDatabaseServer) error {
-	return status.Errorf(codes.Unimplemented, "method ImportDatabase not implemented")
+
+type nitroServiceWatchJobServer struct {
+	grpc.ServerStream
 }
 
-func RegisterNitroServiceServer(s *grpc.Server, srv NitroServiceServer) {
-	s.RegisterService(&_NitroService_serviceDesc, srv)
+func (x *nitroServiceWatchJobServer) Send(m *WatchJobResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func _NitroService_PhpIniSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChangePhpIniSettingRequest)
+func _NitroService_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelJobRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NitroServiceServer).PhpIniSettings(ctx, in)
+		return srv.(NitroServiceServer).CancelJob(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/nitrod.NitroService/PhpIniSettings",
+		FullMethod: "/nitrod.NitroService/CancelJob",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NitroServiceServer).PhpIniSettings(ctx, req.(*ChangePhpIniSettingRequest))
+		return srv.(NitroServiceServer).CancelJob(ctx, req.(*CancelJobRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NitroService_GetPhpIniSetting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetPhpIniSettingRequest)
+func _NitroService_ConfigureBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigureBackupsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NitroServiceServer).GetPhpIniSetting(ctx, in)
+		return srv.(NitroServiceServer).ConfigureBackups(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/nitrod.NitroService/GetPhpIniSetting",
+		FullMethod: "/nitrod.NitroService/ConfigureBackups",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NitroServiceServer).GetPhpIniSetting(ctx, req.(*GetPhpIniSettingRequest))
+		return srv.(NitroServiceServer).ConfigureBackups(ctx, req.(*ConfigureBackupsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NitroService_DisableXdebug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DisableXdebugRequest)
+func _NitroService_CreateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSnapshotRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NitroServiceServer).DisableXdebug(ctx, in)
+		return srv.(NitroServiceServer).CreateSnapshot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/nitrod.NitroService/DisableXdebug",
+		FullMethod: "/nitrod.NitroService/CreateSnapshot",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NitroServiceServer).DisableXdebug(ctx, req.(*DisableXdebugRequest))
+		return srv.(NitroServiceServer).CreateSnapshot(ctx, req.(*CreateSnapshotRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NitroService_EnableXdebug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EnableXdebugRequest)
+func _NitroService_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSnapshotsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NitroServiceServer).EnableXdebug(ctx, in)
+		return srv.(NitroServiceServer).ListSnapshots(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/nitrod.NitroService/EnableXdebug",
+		FullMethod: "/nitrod.NitroService/ListSnapshots",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NitroServiceServer).EnableXdebug(ctx, req.(*EnableXdebugRequest))
+		return srv.(NitroServiceServer).ListSnapshots(ctx, req.(*ListSnapshotsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NitroService_ImportDatabase_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(NitroServiceServer).ImportDatabase(&nitroServiceImportDatabaseServer{stream})
-}
-
-type NitroService_ImportDatabaseServer interface {
-	SendAndClose(*ServiceResponse) error
-	Recv() (*ImportDatabaseRequest, error)
-	grpc.ServerStream
-}
-
-type nitroServiceImportDatabaseServer struct {
-	grpc.ServerStream
-}
-
-func (x *nitroServiceImportDatabaseServer) SendAndClose(m *ServiceResponse) error {
-	return x.ServerStream.SendMsg(m)
+func _NitroService_RollbackSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NitroServiceServer).RollbackSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/RollbackSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).RollbackSnapshot(ctx, req.(*RollbackSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *nitroServiceImportDatabaseServer) Recv() (*ImportDatabaseRequest, error) {
-	m := new(ImportDatabaseRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func _NitroService_RemoveSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSnapshotRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(NitroServiceServer).RemoveSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.NitroService/RemoveSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NitroServiceServer).RemoveSnapshot(ctx, req.(*RemoveSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
 var _NitroService_serviceDesc = grpc.ServiceDesc{
@@ -1115,6 +6307,10 @@ var _NitroService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetPhpIniSetting",
 			Handler:    _NitroService_GetPhpIniSetting_Handler,
 		},
+		{
+			MethodName: "ResetPhpIniSetting",
+			Handler:    _NitroService_ResetPhpIniSetting_Handler,
+		},
 		{
 			MethodName: "DisableXdebug",
 			Handler:    _NitroService_DisableXdebug_Handler,
@@ -1123,13 +6319,89 @@ var _NitroService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "EnableXdebug",
 			Handler:    _NitroService_EnableXdebug_Handler,
 		},
+		{
+			MethodName: "ConfigureXdebug",
+			Handler:    _NitroService_ConfigureXdebug_Handler,
+		},
+		{
+			MethodName: "ListDatabases",
+			Handler:    _NitroService_ListDatabases_Handler,
+		},
+		{
+			MethodName: "CreateDatabase",
+			Handler:    _NitroService_CreateDatabase_Handler,
+		},
+		{
+			MethodName: "DropDatabase",
+			Handler:    _NitroService_DropDatabase_Handler,
+		},
+		{
+			MethodName: "RenameDatabase",
+			Handler:    _NitroService_RenameDatabase_Handler,
+		},
+		{
+			MethodName: "SanitizeDatabase",
+			Handler:    _NitroService_SanitizeDatabase_Handler,
+		},
+		{
+			MethodName: "QueryDatabase",
+			Handler:    _NitroService_QueryDatabase_Handler,
+		},
+		{
+			MethodName: "StartJob",
+			Handler:    _NitroService_StartJob_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _NitroService_GetJob_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _NitroService_ListJobs_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _NitroService_CancelJob_Handler,
+		},
+		{
+			MethodName: "ConfigureBackups",
+			Handler:    _NitroService_ConfigureBackups_Handler,
+		},
+		{
+			MethodName: "CreateSnapshot",
+			Handler:    _NitroService_CreateSnapshot_Handler,
+		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _NitroService_ListSnapshots_Handler,
+		},
+		{
+			MethodName: "RollbackSnapshot",
+			Handler:    _NitroService_RollbackSnapshot_Handler,
+		},
+		{
+			MethodName: "RemoveSnapshot",
+			Handler:    _NitroService_RemoveSnapshot_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "ImportDatabase",
 			Handler:       _NitroService_ImportDatabase_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Exec",
+			Handler:       _NitroService_Exec_Handler,
+			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "WatchJob",
+			Handler:       _NitroService_WatchJob_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "internal/nitrod/nitrod.proto",
 }
@@ -1140,6 +6412,8 @@ var _NitroService_serviceDesc = grpc.ServiceDesc{
 type SystemServiceClient interface {
 	Nginx(ctx context.Context, in *NginxServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
 	PhpFpm(ctx context.Context, in *PhpFpmServiceRequest, opts ...grpc.CallOption) (*ServiceResponse, error)
+	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
+	UpgradeDaemon(ctx context.Context, opts ...grpc.CallOption) (SystemService_UpgradeDaemonClient, error)
 }
 
 type systemServiceClient struct {
@@ -1168,10 +6442,55 @@ func (c *systemServiceClient) PhpFpm(ctx context.Context, in *PhpFpmServiceReque
 	return out, nil
 }
 
+func (c *systemServiceClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error) {
+	out := new(VersionResponse)
+	err := c.cc.Invoke(ctx, "/nitrod.SystemService/Version", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemServiceClient) UpgradeDaemon(ctx context.Context, opts ...grpc.CallOption) (SystemService_UpgradeDaemonClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SystemService_serviceDesc.Streams[0], "/nitrod.SystemService/UpgradeDaemon", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &systemServiceUpgradeDaemonClient{stream}
+	return x, nil
+}
+
+type SystemService_UpgradeDaemonClient interface {
+	Send(*UpgradeDaemonRequest) error
+	CloseAndRecv() (*ServiceResponse, error)
+	grpc.ClientStream
+}
+
+type systemServiceUpgradeDaemonClient struct {
+	grpc.ClientStream
+}
+
+func (x *systemServiceUpgradeDaemonClient) Send(m *UpgradeDaemonRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *systemServiceUpgradeDaemonClient) CloseAndRecv() (*ServiceResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ServiceResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // SystemServiceServer is the server API for SystemService service.
 type SystemServiceServer interface {
 	Nginx(context.Context, *NginxServiceRequest) (*ServiceResponse, error)
 	PhpFpm(context.Context, *PhpFpmServiceRequest) (*ServiceResponse, error)
+	Version(context.Context, *VersionRequest) (*VersionResponse, error)
+	UpgradeDaemon(SystemService_UpgradeDaemonServer) error
 }
 
 // UnimplementedSystemServiceServer can be embedded to have forward compatible implementations.
@@ -1184,6 +6503,12 @@ func (*UnimplementedSystemServiceServer) Nginx(context.Context, *NginxServiceReq
 func (*UnimplementedSystemServiceServer) PhpFpm(context.Context, *PhpFpmServiceRequest) (*ServiceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PhpFpm not implemented")
 }
+func (*UnimplementedSystemServiceServer) Version(context.Context, *VersionRequest) (*VersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
+func (*UnimplementedSystemServiceServer) UpgradeDaemon(SystemService_UpgradeDaemonServer) error {
+	return status.Errorf(codes.Unimplemented, "method UpgradeDaemon not implemented")
+}
 
 func RegisterSystemServiceServer(s *grpc.Server, srv SystemServiceServer) {
 	s.RegisterService(&_SystemService_serviceDesc, srv)
@@ -1225,6 +6550,50 @@ func _SystemService_PhpFpm_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SystemService_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServiceServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitrod.SystemService/Version",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServiceServer).Version(ctx, req.(*VersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemService_UpgradeDaemon_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SystemServiceServer).UpgradeDaemon(&systemServiceUpgradeDaemonServer{stream})
+}
+
+type SystemService_UpgradeDaemonServer interface {
+	SendAndClose(*ServiceResponse) error
+	Recv() (*UpgradeDaemonRequest, error)
+	grpc.ServerStream
+}
+
+type systemServiceUpgradeDaemonServer struct {
+	grpc.ServerStream
+}
+
+func (x *systemServiceUpgradeDaemonServer) SendAndClose(m *ServiceResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *systemServiceUpgradeDaemonServer) Recv() (*UpgradeDaemonRequest, error) {
+	m := new(UpgradeDaemonRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _SystemService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "nitrod.SystemService",
 	HandlerType: (*SystemServiceServer)(nil),
@@ -1237,7 +6606,17 @@ var _SystemService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PhpFpm",
 			Handler:    _SystemService_PhpFpm_Handler,
 		},
+		{
+			MethodName: "Version",
+			Handler:    _SystemService_Version_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UpgradeDaemon",
+			Handler:       _SystemService_UpgradeDaemon_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/nitrod/nitrod.proto",
 }
@@ -17,6 +17,9 @@ import (
 type SystemService struct {
 	command Runner
 	logger  *log.Logger
+	// executable is the path to the nitrod binary
+	// that is replaced when upgrading the daemon
+	executable string
 }
 
 // Nginx is used to manage the nginx service.
@@ -76,8 +79,14 @@ func (s *SystemService) messageAndAction(action ServiceAction) (string, string)
 // service with the default command
 // runner and logging to stdout
 func NewSystemService() *SystemService {
+	executable, err := os.Executable()
+	if err != nil {
+		executable = "/usr/sbin/nitrod"
+	}
+
 	return &SystemService{
-		command: &ServiceRunner{},
-		logger:  log.New(os.Stdout, "nitrod ", 0),
+		command:    &ServiceRunner{},
+		logger:     log.New(os.Stdout, "nitrod ", 0),
+		executable: executable,
 	}
 }
@@ -0,0 +1,119 @@
+package nitrod
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/craftcms/nitro/internal/version"
+)
+
+// Version returns the version of nitrod along with the OS and
+// architecture of the machine so the CLI can check if it is
+// compatible and which release to use when upgrading.
+func (s *SystemService) Version(ctx context.Context, req *VersionRequest) (*VersionResponse, error) {
+	return &VersionResponse{
+		Version: Version,
+		Os:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+	}, nil
+}
+
+// UpgradeDaemon receives a new nitrod binary, verifies the checksum and the
+// architecture and then atomically replaces the current binary before
+// restarting the service.
+func (s *SystemService) UpgradeDaemon(stream SystemService_UpgradeDaemonServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to create the stream: %s", err.Error())
+	}
+
+	header := req.GetHeader()
+	if header == nil {
+		return status.Errorf(codes.InvalidArgument, "the first message must be the upgrade header")
+	}
+	if header.GetChecksum() == "" {
+		return status.Errorf(codes.InvalidArgument, "a checksum is required to upgrade nitrod")
+	}
+
+	// write the binary next to the current one so the rename is atomic
+	file, err := ioutil.TempFile(filepath.Dir(s.executable), ".nitrod-upgrade-")
+	if err != nil {
+		s.logger.Println("error creating the upgrade file:", err)
+		return status.Errorf(codes.Internal, "unable to create the file for the upgrade")
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	hash := sha256.New()
+	w := io.MultiWriter(file, hash)
+
+	var size int64
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "unable to receive the upgrade: %s", err.Error())
+		}
+
+		n, err := w.Write(req.GetData())
+		if err != nil {
+			s.logger.Println("error writing the upgrade file:", err)
+			return status.Errorf(codes.Internal, "unable to write the upgrade file")
+		}
+		size += int64(n)
+	}
+
+	// verify the upload before replacing anything
+	if header.GetSize() != 0 && header.GetSize() != size {
+		return status.Errorf(codes.DataLoss, "expected %d bytes but received %d", header.GetSize(), size)
+	}
+	if checksum := hex.EncodeToString(hash.Sum(nil)); checksum != header.GetChecksum() {
+		s.logger.Printf("upgrade checksum mismatch, expected %s got %s", header.GetChecksum(), checksum)
+		return status.Errorf(codes.DataLoss, "the checksum of the upload does not match, expected %s got %s", header.GetChecksum(), checksum)
+	}
+
+	// a binary for another architecture would stop nitrod from starting
+	arch, err := version.BinaryArch(file)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if arch != runtime.GOARCH {
+		return status.Errorf(codes.InvalidArgument, "the binary is for %s but the machine is %s", arch, runtime.GOARCH)
+	}
+
+	if err := file.Close(); err != nil {
+		return status.Errorf(codes.Internal, "unable to write the upgrade file")
+	}
+	if err := os.Chmod(file.Name(), 0755); err != nil {
+		s.logger.Println("error setting the upgrade permissions:", err)
+		return status.Errorf(codes.Internal, "unable to set the permissions for the upgrade")
+	}
+
+	// swap the binaries, the running process keeps the old file open
+	if err := os.Rename(file.Name(), s.executable); err != nil {
+		s.logger.Println("error replacing nitrod:", err)
+		return status.Errorf(codes.Internal, "unable to replace nitrod")
+	}
+
+	s.logger.Printf("Replaced %s with version %s", s.executable, header.GetVersion())
+
+	// schedule the restart so the response is sent before systemd stops this process
+	if output, err := s.command.Run("systemd-run", []string{"--on-active=2", "systemctl", "restart", "nitrod"}); err != nil {
+		s.logger.Println("error scheduling the nitrod restart:", err)
+		s.logger.Println("output:", string(output))
+		return status.Errorf(codes.Unknown, string(output))
+	}
+
+	return stream.SendAndClose(&ServiceResponse{Message: "Upgraded nitrod to " + header.GetVersion() + ", restarting the service"})
+}
@@ -0,0 +1,189 @@
+package nitrod
+
+import (
+	"crypto/sha256"
+	"debug/elf"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/craftcms/nitro/internal/elftest"
+)
+
+// spyUpgradeDaemonServer is used to send a new nitrod
+// binary to the service in chunks.
+type spyUpgradeDaemonServer struct {
+	grpc.ServerStream
+	requests []*UpgradeDaemonRequest
+	response *ServiceResponse
+}
+
+func (s *spyUpgradeDaemonServer) Recv() (*UpgradeDaemonRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+
+	return req, nil
+}
+
+func (s *spyUpgradeDaemonServer) SendAndClose(resp *ServiceResponse) error {
+	s.response = resp
+	return nil
+}
+
+func TestSystemService_UpgradeDaemon(t *testing.T) {
+	binary := elftest.Binary(t, machines[runtime.GOARCH])
+	sum := sha256.Sum256(binary)
+	checksum := hex.EncodeToString(sum[:])
+
+	other := elftest.Binary(t, elf.EM_AARCH64)
+	if runtime.GOARCH == "arm64" {
+		other = elftest.Binary(t, elf.EM_X86_64)
+	}
+	otherSum := sha256.Sum256(other)
+
+	script := []byte("#!/bin/bash\necho nitrod\n")
+	scriptSum := sha256.Sum256(script)
+
+	tests := []struct {
+		name         string
+		requests     []*UpgradeDaemonRequest
+		want         *ServiceResponse
+		wantErr      bool
+		wantBinary   []byte
+		wantCommands []string
+		wantArgs     []map[string][]string
+	}{
+		{
+			name: "replaces the binary and restarts nitrod",
+			requests: []*UpgradeDaemonRequest{
+				{Request: &UpgradeDaemonRequest_Header{Header: &UpgradeDaemonHeader{Version: "1.2.0", Checksum: checksum, Size: int64(len(binary))}}},
+				{Request: &UpgradeDaemonRequest_Data{Data: binary[:10]}},
+				{Request: &UpgradeDaemonRequest_Data{Data: binary[10:]}},
+			},
+			want:         &ServiceResponse{Message: "Upgraded nitrod to 1.2.0, restarting the service"},
+			wantBinary:   binary,
+			wantCommands: []string{"systemd-run"},
+			wantArgs: []map[string][]string{
+				{"systemd-run": {"--on-active=2", "systemctl", "restart", "nitrod"}},
+			},
+		},
+		{
+			name: "does not replace the binary when the checksum does not match",
+			requests: []*UpgradeDaemonRequest{
+				{Request: &UpgradeDaemonRequest_Header{Header: &UpgradeDaemonHeader{Version: "1.2.0", Checksum: checksum}}},
+				{Request: &UpgradeDaemonRequest_Data{Data: binary[:10]}},
+			},
+			wantErr:    true,
+			wantBinary: []byte("old"),
+		},
+		{
+			name: "does not replace the binary when the size does not match",
+			requests: []*UpgradeDaemonRequest{
+				{Request: &UpgradeDaemonRequest_Header{Header: &UpgradeDaemonHeader{Version: "1.2.0", Checksum: checksum, Size: 10}}},
+				{Request: &UpgradeDaemonRequest_Data{Data: binary}},
+			},
+			wantErr:    true,
+			wantBinary: []byte("old"),
+		},
+		{
+			name: "does not replace the binary with a binary for another architecture",
+			requests: []*UpgradeDaemonRequest{
+				{Request: &UpgradeDaemonRequest_Header{Header: &UpgradeDaemonHeader{Version: "1.2.0", Checksum: hex.EncodeToString(otherSum[:])}}},
+				{Request: &UpgradeDaemonRequest_Data{Data: other}},
+			},
+			wantErr:    true,
+			wantBinary: []byte("old"),
+		},
+		{
+			name: "does not replace the binary with a file that is not a binary",
+			requests: []*UpgradeDaemonRequest{
+				{Request: &UpgradeDaemonRequest_Header{Header: &UpgradeDaemonHeader{Version: "1.2.0", Checksum: hex.EncodeToString(scriptSum[:])}}},
+				{Request: &UpgradeDaemonRequest_Data{Data: script}},
+			},
+			wantErr:    true,
+			wantBinary: []byte("old"),
+		},
+		{
+			name: "requires the header to be the first message",
+			requests: []*UpgradeDaemonRequest{
+				{Request: &UpgradeDaemonRequest_Data{Data: binary}},
+			},
+			wantErr:    true,
+			wantBinary: []byte("old"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "nitrod-upgrade-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			executable := filepath.Join(dir, "nitrod")
+			if err := ioutil.WriteFile(executable, []byte("old"), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			spy := &spyChainRunner{}
+			s := &SystemService{
+				command:    spy,
+				logger:     log.New(ioutil.Discard, "testing", 0),
+				executable: executable,
+			}
+			stream := &spyUpgradeDaemonServer{requests: tt.requests}
+
+			if err := s.UpgradeDaemon(stream); (err != nil) != tt.wantErr {
+				t.Errorf("UpgradeDaemon() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(stream.response, tt.want) {
+				t.Errorf("UpgradeDaemon() got = %v, want %v", stream.response, tt.want)
+			}
+
+			got, err := ioutil.ReadFile(executable)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.wantBinary) {
+				t.Errorf("expected the binary to be %q, got %q", tt.wantBinary, got)
+			}
+
+			if !reflect.DeepEqual(spy.Commands, tt.wantCommands) {
+				t.Errorf("expected the commands to be:\n%v\n, got:\n%v", tt.wantCommands, spy.Commands)
+			}
+			if !reflect.DeepEqual(spy.Args, tt.wantArgs) {
+				t.Errorf("expected the args to be:\n%v\ngot:\n%v", tt.wantArgs, spy.Args)
+			}
+
+			// make sure the temp files are cleaned up
+			files, err := ioutil.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(files) != 1 {
+				t.Errorf("expected only the nitrod binary in the directory, got %d files", len(files))
+			}
+		})
+	}
+}
+
+// machines are the ELF machine types of the architectures.
+var machines = map[string]elf.Machine{
+	"386":   elf.EM_386,
+	"amd64": elf.EM_X86_64,
+	"arm":   elf.EM_ARM,
+	"arm64": elf.EM_AARCH64,
+}
@@ -2,6 +2,12 @@ package nitrod
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -16,7 +22,7 @@ func (s *NitroService) DisableXdebug(ctx context.Context, req *DisableXdebugRequ
 	}
 
 	// disable xdebug
-	if output, err := s.command.Run("phpdismod", []string{"-v", "7.4", "xdebug"}); err != nil {
+	if output, err := s.command.Run("phpdismod", []string{"-v", req.GetVersion(), "xdebug"}); err != nil {
 		s.logger.Println("error disabling xdebug, error:", err)
 		s.logger.Println("output:", string(output))
 		return nil, status.Errorf(codes.Unknown, string(output))
@@ -39,7 +45,7 @@ func (s *NitroService) EnableXdebug(ctx context.Context, req *EnableXdebugReques
 	}
 
 	// enable xdebug
-	if output, err := s.command.Run("phpenmod", []string{"-v", "7.4", "xdebug"}); err != nil {
+	if output, err := s.command.Run("phpenmod", []string{"-v", req.GetVersion(), "xdebug"}); err != nil {
 		s.logger.Println("error enabling xdebug, error:", err)
 		s.logger.Println("output:", string(output))
 		return nil, status.Errorf(codes.Unknown, string(output))
@@ -54,3 +60,218 @@ func (s *NitroService) EnableXdebug(ctx context.Context, req *EnableXdebugReques
 
 	return &ServiceResponse{Message: "Enabled xdebug for PHP " + req.GetVersion()}, nil
 }
+
+// xdebugModes are the modes supported by Xdebug 3.
+var xdebugModes = []string{"off", "develop", "coverage", "debug", "gcstats", "profile", "trace"}
+
+var xdebugVersionPattern = regexp.MustCompile(`^(\d+)\.\d+`)
+
+// ConfigureXdebug writes the xdebug.ini for a PHP version using the setting names
+// for the version of Xdebug that is installed, and restarts php-fpm if Xdebug
+// is enabled. The response has the settings that were written.
+func (s *NitroService) ConfigureXdebug(ctx context.Context, req *ConfigureXdebugRequest) (*XdebugSettingsResponse, error) {
+	version := req.GetVersion()
+
+	// validate the php version
+	if err := validate.PHPVersion(version); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	mode := req.GetMode()
+	if mode == "" {
+		mode = "debug"
+	}
+	for _, m := range strings.Split(mode, ",") {
+		if !contains(xdebugModes, strings.TrimSpace(m)) {
+			return nil, status.Errorf(codes.InvalidArgument, "the xdebug mode %q is not valid, the modes are %s", m, strings.Join(xdebugModes, ", "))
+		}
+	}
+
+	if req.GetClientPort() < 0 || req.GetClientPort() > 65535 {
+		return nil, status.Errorf(codes.InvalidArgument, "the client port %d is not valid", req.GetClientPort())
+	}
+
+	if strings.ContainsAny(req.GetClientHost()+req.GetIdekey(), " \t\r\n\"';=") {
+		return nil, status.Errorf(codes.InvalidArgument, "the client host and idekey cannot contain spaces, quotes or semicolons")
+	}
+
+	xdebugVersion, err := s.xdebugVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	host := req.GetClientHost()
+	if host == "" {
+		host, err = s.hostAddress()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	settings := xdebugSettings(xdebugVersion, mode, host, int(req.GetClientPort()), req.GetIdekey(), req.GetStart())
+
+	// write the settings to the ini file enabled by phpenmod
+	content := "zend_extension=xdebug.so\n"
+	for _, setting := range settings {
+		content += setting.GetName() + "=" + setting.GetValue() + "\n"
+	}
+
+	ini := filepath.Join(s.phpDir, version, "mods-available", "xdebug.ini")
+	if err := os.MkdirAll(filepath.Dir(ini), 0755); err != nil {
+		s.logger.Println("error creating the mods-available directory, error:", err)
+		return nil, status.Errorf(codes.Internal, "unable to write the xdebug settings")
+	}
+	if err := ioutil.WriteFile(ini, []byte(content), 0644); err != nil {
+		s.logger.Println("error writing the xdebug settings, error:", err)
+		return nil, status.Errorf(codes.Internal, "unable to write the xdebug settings")
+	}
+
+	// only restart php-fpm when xdebug is enabled
+	_, err = os.Stat(filepath.Join(s.phpDir, version, "fpm", "conf.d", "20-xdebug.ini"))
+	enabled := err == nil
+	if enabled {
+		if err := s.restartPhpFpm(version, PhpSapi_FPM); err != nil {
+			return nil, err
+		}
+	}
+
+	return &XdebugSettingsResponse{
+		Version:       version,
+		XdebugVersion: xdebugVersion,
+		Enabled:       enabled,
+		Settings:      settings,
+	}, nil
+}
+
+// xdebugVersion returns the version of Xdebug installed for the PHP version. The
+// extension is loaded without the ini files so it works when Xdebug is disabled.
+func (s *NitroService) xdebugVersion(php string) (string, error) {
+	output, err := s.command.Run("php"+php, []string{"-n", "-d", "zend_extension=xdebug.so", "-r", "echo phpversion('xdebug');"})
+	if err != nil {
+		s.logger.Println("error getting the xdebug version, error:", err)
+		s.logger.Println("output:", string(output))
+		return "", status.Errorf(codes.Unknown, string(output))
+	}
+
+	v := strings.TrimSpace(string(output))
+	if !xdebugVersionPattern.MatchString(v) {
+		return "", status.Errorf(codes.FailedPrecondition, "xdebug is not installed for PHP %s", php)
+	}
+
+	return v, nil
+}
+
+// hostAddress returns the gateway of the default route, which is
+// the IP address of the host the machine is running on.
+func (s *NitroService) hostAddress() (string, error) {
+	output, err := s.command.Run("ip", []string{"route", "show", "default"})
+	if err != nil {
+		s.logger.Println("error getting the default route, error:", err)
+		s.logger.Println("output:", string(output))
+		return "", status.Errorf(codes.Unknown, string(output))
+	}
+
+	// e.g. default via 192.168.64.1 dev enp0s2 proto dhcp src 192.168.64.2 metric 100
+	fields := strings.Fields(string(output))
+	for i, f := range fields {
+		if f == "via" && i+1 < len(fields) {
+			return fields[i+1], nil
+		}
+	}
+
+	return "", status.Errorf(codes.FailedPrecondition, "unable to detect the host address, set the client host in the xdebug config")
+}
+
+// xdebugSettings returns the ini settings for the major version of Xdebug, the
+// defaults match Xdebug 3 except for the port which is 9000 for Xdebug 2.
+func xdebugSettings(xdebugVersion, mode, host string, port int, idekey string, start XdebugStart) []*XdebugSetting {
+	if idekey == "" {
+		idekey = "PHPSTORM"
+	}
+
+	major := xdebugVersionPattern.FindStringSubmatch(xdebugVersion)
+	if len(major) == 2 && major[1] == "2" {
+		if port == 0 {
+			port = 9000
+		}
+
+		return xdebug2Settings(mode, host, port, idekey, start)
+	}
+
+	if port == 0 {
+		port = 9003
+	}
+
+	startWithRequest := "yes"
+	if start == XdebugStart_TRIGGER {
+		startWithRequest = "trigger"
+	}
+
+	return []*XdebugSetting{
+		{Name: "xdebug.mode", Value: strings.ReplaceAll(mode, " ", "")},
+		{Name: "xdebug.client_host", Value: host},
+		{Name: "xdebug.client_port", Value: strconv.Itoa(port)},
+		{Name: "xdebug.start_with_request", Value: startWithRequest},
+		{Name: "xdebug.idekey", Value: idekey},
+	}
+}
+
+// xdebug2Settings converts the Xdebug 3 modes into the settings for Xdebug 2.
+func xdebug2Settings(mode, host string, port int, idekey string, start XdebugStart) []*XdebugSetting {
+	autostart := "1"
+	if start == XdebugStart_TRIGGER {
+		autostart = "0"
+	}
+
+	var modes []string
+	for _, m := range strings.Split(mode, ",") {
+		modes = append(modes, strings.TrimSpace(m))
+	}
+
+	remote := "0"
+	if contains(modes, "debug") {
+		remote = "1"
+	}
+
+	settings := []*XdebugSetting{
+		{Name: "xdebug.remote_enable", Value: remote},
+		{Name: "xdebug.remote_connect_back", Value: "0"},
+		{Name: "xdebug.remote_host", Value: host},
+		{Name: "xdebug.remote_port", Value: strconv.Itoa(port)},
+		{Name: "xdebug.remote_autostart", Value: autostart},
+		{Name: "xdebug.idekey", Value: idekey},
+	}
+
+	if contains(modes, "profile") {
+		settings = append(settings, xdebug2Trigger("xdebug.profiler_enable", "xdebug.profiler_enable_trigger", start))
+	}
+
+	if contains(modes, "trace") {
+		settings = append(settings, xdebug2Trigger("xdebug.auto_trace", "xdebug.trace_enable_trigger", start))
+	}
+
+	if contains(modes, "coverage") {
+		settings = append(settings, &XdebugSetting{Name: "xdebug.coverage_enable", Value: "1"})
+	}
+
+	return settings
+}
+
+// xdebug2Trigger returns the setting to always run a feature, or only when triggered.
+func xdebug2Trigger(always, trigger string, start XdebugStart) *XdebugSetting {
+	if start == XdebugStart_TRIGGER {
+		return &XdebugSetting{Name: trigger, Value: "1"}
+	}
+
+	return &XdebugSetting{Name: always, Value: "1"}
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -127,6 +129,27 @@ func TestNitroService_EnableXdebug(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "uses the php version from the request",
+			fields: fields{
+				logger: log.New(ioutil.Discard, "testing", 0),
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: &EnableXdebugRequest{Version: "7.3"},
+			},
+			want:         &ServiceResponse{Message: "Enabled xdebug for PHP 7.3"},
+			wantErr:      false,
+			wantCommands: []string{"phpenmod", "service"},
+			wantArgs: []map[string][]string{
+				{
+					"phpenmod": {"-v", "7.3", "xdebug"},
+				},
+				{
+					"service": {"php7.3-fpm", "restart"},
+				},
+			},
+		},
 		{
 			name: "request fails validation",
 			fields: fields{
@@ -168,4 +191,116 @@ func TestNitroService_EnableXdebug(t *testing.T) {
 	}
 }
 
+func TestNitroService_ConfigureXdebug(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *ConfigureXdebugRequest
+		outputs      []string
+		enabled      bool
+		want         *XdebugSettingsResponse
+		wantErr      bool
+		wantCommands []string
+		wantIni      string
+	}{
+		{
+			name:         "xdebug 3 uses the detected host and defaults",
+			req:          &ConfigureXdebugRequest{Version: "7.4"},
+			outputs:      []string{"3.0.1", "default via 192.168.64.1 dev enp0s2 proto dhcp src 192.168.64.2 metric 100\n"},
+			wantCommands: []string{"php7.4", "ip"},
+			want: &XdebugSettingsResponse{
+				Version:       "7.4",
+				XdebugVersion: "3.0.1",
+				Settings: []*XdebugSetting{
+					{Name: "xdebug.mode", Value: "debug"},
+					{Name: "xdebug.client_host", Value: "192.168.64.1"},
+					{Name: "xdebug.client_port", Value: "9003"},
+					{Name: "xdebug.start_with_request", Value: "yes"},
+					{Name: "xdebug.idekey", Value: "PHPSTORM"},
+				},
+			},
+			wantIni: "zend_extension=xdebug.so\nxdebug.mode=debug\nxdebug.client_host=192.168.64.1\nxdebug.client_port=9003\nxdebug.start_with_request=yes\nxdebug.idekey=PHPSTORM\n",
+		},
+		{
+			name:         "xdebug 2 settings are converted and php-fpm is restarted when enabled",
+			req:          &ConfigureXdebugRequest{Version: "7.2", Mode: "debug,profile", ClientHost: "10.0.0.1", Idekey: "VSCODE", Start: XdebugStart_TRIGGER},
+			outputs:      []string{"2.9.8"},
+			enabled:      true,
+			wantCommands: []string{"php7.2", "service"},
+			want: &XdebugSettingsResponse{
+				Version:       "7.2",
+				XdebugVersion: "2.9.8",
+				Enabled:       true,
+				Settings: []*XdebugSetting{
+					{Name: "xdebug.remote_enable", Value: "1"},
+					{Name: "xdebug.remote_connect_back", Value: "0"},
+					{Name: "xdebug.remote_host", Value: "10.0.0.1"},
+					{Name: "xdebug.remote_port", Value: "9000"},
+					{Name: "xdebug.remote_autostart", Value: "0"},
+					{Name: "xdebug.idekey", Value: "VSCODE"},
+					{Name: "xdebug.profiler_enable_trigger", Value: "1"},
+				},
+			},
+			wantIni: "zend_extension=xdebug.so\nxdebug.remote_enable=1\nxdebug.remote_connect_back=0\nxdebug.remote_host=10.0.0.1\nxdebug.remote_port=9000\nxdebug.remote_autostart=0\nxdebug.idekey=VSCODE\nxdebug.profiler_enable_trigger=1\n",
+		},
+		{
+			name:    "invalid modes return an error",
+			req:     &ConfigureXdebugRequest{Version: "7.4", Mode: "debug,remote"},
+			wantErr: true,
+		},
+		{
+			name:    "hosts cannot change other settings",
+			req:     &ConfigureXdebugRequest{Version: "7.4", ClientHost: "10.0.0.1\nxdebug.mode=off"},
+			wantErr: true,
+		},
+		{
+			name:         "missing xdebug returns an error",
+			req:          &ConfigureXdebugRequest{Version: "8.0"},
+			outputs:      []string{""},
+			wantErr:      true,
+			wantCommands: []string{"php8.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testPhpDir(t, tt.req.GetVersion())
+			defer os.RemoveAll(dir)
+
+			if tt.enabled {
+				if err := ioutil.WriteFile(filepath.Join(dir, tt.req.GetVersion(), "fpm", "conf.d", "20-xdebug.ini"), nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
 
+			spy := &spyChainRunner{Outputs: tt.outputs}
+			s := &NitroService{
+				command: spy,
+				logger:  log.New(ioutil.Discard, "testing", 0),
+				phpDir:  dir,
+			}
+			got, err := s.ConfigureXdebug(context.TODO(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConfigureXdebug() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(spy.Commands, tt.wantCommands) {
+				t.Errorf("expected the commands to be:\n%v\ngot:\n%v", tt.wantCommands, spy.Commands)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConfigureXdebug() got = \n%v, \nwant \n%v", got, tt.want)
+			}
+
+			b, err := ioutil.ReadFile(filepath.Join(dir, tt.req.GetVersion(), "mods-available", "xdebug.ini"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.wantIni {
+				t.Errorf("expected the ini to be:\n%s\ngot:\n%s", tt.wantIni, string(b))
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package phpini
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// OverrideFile is the name of the ini file nitro manages in each
+// SAPI's conf.d directory. The 99- prefix makes sure PHP loads
+// it after every other file so its values always win.
+const OverrideFile = "99-nitro.ini"
+
+// Kind is the type of value a PHP ini setting holds.
+type Kind int
+
+const (
+	String Kind = iota
+	Boolean
+	Integer
+	Bytes
+)
+
+// Value is a typed representation of a raw PHP ini value.
+type Value struct {
+	Kind Kind
+	Raw  string
+	Bool bool
+	// Int is the integer value, for byte sizes this is the
+	// total number of bytes (e.g. 128M is 134217728).
+	Int int64
+}
+
+// Setting is a single entry from the PHP function ini_get_all.
+type Setting struct {
+	Global string `json:"global_value"`
+	Local  string `json:"local_value"`
+	Access int    `json:"access"`
+}
+
+// Settings is the decoded output of ini_get_all(null, true).
+type Settings map[string]Setting
+
+// IniGetAllScript is the PHP code passed to php -r in order to
+// list every setting, and its current value, known to PHP.
+const IniGetAllScript = `echo json_encode(ini_get_all(null, true));`
+
+// booleans are settings PHP treats as booleans, ini_get_all
+// reports them as "1" or "" so they cannot be detected from
+// the value alone.
+var booleans = map[string]bool{
+	"allow_url_fopen":             true,
+	"allow_url_include":           true,
+	"display_errors":              true,
+	"display_startup_errors":      true,
+	"enable_dl":                   true,
+	"expose_php":                  true,
+	"file_uploads":                true,
+	"html_errors":                 true,
+	"ignore_repeated_errors":      true,
+	"ignore_repeated_source":      true,
+	"implicit_flush":              true,
+	"log_errors":                  true,
+	"opcache.enable":              true,
+	"opcache.enable_cli":          true,
+	"opcache.validate_timestamps": true,
+	"report_memleaks":             true,
+	"short_open_tag":              true,
+}
+
+// keywords are the values booleans accept besides On and Off.
+var keywords = map[string][]string{
+	"display_errors": {"stderr", "stdout"},
+}
+
+// sizes are settings that accept the PHP shorthand byte notation.
+var sizes = map[string]bool{
+	"memory_limit":        true,
+	"post_max_size":       true,
+	"upload_max_filesize": true,
+	"realpath_cache_size": true,
+}
+
+// expressions are integer settings that PHP also accepts as expressions
+// of constants (e.g. E_ALL & ~E_DEPRECATED), so they are strings. PHP only
+// evaluates the constants when the value is not quoted.
+var expressions = map[string]bool{
+	"error_reporting":            true,
+	"opcache.optimization_level": true,
+}
+
+// expression matches the numbers, constants and operators allowed in expressions.
+var expression = regexp.MustCompile(`^[A-Za-z0-9_ &|^~!()-]+$`)
+
+// KindOf returns the kind of value a setting holds. Known settings
+// are looked up first, anything else is inferred from the value, which
+// should be the current value of the setting from ini_get_all.
+func KindOf(setting, raw string) Kind {
+	if booleans[setting] {
+		return Boolean
+	}
+
+	if sizes[setting] {
+		return Bytes
+	}
+
+	if expressions[setting] {
+		return String
+	}
+
+	if _, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return Integer
+	}
+
+	if _, err := ParseBytes(raw); err == nil && raw != "" {
+		return Bytes
+	}
+
+	switch strings.ToLower(raw) {
+	case "on", "off":
+		return Boolean
+	}
+
+	return String
+}
+
+// ParseValue converts the raw value for a setting into a Value, the
+// kind is inferred from the value so it is used for the current values.
+func ParseValue(setting, raw string) (Value, error) {
+	return parseKind(KindOf(setting, raw), setting, raw)
+}
+
+// ValidateValue checks that the new value of a setting has the same kind
+// as the current value, so a new value cannot change the type of a setting.
+func ValidateValue(setting, current, raw string) error {
+	// each setting is a single line in the override file
+	if strings.IndexFunc(raw, unicode.IsControl) >= 0 {
+		return fmt.Errorf("%s cannot contain control characters such as new lines", setting)
+	}
+
+	if expressions[setting] && !expression.MatchString(raw) {
+		return fmt.Errorf("%s must be an integer or an expression of constants such as E_ALL & ~E_DEPRECATED", setting)
+	}
+
+	_, err := parseKind(KindOf(setting, current), setting, raw)
+	return err
+}
+
+// parseKind converts the raw value for a setting into a Value of the kind.
+func parseKind(kind Kind, setting, raw string) (Value, error) {
+	v := Value{Kind: kind, Raw: raw}
+
+	switch v.Kind {
+	case Boolean:
+		for _, k := range keywords[setting] {
+			if strings.EqualFold(raw, k) {
+				return v, nil
+			}
+		}
+
+		b, err := ParseBool(raw)
+		if err != nil {
+			if k, ok := keywords[setting]; ok {
+				return v, fmt.Errorf("%s must be a boolean (On or Off) or one of %s", setting, strings.Join(k, ", "))
+			}
+			return v, fmt.Errorf("%s must be a boolean (On or Off)", setting)
+		}
+		v.Bool = b
+	case Integer:
+		i, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			// PHP reads On and Off as 1 and 0
+			b, boolErr := ParseBool(raw)
+			if boolErr != nil || raw == "" {
+				return v, fmt.Errorf("%s must be a valid integer", setting)
+			}
+			if b {
+				i = 1
+			}
+		}
+		v.Int = i
+	case Bytes:
+		i, err := ParseBytes(raw)
+		if err != nil {
+			return v, fmt.Errorf("%s must be a size in bytes (e.g. 256M)", setting)
+		}
+		v.Int = i
+	}
+
+	return v, nil
+}
+
+// ParseBool parses the values PHP accepts for booleans in ini files.
+func ParseBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "on", "true", "yes":
+		return true, nil
+	case "", "0", "off", "false", "no", "none":
+		return false, nil
+	}
+
+	return false, errors.New("invalid boolean value " + s)
+}
+
+// ParseBytes parses the PHP shorthand byte notation (e.g. 512K,
+// 128M, or 1G) and returns the number of bytes. A value of -1 is
+// valid and means there is no limit.
+func ParseBytes(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("empty size")
+	}
+
+	multiplier := int64(1)
+	switch s[len(s)-1] {
+	case 'k', 'K':
+		multiplier = 1024
+	case 'm', 'M':
+		multiplier = 1024 * 1024
+	case 'g', 'G':
+		multiplier = 1024 * 1024 * 1024
+	}
+
+	num := s
+	if multiplier != 1 {
+		num = s[:len(s)-1]
+	}
+
+	i, err := strconv.ParseInt(num, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid size " + s)
+	}
+
+	if i < 0 {
+		return -1, nil
+	}
+
+	return i * multiplier, nil
+}
+
+// Overrides are the settings in a nitro override file.
+type Overrides map[string]string
+
+// Parse reads an ini file and returns the settings it contains,
+// comments and sections are ignored.
+func Parse(r io.Reader) (Overrides, error) {
+	o := Overrides{}
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+
+		// skip comments, sections and empty lines
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") {
+			continue
+		}
+
+		sp := strings.SplitN(line, "=", 2)
+		if len(sp) != 2 {
+			continue
+		}
+
+		o[strings.TrimSpace(sp[0])] = unquote(strings.TrimSpace(sp[1]))
+	}
+
+	return o, s.Err()
+}
+
+// Render returns the overrides as the content of an ini file with
+// the settings sorted by name.
+func (o Overrides) Render() string {
+	var keys []string
+	for k := range o {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("; This file is managed by nitro, changes will be overwritten.\n")
+	b.WriteString("; Use `nitro php iniset` and `nitro php inireset` to make changes.\n")
+	for _, k := range keys {
+		v := o[k]
+		if !expressions[k] || !expression.MatchString(v) {
+			v = quote(v)
+		}
+		b.WriteString(k + " = " + v + "\n")
+	}
+
+	return b.String()
+}
+
+// quote wraps values that contain characters with special meaning
+// in ini files in double quotes.
+func quote(v string) string {
+	if strings.ContainsAny(v, " ;=&|^~!(){}\"$") {
+		return `"` + strings.ReplaceAll(v, `"`, `\"`) + `"`
+	}
+
+	return v
+}
+
+func unquote(v string) string {
+	if len(v) >= 2 && strings.HasPrefix(v, `"`) && strings.HasSuffix(v, `"`) {
+		return strings.ReplaceAll(v[1:len(v)-1], `\"`, `"`)
+	}
+
+	return v
+}
@@ -0,0 +1,175 @@
+package phpini
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseValue(t *testing.T) {
+	type args struct {
+		setting string
+		raw     string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    Value
+		wantErr bool
+	}{
+		{
+			name: "memory_limit is a size in bytes",
+			args: args{setting: "memory_limit", raw: "256M"},
+			want: Value{Kind: Bytes, Raw: "256M", Int: 268435456},
+		},
+		{
+			name: "sizes can be unlimited",
+			args: args{setting: "memory_limit", raw: "-1"},
+			want: Value{Kind: Bytes, Raw: "-1", Int: -1},
+		},
+		{
+			name: "sizes can use gigabytes",
+			args: args{setting: "post_max_size", raw: "1G"},
+			want: Value{Kind: Bytes, Raw: "1G", Int: 1073741824},
+		},
+		{
+			name:    "invalid sizes return an error",
+			args:    args{setting: "upload_max_filesize", raw: "lots"},
+			want:    Value{Kind: Bytes, Raw: "lots"},
+			wantErr: true,
+		},
+		{
+			name: "known booleans reported as empty strings are false",
+			args: args{setting: "opcache.enable", raw: ""},
+			want: Value{Kind: Boolean, Raw: ""},
+		},
+		{
+			name: "known booleans can use On",
+			args: args{setting: "log_errors", raw: "On"},
+			want: Value{Kind: Boolean, Raw: "On", Bool: true},
+		},
+		{
+			name:    "invalid booleans return an error",
+			args:    args{setting: "log_errors", raw: "sometimes"},
+			want:    Value{Kind: Boolean, Raw: "sometimes"},
+			wantErr: true,
+		},
+		{
+			name: "unknown settings with numbers are integers",
+			args: args{setting: "max_execution_time", raw: "300"},
+			want: Value{Kind: Integer, Raw: "300", Int: 300},
+		},
+		{
+			name: "unknown settings with On are booleans",
+			args: args{setting: "mysqli.allow_persistent", raw: "On"},
+			want: Value{Kind: Boolean, Raw: "On", Bool: true},
+		},
+		{
+			name: "display_errors accepts stderr",
+			args: args{setting: "display_errors", raw: "stderr"},
+			want: Value{Kind: Boolean, Raw: "stderr"},
+		},
+		{
+			name: "everything else is a string",
+			args: args{setting: "date.timezone", raw: "UTC"},
+			want: Value{Kind: String, Raw: "UTC"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseValue(tt.args.setting, tt.args.raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseValue() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateValue(t *testing.T) {
+	tests := []struct {
+		setting string
+		current string
+		raw     string
+		wantErr bool
+	}{
+		{setting: "max_input_time", current: "-1", raw: "60"},
+		{setting: "max_input_time", current: "-1", raw: "abc", wantErr: true},
+		{setting: "max_input_time", current: "60", raw: "Off"},
+		{setting: "display_errors", current: "", raw: "On"},
+		{setting: "display_errors", current: "1", raw: "stdout"},
+		{setting: "display_errors", current: "", raw: "banana", wantErr: true},
+		{setting: "pcre.jit", current: "On", raw: "sometimes", wantErr: true},
+		{setting: "session.gc_maxlifetime", current: "1440", raw: "1d", wantErr: true},
+		{setting: "date.timezone", current: "UTC", raw: "America/New_York"},
+		{setting: "error_reporting", current: "32767", raw: "E_ALL"},
+		{setting: "error_reporting", current: "32767", raw: "E_ALL & ~E_DEPRECATED & ~E_STRICT"},
+		{setting: "error_reporting", current: "", raw: "-1"},
+		{setting: "error_reporting", current: "32767", raw: `"E_ALL"`, wantErr: true},
+		{setting: "date.timezone", current: "UTC", raw: "UTC\nauto_prepend_file = /tmp/x.php", wantErr: true},
+		{setting: "error_log", current: "", raw: "/tmp/php.log\r", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.setting+"="+tt.raw, func(t *testing.T) {
+			if err := ValidateValue(tt.setting, tt.current, tt.raw); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	file := `; This file is managed by nitro
+[PHP]
+memory_limit = 512M
+error_log = "/var/log/php errors.log"
+; max_execution_time = 30
+not a setting
+`
+
+	got, err := Parse(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Overrides{
+		"memory_limit": "512M",
+		"error_log":    "/var/log/php errors.log",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() got = %v, want %v", got, want)
+	}
+}
+
+func TestOverrides_Render(t *testing.T) {
+	o := Overrides{
+		"memory_limit":       "512M",
+		"error_log":          "/var/log/php errors.log",
+		"max_execution_time": "300",
+		"error_reporting":    "E_ALL & ~E_DEPRECATED",
+	}
+
+	// expressions are not quoted so PHP evaluates the constants
+	want := `; This file is managed by nitro, changes will be overwritten.
+; Use ` + "`nitro php iniset` and `nitro php inireset`" + ` to make changes.
+error_log = "/var/log/php errors.log"
+error_reporting = E_ALL & ~E_DEPRECATED
+max_execution_time = 300
+memory_limit = 512M
+`
+	if got := o.Render(); got != want {
+		t.Errorf("Render() got:\n%v\nwant:\n%v", got, want)
+	}
+
+	// make sure the rendered file can be parsed again
+	parsed, err := Parse(strings.NewReader(o.Render()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, o) {
+		t.Errorf("Parse(Render()) got = %v, want %v", parsed, o)
+	}
+}
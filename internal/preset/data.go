@@ -0,0 +1,60 @@
+package preset
+
+// presets are in the order they are detected, so a project with the markers
+// of several presets, such as a Craft project with a package.json, is found
+// as the most specific one.
+var presets = []Preset{
+	{
+		Name:     "craft",
+		Title:    "Craft CMS",
+		Markers:  []string{"craft"},
+		Webroots: []string{"web"},
+		Webroot:  "web",
+		Env:      []string{"CRAFT_NITRO=1", "DB_USER=nitro", "DB_PASSWORD=nitro"},
+		Template: craftTemplate,
+	},
+	{
+		Name:     "laravel",
+		Title:    "Laravel",
+		Markers:  []string{"artisan"},
+		Webroots: []string{"public"},
+		Webroot:  "public",
+		Env:      []string{"APP_ENV=local", "DB_USERNAME=nitro", "DB_PASSWORD=nitro"},
+		Template: laravelTemplate,
+	},
+	{
+		Name:  "wordpress",
+		Title: "WordPress",
+		// Bedrock keeps WordPress in web/
+		Markers:  []string{"wp-config.php", "wp-load.php", "web/wp-config.php"},
+		Webroots: []string{"web", "public_html", "."},
+		Webroot:  ".",
+		Env:      []string{"WP_ENVIRONMENT_TYPE=local", "DB_USER=nitro", "DB_PASSWORD=nitro"},
+		Template: wordpressTemplate,
+	},
+	{
+		Name:     "symfony",
+		Title:    "Symfony",
+		Markers:  []string{"symfony.lock", "bin/console"},
+		Webroots: []string{"public", "web"},
+		Webroot:  "public",
+		Env:      []string{"APP_ENV=dev"},
+		Template: symfonyTemplate,
+	},
+	{
+		Name:     "spa",
+		Title:    "Single page app",
+		Markers:  []string{"package.json"},
+		Webroots: []string{"dist", "build", "public"},
+		Webroot:  "dist",
+		Template: spaTemplate,
+	},
+	{
+		Name:     "static",
+		Title:    "Static site",
+		Markers:  []string{"index.html"},
+		Webroots: []string{"public", "_site", "dist", "build", "www", "public_html", "."},
+		Webroot:  ".",
+		Template: staticTemplate,
+	},
+}
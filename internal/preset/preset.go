@@ -0,0 +1,94 @@
+// Package preset describes the types of sites nitro supports. Each preset
+// has the nginx template of its sites, the directories its webroot is
+// usually in and the environment variables passed to PHP by default.
+package preset
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Default is the preset of sites without a type.
+const Default = "craft"
+
+// Preset is a type of site, such as a Craft or a Laravel project.
+type Preset struct {
+	Name  string
+	Title string
+	// Markers are files in the root of a project that identify the type
+	Markers []string
+	// Webroots are the directories, relative to the project, searched for
+	// the webroot in order. "." is the root of the project.
+	Webroots []string
+	// Webroot is the webroot when none of the Webroots exist.
+	Webroot string
+	// Env are the NAME=value environment variables passed to PHP, the
+	// env of a site replaces the variables with the same name
+	Env []string
+	// Template is the nginx template of the sites.
+	Template string
+}
+
+// Names returns the names of the presets.
+func Names() []string {
+	var names []string
+	for _, p := range presets {
+		names = append(names, p.Name)
+	}
+
+	return names
+}
+
+// Lookup returns the preset and false when it does not exist, an
+// empty name is the Default preset.
+func Lookup(name string) (Preset, bool) {
+	if name == "" {
+		name = Default
+	}
+
+	for _, p := range presets {
+		if p.Name == name {
+			return p, true
+		}
+	}
+
+	return Preset{}, false
+}
+
+// Detect returns the name of the preset of the project in the directory,
+// using the markers of each preset. It returns an empty string when the
+// type of the project is not known.
+func Detect(dir string) string {
+	for _, p := range presets {
+		for _, marker := range p.Markers {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return p.Name
+			}
+		}
+	}
+
+	return ""
+}
+
+// FindWebroot returns the first of the preset's webroots in the directory
+// of the project, or the default webroot of the preset and false when
+// none of them exist.
+func (p Preset) FindWebroot(dir string) (string, bool) {
+	for _, w := range p.Webroots {
+		if w == "." {
+			return w, true
+		}
+
+		if info, err := os.Stat(filepath.Join(dir, w)); err == nil && info.IsDir() {
+			return w, true
+		}
+	}
+
+	return p.Webroot, false
+}
+
+// Environment returns the env of the preset followed by the env of the
+// site, so the site's variables replace the preset's.
+func (p Preset) Environment(env []string) []string {
+	return append(append([]string{}, p.Env...), env...)
+}
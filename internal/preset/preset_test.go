@@ -0,0 +1,87 @@
+package preset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{dir: "./testdata/craft-example", want: "craft"},
+		{dir: "./testdata/laravel-example", want: "laravel"},
+		{dir: "./testdata/wordpress-example", want: "wordpress"},
+		{dir: "./testdata/bedrock-example", want: "wordpress"},
+		{dir: "./testdata/symfony-example", want: "symfony"},
+		{dir: "./testdata/spa-example", want: "spa"},
+		{dir: "./testdata/static-example", want: "static"},
+		{dir: "./testdata/unknown-example", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			if got := Detect(tt.dir); got != tt.want {
+				t.Errorf("Detect() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreset_FindWebroot(t *testing.T) {
+	tests := []struct {
+		preset string
+		dir    string
+		want   string
+		wantOK bool
+	}{
+		{preset: "craft", dir: "./testdata/craft-example", want: "web", wantOK: true},
+		{preset: "laravel", dir: "./testdata/laravel-example", want: "public", wantOK: true},
+		{preset: "wordpress", dir: "./testdata/wordpress-example", want: ".", wantOK: true},
+		{preset: "wordpress", dir: "./testdata/bedrock-example", want: "web", wantOK: true},
+		{preset: "symfony", dir: "./testdata/symfony-example", want: "public", wantOK: true},
+		{preset: "spa", dir: "./testdata/spa-example", want: "dist", wantOK: true},
+		{preset: "static", dir: "./testdata/static-example", want: ".", wantOK: true},
+		{preset: "laravel", dir: "./testdata/unknown-example", want: "public", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.preset+" "+tt.dir, func(t *testing.T) {
+			p, ok := Lookup(tt.preset)
+			if !ok {
+				t.Fatalf("expected the preset %q to exist", tt.preset)
+			}
+
+			got, gotOK := p.FindWebroot(tt.dir)
+			if got != tt.want || gotOK != tt.wantOK {
+				t.Errorf("FindWebroot() got = %q, %v, want %q, %v", got, gotOK, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	p, ok := Lookup("")
+	if !ok || p.Name != Default {
+		t.Errorf("expected an empty type to be the default preset, got %q", p.Name)
+	}
+
+	if _, ok := Lookup("drupal"); ok {
+		t.Errorf("expected an unknown preset to not exist")
+	}
+
+	want := []string{"craft", "laravel", "wordpress", "symfony", "spa", "static"}
+	if got := Names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() got = %v, want %v", got, want)
+	}
+}
+
+func TestPreset_Environment(t *testing.T) {
+	p, _ := Lookup("laravel")
+
+	env := p.Environment([]string{"APP_ENV=testing", "APP_DEBUG=true"})
+
+	want := []string{"APP_ENV=local", "DB_USERNAME=nitro", "DB_PASSWORD=nitro", "APP_ENV=testing", "APP_DEBUG=true"}
+	if !reflect.DeepEqual(env, want) {
+		t.Errorf("Environment() got = %v, want %v", env, want)
+	}
+}
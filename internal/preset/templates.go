@@ -0,0 +1,227 @@
+package preset
+
+// The templates are rendered with nginx.Vars, they share the listen,
+// server name and webroot settings and the extra config at the end.
+
+const serverStart = `server {
+    # Listen for both IPv4 & IPv6 on port 80
+    listen 80;
+    listen [::]:80;
+
+    # Listen for HTTPS using the certificate from the Nitro CA
+    listen 443 ssl http2;
+    listen [::]:443 ssl http2;
+    ssl_certificate {{ .Certificate }};
+    ssl_certificate_key {{ .CertificateKey }};
+    ssl_protocols TLSv1.2 TLSv1.3;
+
+    # General virtual host settings
+    server_name {{ .ServerNames }};
+    root {{ .Webroot }};
+`
+
+const serverEnd = `
+{{- with .Extra }}
+
+    # Extra configuration from the config file
+{{ indent 4 . }}
+{{- end }}
+
+    # Misc settings
+    sendfile off;
+}
+`
+
+// fastcgiEnv passes the env to PHP, it is used in the php-fpm location.
+const fastcgiEnv = `
+{{- range $name, $value := .Env }}
+        fastcgi_param {{ $name }} {{ quote $value }};
+{{- end }}`
+
+// Hat tip to https://github.com/nystudio107/nginx-craft
+const craftTemplate = `# Hat tip to https://github.com/nystudio107/nginx-craft
+
+` + serverStart + `    index index.html index.htm index.php;
+    charset utf-8;
+
+    # Enable serving of static gzip files as per: http://nginx.org/en/docs/http/ngx_http_gzip_static_module.html
+    gzip_static  on;
+
+    # Enable server-side includes as per: http://nginx.org/en/docs/http/ngx_http_ssi_module.html
+    ssi on;
+
+    # Disable limits on the maximum allowed size of the client request body
+    client_max_body_size 0;
+
+    # 404 error handler
+    error_page 404 /index.php$is_args$args;
+
+    # Root directory location handler
+    location / {
+        try_files $uri/index.html $uri $uri/ /index.php$is_args$args;
+    }
+
+    # php-fpm configuration
+    location ~ [^/]\.php(/|$) {
+        include snippets/fastcgi-php.conf;
+
+        fastcgi_pass unix:{{ .PHPSocket }};
+
+        # FastCGI params
+        fastcgi_param HTTP_PROXY "";
+        fastcgi_param HTTP_HOST {{ .Hostname }};` + fastcgiEnv + `
+
+        # Don't allow browser caching of dynamically generated content
+        add_header Last-Modified $date_gmt;
+        add_header Cache-Control "no-store, no-cache, must-revalidate, proxy-revalidate, max-age=0";
+        if_modified_since off;
+        expires off;
+        etag off;
+
+        fastcgi_intercept_errors off;
+        fastcgi_buffer_size 16k;
+        fastcgi_buffers 4 16k;
+        fastcgi_connect_timeout 240;
+        fastcgi_send_timeout 240;
+        fastcgi_read_timeout 240;
+    }
+
+    # Disable reading of Apache .htaccess files
+    location ~ /\.ht {
+        deny all;
+    }` + serverEnd
+
+const laravelTemplate = serverStart + `    index index.php;
+    charset utf-8;
+
+    add_header X-Frame-Options "SAMEORIGIN";
+    add_header X-Content-Type-Options "nosniff";
+
+    # Disable limits on the maximum allowed size of the client request body
+    client_max_body_size 0;
+
+    location / {
+        try_files $uri $uri/ /index.php?$query_string;
+    }
+
+    location = /favicon.ico { access_log off; log_not_found off; }
+    location = /robots.txt  { access_log off; log_not_found off; }
+
+    error_page 404 /index.php;
+
+    # php-fpm configuration
+    location ~ \.php$ {
+        fastcgi_pass unix:{{ .PHPSocket }};
+        fastcgi_param SCRIPT_FILENAME $realpath_root$fastcgi_script_name;
+        include fastcgi_params;
+
+        # FastCGI params
+        fastcgi_param HTTP_PROXY "";` + fastcgiEnv + `
+
+        fastcgi_buffer_size 16k;
+        fastcgi_buffers 4 16k;
+        fastcgi_read_timeout 240;
+    }
+
+    # Deny access to hidden files, except for .well-known
+    location ~ /\.(?!well-known).* {
+        deny all;
+    }` + serverEnd
+
+const wordpressTemplate = serverStart + `    index index.php index.html;
+    charset utf-8;
+
+    # Disable limits on the maximum allowed size of the client request body
+    client_max_body_size 0;
+
+    location / {
+        try_files $uri $uri/ /index.php?$args;
+    }
+
+    location = /favicon.ico { access_log off; log_not_found off; }
+    location = /robots.txt  { access_log off; log_not_found off; }
+
+    # php-fpm configuration
+    location ~ \.php$ {
+        include snippets/fastcgi-php.conf;
+
+        fastcgi_pass unix:{{ .PHPSocket }};
+
+        # FastCGI params
+        fastcgi_param HTTP_PROXY "";` + fastcgiEnv + `
+
+        fastcgi_buffer_size 16k;
+        fastcgi_buffers 4 16k;
+        fastcgi_read_timeout 240;
+    }
+
+    # Static assets from the themes and plugins
+    location ~* \.(css|js|gif|ico|jpe?g|png|svg|webp|woff2?)$ {
+        expires max;
+        log_not_found off;
+    }
+
+    # Disable reading of Apache .htaccess files
+    location ~ /\.ht {
+        deny all;
+    }` + serverEnd
+
+const symfonyTemplate = serverStart + `    charset utf-8;
+
+    # Disable limits on the maximum allowed size of the client request body
+    client_max_body_size 0;
+
+    location / {
+        try_files $uri /index.php$is_args$args;
+    }
+
+    # php-fpm configuration, only the front controller is run
+    location ~ ^/index\.php(/|$) {
+        fastcgi_pass unix:{{ .PHPSocket }};
+        fastcgi_split_path_info ^(.+\.php)(/.*)$;
+        include fastcgi_params;
+
+        fastcgi_param SCRIPT_FILENAME $realpath_root$fastcgi_script_name;
+        fastcgi_param DOCUMENT_ROOT $realpath_root;
+
+        # FastCGI params
+        fastcgi_param HTTP_PROXY "";` + fastcgiEnv + `
+
+        fastcgi_buffer_size 16k;
+        fastcgi_buffers 4 16k;
+        fastcgi_read_timeout 240;
+        internal;
+    }
+
+    # Other PHP files are not run
+    location ~ \.php$ {
+        return 404;
+    }` + serverEnd
+
+const spaTemplate = serverStart + `    index index.html;
+    charset utf-8;
+
+    # Routes that are not files are handled by the app
+    location / {
+        try_files $uri $uri/ /index.html;
+    }
+
+    # Don't cache the app so new builds are loaded
+    location = /index.html {
+        add_header Cache-Control "no-store, no-cache, must-revalidate, max-age=0";
+    }` + serverEnd
+
+const staticTemplate = serverStart + `    index index.html index.htm;
+    charset utf-8;
+
+    # Enable serving of static gzip files as per: http://nginx.org/en/docs/http/ngx_http_gzip_static_module.html
+    gzip_static  on;
+
+    location / {
+        try_files $uri $uri/ $uri.html =404;
+    }
+
+    # Disable reading of hidden files
+    location ~ /\. {
+        deny all;
+    }` + serverEnd
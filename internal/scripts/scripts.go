@@ -15,8 +15,6 @@ const (
 	FmtDockerPostgresCreateDatabase           = `docker exec -i %s psql --username nitro -c "CREATE DATABASE %s;"`
 	FmtDockerMysqlImportDatabase              = `cat %s | docker exec -i %s mysql -unitro -pnitro %s --init-command="SET autocommit=0;"`
 	FmtDockerPostgresImportDatabase           = `docker exec -i %s psql -U nitro -h 127.0.0.1 %s < %s`
-	FmtDockerMysqlShowAllDatabases            = `docker exec -i %s mysql -unitro -pnitro -e "SHOW DATABASES;"`
-	FmtDockerPostgresShowAllDatabases         = `docker exec -i %s psql --username nitro --command "SELECT datname FROM pg_database WHERE datistemplate = false;"`
 	DockerListContainerNames                  = `docker container ls --all --format '{{ .Names }}'`
 	FmtDockerRestartContainer                 = `docker container restart %s`
 	FmtDockerStopContainer                    = `docker container stop %s`
@@ -27,6 +25,7 @@ const (
 	FmtDockerBackupIndividualPostgresDatabase = `docker exec -i %s pg_dump -U nitro %s > %s`
 	FmtDockerBackupIndividualMysqlDatabase    = `docker exec %s /usr/bin/mysqldump -unitro -pnitro %s > %s`
 	FmtCreateDirectory                        = `mkdir -p %s`
+	NginxSiteChecksums                        = `sha256sum /etc/nginx/sites-available/* /home/ubuntu/.nitro/certs/*.crt 2>/dev/null || true`
 )
 
 type Script struct {
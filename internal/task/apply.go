@@ -3,19 +3,31 @@ package task
 import (
 	"fmt"
 
+	"github.com/craftcms/nitro/internal/certs"
 	"github.com/craftcms/nitro/internal/config"
+	"github.com/craftcms/nitro/internal/nginx"
 	"github.com/craftcms/nitro/internal/nitro"
 )
 
 // Apply is responsible for comparing the current configuration and what information is
 // found on a machine such as fromMultipassMounts and sites. Apple will then take the appropriate
-// steps to compare are create actions that "normal up" the configuration state.
-func Apply(machine string, configFile config.Config, mounts []config.Mount, sites []config.Site, dbs []config.Database, php string) ([]nitro.Action, error) {
+// steps to compare are create actions that "normal up" the configuration state. The
+// nginx configurations and certificates of the sites are copied to the machine
+// from the nitro directory on the host, where they are rendered and issued.
+func Apply(machine string, configFile config.Config, mounts []config.Mount, sites []config.Site, dbs []config.Database, php, dir string) ([]nitro.Action, error) {
 	var actions []nitro.Action
 	inMemoryConfig := config.Config{PHP: php, Mounts: mounts, Sites: sites, Databases: dbs}
 
 	// check if there are mounts we need to remove
+	backupsMounted := false
 	for _, mount := range inMemoryConfig.Mounts {
+		// the backups are not in the config mounts and removing the
+		// mount would delete the backups on the host with rm -rf
+		if mount.Dest == config.BackupsDir {
+			backupsMounted = true
+			continue
+		}
+
 		exists, _ := configFile.AlreadyMounted(mount)
 		if !exists {
 			unmountAction, err := nitro.UnmountDir(machine, mount.Dest)
@@ -46,6 +58,17 @@ func Apply(machine string, configFile config.Config, mounts []config.Mount, site
 		}
 	}
 
+	// mount the backups directory for automatic backups
+	if configFile.Backups != nil && !backupsMounted {
+		mount := config.BackupsMount(machine)
+		mountAction, err := nitro.MountDir(machine, mount.AbsSourcePath(), mount.Dest)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, *mountAction)
+		fmt.Println("Mounting", mount.Source, "to", machine)
+	}
+
 	// check if there are sites we need to remove
 	for _, site := range inMemoryConfig.Sites {
 		if !configFile.SiteExists(site) {
@@ -77,25 +100,26 @@ func Apply(machine string, configFile config.Config, mounts []config.Mount, site
 	for _, site := range configFile.Sites {
 		// find the parent to mount
 		if !inMemoryConfig.SiteExists(site) {
-			// copy template
-			copyTemplateAction, err := nitro.CopyNginxTemplate(machine, site.Hostname)
+			// copy the configuration rendered on the host
+			transferConfigAction, err := nitro.TransferNginxConfig(machine, site.Hostname, nginx.ConfPath(dir, machine, site.Hostname))
 			if err != nil {
 				return nil, err
 			}
-			actions = append(actions, *copyTemplateAction)
+			actions = append(actions, *transferConfigAction...)
 
-			// replace variable
-			changeNginxVariablesAction, err := nitro.ChangeTemplateVariables(machine, site.Webroot, site.Hostname, configFile.PHP, site.Aliases)
+			// copy the certificate for https
+			transferCertAction, err := nitro.TransferSiteCertificate(machine, site.Hostname, certs.CertPath(dir, site.Hostname), certs.KeyPath(dir, site.Hostname))
 			if err != nil {
 				return nil, err
 			}
-			actions = append(actions, *changeNginxVariablesAction...)
+			actions = append(actions, *transferCertAction...)
 
-			createSymlink, err := nitro.CreateSiteSymllink(machine, site.Hostname)
+			// enable the site, the changes are undone if nginx rejects the configuration
+			enableSiteAction, err := nitro.EnableNginxSite(machine, site.Hostname)
 			if err != nil {
 				return nil, err
 			}
-			actions = append(actions, *createSymlink)
+			actions = append(actions, *enableSiteAction)
 
 			// reload nginx
 			reloadNginxAction, err := nitro.NginxReload(machine)
@@ -122,6 +146,14 @@ func Apply(machine string, configFile config.Config, mounts []config.Mount, site
 	// check if there are database to create
 	for _, database := range configFile.Databases {
 		if !inMemoryConfig.DatabaseExists(database) {
+			if database.Engine == "mariadb" {
+				createConfig, err := nitro.CreateMariaDBConfig(machine)
+				if err != nil {
+					return nil, err
+				}
+				actions = append(actions, *createConfig)
+			}
+
 			createVolume, err := nitro.CreateDatabaseVolume(machine, database.Engine, database.Version, database.Port)
 			if err != nil {
 				return nil, err
@@ -147,6 +179,19 @@ func Apply(machine string, configFile config.Config, mounts []config.Mount, site
 		}
 		actions = append(actions, *installPhp)
 
+		// enable the php-fpm status page for the metrics of nitrod
+		fpmStatus, err := nitro.ConfigurePHPFpmStatus(machine, configFile.PHP)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, *fpmStatus)
+
+		restartPhpFpm, err := nitro.RestartPhpFpm(machine, configFile.PHP)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, *restartPhpFpm)
+
 		// set the default php
 		setPhpDefault := &nitro.Action{
 			Type:       "exec",
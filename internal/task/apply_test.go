@@ -75,28 +75,34 @@ func TestApply(t *testing.T) {
 				{
 					Type:       "exec",
 					UseSyscall: false,
-					Args:       []string{"exec", "mytestmachine", "--", "sudo", "cp", "/opt/nitro/nginx/template.conf", "/etc/nginx/sites-available/existing-site"},
+					Args:       []string{"exec", "mytestmachine", "--", "mkdir", "-p", "/home/ubuntu/.nitro/nginx"},
 				},
 				{
-					Type:       "exec",
+					Type:       "transfer",
 					UseSyscall: false,
-					Args:       []string{"exec", "mytestmachine", "--", "sudo", "sed", "-i", "s|CHANGEWEBROOTDIR|/nitro/sites/existing-site/public|g", "/etc/nginx/sites-available/existing-site"},
+					Args:       []string{"transfer", "/home/testuser/.nitro/nginx/mytestmachine/existing-site.conf", "mytestmachine:/home/ubuntu/.nitro/nginx/existing-site.conf"},
 				},
 				{
 					Type:       "exec",
 					UseSyscall: false,
-					Args:       []string{"exec", "mytestmachine", "--", "sudo", "sed", "-i", "s|CHANGESERVERNAME|existing-site|g", "/etc/nginx/sites-available/existing-site"},
+					Args:       []string{"exec", "mytestmachine", "--", "mkdir", "-p", "/home/ubuntu/.nitro/certs"},
 				},
 				{
-					Type:       "exec",
+					Type:       "transfer",
 					UseSyscall: false,
-					Args:       []string{"exec", "mytestmachine", "--", "sudo", "sed", "-i", "s|CHANGEPHPVERSION|7.4|g", "/etc/nginx/sites-available/existing-site"},
+					Args:       []string{"transfer", "/home/testuser/.nitro/certs/existing-site.crt", "mytestmachine:/home/ubuntu/.nitro/certs/existing-site.crt"},
+				},
+				{
+					Type:       "transfer",
+					UseSyscall: false,
+					Args:       []string{"transfer", "/home/testuser/.nitro/certs/existing-site.key", "mytestmachine:/home/ubuntu/.nitro/certs/existing-site.key"},
 				},
 				{
 					Type:       "exec",
 					UseSyscall: false,
-					Args:       []string{"exec", "mytestmachine", "--", "sudo", "ln", "-s", "/etc/nginx/sites-available/existing-site", "/etc/nginx/sites-enabled/"},
+					Args:       []string{"exec", "mytestmachine", "--", "chmod", "600", "/home/ubuntu/.nitro/certs/existing-site.key"},
 				},
+				enableSite(t, "mytestmachine", "existing-site"),
 				{
 					Type:       "exec",
 					UseSyscall: false,
@@ -118,6 +124,16 @@ func TestApply(t *testing.T) {
 					UseSyscall: false,
 					Args:       []string{"exec", "mytestmachine", "--", "sudo", "apt-get", "install", "-y", "php7.4", "php7.4-mbstring", "php7.4-cli", "php7.4-curl", "php7.4-fpm", "php7.4-gd", "php7.4-intl", "php7.4-json", "php7.4-mysql", "php7.4-pgsql", "php7.4-zip", "php7.4-xml", "php7.4-soap", "php7.4-bcmath", "php7.4-gmp", "php-xdebug", "php-imagick", "blackfire-agent", "blackfire-php"},
 				},
+				{
+					Type:       "exec",
+					UseSyscall: false,
+					Args:       []string{"exec", "mytestmachine", "--", "sudo", "sed", "-i", "s|;pm.status_path = /status|pm.status_path = /status|g", "/etc/php/7.4/fpm/pool.d/www.conf"},
+				},
+				{
+					Type:       "exec",
+					UseSyscall: false,
+					Args:       []string{"exec", "mytestmachine", "--", "sudo", "service", "php7.4-fpm", "restart"},
+				},
 				{
 					Type:       "exec",
 					UseSyscall: false,
@@ -338,28 +354,34 @@ func TestApply(t *testing.T) {
 				{
 					Type:       "exec",
 					UseSyscall: false,
-					Args:       []string{"exec", "mytestmachine", "--", "sudo", "cp", "/opt/nitro/nginx/template.conf", "/etc/nginx/sites-available/new-site"},
+					Args:       []string{"exec", "mytestmachine", "--", "mkdir", "-p", "/home/ubuntu/.nitro/nginx"},
 				},
 				{
-					Type:       "exec",
+					Type:       "transfer",
 					UseSyscall: false,
-					Args:       []string{"exec", "mytestmachine", "--", "sudo", "sed", "-i", "s|CHANGEWEBROOTDIR|/nitro/sites/new-site|g", "/etc/nginx/sites-available/new-site"},
+					Args:       []string{"transfer", "/home/testuser/.nitro/nginx/mytestmachine/new-site.conf", "mytestmachine:/home/ubuntu/.nitro/nginx/new-site.conf"},
 				},
 				{
 					Type:       "exec",
 					UseSyscall: false,
-					Args:       []string{"exec", "mytestmachine", "--", "sudo", "sed", "-i", "s|CHANGESERVERNAME|new-site|g", "/etc/nginx/sites-available/new-site"},
+					Args:       []string{"exec", "mytestmachine", "--", "mkdir", "-p", "/home/ubuntu/.nitro/certs"},
 				},
 				{
-					Type:       "exec",
+					Type:       "transfer",
+					UseSyscall: false,
+					Args:       []string{"transfer", "/home/testuser/.nitro/certs/new-site.crt", "mytestmachine:/home/ubuntu/.nitro/certs/new-site.crt"},
+				},
+				{
+					Type:       "transfer",
 					UseSyscall: false,
-					Args:       []string{"exec", "mytestmachine", "--", "sudo", "sed", "-i", "s|CHANGEPHPVERSION|7.4|g", "/etc/nginx/sites-available/new-site"},
+					Args:       []string{"transfer", "/home/testuser/.nitro/certs/new-site.key", "mytestmachine:/home/ubuntu/.nitro/certs/new-site.key"},
 				},
 				{
 					Type:       "exec",
 					UseSyscall: false,
-					Args:       []string{"exec", "mytestmachine", "--", "sudo", "ln", "-s", "/etc/nginx/sites-available/new-site", "/etc/nginx/sites-enabled/"},
+					Args:       []string{"exec", "mytestmachine", "--", "chmod", "600", "/home/ubuntu/.nitro/certs/new-site.key"},
 				},
+				enableSite(t, "mytestmachine", "new-site"),
 				{
 					Type:       "exec",
 					UseSyscall: false,
@@ -409,28 +431,34 @@ func TestApply(t *testing.T) {
 				{
 					Type:       "exec",
 					UseSyscall: false,
-					Args:       []string{"exec", "mytestmachine", "--", "sudo", "cp", "/opt/nitro/nginx/template.conf", "/etc/nginx/sites-available/new-site"},
+					Args:       []string{"exec", "mytestmachine", "--", "mkdir", "-p", "/home/ubuntu/.nitro/nginx"},
 				},
 				{
-					Type:       "exec",
+					Type:       "transfer",
 					UseSyscall: false,
-					Args:       []string{"exec", "mytestmachine", "--", "sudo", "sed", "-i", "s|CHANGEWEBROOTDIR|/nitro/sites/new-site|g", "/etc/nginx/sites-available/new-site"},
+					Args:       []string{"transfer", "/home/testuser/.nitro/nginx/mytestmachine/new-site.conf", "mytestmachine:/home/ubuntu/.nitro/nginx/new-site.conf"},
 				},
 				{
 					Type:       "exec",
 					UseSyscall: false,
-					Args:       []string{"exec", "mytestmachine", "--", "sudo", "sed", "-i", "s|CHANGESERVERNAME|new-site|g", "/etc/nginx/sites-available/new-site"},
+					Args:       []string{"exec", "mytestmachine", "--", "mkdir", "-p", "/home/ubuntu/.nitro/certs"},
 				},
 				{
-					Type:       "exec",
+					Type:       "transfer",
+					UseSyscall: false,
+					Args:       []string{"transfer", "/home/testuser/.nitro/certs/new-site.crt", "mytestmachine:/home/ubuntu/.nitro/certs/new-site.crt"},
+				},
+				{
+					Type:       "transfer",
 					UseSyscall: false,
-					Args:       []string{"exec", "mytestmachine", "--", "sudo", "sed", "-i", "s|CHANGEPHPVERSION|7.4|g", "/etc/nginx/sites-available/new-site"},
+					Args:       []string{"transfer", "/home/testuser/.nitro/certs/new-site.key", "mytestmachine:/home/ubuntu/.nitro/certs/new-site.key"},
 				},
 				{
 					Type:       "exec",
 					UseSyscall: false,
-					Args:       []string{"exec", "mytestmachine", "--", "sudo", "ln", "-s", "/etc/nginx/sites-available/new-site", "/etc/nginx/sites-enabled/"},
+					Args:       []string{"exec", "mytestmachine", "--", "chmod", "600", "/home/ubuntu/.nitro/certs/new-site.key"},
 				},
+				enableSite(t, "mytestmachine", "new-site"),
 				{
 					Type:       "exec",
 					UseSyscall: false,
@@ -509,6 +537,32 @@ func TestApply(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "the backups mount is not removed",
+			args: args{
+				machine: "mytestmachine",
+				configFile: config.Config{
+					Mounts: []config.Mount{
+						{
+							Source: "./testdata/new-mount",
+							Dest:   "/nitro/sites/new-site",
+						},
+					},
+				},
+				fromMultipassMounts: []config.Mount{
+					{
+						Source: "./testdata/new-mount",
+						Dest:   "/nitro/sites/new-site",
+					},
+					{
+						Source: "./testdata/existing-mount",
+						Dest:   config.BackupsDir,
+					},
+				},
+			},
+			want:    nil,
+			wantErr: false,
+		},
 		{
 			name: "renamed mounts get removed and added",
 			args: args{
@@ -550,7 +604,7 @@ func TestApply(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := Apply(tt.args.machine, tt.args.configFile, tt.args.fromMultipassMounts, tt.args.sites, tt.args.dbs, tt.args.php)
+			got, err := Apply(tt.args.machine, tt.args.configFile, tt.args.fromMultipassMounts, tt.args.sites, tt.args.dbs, tt.args.php, "/home/testuser/.nitro")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Apply() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -571,3 +625,13 @@ func TestApply(t *testing.T) {
 		})
 	}
 }
+
+// enableSite returns the action that enables the nginx configuration of the site.
+func enableSite(t *testing.T, machine, hostname string) nitro.Action {
+	a, err := nitro.EnableNginxSite(machine, hostname)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return *a
+}
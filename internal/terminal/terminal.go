@@ -0,0 +1,51 @@
+// Package terminal puts the local terminal into raw mode and reports
+// its size so commands can run interactively on the machine, and reads
+// passwords without showing them.
+package terminal
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// Size is the number of rows and columns in the terminal.
+type Size struct {
+	Rows int
+	Cols int
+}
+
+// ReadPassword reads a line from the terminal without showing what is
+// typed, the terminal is put into raw mode while the line is read.
+func ReadPassword(f *os.File) (string, error) {
+	state, err := MakeRaw(f.Fd())
+	if err != nil {
+		return "", err
+	}
+	defer Restore(f.Fd(), state)
+
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		if _, err := f.Read(b); err != nil {
+			return "", err
+		}
+
+		switch b[0] {
+		case '\r', '\n':
+			return string(line), nil
+		case 0x03:
+			return "", errors.New("interrupted")
+		case 0x04:
+			if len(line) == 0 {
+				return "", io.EOF
+			}
+		case 0x08, 0x7f:
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+			}
+		default:
+			line = append(line, b[0])
+		}
+	}
+}
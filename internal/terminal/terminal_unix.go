@@ -0,0 +1,96 @@
+// +build darwin linux
+
+package terminal
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// State is the state of the terminal before it was changed.
+type State struct {
+	termios unix.Termios
+}
+
+// IsTerminal returns true if the file descriptor is a terminal.
+func IsTerminal(fd uintptr) bool {
+	_, err := unix.IoctlGetTermios(int(fd), ioctlGetTermios)
+	return err == nil
+}
+
+// MakeRaw puts the terminal into raw mode so the input is sent
+// as it is typed, and returns the state to restore.
+func MakeRaw(fd uintptr) (*State, error) {
+	termios, err := unix.IoctlGetTermios(int(fd), ioctlGetTermios)
+	if err != nil {
+		return nil, err
+	}
+
+	old := State{termios: *termios}
+
+	termios.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	termios.Oflag &^= unix.OPOST
+	termios.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	termios.Cflag &^= unix.CSIZE | unix.PARENB
+	termios.Cflag |= unix.CS8
+	termios.Cc[unix.VMIN] = 1
+	termios.Cc[unix.VTIME] = 0
+
+	if err := unix.IoctlSetTermios(int(fd), ioctlSetTermios, termios); err != nil {
+		return nil, err
+	}
+
+	return &old, nil
+}
+
+// Restore returns the terminal to the state before MakeRaw.
+func Restore(fd uintptr, state *State) error {
+	return unix.IoctlSetTermios(int(fd), ioctlSetTermios, &state.termios)
+}
+
+// GetSize returns the size of the terminal.
+func GetSize(fd uintptr) (Size, error) {
+	ws, err := unix.IoctlGetWinsize(int(fd), unix.TIOCGWINSZ)
+	if err != nil {
+		return Size{}, err
+	}
+
+	return Size{Rows: int(ws.Row), Cols: int(ws.Col)}, nil
+}
+
+// WatchSize sends the size of the terminal each time it
+// changes, until the context is done.
+func WatchSize(ctx context.Context, fd uintptr) <-chan Size {
+	sizes := make(chan Size)
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGWINCH)
+
+	go func() {
+		defer signal.Stop(sig)
+		defer close(sizes)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sig:
+				size, err := GetSize(fd)
+				if err != nil {
+					continue
+				}
+
+				select {
+				case sizes <- size:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return sizes
+}
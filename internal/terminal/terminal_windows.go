@@ -0,0 +1,103 @@
+// +build windows
+
+package terminal
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// State is the state of the console before it was changed.
+type State struct {
+	in  uint32
+	out uint32
+}
+
+// IsTerminal returns true if the file descriptor is a console.
+func IsTerminal(fd uintptr) bool {
+	var mode uint32
+	return windows.GetConsoleMode(windows.Handle(fd), &mode) == nil
+}
+
+// MakeRaw puts the console into raw mode so the input is sent as it is
+// typed, and enables the terminal sequences used by the machine.
+func MakeRaw(fd uintptr) (*State, error) {
+	var state State
+	if err := windows.GetConsoleMode(windows.Handle(fd), &state.in); err != nil {
+		return nil, err
+	}
+
+	raw := state.in &^ (windows.ENABLE_ECHO_INPUT | windows.ENABLE_PROCESSED_INPUT | windows.ENABLE_LINE_INPUT | windows.ENABLE_PROCESSED_OUTPUT)
+	raw |= windows.ENABLE_VIRTUAL_TERMINAL_INPUT
+	if err := windows.SetConsoleMode(windows.Handle(fd), raw); err != nil {
+		return nil, err
+	}
+
+	out := windows.Handle(os.Stdout.Fd())
+	if err := windows.GetConsoleMode(out, &state.out); err == nil {
+		_ = windows.SetConsoleMode(out, state.out|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING)
+	}
+
+	return &state, nil
+}
+
+// Restore returns the console to the state before MakeRaw.
+func Restore(fd uintptr, state *State) error {
+	if state.out != 0 {
+		_ = windows.SetConsoleMode(windows.Handle(os.Stdout.Fd()), state.out)
+	}
+
+	return windows.SetConsoleMode(windows.Handle(fd), state.in)
+}
+
+// GetSize returns the size of the console window.
+func GetSize(fd uintptr) (Size, error) {
+	var info windows.ConsoleScreenBufferInfo
+	if err := windows.GetConsoleScreenBufferInfo(windows.Handle(os.Stdout.Fd()), &info); err != nil {
+		return Size{}, err
+	}
+
+	return Size{
+		Rows: int(info.Window.Bottom-info.Window.Top) + 1,
+		Cols: int(info.Window.Right-info.Window.Left) + 1,
+	}, nil
+}
+
+// WatchSize sends the size of the console each time it changes, until
+// the context is done. Windows does not signal when the console is
+// resized so the size is checked every second.
+func WatchSize(ctx context.Context, fd uintptr) <-chan Size {
+	sizes := make(chan Size)
+
+	go func() {
+		defer close(sizes)
+
+		last, _ := GetSize(fd)
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				size, err := GetSize(fd)
+				if err != nil || size == last {
+					continue
+				}
+				last = size
+
+				select {
+				case sizes <- size:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return sizes
+}
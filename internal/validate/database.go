@@ -3,68 +3,35 @@ package validate
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/craftcms/nitro/internal/catalog"
 	"github.com/craftcms/nitro/internal/config"
 )
 
+var snapshotName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
 func DatabaseEngine(v string) error {
-	switch v {
-	case "mysql":
-		return nil
-	case "postgres":
-		return nil
+	if _, ok := catalog.LookupEngine(v); !ok {
+		return errors.New("Unsupported database engine: " + v)
 	}
-	return errors.New("Unsupported database engine: " + v)
+
+	return nil
 }
 
 func DatabaseEngineAndVersion(e, v string) error {
-	if err := DatabaseEngine(e); err != nil {
-		return err
-	}
-
-	if e == "mysql" {
-		switch v {
-		case "8.0":
-			return nil
-		case "8":
-			return nil
-		case "5.8":
-			return nil
-		case "5.7":
-			return nil
-		case "5.6":
-			return nil
-		case "5":
-			return nil
-		}
+	engine, ok := catalog.LookupEngine(e)
+	if !ok {
+		return errors.New("Unsupported database engine: " + e)
 	}
 
-	if e == "postgres" {
-		switch v {
-		case "12.2":
-			return nil
-		case "12":
-			return nil
-		case "11.7":
-			return nil
-		case "11":
-			return nil
-		case "10.12":
-			return nil
-		case "10":
-			return nil
-		case "9.6":
-			return nil
-		case "9.5":
-			return nil
-		case "9":
-			return nil
-		}
+	if _, ok := engine.Lookup(v); !ok {
+		return errors.New("unsupported version of " + e + ": " + v)
 	}
 
-	return errors.New("unsupported version of " + e + ": " + v)
+	return nil
 }
 
 func DatabaseConfig(databases []config.Database) error {
@@ -116,3 +83,21 @@ func DatabaseName(s string) error {
 
 	return nil
 }
+
+// SnapshotName validates the name of a database snapshot, the name
+// is used for a docker volume so it has the same restrictions.
+func SnapshotName(s string) error {
+	if s == "" {
+		return errors.New("the snapshot name cannot be empty")
+	}
+
+	if len(s) > 64 {
+		return errors.New("length of the snapshot name must be less than 64 chars")
+	}
+
+	if !snapshotName.MatchString(s) {
+		return errors.New("invalid snapshot name, can only contain letters, numbers, periods, dashes and underscores")
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package validate
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/craftcms/nitro/internal/config"
@@ -25,6 +26,11 @@ func TestDatabaseEngine(t *testing.T) {
 			args:    args{v: "mySQL"},
 			wantErr: true,
 		},
+		{
+			name:    "mariadb does not return error",
+			args:    args{v: "mariadb"},
+			wantErr: false,
+		},
 		{
 			name:    "postgres does not return error",
 			args:    args{v: "postgres"},
@@ -152,12 +158,12 @@ func TestDatabaseVersion(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "supported engine and version does not return error",
+			name: "mysql version 5.8 that does not exist returns error",
 			args: args{
 				e: "mysql",
 				v: "5.8",
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 		{
 			name: "supported engine and version does not return error",
@@ -167,6 +173,22 @@ func TestDatabaseVersion(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "supported engine and version does not return error",
+			args: args{
+				e: "mariadb",
+				v: "10.5",
+			},
+			wantErr: false,
+		},
+		{
+			name: "mysql versions are not mariadb versions",
+			args: args{
+				e: "mariadb",
+				v: "5.7",
+			},
+			wantErr: true,
+		},
 		{
 			name: "supported engine and unsupported version returns error",
 			args: args{
@@ -342,3 +364,25 @@ func TestDatabaseName(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotName(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		wantErr bool
+	}{
+		{name: "letters, numbers, periods and dashes are valid", s: "before-migration.3_5", wantErr: false},
+		{name: "empty names return an error", s: "", wantErr: true},
+		{name: "spaces return an error", s: "before migration", wantErr: true},
+		{name: "slashes return an error", s: "../data", wantErr: true},
+		{name: "names starting with a dash return an error", s: "-rm", wantErr: true},
+		{name: "names longer than 64 chars return an error", s: strings.Repeat("a", 65), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SnapshotName(tt.s); (err != nil) != tt.wantErr {
+				t.Errorf("SnapshotName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
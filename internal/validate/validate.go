@@ -6,6 +6,9 @@ import (
 	"os"
 	"strconv"
 	"strings"
+
+	"github.com/craftcms/nitro/internal/catalog"
+	"github.com/craftcms/nitro/internal/preset"
 )
 
 func Hostname(v string) error {
@@ -24,6 +27,15 @@ func Hostname(v string) error {
 }
 
 // path will check is a fali
+// SiteType validates the type is the name of a site preset.
+func SiteType(v string) error {
+	if _, ok := preset.Lookup(v); !ok || v == "" {
+		return errors.New("the type must be one of " + strings.Join(preset.Names(), ", "))
+	}
+
+	return nil
+}
+
 func Path(p string) error {
 	f, err := os.Stat(p)
 	if err != nil {
@@ -41,14 +53,7 @@ func Path(p string) error {
 // PHPVersion takes a string that represents a PHP version to install and returns and error if that PHP version
 // is not yet supported.
 func PHPVersion(v string) error {
-	switch v {
-	case "7.4":
-		return nil
-	case "7.3":
-		return nil
-	case "7.2":
-		return nil
-	case "8.0":
+	if _, ok := catalog.LookupPHP(v); ok {
 		return nil
 	}
 
@@ -82,6 +82,40 @@ func TestDomain(t *testing.T) {
 	}
 }
 
+func TestSiteType(t *testing.T) {
+	type args struct {
+		v string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "is valid",
+			args:    args{v: "laravel"},
+			wantErr: false,
+		},
+		{
+			name:    "unknown type returns an error",
+			args:    args{v: "drupal"},
+			wantErr: true,
+		},
+		{
+			name:    "empty type returns an error",
+			args:    args{v: ""},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SiteType(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("SiteType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestMemory(t *testing.T) {
 	type args struct {
 		v string
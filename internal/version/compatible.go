@@ -0,0 +1,83 @@
+package version
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Compatibility is the result of comparing the
+// nitro CLI version with the nitrod version.
+type Compatibility int
+
+const (
+	// Match is when both versions are identical.
+	Match Compatibility = iota
+	// Mismatch is when the major versions are the same
+	// but the minor or patch versions are different.
+	Mismatch
+	// Incompatible is when the major versions are different.
+	Incompatible
+	// Unknown is when either version is a development build
+	// or cannot be parsed, so they cannot be compared.
+	Unknown
+)
+
+// Compatible compares the version of the nitro CLI with
+// the version of nitrod running on a machine.
+func Compatible(cli, daemon string) Compatibility {
+	c, err := Parse(cli)
+	if err != nil {
+		return Unknown
+	}
+
+	d, err := Parse(daemon)
+	if err != nil {
+		return Unknown
+	}
+
+	// development builds are not versioned
+	if c == [3]int{} || d == [3]int{} {
+		return Unknown
+	}
+
+	switch {
+	case c[0] != d[0]:
+		return Incompatible
+	case c != d:
+		return Mismatch
+	}
+
+	return Match
+}
+
+// Parse takes a version such as 1.1.0, v1.1.0, or 1.0.0-RC1
+// and returns the major, minor and patch versions.
+func Parse(v string) ([3]int, error) {
+	var parsed [3]int
+
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if v == "" {
+		return parsed, errors.New("empty version")
+	}
+
+	// remove any pre-release or build metadata
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+
+	sp := strings.Split(v, ".")
+	if len(sp) > 3 {
+		return parsed, errors.New("invalid version " + v)
+	}
+
+	for i, p := range sp {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return parsed, errors.New("invalid version " + v)
+		}
+		parsed[i] = n
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,103 @@
+package version
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompatible(t *testing.T) {
+	type args struct {
+		cli    string
+		daemon string
+	}
+	tests := []struct {
+		name string
+		args args
+		want Compatibility
+	}{
+		{
+			name: "identical versions match",
+			args: args{cli: "1.1.0", daemon: "1.1.0"},
+			want: Match,
+		},
+		{
+			name: "the v prefix is ignored",
+			args: args{cli: "v1.1.0", daemon: "1.1.0"},
+			want: Match,
+		},
+		{
+			name: "different patch versions are a mismatch",
+			args: args{cli: "1.1.1", daemon: "1.1.0"},
+			want: Mismatch,
+		},
+		{
+			name: "different minor versions are a mismatch",
+			args: args{cli: "1.2.0", daemon: "1.1.0"},
+			want: Mismatch,
+		},
+		{
+			name: "different major versions are incompatible",
+			args: args{cli: "2.0.0", daemon: "1.1.0"},
+			want: Incompatible,
+		},
+		{
+			name: "pre-releases use the version",
+			args: args{cli: "1.0.0-RC1", daemon: "1.0.0"},
+			want: Match,
+		},
+		{
+			name: "unversioned nitrod is unknown",
+			args: args{cli: "1.1.0", daemon: ""},
+			want: Unknown,
+		},
+		{
+			name: "development builds are unknown",
+			args: args{cli: "0.0.0", daemon: "1.1.0"},
+			want: Unknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Compatible(tt.args.cli, tt.args.daemon); got != tt.want {
+				t.Errorf("Compatible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       string
+		want    [3]int
+		wantErr bool
+	}{
+		{
+			name: "parses all parts",
+			v:    "1.12.3",
+			want: [3]int{1, 12, 3},
+		},
+		{
+			name: "missing parts are zero",
+			v:    "2",
+			want: [3]int{2, 0, 0},
+		},
+		{
+			name:    "invalid versions return an error",
+			v:       "latest",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package version
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"debug/elf"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+)
+
+// DaemonURL returns the URL of the nitrod release archive
+// for the version and architecture of a machine.
+func DaemonURL(version, arch string) string {
+	if arch == "amd64" {
+		arch = "x86_64"
+	}
+
+	return fmt.Sprintf("https://github.com/craftcms/nitro/releases/download/%s/nitrod_linux_%s.tar.gz", version, arch)
+}
+
+// DownloadDaemon downloads the nitrod release archive from
+// the URL and returns the nitrod binary from the archive.
+func DownloadDaemon(client *http.Client, url string) ([]byte, error) {
+	res, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to download %s, status: %s", url, res.Status)
+	}
+
+	return ExtractDaemon(res.Body)
+}
+
+// ExtractDaemon reads a gzipped tar release archive
+// and returns the contents of the nitrod binary.
+func ExtractDaemon(r io.Reader) ([]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if hdr.Typeflag == tar.TypeReg && path.Base(hdr.Name) == "nitrod" {
+			return ioutil.ReadAll(tr)
+		}
+	}
+
+	return nil, errors.New("unable to find nitrod in the release archive")
+}
+
+// archs are the GOARCH names of the ELF machine types.
+var archs = map[elf.Machine]string{
+	elf.EM_386:     "386",
+	elf.EM_X86_64:  "amd64",
+	elf.EM_ARM:     "arm",
+	elf.EM_AARCH64: "arm64",
+}
+
+// BinaryArch returns the architecture, as a GOARCH name, of a linux
+// binary so a nitrod binary can be checked before it is installed.
+func BinaryArch(r io.ReaderAt) (string, error) {
+	f, err := elf.NewFile(r)
+	if err != nil {
+		return "", errors.New("the file is not a linux binary")
+	}
+	defer f.Close()
+
+	arch, ok := archs[f.Machine]
+	if !ok {
+		return "", fmt.Errorf("the binary is for the unsupported architecture %s", f.Machine)
+	}
+
+	return arch, nil
+}
@@ -0,0 +1,98 @@
+package version
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"debug/elf"
+	"testing"
+
+	"github.com/craftcms/nitro/internal/elftest"
+)
+
+func TestDaemonURL(t *testing.T) {
+	want := "https://github.com/craftcms/nitro/releases/download/1.1.0/nitrod_linux_x86_64.tar.gz"
+	if got := DaemonURL("1.1.0", "amd64"); got != want {
+		t.Errorf("DaemonURL() = %v, want %v", got, want)
+	}
+
+	want = "https://github.com/craftcms/nitro/releases/download/1.1.0/nitrod_linux_arm64.tar.gz"
+	if got := DaemonURL("1.1.0", "arm64"); got != want {
+		t.Errorf("DaemonURL() = %v, want %v", got, want)
+	}
+}
+
+func TestExtractDaemon(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	files := map[string]string{
+		"nitrod.service": "[Unit]",
+		"nitrod":         "binary",
+	}
+	for _, name := range []string{"nitrod.service", "nitrod"} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(files[name])), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(files[name])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ExtractDaemon(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != "binary" {
+		t.Errorf("ExtractDaemon() = %q, want %q", got, "binary")
+	}
+}
+
+func TestBinaryArch(t *testing.T) {
+	tests := []struct {
+		name    string
+		binary  []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "amd64 binaries",
+			binary: elftest.Binary(t, elf.EM_X86_64),
+			want:   "amd64",
+		},
+		{
+			name:   "arm64 binaries",
+			binary: elftest.Binary(t, elf.EM_AARCH64),
+			want:   "arm64",
+		},
+		{
+			name:    "unsupported architectures",
+			binary:  elftest.Binary(t, elf.EM_MIPS),
+			wantErr: true,
+		},
+		{
+			name:    "files that are not binaries",
+			binary:  []byte("#!/bin/bash\necho nitrod\n"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BinaryArch(bytes.NewReader(tt.binary))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BinaryArch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("BinaryArch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
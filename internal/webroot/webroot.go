@@ -3,6 +3,7 @@ package webroot
 import (
 	"errors"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -97,3 +98,60 @@ func ForExistingMount(mount config.Mount, absolutePath, webrootDirectory string)
 	// join with the linux path separator
 	return strings.Join(dest, "/")
 }
+
+// MachinePath returns the path on the virtual machine for a local directory using
+// the mount that contains it, keeping any nested folders. When mounts are nested,
+// the mount closest to the directory is used.
+func MachinePath(mounts []config.Mount, dir string) (string, error) {
+	longest := -1
+	var path string
+	for _, m := range mounts {
+		source := filepath.Clean(m.AbsSourcePath())
+		rel, err := filepath.Rel(source, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+			continue
+		}
+
+		if len(source) <= longest {
+			continue
+		}
+		longest = len(source)
+
+		path = m.Dest
+		if rel != "." {
+			path = strings.TrimRight(m.Dest, "/") + "/" + filepath.ToSlash(rel)
+		}
+	}
+
+	if longest < 0 {
+		return "", errors.New("the directory " + dir + " is not mounted on the machine")
+	}
+
+	return path, nil
+}
+
+// Site returns the site whose project, the directory that contains its
+// webroot, contains the directory on the machine. When projects are nested,
+// the project closest to the directory is used.
+func Site(sites []config.Site, dir string) (config.Site, bool) {
+	longest := -1
+	var site config.Site
+	for _, s := range sites {
+		if !path.IsAbs(s.Webroot) {
+			continue
+		}
+
+		project := path.Dir(path.Clean(s.Webroot))
+		if dir != project && !strings.HasPrefix(dir, strings.TrimRight(project, "/")+"/") {
+			continue
+		}
+
+		if len(project) <= longest {
+			continue
+		}
+		longest = len(project)
+		site = s
+	}
+
+	return site, longest >= 0
+}
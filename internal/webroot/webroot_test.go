@@ -119,3 +119,108 @@ func TestForExistingMount(t *testing.T) {
 		})
 	}
 }
+
+func TestMachinePath(t *testing.T) {
+	mounts := []config.Mount{
+		{Source: "/Users/someuser/dev", Dest: "/home/ubuntu/sites"},
+		{Source: "/Users/someuser/dev/craft", Dest: "/home/ubuntu/craft"},
+	}
+
+	tests := []struct {
+		name    string
+		dir     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "returns the destination of the mount",
+			dir:  "/Users/someuser/dev",
+			want: "/home/ubuntu/sites",
+		},
+		{
+			name: "keeps the nested folders",
+			dir:  "/Users/someuser/dev/someproject/web",
+			want: "/home/ubuntu/sites/someproject/web",
+		},
+		{
+			name: "uses the closest mount",
+			dir:  "/Users/someuser/dev/craft/config",
+			want: "/home/ubuntu/craft/config",
+		},
+		{
+			name:    "folders with the same prefix are not mounted",
+			dir:     "/Users/someuser/dev-folder",
+			wantErr: true,
+		},
+		{
+			name:    "returns an error when the directory is not mounted",
+			dir:     "/Users/someuser/Documents",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MachinePath(mounts, tt.dir)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MachinePath() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MachinePath() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSite(t *testing.T) {
+	sites := []config.Site{
+		{Hostname: "demo.test", Webroot: "/home/ubuntu/sites/demo/web"},
+		{Hostname: "docs.test", Webroot: "/home/ubuntu/sites/demo/docs/public"},
+		{Hostname: "relative.test", Webroot: "web"},
+	}
+
+	tests := []struct {
+		name   string
+		dir    string
+		want   string
+		wantOk bool
+	}{
+		{
+			name:   "returns the site of the project",
+			dir:    "/home/ubuntu/sites/demo",
+			want:   "demo.test",
+			wantOk: true,
+		},
+		{
+			name:   "returns the site of nested folders",
+			dir:    "/home/ubuntu/sites/demo/config/project",
+			want:   "demo.test",
+			wantOk: true,
+		},
+		{
+			name:   "uses the closest project",
+			dir:    "/home/ubuntu/sites/demo/docs/templates",
+			want:   "docs.test",
+			wantOk: true,
+		},
+		{
+			name: "projects with the same prefix do not match",
+			dir:  "/home/ubuntu/sites/demo-old",
+		},
+		{
+			name: "directories outside of the sites do not match",
+			dir:  "/home/ubuntu",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Site(sites, tt.dir)
+			if ok != tt.wantOk {
+				t.Fatalf("Site() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got.Hostname != tt.want {
+				t.Errorf("Site() got = %v, want %v", got.Hostname, tt.want)
+			}
+		})
+	}
+}